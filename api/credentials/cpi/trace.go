@@ -0,0 +1,21 @@
+package cpi
+
+import (
+	"ocm.software/ocm/api/credentials/internal"
+)
+
+type (
+	Tracer     = internal.Tracer
+	TraceEvent = internal.TraceEvent
+)
+
+const (
+	TRACE_ALIAS      = internal.TRACE_ALIAS
+	TRACE_REPOSITORY = internal.TRACE_REPOSITORY
+)
+
+// Trace records a trace event for a credentials context,
+// if a tracer is configured.
+func Trace(ctx Context, evt *TraceEvent) {
+	internal.Trace(ctx, evt)
+}
@@ -53,7 +53,10 @@ func (a *RepositorySpec) Repository(ctx cpi.Context, creds cpi.Credentials) (cpi
 	}
 	alias := repos.GetRepository(a.Alias)
 	if alias == nil {
-		return nil, cpi.ErrUnknownRepository(Type, a.Alias)
+		err := cpi.ErrUnknownRepository(Type, a.Alias)
+		cpi.Trace(ctx, &cpi.TraceEvent{Kind: cpi.TRACE_ALIAS, Name: a.Alias, Error: err})
+		return nil, err
 	}
+	cpi.Trace(ctx, &cpi.TraceEvent{Kind: cpi.TRACE_ALIAS, Name: a.Alias, Spec: alias.spec.GetType(), Accepted: true})
 	return alias.GetRepository(ctx, creds)
 }
@@ -0,0 +1,30 @@
+package encrypted
+
+import (
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+var usage = `
+This repository type can be used to access credentials stored in an
+encrypted local file (default is <code>~/.ocm/` + DefaultStoreFileName + `</code>).
+The file can be maintained with the commands <code>ocm set credentials</code>,
+<code>ocm delete credentials</code> and <code>ocm list credentials</code>.
+
+The content is encrypted with AES using a master key. The master key is
+either read from a PEM encoded key file or derived from a passphrase
+(scrypt) taken from an environment variable. If no key file is configured
+and the environment variable is not set, the key file
+<code>~/.ocm/` + DefaultKeyFileName + `</code> is used.
+
+Credentials stored for consumer ids are automatically assigned to matching
+consumers, if enabled. Credentials can be looked up by their name or the
+string representation of their consumer id.
+`
+
+var format = `The repository specification supports the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"storeFile", "*string*: the file path of the encrypted store",
+	"keyFile", "*string*(optional): the file path of a PEM encoded encryption key",
+	"passphraseEnv", "*string*(optional): the environment variable providing the passphrase (default: " + ENV_PASSPHRASE + ")",
+	"propagateConsumerIdentity", "*bool*(optional): enable consumer id propagation",
+})
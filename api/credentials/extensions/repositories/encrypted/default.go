@@ -0,0 +1,60 @@
+package encrypted
+
+import (
+	"os"
+
+	"github.com/mandelsoft/filepath/pkg/filepath"
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/utils"
+)
+
+const (
+	DefaultStoreFileName = "credentials.enc"
+	DefaultKeyFileName   = "credentials.key"
+)
+
+func defaultFile(name string) string {
+	d, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(d, ".ocm", name)
+}
+
+// DefaultStoreFile provides the default path of the store file.
+func DefaultStoreFile() string {
+	return defaultFile(DefaultStoreFileName)
+}
+
+// DefaultKeyFile provides the default path of the key file.
+func DefaultKeyFile() string {
+	return defaultFile(DefaultKeyFileName)
+}
+
+// GetMasterKey determines the master key of a store. If a key file is given,
+// it is used. Otherwise, the passphrase is taken from the given environment
+// variable (default ENV_PASSPHRASE). If it is not set, the default key
+// file is used, if it exists.
+func GetMasterKey(keyFile string, passphraseEnv string, fss ...vfs.FileSystem) (*MasterKey, error) {
+	if keyFile != "" {
+		p, err := utils.ResolvePath(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		return KeyFromFile(p, fss...)
+	}
+	if k := KeyFromEnv(passphraseEnv); k != nil {
+		return k, nil
+	}
+	if d := DefaultKeyFile(); d != "" {
+		if ok, err := vfs.FileExists(utils.FileSystem(fss...), d); ok && err == nil {
+			return KeyFromFile(d, fss...)
+		}
+	}
+	if passphraseEnv == "" {
+		passphraseEnv = ENV_PASSPHRASE
+	}
+	return nil, errors.Newf("no master key found: specify a key file or set the environment variable %s", passphraseEnv)
+}
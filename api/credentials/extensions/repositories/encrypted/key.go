@@ -0,0 +1,89 @@
+package encrypted
+
+import (
+	"os"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"golang.org/x/crypto/scrypt"
+
+	"ocm.software/ocm/api/utils"
+	"ocm.software/ocm/api/utils/encrypt"
+)
+
+const (
+	// ENV_PASSPHRASE is the default environment variable used to
+	// provide the passphrase of a store.
+	ENV_PASSPHRASE = "OCM_CREDENTIAL_STORE_PASSPHRASE"
+
+	KDF_SCRYPT = "scrypt"
+)
+
+// MasterKey describes the key used to encrypt a store. It is either
+// a raw AES key or a passphrase used to derive a key for the stored data.
+type MasterKey struct {
+	key        []byte
+	passphrase []byte
+}
+
+// KeyFromFile reads a master key from a PEM encoded encryption key file
+// (for example created with encrypt.WriteKey).
+func KeyFromFile(path string, fss ...vfs.FileSystem) (*MasterKey, error) {
+	key, err := encrypt.ReadKey(path, fss...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read key file %q", path)
+	}
+	if _, err := encrypt.AlgoForKey(key); err != nil {
+		return nil, errors.Wrapf(err, "invalid key file %q", path)
+	}
+	return &MasterKey{key: key}, nil
+}
+
+// CreateKeyFile creates a new AES-256 master key and stores it in
+// the given file.
+func CreateKeyFile(path string, fss ...vfs.FileSystem) (*MasterKey, error) {
+	key, err := encrypt.NewKey(encrypt.AES_256)
+	if err != nil {
+		return nil, err
+	}
+	fs := utils.FileSystem(fss...)
+	err = fs.MkdirAll(vfs.Dir(fs, path), 0o700)
+	if err == nil {
+		err = vfs.WriteFile(fs, path, encrypt.KeyToPem(key), 0o600)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot write key file %q", path)
+	}
+	return &MasterKey{key: key}, nil
+}
+
+// KeyFromPassphrase provides a master key based on a passphrase.
+func KeyFromPassphrase(passphrase string) *MasterKey {
+	return &MasterKey{passphrase: []byte(passphrase)}
+}
+
+// KeyFromEnv provides a passphrase based master key using the given
+// environment variable (default ENV_PASSPHRASE). If the variable is
+// not set, nil is returned.
+func KeyFromEnv(name string) *MasterKey {
+	if name == "" {
+		name = ENV_PASSPHRASE
+	}
+	if p := os.Getenv(name); p != "" {
+		return KeyFromPassphrase(p)
+	}
+	return nil
+}
+
+// IsPassphrase reports whether the key is based on a passphrase.
+func (k *MasterKey) IsPassphrase() bool {
+	return k.key == nil
+}
+
+// derive provides the AES key for the given salt.
+func (k *MasterKey) derive(salt []byte) ([]byte, error) {
+	if k.key != nil {
+		return k.key, nil
+	}
+	return scrypt.Key(k.passphrase, salt, 1<<15, 8, 1, int(encrypt.AES_256))
+}
@@ -0,0 +1,38 @@
+package encrypted
+
+import (
+	"ocm.software/ocm/api/credentials/cpi"
+)
+
+type ConsumerProvider struct {
+	store *Store
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func (p *ConsumerProvider) Unregister(_ cpi.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(ectx cpi.EvaluationContext, req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+func (p *ConsumerProvider) get(requested cpi.ConsumerIdentity, currentFound cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	var creds cpi.CredentialsSource
+
+	for _, e := range p.store.Entries() {
+		if len(e.ConsumerId) == 0 {
+			continue
+		}
+		if m(requested, currentFound, e.ConsumerId) {
+			creds = cpi.NewCredentials(e.Properties)
+			currentFound = e.ConsumerId
+		}
+	}
+	return creds, currentFound
+}
@@ -0,0 +1,78 @@
+package encrypted
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/utils"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const PROVIDER = "ocm.software/credentialprovider/" + Type
+
+type Repository struct {
+	ctx   cpi.Context
+	store *Store
+}
+
+// NewRepository creates a repository for the given store file.
+// Credentials are looked up by their credential name or the string
+// representation of their consumer id.
+func NewRepository(ctx cpi.Context, path string, key *MasterKey, prop bool, fss ...vfs.FileSystem) (*Repository, error) {
+	path, err := utils.ResolvePath(path)
+	if err != nil {
+		return nil, err
+	}
+	store, err := OpenStore(path, key, fss...)
+	if err != nil {
+		return nil, err
+	}
+	r := &Repository{
+		ctx:   ctx,
+		store: store,
+	}
+	if prop {
+		ctx.RegisterConsumerProvider(cpi.ProviderIdentity(PROVIDER+"/"+path), &ConsumerProvider{store})
+	}
+	return r, nil
+}
+
+var _ cpi.Repository = &Repository{}
+
+// Store provides access to the underlying credential store.
+func (r *Repository) Store() *Store {
+	return r.store
+}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	return r.lookup(name) != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	props := r.lookup(name)
+	if props == nil {
+		return nil, errors.ErrNotFound("credentials", name, Type)
+	}
+	return cpi.NewCredentials(props), nil
+}
+
+func (r *Repository) lookup(name string) common.Properties {
+	if props := r.store.GetNamed(name); props != nil {
+		return props
+	}
+	for _, e := range r.store.Entries() {
+		if len(e.ConsumerId) > 0 && e.ConsumerId.String() == name {
+			return e.Properties.Copy()
+		}
+	}
+	return nil
+}
+
+func (r *Repository) WriteCredentials(name string, creds cpi.Credentials) (cpi.Credentials, error) {
+	r.store.SetNamed(name, creds.Properties())
+	if err := r.store.Save(); err != nil {
+		return nil, err
+	}
+	return cpi.NewCredentials(creds.Properties()), nil
+}
@@ -0,0 +1,68 @@
+package encrypted_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/extensions/repositories/encrypted"
+	oci "ocm.software/ocm/api/tech/oci/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("encrypted store repository", func() {
+	props := common.Properties{
+		cpi.ATTR_USERNAME: "user",
+		cpi.ATTR_PASSWORD: "pass",
+	}
+
+	var DefaultContext credentials.Context
+	var path string
+
+	BeforeEach(func() {
+		DefaultContext = credentials.New()
+		path = filepath.Join(GinkgoT().TempDir(), "store")
+		MustBeSuccessful(os.Setenv(encrypted.ENV_PASSPHRASE, "secret"))
+		DeferCleanup(os.Unsetenv, encrypted.ENV_PASSPHRASE)
+
+		store := Must(encrypted.OpenStore(path, encrypted.KeyFromEnv("")))
+		store.Set(oci.GetConsumerId("ghcr.io", "acme"), props)
+		MustBeSuccessful(store.Save())
+	})
+
+	It("serializes repo spec", func() {
+		spec := encrypted.NewRepositorySpec("/store", "/key")
+		data := Must(json.Marshal(spec))
+		Expect(string(data)).To(Equal(`{"type":"EncryptedStore","storeFile":"/store","keyFile":"/key"}`))
+	})
+
+	It("retrieves credentials", func() {
+		repo := Must(DefaultContext.RepositoryForSpec(encrypted.NewRepositorySpec(path, "")))
+		Expect(reflect.TypeOf(repo).String()).To(Equal("*encrypted.Repository"))
+
+		creds := Must(repo.LookupCredentials(oci.GetConsumerId("ghcr.io", "acme").String()))
+		Expect(creds.Properties()).To(Equal(props))
+
+		Must(repo.WriteCredentials("named", cpi.DirectCredentials{cpi.ATTR_TOKEN: "token"}))
+		creds = Must(repo.LookupCredentials("named"))
+		Expect(creds.Properties()).To(Equal(common.Properties{cpi.ATTR_TOKEN: "token"}))
+
+		store := Must(encrypted.OpenStore(path, encrypted.KeyFromPassphrase("secret")))
+		Expect(store.GetNamed("named")).To(Equal(common.Properties{cpi.ATTR_TOKEN: "token"}))
+	})
+
+	It("propagates consumer ids", func() {
+		Must(DefaultContext.RepositoryForSpec(encrypted.NewRepositorySpec(path, "")))
+
+		creds := Must(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("ghcr.io", "acme/image")))
+		Expect(creds.Properties()).To(Equal(props))
+		Expect(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("ghcr.io", "other/image"))).To(BeNil())
+	})
+})
@@ -0,0 +1,249 @@
+package encrypted
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/utils"
+	"ocm.software/ocm/api/utils/encrypt"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	HEADER_KDF  = "kdf"
+	HEADER_SALT = "salt"
+)
+
+// Entry is a credential entry of a store. It is either assigned to a
+// consumer id or a credential name.
+type Entry struct {
+	Name       string               `json:"name,omitempty"`
+	ConsumerId cpi.ConsumerIdentity `json:"consumerId,omitempty"`
+	Properties common.Properties    `json:"properties"`
+}
+
+type content struct {
+	Entries []*Entry `json:"entries,omitempty"`
+}
+
+// Store is a file based credential store. The content is
+// encrypted with a master key.
+type Store struct {
+	lock    sync.RWMutex
+	fs      vfs.FileSystem
+	path    string
+	key     *MasterKey
+	entries []*Entry
+}
+
+// OpenStore opens a store file. If the file does not exist,
+// an empty store is provided, which is created by Save.
+func OpenStore(path string, key *MasterKey, fss ...vfs.FileSystem) (*Store, error) {
+	if key == nil {
+		return nil, errors.New("master key required for encrypted credential store")
+	}
+	s := &Store{
+		fs:   utils.FileSystem(fss...),
+		path: path,
+		key:  key,
+	}
+	ok, err := vfs.FileExists(s.fs, path)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		err = s.read()
+		if err != nil {
+			return nil, errors.Wrapf(err, "credential store %q", path)
+		}
+	}
+	return s, nil
+}
+
+func (s *Store) read() error {
+	data, err := vfs.ReadFile(s.fs, s.path)
+	if err != nil {
+		return err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != encrypt.PEM_ENCRYPTED_DATA {
+		return errors.Newf("no encrypted data found")
+	}
+	var salt []byte
+	switch block.Headers[HEADER_KDF] {
+	case "":
+		if s.key.IsPassphrase() {
+			return errors.Newf("store requires a key file")
+		}
+	case KDF_SCRYPT:
+		if !s.key.IsPassphrase() {
+			return errors.Newf("store requires a passphrase")
+		}
+		salt, err = base64.StdEncoding.DecodeString(block.Headers[HEADER_SALT])
+		if err != nil {
+			return errors.Wrapf(err, "invalid salt")
+		}
+	default:
+		return errors.ErrNotSupported("key derivation function", block.Headers[HEADER_KDF])
+	}
+	key, err := s.key.derive(salt)
+	if err != nil {
+		return err
+	}
+	plain, err := encrypt.Decrypt(key, block.Bytes)
+	if err != nil {
+		return errors.Wrapf(err, "cannot decrypt (wrong key?)")
+	}
+	var c content
+	err = json.Unmarshal(plain, &c)
+	if err != nil {
+		return errors.Wrapf(err, "invalid store content")
+	}
+	s.entries = c.Entries
+	return nil
+}
+
+// Save writes the actual content to the store file.
+func (s *Store) Save() error {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	data, err := json.Marshal(&content{Entries: s.entries})
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{}
+	var salt []byte
+	if s.key.IsPassphrase() {
+		salt = make([]byte, 16)
+		if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+			return err
+		}
+		headers[HEADER_KDF] = KDF_SCRYPT
+		headers[HEADER_SALT] = base64.StdEncoding.EncodeToString(salt)
+	}
+	key, err := s.key.derive(salt)
+	if err != nil {
+		return err
+	}
+	algo, err := encrypt.AlgoForKey(key)
+	if err != nil {
+		return err
+	}
+	headers[encrypt.ALGO] = algo.String()
+	cipherText, err := encrypt.Encrypt(key, data)
+	if err != nil {
+		return err
+	}
+	err = s.fs.MkdirAll(vfs.Dir(s.fs, s.path), 0o700)
+	if err != nil {
+		return err
+	}
+	return vfs.WriteFile(s.fs, s.path, pem.EncodeToMemory(&pem.Block{
+		Type:    encrypt.PEM_ENCRYPTED_DATA,
+		Headers: headers,
+		Bytes:   cipherText,
+	}), 0o600)
+}
+
+// Entries provides the store entries ordered by name and consumer id.
+func (s *Store) Entries() []*Entry {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	result := append([]*Entry(nil), s.entries...)
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+		return result[i].ConsumerId.String() < result[j].ConsumerId.String()
+	})
+	return result
+}
+
+// Get provides the credential properties for a consumer id.
+func (s *Store) Get(id cpi.ConsumerIdentity) common.Properties {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if i := s.index(id, ""); i >= 0 {
+		return s.entries[i].Properties.Copy()
+	}
+	return nil
+}
+
+// GetNamed provides the credential properties for a credential name.
+func (s *Store) GetNamed(name string) common.Properties {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if i := s.index(nil, name); i >= 0 {
+		return s.entries[i].Properties.Copy()
+	}
+	return nil
+}
+
+// Set sets the credential properties for a consumer id.
+func (s *Store) Set(id cpi.ConsumerIdentity, props common.Properties) {
+	s.set(&Entry{ConsumerId: id.Copy(), Properties: props.Copy()})
+}
+
+// SetNamed sets the credential properties for a credential name.
+func (s *Store) SetNamed(name string, props common.Properties) {
+	s.set(&Entry{Name: name, Properties: props.Copy()})
+}
+
+func (s *Store) set(e *Entry) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if i := s.index(e.ConsumerId, e.Name); i >= 0 {
+		s.entries[i] = e
+	} else {
+		s.entries = append(s.entries, e)
+	}
+}
+
+// Delete removes the credentials for a consumer id.
+// It reports whether an entry has been found.
+func (s *Store) Delete(id cpi.ConsumerIdentity) bool {
+	return s.delete(id, "")
+}
+
+// DeleteNamed removes the credentials for a credential name.
+// It reports whether an entry has been found.
+func (s *Store) DeleteNamed(name string) bool {
+	return s.delete(nil, name)
+}
+
+func (s *Store) delete(id cpi.ConsumerIdentity, name string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	i := s.index(id, name)
+	if i < 0 {
+		return false
+	}
+	s.entries = append(s.entries[:i], s.entries[i+1:]...)
+	return true
+}
+
+func (s *Store) index(id cpi.ConsumerIdentity, name string) int {
+	for i, e := range s.entries {
+		if name != "" {
+			if e.Name == name {
+				return i
+			}
+			continue
+		}
+		if len(e.ConsumerId) > 0 && e.ConsumerId.Equals(id) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,64 @@
+package encrypted_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/extensions/repositories/encrypted"
+	oci "ocm.software/ocm/api/tech/oci/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("store", func() {
+	var fs vfs.FileSystem
+
+	id := oci.GetConsumerId("ghcr.io", "acme")
+	props := common.Properties{
+		cpi.ATTR_USERNAME: "user",
+		cpi.ATTR_PASSWORD: "pass",
+	}
+
+	BeforeEach(func() {
+		fs = memoryfs.New()
+	})
+
+	It("stores credentials with key file", func() {
+		key := Must(encrypted.CreateKeyFile("/home/.ocm/key", fs))
+		store := Must(encrypted.OpenStore("/home/.ocm/store", key, fs))
+		Expect(store.Entries()).To(BeEmpty())
+		store.Set(id, props)
+		store.SetNamed("test", common.Properties{cpi.ATTR_TOKEN: "token"})
+		MustBeSuccessful(store.Save())
+
+		data := Must(vfs.ReadFile(fs, "/home/.ocm/store"))
+		Expect(string(data)).To(HavePrefix("-----BEGIN ENCRYPTED DATA-----\nalgorithm: AES-256\n"))
+		Expect(string(data)).NotTo(ContainSubstring("pass"))
+
+		store = Must(encrypted.OpenStore("/home/.ocm/store", Must(encrypted.KeyFromFile("/home/.ocm/key", fs)), fs))
+		Expect(store.Get(id)).To(Equal(props))
+		Expect(store.GetNamed("test")).To(Equal(common.Properties{cpi.ATTR_TOKEN: "token"}))
+
+		Expect(store.Delete(id)).To(BeTrue())
+		Expect(store.Delete(id)).To(BeFalse())
+		Expect(store.Get(id)).To(BeNil())
+	})
+
+	It("stores credentials with passphrase", func() {
+		store := Must(encrypted.OpenStore("/store", encrypted.KeyFromPassphrase("secret"), fs))
+		store.Set(id, props)
+		MustBeSuccessful(store.Save())
+
+		data := Must(vfs.ReadFile(fs, "/store"))
+		Expect(string(data)).To(ContainSubstring("kdf: scrypt\n"))
+
+		store = Must(encrypted.OpenStore("/store", encrypted.KeyFromPassphrase("secret"), fs))
+		Expect(store.Get(id)).To(Equal(props))
+
+		ExpectError(encrypted.OpenStore("/store", encrypted.KeyFromPassphrase("wrong"), fs)).To(MatchError(`credential store "/store": cannot decrypt (wrong key?): cipher: message authentication failed`))
+		ExpectError(encrypted.OpenStore("/store", Must(encrypted.CreateKeyFile("/key", fs)), fs)).To(MatchError(`credential store "/store": store requires a passphrase`))
+	})
+})
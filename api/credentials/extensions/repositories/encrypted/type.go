@@ -0,0 +1,66 @@
+package encrypted
+
+import (
+	"github.com/mandelsoft/goutils/generics"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	"ocm.software/ocm/api/utils"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	// Type is the type of the encrypted credential store.
+	Type   = "EncryptedStore"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](Type))
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](TypeV1, cpi.WithDescription(usage), cpi.WithFormatSpec(format)))
+}
+
+// RepositorySpec describes an encrypted file based credential repository interface.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	StoreFile                   string `json:"storeFile,omitempty"`
+	KeyFile                     string `json:"keyFile,omitempty"`
+	PassphraseEnv               string `json:"passphraseEnv,omitempty"`
+	PropgateConsumerIdentity    *bool  `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// NewRepositorySpec creates a new encrypted store RepositorySpec.
+// If no store file is given, the default store file is used.
+func NewRepositorySpec(path string, keyFile string, propagate ...bool) *RepositorySpec {
+	var p *bool
+	if path == "" {
+		path = DefaultStoreFile()
+	}
+	if len(propagate) > 0 {
+		p = generics.Pointer(utils.OptionalDefaultedBool(true, propagate...))
+	}
+
+	return &RepositorySpec{
+		ObjectVersionedType:      runtime.NewVersionedTypedObject(Type),
+		StoreFile:                path,
+		KeyFile:                  keyFile,
+		PropgateConsumerIdentity: p,
+	}
+}
+
+func (rs *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (rs *RepositorySpec) Repository(ctx cpi.Context, _ cpi.Credentials) (cpi.Repository, error) {
+	fs := vfsattr.Get(ctx)
+	key, err := GetMasterKey(rs.KeyFile, rs.PassphraseEnv, fs)
+	if err != nil {
+		return nil, err
+	}
+	path := rs.StoreFile
+	if path == "" {
+		path = DefaultStoreFile()
+	}
+	return NewRepository(ctx, path, key, utils.AsBool(rs.PropgateConsumerIdentity, true), fs)
+}
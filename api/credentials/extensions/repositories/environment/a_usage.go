@@ -0,0 +1,52 @@
+package environment
+
+import (
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+var usage = `
+This repository type can be used to access credentials provided by
+environment variables, for example secrets injected into CI jobs.
+
+Environment variables following the naming scheme
+<code>&lt;prefix>&lt;CONSUMERTYPE>_&lt;HOSTNAME>_&lt;PROPERTY></code>
+are mapped to consumer ids with the given consumer type and hostname
+(default prefix is <code>` + DefaultPrefix + `</code>):
+
+- *<code>CONSUMERTYPE</code>*: the consumer type in upper case without
+  non-alphanumeric characters (for example <code>OCIREGISTRY</code> for
+  <code>OCIRegistry</code>). Only consumer types known by the credential
+  context are mapped.
+- *<code>HOSTNAME</code>*: the hostname in upper case. A single underscore
+  is mapped to a dot, a double underscore to a dash
+  (for example <code>GHCR_IO</code> for <code>ghcr.io</code>).
+- *<code>PROPERTY</code>*: the credential property in upper case (for example
+  <code>USERNAME</code>, <code>PASSWORD</code> or <code>IDENTITYTOKEN</code>).
+
+For example, the variables <code>OCM_CRED_OCIREGISTRY_GHCR_IO_USERNAME</code>
+and <code>OCM_CRED_OCIREGISTRY_GHCR_IO_PASSWORD</code> provide the credentials
+for the consumer id <code>type=OCIRegistry,hostname=ghcr.io</code>. Those
+credentials can be looked up with the name <code>OCIREGISTRY_GHCR_IO</code>.
+
+Consumer ids not covered by this scheme (for example with ports or
+path prefixes) can be configured with an explicit mapping table.
+Every mapping describes a consumer id and the environment variables
+used for its credential properties. Mapped credentials can be looked up
+by the mapping name.
+
+If enabled, the described credentials will be automatically assigned to the
+consumer ids.
+`
+
+var format = `The repository specification supports the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"prefix", "*string*(optional): the prefix of environment variables evaluated according to the naming scheme (default: " + DefaultPrefix + ")",
+	"mappings", "*[]mapping*(optional): explicit mappings of environment variables to consumer ids",
+	"propagateConsumerIdentity", "*bool*(optional): enable consumer id propagation",
+}) + `
+A mapping has the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"name", "*string*(optional): the credential name (default is the consumer id)",
+	"consumerId", "*map[string]string*: the consumer id",
+	"credentials", "*map[string]string*: the environment variables used for the credential properties",
+})
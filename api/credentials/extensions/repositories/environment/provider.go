@@ -0,0 +1,40 @@
+package environment
+
+import (
+	"maps"
+	"slices"
+
+	"ocm.software/ocm/api/credentials/cpi"
+)
+
+type ConsumerProvider struct {
+	repo *Repository
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func (p *ConsumerProvider) Unregister(_ cpi.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(ectx cpi.EvaluationContext, req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+func (p *ConsumerProvider) get(requested cpi.ConsumerIdentity, currentFound cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	var creds cpi.CredentialsSource
+
+	entries := p.repo.entries()
+	for _, n := range slices.Sorted(maps.Keys(entries)) {
+		e := entries[n]
+		if m(requested, currentFound, e.id) {
+			creds = cpi.NewCredentials(e.props)
+			currentFound = e.id
+		}
+	}
+	return creds, currentFound
+}
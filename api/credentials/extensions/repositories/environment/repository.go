@@ -0,0 +1,179 @@
+package environment
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	"ocm.software/ocm/api/utils"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	PROVIDER = "ocm.software/credentialprovider/" + Type
+
+	// DefaultPrefix is the default prefix of environment variables
+	// evaluated according to the naming scheme.
+	DefaultPrefix = "OCM_CRED_"
+)
+
+type Repository struct {
+	ctx      cpi.Context
+	prefix   string
+	mappings []Mapping
+}
+
+// entry describes the credentials found for a consumer id.
+type entry struct {
+	id    cpi.ConsumerIdentity
+	props common.Properties
+}
+
+func NewRepository(ctx cpi.Context, prefix string, mappings []Mapping, prop ...bool) (*Repository, error) {
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	for i, m := range mappings {
+		if len(m.ConsumerId) == 0 {
+			return nil, errors.Newf("mapping %d: consumer id required", i)
+		}
+		if len(m.Credentials) == 0 {
+			return nil, errors.Newf("mapping %d: credentials required", i)
+		}
+	}
+	r := &Repository{
+		ctx:      ctx,
+		prefix:   prefix,
+		mappings: mappings,
+	}
+	if utils.OptionalDefaultedBool(true, prop...) {
+		data, err := json.Marshal(mappings)
+		if err != nil {
+			return nil, err
+		}
+		id := cpi.ProviderIdentity(PROVIDER + "/" + prefix + "/" + digest.FromBytes(data).Encoded())
+		ctx.RegisterConsumerProvider(id, &ConsumerProvider{r})
+	}
+	return r, nil
+}
+
+var _ cpi.Repository = &Repository{}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	return r.entries()[name] != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	e := r.entries()[name]
+	if e == nil {
+		return nil, errors.ErrNotFound("credentials", name, Type)
+	}
+	return cpi.NewCredentials(e.props), nil
+}
+
+func (r *Repository) WriteCredentials(_ string, _ cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
+
+// entries evaluates the actual environment and provides the
+// found credentials by name.
+func (r *Repository) entries() map[string]*entry {
+	result := map[string]*entry{}
+
+	types := map[string]string{}
+	for _, i := range r.ctx.ConsumerIdentityMatchers().List() {
+		types[normalize(i.Type)] = i.Type
+	}
+
+	for _, env := range os.Environ() {
+		name, value, _ := strings.Cut(env, "=")
+		key, ok := strings.CutPrefix(name, r.prefix)
+		if !ok || value == "" {
+			continue
+		}
+		typ, rest, ok := strings.Cut(key, "_")
+		if !ok || types[typ] == "" {
+			continue
+		}
+		i := strings.LastIndex(rest, "_")
+		if i <= 0 {
+			continue
+		}
+		host, prop := rest[:i], rest[i+1:]
+		if prop == "" {
+			continue
+		}
+		stem := typ + "_" + host
+		e := result[stem]
+		if e == nil {
+			e = &entry{
+				id:    cpi.NewConsumerIdentity(types[typ], hostpath.ID_HOSTNAME, hostName(host)),
+				props: common.Properties{},
+			}
+			result[stem] = e
+		}
+		e.props[propertyName(prop)] = value
+	}
+
+	for _, m := range r.mappings {
+		props := common.Properties{}
+		for p, v := range m.Credentials {
+			props.SetNonEmptyValue(p, os.Getenv(v))
+		}
+		if len(props) == 0 {
+			continue
+		}
+		name := m.Name
+		if name == "" {
+			name = m.ConsumerId.String()
+		}
+		result[name] = &entry{id: m.ConsumerId, props: props}
+	}
+	return result
+}
+
+// properties are the well-known credential properties used to map
+// upper case environment variable name parts.
+var properties = []string{
+	cpi.ATTR_USERNAME,
+	cpi.ATTR_EMAIL,
+	cpi.ATTR_PASSWORD,
+	cpi.ATTR_SERVER_ADDRESS,
+	cpi.ATTR_TOKEN,
+	cpi.ATTR_IDENTITY_TOKEN,
+	cpi.ATTR_REGISTRY_TOKEN,
+	cpi.ATTR_KEY,
+	cpi.ATTR_CERTIFICATE_AUTHORITY,
+	cpi.ATTR_CERTIFICATE,
+	cpi.ATTR_PRIVATE_KEY,
+}
+
+func propertyName(s string) string {
+	for _, p := range properties {
+		if strings.ToUpper(p) == s {
+			return p
+		}
+	}
+	return strings.ToLower(s)
+}
+
+func hostName(s string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.ReplaceAll(s, "__", "-"), "_", "."))
+}
+
+// normalize maps a consumer type to its representation in
+// environment variable names.
+func normalize(typ string) string {
+	var b strings.Builder
+	for _, c := range strings.ToUpper(typ) {
+		if (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+		}
+	}
+	return b.String()
+}
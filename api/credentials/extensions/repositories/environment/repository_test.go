@@ -0,0 +1,113 @@
+package environment_test
+
+import (
+	"encoding/json"
+	"os"
+	"reflect"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	local "ocm.software/ocm/api/credentials/extensions/repositories/environment"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	oci "ocm.software/ocm/api/tech/oci/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+func setenv(name, value string) {
+	MustBeSuccessful(os.Setenv(name, value))
+	DeferCleanup(os.Unsetenv, name)
+}
+
+var _ = Describe("environment", func() {
+	props := common.Properties{
+		cpi.ATTR_USERNAME: "user",
+		cpi.ATTR_PASSWORD: "pass",
+	}
+
+	var DefaultContext credentials.Context
+
+	BeforeEach(func() {
+		DefaultContext = credentials.New()
+		setenv("OCM_CRED_OCIREGISTRY_GHCR_IO_USERNAME", "user")
+		setenv("OCM_CRED_OCIREGISTRY_GHCR_IO_PASSWORD", "pass")
+		setenv("OCM_CRED_OCIREGISTRY_MY__REGISTRY_ACME_ORG_IDENTITYTOKEN", "token")
+		setenv("OCM_CRED_UNKNOWN_ACME_ORG_PASSWORD", "unknown")
+		setenv("TEST_ENV_REPO_USER", "mapped")
+		setenv("TEST_ENV_REPO_PASS", "mappedpass")
+	})
+
+	specdata := "{\"type\":\"Environment\",\"mappings\":[{\"name\":\"local\",\"consumerId\":{\"hostname\":\"localhost\",\"port\":\"5000\",\"type\":\"OCIRegistry\"},\"credentials\":{\"password\":\"TEST_ENV_REPO_PASS\",\"username\":\"TEST_ENV_REPO_USER\"}}]}"
+
+	It("serializes repo spec", func() {
+		spec := local.NewRepositorySpec("", []local.Mapping{{
+			Name:       "local",
+			ConsumerId: cpi.NewConsumerIdentity(oci.CONSUMER_TYPE, hostpath.ID_HOSTNAME, "localhost", hostpath.ID_PORT, "5000"),
+			Credentials: map[string]string{
+				cpi.ATTR_USERNAME: "TEST_ENV_REPO_USER",
+				cpi.ATTR_PASSWORD: "TEST_ENV_REPO_PASS",
+			},
+		}})
+		data := Must(json.Marshal(spec))
+		Expect(data).To(Equal([]byte(specdata)))
+	})
+
+	It("deserializes repo spec", func() {
+		spec := Must(DefaultContext.RepositorySpecForConfig([]byte(specdata), nil))
+		Expect(reflect.TypeOf(spec).String()).To(Equal("*environment.RepositorySpec"))
+		Expect(spec.(*local.RepositorySpec).Mappings[0].Name).To(Equal("local"))
+	})
+
+	It("retrieves credentials", func() {
+		repo := Must(DefaultContext.RepositoryForConfig([]byte(specdata), nil))
+		Expect(reflect.TypeOf(repo).String()).To(Equal("*environment.Repository"))
+
+		creds := Must(repo.LookupCredentials("OCIREGISTRY_GHCR_IO"))
+		Expect(creds.Properties()).To(Equal(props))
+
+		creds = Must(repo.LookupCredentials("local"))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			cpi.ATTR_USERNAME: "mapped",
+			cpi.ATTR_PASSWORD: "mappedpass",
+		}))
+
+		Expect(repo.ExistsCredentials("UNKNOWN_ACME_ORG")).To(BeFalse())
+	})
+
+	It("propagates consumer ids", func() {
+		Must(DefaultContext.RepositoryForConfig([]byte(specdata), nil))
+
+		creds := Must(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("ghcr.io", "acme/image")))
+		Expect(creds.Properties()).To(Equal(props))
+
+		creds = Must(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("my-registry.acme.org", "acme/image")))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			cpi.ATTR_IDENTITY_TOKEN: "token",
+		}))
+
+		creds = Must(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("localhost:5000", "acme/image")))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			cpi.ATTR_USERNAME: "mapped",
+			cpi.ATTR_PASSWORD: "mappedpass",
+		}))
+
+		Expect(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("localhost:6000", "acme/image"))).To(BeNil())
+	})
+
+	It("uses a configured prefix", func() {
+		setenv("CI_OCIREGISTRY_GHCR_IO_TOKEN", "citoken")
+		Must(DefaultContext.RepositoryForSpec(local.NewRepositorySpec("CI_", nil)))
+
+		creds := Must(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("ghcr.io", "acme/image")))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			cpi.ATTR_TOKEN: "citoken",
+		}))
+	})
+
+	It("rejects invalid mappings", func() {
+		ExpectError(DefaultContext.RepositoryForSpec(local.NewRepositorySpec("", []local.Mapping{{Name: "x"}}))).To(MatchError("mapping 0: consumer id required"))
+	})
+})
@@ -0,0 +1,63 @@
+package environment
+
+import (
+	"github.com/mandelsoft/goutils/generics"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/utils"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	// Type is the type of the environment credential repository.
+	Type   = "Environment"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](Type))
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](TypeV1, cpi.WithDescription(usage), cpi.WithFormatSpec(format)))
+}
+
+// RepositorySpec describes an environment variable based credential repository interface.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	Prefix                      string    `json:"prefix,omitempty"`
+	Mappings                    []Mapping `json:"mappings,omitempty"`
+	PropgateConsumerIdentity    *bool     `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// Mapping explicitly maps environment variables to the credential
+// properties of a consumer id.
+type Mapping struct {
+	// Name is the name used to look up the credentials in the repository.
+	// If not given, the consumer id is used.
+	Name       string               `json:"name,omitempty"`
+	ConsumerId cpi.ConsumerIdentity `json:"consumerId"`
+	// Credentials maps credential property names to environment variable names.
+	Credentials map[string]string `json:"credentials"`
+}
+
+// NewRepositorySpec creates a new environment RepositorySpec.
+// If no prefix is given, the DefaultPrefix is used.
+func NewRepositorySpec(prefix string, mappings []Mapping, propagate ...bool) *RepositorySpec {
+	var p *bool
+	if len(propagate) > 0 {
+		p = generics.Pointer(utils.OptionalDefaultedBool(true, propagate...))
+	}
+
+	return &RepositorySpec{
+		ObjectVersionedType:      runtime.NewVersionedTypedObject(Type),
+		Prefix:                   prefix,
+		Mappings:                 mappings,
+		PropgateConsumerIdentity: p,
+	}
+}
+
+func (rs *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (rs *RepositorySpec) Repository(ctx cpi.Context, _ cpi.Credentials) (cpi.Repository, error) {
+	return NewRepository(ctx, rs.Prefix, rs.Mappings, utils.AsBool(rs.PropgateConsumerIdentity, true))
+}
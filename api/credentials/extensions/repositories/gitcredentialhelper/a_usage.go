@@ -0,0 +1,35 @@
+package gitcredentialhelper
+
+import (
+	"strings"
+
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+var usage = `
+This repository type can be used to access credentials provided by a
+git credential helper (see <code>git help credential</code>). The helper
+is invoked with the action <code>get</code> following the git credential
+helper protocol. Credentials can be looked up by a host name, optionally
+followed by a port and a path, or a URL.
+
+If enabled, the credentials are automatically provided for host based
+consumer ids of the configured consumer types. The credential properties
+<code>username</code> and <code>password</code> are provided. For the consumer
+type <code>Github</code> the password is provided as property <code>token</code>,
+also.
+
+The helper is specified like for the git option <code>credential.helper</code>:
+a name (for example <code>store</code> or <code>osxkeychain</code>), which is
+executed as <code>git credential-&lt;name></code>, an absolute path of a helper
+executable or a shell command prefixed with <code>!</code>. Arguments may be
+added separated by spaces.
+`
+
+var format = `The repository specification supports the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"helper", "*string*: the git credential helper to call",
+	"protocol", "*string*(optional): the protocol passed to the helper if the consumer id does not specify a scheme (default: " + DefaultProtocol + ")",
+	"consumerTypes", "*[]string*(optional): the consumer types the helper is used for (default: " + strings.Join(DefaultConsumerTypes, ", ") + ")",
+	"propagateConsumerIdentity", "*bool*(optional): enable consumer id propagation",
+})
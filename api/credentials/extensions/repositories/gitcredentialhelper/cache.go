@@ -0,0 +1,34 @@
+package gitcredentialhelper
+
+import (
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/datacontext"
+)
+
+type Cache struct {
+	repos map[string]*Repository
+}
+
+func createCache(_ datacontext.Context) interface{} {
+	return &Cache{
+		repos: map[string]*Repository{},
+	}
+}
+
+func (r *Cache) GetRepository(ctx cpi.Context, helper string, protocol string, types []string, prop bool) (*Repository, error) {
+	var (
+		err  error = nil
+		repo *Repository
+	)
+	key := repositoryKey(helper, protocol, types)
+	if helper != "" {
+		repo = r.repos[key]
+	}
+	if repo == nil {
+		repo, err = NewRepository(ctx, helper, protocol, types, prop)
+		if err == nil {
+			r.repos[key] = repo
+		}
+	}
+	return repo, err
+}
@@ -0,0 +1,99 @@
+package gitcredentialhelper
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+// Request describes the attributes passed to a credential helper.
+type Request struct {
+	Protocol string
+	Host     string
+	Path     string
+}
+
+func (r Request) String() string {
+	s := fmt.Sprintf("protocol=%s\nhost=%s\n", r.Protocol, r.Host)
+	if r.Path != "" {
+		s += fmt.Sprintf("path=%s\n", r.Path)
+	}
+	return s
+}
+
+// Helper executes a git credential helper. Results are cached
+// per request.
+type Helper struct {
+	lock    sync.Mutex
+	command string
+	cache   map[Request]common.Properties
+}
+
+// NewHelper creates a helper for a helper specification following the
+// syntax of the git option credential.helper.
+func NewHelper(spec string) *Helper {
+	spec = strings.TrimSpace(spec)
+	var cmd string
+	switch {
+	case strings.HasPrefix(spec, "!"):
+		cmd = spec[1:]
+	case filepath.IsAbs(spec):
+		cmd = spec
+	default:
+		cmd = "git credential-" + spec
+	}
+	return &Helper{
+		command: cmd,
+		cache:   map[Request]common.Properties{},
+	}
+}
+
+// Get calls the helper with action get and provides the returned
+// attributes.
+func (h *Helper) Get(req Request) (common.Properties, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if props, ok := h.cache[req]; ok {
+		return props, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("sh", "-c", h.command+" get")
+	cmd.Stdin = strings.NewReader(req.String() + "\n")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return nil, errors.Wrapf(err, "credential helper %q failed: %s", h.command, strings.TrimSpace(stderr.String()))
+		}
+		return nil, errors.Wrapf(err, "credential helper %q failed", h.command)
+	}
+
+	props := common.Properties{}
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+		if k, v, ok := strings.Cut(line, "="); ok {
+			props[k] = v
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	h.cache[req] = props
+	return props, nil
+}
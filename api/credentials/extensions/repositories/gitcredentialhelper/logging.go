@@ -0,0 +1,7 @@
+package gitcredentialhelper
+
+import (
+	ocmlog "ocm.software/ocm/api/utils/logging"
+)
+
+var REALM = ocmlog.DefineSubRealm("git credential helper as credential repository", "credentials/gitcredentialhelper")
@@ -0,0 +1,81 @@
+package gitcredentialhelper
+
+import (
+	"slices"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	github "ocm.software/ocm/api/tech/github/identity"
+	helm "ocm.software/ocm/api/tech/helm/identity"
+	maven "ocm.software/ocm/api/tech/maven/identity"
+	wget "ocm.software/ocm/api/tech/wget/identity"
+	"ocm.software/ocm/api/utils/logging"
+)
+
+// DefaultConsumerTypes are the host based consumer types the helper is
+// used for, if no consumer types are configured.
+var DefaultConsumerTypes = []string{
+	wget.CONSUMER_TYPE,
+	maven.CONSUMER_TYPE,
+	helm.CONSUMER_TYPE,
+	github.CONSUMER_TYPE,
+}
+
+type ConsumerProvider struct {
+	repo  *Repository
+	types []string
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func (p *ConsumerProvider) Unregister(_ cpi.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(ectx cpi.EvaluationContext, req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+// get asks the helper for the host and path described by the requested
+// consumer id. Credentials provided by the helper are assigned to the
+// requested id.
+func (p *ConsumerProvider) get(requested cpi.ConsumerIdentity, currentFound cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	typ := requested[cpi.ID_TYPE]
+	host := requested[hostpath.ID_HOSTNAME]
+	if host == "" || !slices.Contains(p.types, typ) {
+		return nil, currentFound
+	}
+
+	// the helper is only called, if it could provide a better match
+	id := requested.Copy()
+	if !m(requested, currentFound, id) {
+		return nil, currentFound
+	}
+
+	req := Request{
+		Protocol: requested[hostpath.ID_SCHEME],
+		Host:     host,
+		Path:     hostpath.PathPrefix(requested),
+	}
+	if req.Protocol == "" {
+		req.Protocol = p.repo.protocol
+	}
+	if port := requested[hostpath.ID_PORT]; port != "" {
+		req.Host += ":" + port
+	}
+
+	props, err := p.repo.lookup(req)
+	if err != nil {
+		log := logging.Context().Logger(REALM)
+		log.LogError(err, "Failed to call git credential helper", "host", req.Host, "path", req.Path)
+		return nil, currentFound
+	}
+	if props == nil {
+		return nil, currentFound
+	}
+	return newCredentials(props, typ), id
+}
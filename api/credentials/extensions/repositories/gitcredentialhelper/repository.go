@@ -0,0 +1,126 @@
+package gitcredentialhelper
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	github "ocm.software/ocm/api/tech/github/identity"
+	"ocm.software/ocm/api/utils"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	PROVIDER = "ocm.software/credentialprovider/" + Type
+
+	// DefaultProtocol is the protocol passed to the helper if no
+	// protocol is known.
+	DefaultProtocol = "https"
+)
+
+type Repository struct {
+	ctx      cpi.Context
+	helper   *Helper
+	protocol string
+}
+
+// NewRepository creates a repository for the given credential helper. If
+// propagation is enabled, the helper is used for consumer ids of the
+// given consumer types (default: DefaultConsumerTypes).
+func NewRepository(ctx cpi.Context, helper string, protocol string, types []string, prop ...bool) (*Repository, error) {
+	return newRepository(ctx, helper, protocol, types, utils.OptionalDefaultedBool(true, prop...))
+}
+
+func newRepository(ctx cpi.Context, helper string, protocol string, types []string, prop bool) (*Repository, error) {
+	if helper == "" {
+		return nil, errors.New("git credential helper not provided")
+	}
+	if protocol == "" {
+		protocol = DefaultProtocol
+	}
+	if len(types) == 0 {
+		types = DefaultConsumerTypes
+	}
+	r := &Repository{
+		ctx:      ctx,
+		helper:   NewHelper(helper),
+		protocol: protocol,
+	}
+	if prop {
+		ctx.RegisterConsumerProvider(cpi.ProviderIdentity(PROVIDER+"/"+repositoryKey(helper, protocol, types)), &ConsumerProvider{r, types})
+	}
+	return r, nil
+}
+
+var _ cpi.Repository = &Repository{}
+
+// repositoryKey identifies a repository by the helper and
+// the settings influencing the provided credentials.
+func repositoryKey(helper string, protocol string, types []string) string {
+	return helper + "|" + protocol + "|" + strings.Join(types, ",")
+}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	props, err := r.get(name)
+	if err != nil {
+		return false, err
+	}
+	return props != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	props, err := r.get(name)
+	if err != nil {
+		return nil, err
+	}
+	if props == nil {
+		return nil, errors.ErrNotFound("credentials", name, Type)
+	}
+	return newCredentials(props, ""), nil
+}
+
+func (r *Repository) WriteCredentials(_ string, _ cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
+
+// get provides the helper result for a credential name, which is
+// either a URL or a host name optionally followed by a path.
+func (r *Repository) get(name string) (common.Properties, error) {
+	req := Request{Protocol: r.protocol}
+	if strings.Contains(name, "://") {
+		u, err := url.Parse(name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid credential name %q", name)
+		}
+		req.Protocol = u.Scheme
+		req.Host = u.Host
+		req.Path = strings.Trim(u.Path, "/")
+	} else {
+		req.Host, req.Path, _ = strings.Cut(name, "/")
+	}
+	return r.lookup(req)
+}
+
+// lookup calls the helper and provides the result, if it contains credentials.
+func (r *Repository) lookup(req Request) (common.Properties, error) {
+	props, err := r.helper.Get(req)
+	if err != nil {
+		return nil, err
+	}
+	if props[cpi.ATTR_USERNAME] == "" && props[cpi.ATTR_PASSWORD] == "" {
+		return nil, nil
+	}
+	return props, nil
+}
+
+func newCredentials(found common.Properties, typ string) cpi.Credentials {
+	props := common.Properties{}
+	props.SetNonEmptyValue(cpi.ATTR_USERNAME, found[cpi.ATTR_USERNAME])
+	props.SetNonEmptyValue(cpi.ATTR_PASSWORD, found[cpi.ATTR_PASSWORD])
+	if typ == github.CONSUMER_TYPE {
+		props.SetNonEmptyValue(github.ATTR_TOKEN, found[cpi.ATTR_PASSWORD])
+	}
+	return cpi.NewCredentials(props)
+}
@@ -0,0 +1,98 @@
+package gitcredentialhelper_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	local "ocm.software/ocm/api/credentials/extensions/repositories/gitcredentialhelper"
+	github "ocm.software/ocm/api/tech/github/identity"
+	oci "ocm.software/ocm/api/tech/oci/identity"
+	wget "ocm.software/ocm/api/tech/wget/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("git credential helper", func() {
+	props := common.Properties{
+		cpi.ATTR_USERNAME: "git",
+		cpi.ATTR_PASSWORD: "secret",
+	}
+
+	var DefaultContext credentials.Context
+	var helper string
+
+	BeforeEach(func() {
+		DefaultContext = credentials.New()
+		helper = Must(filepath.Abs("testdata/helper.sh"))
+	})
+
+	specdata := "{\"type\":\"GitCredentialHelper\",\"helper\":\"store\"}"
+
+	It("serializes repo spec", func() {
+		spec := local.NewRepositorySpec("store")
+		data := Must(json.Marshal(spec))
+		Expect(data).To(Equal([]byte(specdata)))
+	})
+
+	It("deserializes repo spec", func() {
+		spec := Must(DefaultContext.RepositorySpecForConfig([]byte(specdata), nil))
+		Expect(reflect.TypeOf(spec).String()).To(Equal("*gitcredentialhelper.RepositorySpec"))
+		Expect(spec.(*local.RepositorySpec).Helper).To(Equal("store"))
+	})
+
+	It("retrieves credentials", func() {
+		repo := Must(DefaultContext.RepositoryForSpec(local.NewRepositorySpec(helper)))
+		Expect(reflect.TypeOf(repo).String()).To(Equal("*gitcredentialhelper.Repository"))
+
+		creds := Must(repo.LookupCredentials("git.acme.org"))
+		Expect(creds.Properties()).To(Equal(props))
+		creds = Must(repo.LookupCredentials("https://github.com/acme/repo"))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			cpi.ATTR_USERNAME: "octocat",
+			cpi.ATTR_PASSWORD: "ghp_token",
+		}))
+
+		Expect(repo.ExistsCredentials("github.com/other")).To(BeFalse())
+		Expect(repo.ExistsCredentials("http://git.acme.org")).To(BeFalse())
+	})
+
+	It("propagates consumer ids", func() {
+		Must(DefaultContext.RepositoryForSpec(local.NewRepositorySpec("!" + helper)))
+
+		creds := Must(cpi.CredentialsForConsumer(DefaultContext, wget.GetConsumerId("https://git.acme.org:8443/files/a.tgz")))
+		Expect(creds.Properties()).To(Equal(props))
+
+		creds = Must(cpi.CredentialsForConsumer(DefaultContext, github.GetConsumerId("https://github.com", "acme/repo")))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			cpi.ATTR_USERNAME: "octocat",
+			cpi.ATTR_PASSWORD: "ghp_token",
+			github.ATTR_TOKEN: "ghp_token",
+		}))
+
+		Expect(cpi.CredentialsForConsumer(DefaultContext, github.GetConsumerId("https://github.com", "other/repo"))).To(BeNil())
+		Expect(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("git.acme.org", "acme/image"))).To(BeNil())
+	})
+
+	It("propagates consumer ids for specs with different consumer types", func() {
+		spec := local.NewRepositorySpec("!" + helper)
+		spec.ConsumerTypes = []string{oci.CONSUMER_TYPE}
+		Must(DefaultContext.RepositoryForSpec(spec))
+		Must(DefaultContext.RepositoryForSpec(local.NewRepositorySpec("!" + helper)))
+
+		creds := Must(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("git.acme.org", "acme/image")))
+		Expect(creds.Properties()).To(Equal(props))
+		creds = Must(cpi.CredentialsForConsumer(DefaultContext, wget.GetConsumerId("https://git.acme.org:8443/files/a.tgz")))
+		Expect(creds.Properties()).To(Equal(props))
+	})
+
+	It("fails for failing helpers", func() {
+		repo := Must(DefaultContext.RepositoryForSpec(local.NewRepositorySpec("!exit 1")))
+		ExpectError(repo.LookupCredentials("git.acme.org")).To(MatchError(`credential helper "exit 1" failed: exit status 1`))
+	})
+})
@@ -0,0 +1,27 @@
+#!/bin/sh
+# test credential helper providing credentials for git.acme.org
+# and the path acme on github.com
+
+[ "$1" = get ] || exit 0
+
+while IFS='=' read -r key value; do
+  [ -z "$key" ] && break
+  case "$key" in
+    protocol) protocol="$value";;
+    host) host="$value";;
+    path) path="$value";;
+  esac
+done
+
+case "$protocol://$host/$path" in
+  https://git.acme.org/*|https://git.acme.org:8443/*)
+    echo "protocol=$protocol"
+    echo "host=$host"
+    echo "username=git"
+    echo "password=secret"
+    ;;
+  https://github.com/acme*)
+    echo "username=octocat"
+    echo "password=ghp_token"
+    ;;
+esac
@@ -0,0 +1,59 @@
+package gitcredentialhelper
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/goutils/generics"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/utils"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	// Type is the type of the GitCredentialHelper credential repository.
+	Type   = "GitCredentialHelper"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](Type))
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](TypeV1, cpi.WithDescription(usage), cpi.WithFormatSpec(format)))
+}
+
+// RepositorySpec describes a git credential helper based credential repository interface.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	Helper                      string   `json:"helper"`
+	Protocol                    string   `json:"protocol,omitempty"`
+	ConsumerTypes               []string `json:"consumerTypes,omitempty"`
+	PropgateConsumerIdentity    *bool    `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// NewRepositorySpec creates a new git credential helper RepositorySpec.
+// The helper is specified like for the git option credential.helper.
+func NewRepositorySpec(helper string, propagate ...bool) *RepositorySpec {
+	var p *bool
+	if len(propagate) > 0 {
+		p = generics.Pointer(utils.OptionalDefaultedBool(true, propagate...))
+	}
+
+	return &RepositorySpec{
+		ObjectVersionedType:      runtime.NewVersionedTypedObject(Type),
+		Helper:                   helper,
+		PropgateConsumerIdentity: p,
+	}
+}
+
+func (rs *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (rs *RepositorySpec) Repository(ctx cpi.Context, _ cpi.Credentials) (cpi.Repository, error) {
+	r := ctx.GetAttributes().GetOrCreateAttribute(".gitcredentialhelper", createCache)
+	cache, ok := r.(*Cache)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type %T to Cache", r)
+	}
+	return cache.GetRepository(ctx, rs.Helper, rs.Protocol, rs.ConsumerTypes, utils.AsBool(rs.PropgateConsumerIdentity, true))
+}
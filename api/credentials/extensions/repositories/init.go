@@ -4,9 +4,14 @@ import (
 	_ "ocm.software/ocm/api/credentials/extensions/repositories/aliases"
 	_ "ocm.software/ocm/api/credentials/extensions/repositories/directcreds"
 	_ "ocm.software/ocm/api/credentials/extensions/repositories/dockerconfig"
+	_ "ocm.software/ocm/api/credentials/extensions/repositories/encrypted"
+	_ "ocm.software/ocm/api/credentials/extensions/repositories/environment"
 	_ "ocm.software/ocm/api/credentials/extensions/repositories/gardenerconfig"
+	_ "ocm.software/ocm/api/credentials/extensions/repositories/gitcredentialhelper"
 	_ "ocm.software/ocm/api/credentials/extensions/repositories/memory"
 	_ "ocm.software/ocm/api/credentials/extensions/repositories/memory/config"
+	_ "ocm.software/ocm/api/credentials/extensions/repositories/netrc"
 	_ "ocm.software/ocm/api/credentials/extensions/repositories/npm"
+	_ "ocm.software/ocm/api/credentials/extensions/repositories/tokenexchange"
 	_ "ocm.software/ocm/api/credentials/extensions/repositories/vault"
 )
@@ -0,0 +1,28 @@
+package netrc
+
+import (
+	"strings"
+
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+var usage = `
+This repository type can be used to access credentials stored in a file
+following the netrc format (~/.netrc), which is used by tools like curl,
+wget or git. Every <code>machine</code> entry provides the credentials
+for the given host. The credentials can be looked up by the machine name.
+The <code>default</code> entry is ignored.
+
+If enabled, the described credentials will be automatically assigned to
+host based consumer ids of the configured consumer types. The
+credential properties <code>username</code> and <code>password</code> are
+provided. For the consumer type <code>Github</code> the password is
+provided as property <code>token</code>, also.
+`
+
+var format = `The repository specification supports the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"netrcFile", "*string*: the file path to a netrc file (default is <code>$NETRC</code> or <code>~/.netrc</code>)",
+	"consumerTypes", "*[]string*(optional): the consumer types used for consumer id propagation (default: " + strings.Join(DefaultConsumerTypes, ", ") + ")",
+	"propagateConsumerIdentity", "*bool*(optional): enable consumer id propagation",
+})
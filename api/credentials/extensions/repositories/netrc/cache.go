@@ -0,0 +1,36 @@
+package netrc
+
+import (
+	"strings"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/datacontext"
+)
+
+type Cache struct {
+	repos map[string]*Repository
+}
+
+func createCache(_ datacontext.Context) interface{} {
+	return &Cache{
+		repos: map[string]*Repository{},
+	}
+}
+
+func (r *Cache) GetRepository(ctx cpi.Context, name string, types []string, prop bool) (*Repository, error) {
+	var (
+		err  error = nil
+		repo *Repository
+	)
+	key := name + ":" + strings.Join(types, ",")
+	if name != "" {
+		repo = r.repos[key]
+	}
+	if repo == nil {
+		repo, err = NewRepository(ctx, name, types, prop)
+		if err == nil {
+			r.repos[key] = repo
+		}
+	}
+	return repo, err
+}
@@ -0,0 +1,163 @@
+package netrc
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/utils"
+)
+
+// MachineEntry is a machine entry of a netrc file.
+// The default entry has an empty machine name.
+type MachineEntry struct {
+	Machine  string
+	Login    string
+	Password string
+	Account  string
+}
+
+type netrcConfig []*MachineEntry
+
+// Get provides the entry for the given machine.
+func (c netrcConfig) Get(machine string) *MachineEntry {
+	for _, e := range c {
+		if e.Machine != "" && e.Machine == machine {
+			return e
+		}
+	}
+	return nil
+}
+
+// readNetRCFile reads a netrc file and returns its entries.
+func readNetRCFile(path string) (netrcConfig, string, error) {
+	path, err := utils.ResolvePath(path)
+	if err != nil {
+		return nil, path, errors.Wrapf(err, "cannot resolve path %q", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, path, err
+	}
+	cfg, err := parseNetRC(data)
+	if err != nil {
+		return nil, path, errors.Wrapf(err, "invalid netrc file %q", path)
+	}
+	return cfg, path, nil
+}
+
+// parseNetRC parses the content of a netrc file.
+// Macro definitions (macdef) are skipped up to the next empty line and
+// lines starting with # are treated as comments.
+func parseNetRC(data []byte) (netrcConfig, error) {
+	var (
+		cfg     netrcConfig
+		cur     *MachineEntry
+		key     string
+		inMacro bool
+	)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if inMacro {
+			if strings.TrimSpace(line) == "" {
+				inMacro = false
+			}
+			continue
+		}
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		tokens, err := tokenize(line)
+		if err != nil {
+			return nil, err
+		}
+	loop:
+		for _, t := range tokens {
+			if key != "" {
+				switch key {
+				case "machine":
+					cur = &MachineEntry{Machine: t}
+					cfg = append(cfg, cur)
+				case "login":
+					cur.Login = t
+				case "password":
+					cur.Password = t
+				case "account":
+					cur.Account = t
+				case "macdef":
+					inMacro = true
+				}
+				key = ""
+				if inMacro {
+					// the rest of the line belongs to the macro
+					break loop
+				}
+				continue
+			}
+			switch t {
+			case "machine", "macdef":
+				key = t
+			case "default":
+				cur = &MachineEntry{}
+				cfg = append(cfg, cur)
+			case "login", "password", "account":
+				if cur == nil {
+					return nil, errors.Newf("%s without machine", t)
+				}
+				key = t
+			default:
+				return nil, errors.Newf("unexpected token %q", t)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if key != "" && key != "macdef" {
+		return nil, errors.Newf("missing value for %s", key)
+	}
+	return cfg, nil
+}
+
+// tokenize splits a line into whitespace separated tokens.
+// Tokens may be quoted with double quotes.
+func tokenize(line string) ([]string, error) {
+	var (
+		result []string
+		token  strings.Builder
+		quoted bool
+		found  bool
+	)
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quoted && c == '\\' && i+1 < len(line):
+			i++
+			token.WriteByte(line[i])
+		case c == '"':
+			quoted = !quoted
+			found = true
+		case !quoted && (c == ' ' || c == '\t' || c == '\r'):
+			if found {
+				result = append(result, token.String())
+				token.Reset()
+				found = false
+			}
+		default:
+			token.WriteByte(c)
+			found = true
+		}
+	}
+	if quoted {
+		return nil, errors.Newf("unterminated quote")
+	}
+	if found {
+		result = append(result, token.String())
+	}
+	return result, nil
+}
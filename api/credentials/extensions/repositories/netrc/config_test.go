@@ -0,0 +1,43 @@
+package netrc
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("netrc parser", func() {
+	It("parses entries", func() {
+		cfg := Must(parseNetRC([]byte(`
+machine a.org login a password "pass word"
+machine b.org
+  login b
+  account acct
+  password "quoted \" pass"
+default login anon password anon
+`)))
+		Expect(cfg).To(Equal(netrcConfig{
+			{Machine: "a.org", Login: "a", Password: "pass word"},
+			{Machine: "b.org", Login: "b", Password: `quoted " pass`, Account: "acct"},
+			{Login: "anon", Password: "anon"},
+		}))
+	})
+
+	It("skips macros", func() {
+		cfg := Must(parseNetRC([]byte(`
+macdef init
+machine x.org login x password x
+
+machine a.org login a password a
+`)))
+		Expect(cfg).To(Equal(netrcConfig{
+			{Machine: "a.org", Login: "a", Password: "a"},
+		}))
+	})
+
+	It("rejects invalid files", func() {
+		ExpectError(parseNetRC([]byte("login a"))).To(MatchError("login without machine"))
+		ExpectError(parseNetRC([]byte("machine a.org login"))).To(MatchError("missing value for login"))
+		ExpectError(parseNetRC([]byte("machine a.org other"))).To(MatchError(`unexpected token "other"`))
+	})
+})
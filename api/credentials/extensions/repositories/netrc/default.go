@@ -0,0 +1,27 @@
+package netrc
+
+import (
+	"os"
+
+	"github.com/mandelsoft/filepath/pkg/filepath"
+)
+
+const (
+	ConfigFileName = ".netrc"
+
+	// ENV_NETRC is the environment variable used by curl and git to
+	// specify an alternative netrc file.
+	ENV_NETRC = "NETRC"
+)
+
+// DefaultConfig provides the path of the default netrc file.
+func DefaultConfig() (string, error) {
+	if p := os.Getenv(ENV_NETRC); p != "" {
+		return p, nil
+	}
+	d, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(d, ConfigFileName), nil
+}
@@ -0,0 +1,7 @@
+package netrc
+
+import (
+	ocmlog "ocm.software/ocm/api/utils/logging"
+)
+
+var REALM = ocmlog.DefineSubRealm("netrc file handling as credential repository", "credentials/netrc")
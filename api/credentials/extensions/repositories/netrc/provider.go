@@ -0,0 +1,65 @@
+package netrc
+
+import (
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/identity/hostpath"
+	github "ocm.software/ocm/api/tech/github/identity"
+	helm "ocm.software/ocm/api/tech/helm/identity"
+	maven "ocm.software/ocm/api/tech/maven/identity"
+	wget "ocm.software/ocm/api/tech/wget/identity"
+	"ocm.software/ocm/api/utils/logging"
+)
+
+// DefaultConsumerTypes are the host based consumer types the machine
+// entries are propagated to, if no consumer types are configured.
+var DefaultConsumerTypes = []string{
+	wget.CONSUMER_TYPE,
+	maven.CONSUMER_TYPE,
+	helm.CONSUMER_TYPE,
+	github.CONSUMER_TYPE,
+}
+
+type ConsumerProvider struct {
+	netrcPath string
+	types     []string
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func (p *ConsumerProvider) Unregister(_ cpi.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(ectx cpi.EvaluationContext, req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+func (p *ConsumerProvider) get(requested cpi.ConsumerIdentity, currentFound cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	all, path, err := readNetRCFile(p.netrcPath)
+	if err != nil {
+		log := logging.Context().Logger(REALM)
+		log.LogError(err, "Failed to read netrc file", "path", path)
+		return nil, currentFound
+	}
+
+	var creds cpi.CredentialsSource
+
+	for _, e := range all {
+		if e.Machine == "" {
+			continue
+		}
+		for _, typ := range p.types {
+			id := cpi.NewConsumerIdentity(typ, hostpath.ID_HOSTNAME, e.Machine)
+			if m(requested, currentFound, id) {
+				creds = newCredentials(e, typ)
+				currentFound = id
+			}
+		}
+	}
+
+	return creds, currentFound
+}
@@ -0,0 +1,100 @@
+package netrc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	github "ocm.software/ocm/api/tech/github/identity"
+	"ocm.software/ocm/api/utils"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const PROVIDER = "ocm.software/credentialprovider/" + Type
+
+type Repository struct {
+	ctx       cpi.Context
+	path      string
+	types     []string
+	propagate bool
+	netrc     netrcConfig
+}
+
+// NewRepository creates a repository for the given netrc file. If
+// propagation is enabled, the machine entries are provided for consumer ids
+// of the given consumer types (default: DefaultConsumerTypes).
+func NewRepository(ctx cpi.Context, path string, types []string, prop ...bool) (*Repository, error) {
+	return newRepository(ctx, path, types, utils.OptionalDefaultedBool(true, prop...))
+}
+
+func newRepository(ctx cpi.Context, path string, types []string, prop bool) (*Repository, error) {
+	if len(types) == 0 {
+		types = DefaultConsumerTypes
+	}
+	r := &Repository{
+		ctx:       ctx,
+		path:      path,
+		types:     types,
+		propagate: prop,
+	}
+	err := r.Read(true)
+	return r, err
+}
+
+var _ cpi.Repository = &Repository{}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	err := r.Read(false)
+	if err != nil {
+		return false, err
+	}
+	return r.netrc.Get(name) != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	exists, err := r.ExistsCredentials(name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.ErrNotFound("credentials", name, Type)
+	}
+	return newCredentials(r.netrc.Get(name), ""), nil
+}
+
+func (r *Repository) WriteCredentials(_ string, _ cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
+
+func (r *Repository) Read(force bool) error {
+	if !force && r.netrc != nil {
+		return nil
+	}
+
+	if r.path == "" {
+		return errors.New("netrc path not provided")
+	}
+	cfg, path, err := readNetRCFile(r.path)
+	if err != nil {
+		return fmt.Errorf("failed to load netrc: %w", err)
+	}
+	id := cpi.ProviderIdentity(PROVIDER + "/" + path + ":" + strings.Join(r.types, ","))
+
+	if r.propagate {
+		r.ctx.RegisterConsumerProvider(id, &ConsumerProvider{r.path, r.types})
+	}
+	r.netrc = cfg
+	return nil
+}
+
+func newCredentials(e *MachineEntry, typ string) cpi.Credentials {
+	props := common.Properties{}
+	props.SetNonEmptyValue(cpi.ATTR_USERNAME, e.Login)
+	props.SetNonEmptyValue(cpi.ATTR_PASSWORD, e.Password)
+	if typ == github.CONSUMER_TYPE {
+		props.SetNonEmptyValue(github.ATTR_TOKEN, e.Password)
+	}
+	return cpi.NewCredentials(props)
+}
@@ -0,0 +1,98 @@
+package netrc_test
+
+import (
+	"encoding/json"
+	"reflect"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	local "ocm.software/ocm/api/credentials/extensions/repositories/netrc"
+	github "ocm.software/ocm/api/tech/github/identity"
+	maven "ocm.software/ocm/api/tech/maven/identity"
+	oci "ocm.software/ocm/api/tech/oci/identity"
+	wget "ocm.software/ocm/api/tech/wget/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("netrc", func() {
+	props := common.Properties{
+		cpi.ATTR_USERNAME: "maven",
+		cpi.ATTR_PASSWORD: "mavenpass",
+	}
+
+	var DefaultContext credentials.Context
+
+	BeforeEach(func() {
+		DefaultContext = credentials.New()
+	})
+
+	specdata := "{\"type\":\"NetRC\",\"netrcFile\":\"testdata/.netrc\"}"
+
+	It("serializes repo spec", func() {
+		spec := local.NewRepositorySpec("testdata/.netrc")
+		data := Must(json.Marshal(spec))
+		Expect(data).To(Equal([]byte(specdata)))
+	})
+
+	It("deserializes repo spec", func() {
+		spec := Must(DefaultContext.RepositorySpecForConfig([]byte(specdata), nil))
+		Expect(reflect.TypeOf(spec).String()).To(Equal("*netrc.RepositorySpec"))
+		Expect(spec.(*local.RepositorySpec).NetRCFile).To(Equal("testdata/.netrc"))
+	})
+
+	It("retrieves credentials", func() {
+		repo := Must(DefaultContext.RepositoryForConfig([]byte(specdata), nil))
+		Expect(reflect.TypeOf(repo).String()).To(Equal("*netrc.Repository"))
+
+		creds := Must(repo.LookupCredentials("repo.acme.org"))
+		Expect(creds.Properties()).To(Equal(props))
+
+		creds = Must(repo.LookupCredentials("github.com"))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			cpi.ATTR_USERNAME: "octocat",
+			cpi.ATTR_PASSWORD: "ghp_token",
+		}))
+
+		Expect(repo.ExistsCredentials("ignored.org")).To(BeFalse())
+		Expect(repo.ExistsCredentials("other.org")).To(BeFalse())
+	})
+
+	It("uses default netrc file", func() {
+		GinkgoT().Setenv(local.ENV_NETRC, "testdata/.netrc")
+		repo := Must(DefaultContext.RepositoryForConfig([]byte(`{"type":"NetRC"}`), nil))
+		creds := Must(repo.LookupCredentials("repo.acme.org"))
+		Expect(creds.Properties()).To(Equal(props))
+	})
+
+	It("propagates consumer ids", func() {
+		Must(DefaultContext.RepositoryForConfig([]byte(specdata), nil))
+
+		creds := Must(cpi.CredentialsForConsumer(DefaultContext, Must(maven.GetConsumerId("https://repo.acme.org/maven2", "org.acme"))))
+		Expect(creds.Properties()).To(Equal(props))
+
+		creds = Must(cpi.CredentialsForConsumer(DefaultContext, wget.GetConsumerId("https://repo.acme.org/files/a.tgz")))
+		Expect(creds.Properties()).To(Equal(props))
+
+		creds = Must(cpi.CredentialsForConsumer(DefaultContext, github.GetConsumerId("https://github.com", "acme/repo")))
+		Expect(creds.Properties()).To(Equal(common.Properties{
+			cpi.ATTR_USERNAME: "octocat",
+			cpi.ATTR_PASSWORD: "ghp_token",
+			github.ATTR_TOKEN: "ghp_token",
+		}))
+
+		Expect(cpi.CredentialsForConsumer(DefaultContext, wget.GetConsumerId("https://other.org/a.tgz"))).To(BeNil())
+		Expect(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("repo.acme.org", "acme/image"))).To(BeNil())
+	})
+
+	It("propagates consumer ids for configured types", func() {
+		Must(DefaultContext.RepositoryForConfig([]byte("{\"type\":\"NetRC\",\"netrcFile\":\"testdata/.netrc\",\"consumerTypes\":[\"OCIRegistry\"]}"), nil))
+
+		creds := Must(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("repo.acme.org", "acme/image")))
+		Expect(creds.Properties()).To(Equal(props))
+		Expect(cpi.CredentialsForConsumer(DefaultContext, wget.GetConsumerId("https://repo.acme.org/files/a.tgz"))).To(BeNil())
+	})
+})
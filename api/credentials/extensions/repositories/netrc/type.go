@@ -0,0 +1,72 @@
+package netrc
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/goutils/generics"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/utils"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	// Type is the type of the NetRC credential repository.
+	Type   = "NetRC"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](Type))
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](TypeV1, cpi.WithDescription(usage), cpi.WithFormatSpec(format)))
+}
+
+// RepositorySpec describes a netrc file based credential repository interface.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	NetRCFile                   string   `json:"netrcFile,omitempty"`
+	ConsumerTypes               []string `json:"consumerTypes,omitempty"`
+	PropgateConsumerIdentity    *bool    `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// NewRepositorySpec creates a new netrc RepositorySpec.
+// If no path is given, the default netrc file is used.
+func NewRepositorySpec(path string, propagate ...bool) *RepositorySpec {
+	var p *bool
+	if path == "" {
+		d, err := DefaultConfig()
+		if err == nil {
+			path = d
+		}
+	}
+	if len(propagate) > 0 {
+		p = generics.Pointer(utils.OptionalDefaultedBool(true, propagate...))
+	}
+
+	return &RepositorySpec{
+		ObjectVersionedType:      runtime.NewVersionedTypedObject(Type),
+		NetRCFile:                path,
+		PropgateConsumerIdentity: p,
+	}
+}
+
+func (rs *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (rs *RepositorySpec) Repository(ctx cpi.Context, _ cpi.Credentials) (cpi.Repository, error) {
+	r := ctx.GetAttributes().GetOrCreateAttribute(".netrc", createCache)
+	cache, ok := r.(*Cache)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type %T to Cache", r)
+	}
+	path := rs.NetRCFile
+	if path == "" {
+		d, err := DefaultConfig()
+		if err != nil {
+			return nil, err
+		}
+		path = d
+	}
+	return cache.GetRepository(ctx, path, rs.ConsumerTypes, utils.AsBool(rs.PropgateConsumerIdentity, true))
+}
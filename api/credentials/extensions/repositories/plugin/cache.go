@@ -0,0 +1,37 @@
+package plugin
+
+import (
+	"sync"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/datacontext"
+)
+
+const ATTR_REPOS = "ocm.software/ocm/api/credentials/extensions/repositories/plugin"
+
+// Cache keeps the repositories per specification.
+type Cache struct {
+	lock  sync.Mutex
+	repos map[string]*Repository
+}
+
+func createCache(_ datacontext.Context) interface{} {
+	return &Cache{
+		repos: map[string]*Repository{},
+	}
+}
+
+func (r *Cache) GetRepository(ctx cpi.Context, key string, handler *PluginHandler, spec []byte) (*Repository, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var err error
+	repo := r.repos[key]
+	if repo == nil {
+		repo, err = NewRepository(ctx, key, handler, spec)
+		if err == nil {
+			r.repos[key] = repo
+		}
+	}
+	return repo, err
+}
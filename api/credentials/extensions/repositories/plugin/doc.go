@@ -0,0 +1,8 @@
+// Package plugin is an adapter implementation that provides a generic handling of all
+// credential repository types provided by plugins.
+// It includes a generic RepositoryType object (responsible for un-/marshaling
+// RepositorySpec objects), a RepositorySpec object and a Repository implementation
+// mapping the credential repository interface to the plugin interface.
+// The types are registered for the plugins found in the plugin
+// directory of an OCM context by the plugin registration.
+package plugin
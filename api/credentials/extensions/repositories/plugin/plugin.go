@@ -0,0 +1,45 @@
+package plugin
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/ocm/plugin"
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+)
+
+type plug = plugin.Plugin
+
+// PluginHandler is a shared object between the RepositorySpec and the Repository
+// implementation. The object knows the actual plugin and can therefore forward
+// the method calls to corresponding cli commands.
+type PluginHandler struct {
+	plug
+}
+
+func NewPluginHandler(p plugin.Plugin) *PluginHandler {
+	return &PluginHandler{plug: p}
+}
+
+// Repository provides the repository for a specification. The repositories are
+// kept per credential context, so the consumer provider registration
+// is done only once per specification.
+func (p *PluginHandler) Repository(ctx cpi.Context, spec *RepositorySpec) (*Repository, error) {
+	if p.GetCredentialRepositoryDescriptor(spec.GetKind(), spec.GetVersion()) == nil {
+		return nil, errors.ErrNotFound(descriptor.KIND_CREDENTIALREPOSITORY, spec.GetType(), descriptor.KIND_PLUGIN, p.Name())
+	}
+	raw, err := spec.GetRaw()
+	if err != nil {
+		return nil, err
+	}
+	r := ctx.GetAttributes().GetOrCreateAttribute(ATTR_REPOS, createCache)
+	cache, ok := r.(*Cache)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type %T to Cache", r)
+	}
+	key := p.Name() + "/" + digest.FromBytes(raw).Encoded()
+	return cache.GetRepository(ctx, key, p, raw)
+}
@@ -0,0 +1,53 @@
+package plugin
+
+import (
+	"ocm.software/ocm/api/credentials/cpi"
+)
+
+type ConsumerProvider struct {
+	repo *Repository
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func (p *ConsumerProvider) Unregister(_ cpi.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(ectx cpi.EvaluationContext, req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+func (p *ConsumerProvider) get(requested cpi.ConsumerIdentity, currentFound cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	var creds cpi.CredentialsSource
+
+	for _, id := range p.repo.info.ConsumerIds {
+		if m(requested, currentFound, id) {
+			creds = &credentialsSource{p.repo, requested}
+			currentFound = id
+		}
+	}
+	return creds, currentFound
+}
+
+// credentialsSource requests the credentials from the plugin
+// only if the credentials are finally used.
+type credentialsSource struct {
+	repo     *Repository
+	consumer cpi.ConsumerIdentity
+}
+
+func (s *credentialsSource) Credentials(_ cpi.Context, _ ...cpi.CredentialsSource) (cpi.Credentials, error) {
+	creds, err := s.repo.handler.GetCredentials(s.repo.spec, "", s.consumer)
+	if err != nil {
+		return nil, err
+	}
+	if creds == nil {
+		return nil, cpi.ErrUnknownCredentials(s.consumer.String())
+	}
+	return creds, nil
+}
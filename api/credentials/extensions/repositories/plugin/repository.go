@@ -0,0 +1,64 @@
+package plugin
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+)
+
+const PROVIDER = "ocm.software/credentialprovider/plugin"
+
+type Repository struct {
+	handler *PluginHandler
+	spec    []byte
+	info    *ppi.CredentialRepositorySpecInfo
+}
+
+var _ cpi.Repository = (*Repository)(nil)
+
+// NewRepository creates a repository for a plugin based repository specification.
+// If the plugin reports consumer ids for the specification, a consumer provider
+// is registered using the given key.
+func NewRepository(ctx cpi.Context, key string, handler *PluginHandler, spec []byte) (*Repository, error) {
+	info, err := handler.ValidateCredentialRepository(spec)
+	if err != nil {
+		return nil, err
+	}
+	r := &Repository{
+		handler: handler,
+		spec:    spec,
+		info:    info,
+	}
+	if len(info.ConsumerIds) > 0 {
+		ctx.RegisterConsumerProvider(cpi.ProviderIdentity(PROVIDER+"/"+key), &ConsumerProvider{r})
+	}
+	return r, nil
+}
+
+func (r *Repository) Info() *ppi.CredentialRepositorySpecInfo {
+	return r.info
+}
+
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	creds, err := r.handler.GetCredentials(r.spec, name, nil)
+	if err != nil {
+		return false, err
+	}
+	return creds != nil, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	creds, err := r.handler.GetCredentials(r.spec, name, nil)
+	if err != nil {
+		return nil, err
+	}
+	if creds == nil {
+		return nil, cpi.ErrUnknownCredentials(name)
+	}
+	return creds, nil
+}
+
+func (r *Repository) WriteCredentials(_ string, _ cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", r.handler.Name())
+}
@@ -0,0 +1,77 @@
+//go:build unix
+
+package plugin_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/ocm/plugin/testutils"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/extensions/repositories/plugin"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/plugin/registration"
+	"ocm.software/ocm/api/tech/oci/identity"
+)
+
+var _ = Describe("plugin credential repository", func() {
+	var ctx ocm.Context
+	var plugins TempPluginDir
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		plugins = Must(ConfigureTestPlugins(ctx, "testdata"))
+		MustBeSuccessful(registration.RegisterExtensions(ctx))
+	})
+
+	AfterEach(func() {
+		plugins.Cleanup()
+	})
+
+	It("registers repository types", func() {
+		t := ctx.CredentialsContext().RepositoryTypes().GetType("test")
+		Expect(t).NotTo(BeNil())
+		Expect(t.Description()).To(Equal("test repository"))
+		Expect(t.Format()).To(Equal("\ntest format"))
+		Expect(ctx.CredentialsContext().RepositoryTypes().GetType("test/v1")).NotTo(BeNil())
+	})
+
+	It("looks up credentials by name", func() {
+		spec := Must(ctx.CredentialsContext().RepositorySpecForConfig([]byte(`{"type":"test","region":"eu"}`), nil))
+		Expect(spec.(*plugin.RepositorySpec).Handler()).NotTo(BeNil())
+
+		repo := Must(ctx.CredentialsContext().RepositoryForSpec(spec))
+		Expect(repo.(*plugin.Repository).Info().Short).To(Equal("a test repository"))
+
+		creds := Must(repo.LookupCredentials("alice"))
+		Expect(creds.Properties()).To(Equal(cpi.DirectCredentials{
+			cpi.ATTR_USERNAME: "alice",
+			cpi.ATTR_PASSWORD: "secret",
+		}.Properties()))
+		Expect(repo.ExistsCredentials("alice")).To(BeTrue())
+
+		Expect(repo.ExistsCredentials("bob")).To(BeFalse())
+		ExpectError(repo.LookupCredentials("bob")).To(MatchError(cpi.ErrUnknownCredentials("bob")))
+		ExpectError(repo.LookupCredentials("fail")).To(MatchError(ContainSubstring("lookup failed")))
+	})
+
+	It("provides credentials for consumers", func() {
+		spec := Must(ctx.CredentialsContext().RepositorySpecForConfig([]byte(`{"type":"test/v1"}`), nil))
+		Must(ctx.CredentialsContext().RepositoryForSpec(spec))
+
+		creds := Must(credentials.CredentialsForConsumer(ctx, identity.GetConsumerId("ghcr.io/acme/image", "")))
+		Expect(creds.Properties()).To(Equal(cpi.DirectCredentials{
+			cpi.ATTR_USERNAME: "consumer",
+			cpi.ATTR_PASSWORD: "ghcr.io",
+		}.Properties()))
+
+		Expect(credentials.CredentialsForConsumer(ctx, identity.GetConsumerId("docker.io/acme/image", ""))).To(BeNil())
+	})
+
+	It("rejects unknown plugin types", func() {
+		spec := Must(ctx.CredentialsContext().RepositorySpecForConfig([]byte(`{"type":"test/v2"}`), nil))
+		ExpectError(ctx.CredentialsContext().RepositoryForSpec(spec)).NotTo(Succeed())
+	})
+})
@@ -0,0 +1,27 @@
+package plugin
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+type RepositorySpec struct {
+	runtime.UnstructuredVersionedTypedObject `json:",inline"`
+	handler                                  *PluginHandler
+}
+
+var _ cpi.RepositorySpec = (*RepositorySpec)(nil)
+
+func (s *RepositorySpec) Repository(ctx cpi.Context, _ cpi.Credentials) (cpi.Repository, error) {
+	if s.handler == nil {
+		return nil, errors.ErrUnknown(descriptor.KIND_CREDENTIALREPOSITORY, s.GetType())
+	}
+	return s.handler.Repository(ctx, s)
+}
+
+func (s *RepositorySpec) Handler() *PluginHandler {
+	return s.handler
+}
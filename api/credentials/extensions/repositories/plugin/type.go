@@ -0,0 +1,35 @@
+package plugin
+
+import (
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/ocm/plugin"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+type repositoryType struct {
+	cpi.RepositoryType
+	plug plugin.Plugin
+}
+
+var _ cpi.RepositoryType = (*repositoryType)(nil)
+
+func NewType(name string, p plugin.Plugin, desc *plugin.CredentialRepositoryDescriptor) cpi.RepositoryType {
+	format := desc.Format
+	if format != "" {
+		format = "\n" + format
+	}
+
+	return &repositoryType{
+		RepositoryType: cpi.NewRepositoryType[*RepositorySpec](name, cpi.WithDescription(desc.Description), cpi.WithFormatSpec(format)),
+		plug:           p,
+	}
+}
+
+func (t *repositoryType) Decode(data []byte, unmarshaler runtime.Unmarshaler) (cpi.RepositorySpec, error) {
+	spec, err := t.RepositoryType.Decode(data, unmarshaler)
+	if err != nil {
+		return nil, err
+	}
+	spec.(*RepositorySpec).handler = NewPluginHandler(t.plug)
+	return spec, nil
+}
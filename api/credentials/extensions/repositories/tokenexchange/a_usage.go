@@ -0,0 +1,40 @@
+package tokenexchange
+
+import (
+	"ocm.software/ocm/api/utils/listformat"
+)
+
+var usage = `
+This repository type can be used to provide short-lived bearer tokens
+obtained from an OAuth2 token endpoint. The token is requested with the
+client credentials grant (client id and secret). If an ID token file is
+configured (for example a workload identity token provided by a CI system),
+an OAuth2 token exchange (RFC 8693) is performed using the ID token as
+subject token.
+
+The obtained tokens are cached until they expire. If the token endpoint
+does not provide an expiration time, they are cached for five minutes.
+They are provided as credential properties <code>token</code> and
+<code>identityToken</code> for the configured consumer ids (for example OCI
+registries, maven or helm repositories or wget consumers), if enabled.
+For maven and helm repositories, which use basic authentication, the token
+is additionally provided as <code>password</code> together with the
+configured <code>username</code>. The consumer ids are matched
+with the identity matcher of the requested consumer type. The credentials
+can be looked up by any name, also.
+`
+
+var format = `The repository specification supports the following fields:
+` + listformat.FormatListElements("", listformat.StringElementDescriptionList{
+	"tokenEndpoint", "*string*: the URL of the token endpoint",
+	"clientId", "*string*(optional): the OAuth2 client id",
+	"clientSecret", "*string*(optional): the OAuth2 client secret",
+	"clientSecretFile", "*string*(optional): a file containing the OAuth2 client secret",
+	"idTokenFile", "*string*(optional): a file containing an ID token used for a token exchange",
+	"subjectTokenType", "*string*(optional): the subject token type used for the token exchange (default: " + TOKEN_TYPE_ID_TOKEN + ")",
+	"scopes", "*[]string*(optional): the requested scopes",
+	"audience", "*string*(optional): the requested audience",
+	"username", "*string*(optional): the user name provided for basic authentication (default: " + DEFAULT_USERNAME + ")",
+	"consumers", "*[]map[string]string*: the consumer ids the token is provided for",
+	"propagateConsumerIdentity", "*bool*(optional): enable consumer id propagation",
+})
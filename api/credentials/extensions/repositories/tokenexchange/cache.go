@@ -0,0 +1,36 @@
+package tokenexchange
+
+import (
+	"sync"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/datacontext"
+)
+
+// Cache keeps the repositories per specification to
+// reuse the cached tokens.
+type Cache struct {
+	lock  sync.Mutex
+	repos map[string]*Repository
+}
+
+func createCache(_ datacontext.Context) interface{} {
+	return &Cache{
+		repos: map[string]*Repository{},
+	}
+}
+
+func (r *Cache) GetRepository(ctx cpi.Context, key string, spec *RepositorySpec, prop bool) (*Repository, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var err error
+	repo := r.repos[key]
+	if repo == nil {
+		repo, err = NewRepository(ctx, key, spec, prop)
+		if err == nil {
+			r.repos[key] = repo
+		}
+	}
+	return repo, err
+}
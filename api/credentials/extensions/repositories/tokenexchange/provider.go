@@ -0,0 +1,46 @@
+package tokenexchange
+
+import (
+	"ocm.software/ocm/api/credentials/cpi"
+)
+
+type ConsumerProvider struct {
+	repo *Repository
+}
+
+var _ cpi.ConsumerProvider = (*ConsumerProvider)(nil)
+
+func (p *ConsumerProvider) Unregister(_ cpi.ProviderIdentity) {
+}
+
+func (p *ConsumerProvider) Match(ectx cpi.EvaluationContext, req cpi.ConsumerIdentity, cur cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	return p.get(req, cur, m)
+}
+
+func (p *ConsumerProvider) Get(req cpi.ConsumerIdentity) (cpi.CredentialsSource, bool) {
+	creds, _ := p.get(req, nil, cpi.CompleteMatch)
+	return creds, creds != nil
+}
+
+func (p *ConsumerProvider) get(requested cpi.ConsumerIdentity, currentFound cpi.ConsumerIdentity, m cpi.IdentityMatcher) (cpi.CredentialsSource, cpi.ConsumerIdentity) {
+	var creds cpi.CredentialsSource
+
+	for _, id := range p.repo.spec.Consumers {
+		if m(requested, currentFound, id) {
+			creds = &credentialsSource{p.repo, requested[cpi.ID_TYPE]}
+			currentFound = id
+		}
+	}
+	return creds, currentFound
+}
+
+// credentialsSource requests the token only if the
+// credentials are finally used.
+type credentialsSource struct {
+	repo *Repository
+	typ  string
+}
+
+func (s *credentialsSource) Credentials(_ cpi.Context, _ ...cpi.CredentialsSource) (cpi.Credentials, error) {
+	return s.repo.credentials(s.typ)
+}
@@ -0,0 +1,96 @@
+package tokenexchange
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	helm "ocm.software/ocm/api/tech/helm/identity"
+	maven "ocm.software/ocm/api/tech/maven/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const PROVIDER = "ocm.software/credentialprovider/" + Type
+
+// DEFAULT_USERNAME is the user name provided together with the token
+// for consumer types using basic authentication.
+const DEFAULT_USERNAME = "oauth2"
+
+type Repository struct {
+	lock   sync.Mutex
+	ctx    cpi.Context
+	spec   *RepositorySpec
+	client *http.Client
+	token  *token
+}
+
+// NewRepository creates a repository for a token exchange specification.
+// The key is used to identify the consumer provider, if propagation is enabled.
+func NewRepository(ctx cpi.Context, key string, spec *RepositorySpec, prop bool) (*Repository, error) {
+	if spec.TokenEndpoint == "" {
+		return nil, errors.ErrRequired("token endpoint")
+	}
+	r := &Repository{
+		ctx:    ctx,
+		spec:   spec,
+		client: &http.Client{Timeout: 30 * time.Second},
+	}
+	if prop && len(spec.Consumers) > 0 {
+		ctx.RegisterConsumerProvider(cpi.ProviderIdentity(PROVIDER+"/"+key), &ConsumerProvider{r})
+	}
+	return r, nil
+}
+
+var _ cpi.Repository = &Repository{}
+
+// ExistsCredentials reports whether a token can be provided. Because
+// the token is provided for any name, this requires a valid token.
+func (r *Repository) ExistsCredentials(name string) (bool, error) {
+	if _, err := r.credentials(""); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *Repository) LookupCredentials(name string) (cpi.Credentials, error) {
+	return r.credentials("")
+}
+
+func (r *Repository) WriteCredentials(_ string, _ cpi.Credentials) (cpi.Credentials, error) {
+	return nil, errors.ErrNotSupported("write", "credentials", Type)
+}
+
+// credentials provides the credentials for the actual token. A new
+// token is requested, if there is no valid cached token.
+// For consumer types using basic authentication, the token is
+// additionally provided as password.
+func (r *Repository) credentials(typ string) (cpi.Credentials, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	t := r.token
+	if !t.valid() {
+		var err error
+		t, err = requestToken(r.client, r.spec)
+		if err != nil {
+			return nil, err
+		}
+		r.token = t
+	}
+	props := common.Properties{
+		cpi.ATTR_TOKEN:          t.value,
+		cpi.ATTR_IDENTITY_TOKEN: t.value,
+	}
+	switch typ {
+	case maven.CONSUMER_TYPE, helm.CONSUMER_TYPE:
+		props[cpi.ATTR_USERNAME] = r.spec.Username
+		if props[cpi.ATTR_USERNAME] == "" {
+			props[cpi.ATTR_USERNAME] = DEFAULT_USERNAME
+		}
+		props[cpi.ATTR_PASSWORD] = t.value
+	}
+	return cpi.NewCredentials(props), nil
+}
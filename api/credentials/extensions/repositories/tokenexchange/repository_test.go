@@ -0,0 +1,190 @@
+package tokenexchange_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/extensions/repositories/tokenexchange"
+	helm "ocm.software/ocm/api/tech/helm/identity"
+	maven "ocm.software/ocm/api/tech/maven/identity"
+	oci "ocm.software/ocm/api/tech/oci/identity"
+	mavenblob "ocm.software/ocm/api/utils/blobaccess/maven"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const ID_TOKEN = "eyJhbGciOiJub25lIn0.eyJzdWIiOiJjaSJ9."
+
+var _ = Describe("token exchange", func() {
+	var DefaultContext credentials.Context
+	var server *httptest.Server
+	var requests atomic.Int32
+	var expiresIn int
+
+	BeforeEach(func() {
+		DefaultContext = credentials.New()
+		requests.Store(0)
+		expiresIn = 3600
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := requests.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			if err := r.ParseForm(); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			var token string
+			switch r.PostForm.Get("grant_type") {
+			case tokenexchange.GRANT_TYPE_CLIENT_CREDENTIALS:
+				user, pass, ok := r.BasicAuth()
+				if !ok || user != "client" || pass != "secret" {
+					w.WriteHeader(http.StatusUnauthorized)
+					fmt.Fprint(w, `{"error":"invalid_client","error_description":"client authentication failed"}`)
+					return
+				}
+				token = fmt.Sprintf("cc-%s-%d", r.PostForm.Get("scope"), n)
+			case tokenexchange.GRANT_TYPE_TOKEN_EXCHANGE:
+				if r.PostForm.Get("subject_token") != ID_TOKEN || r.PostForm.Get("subject_token_type") != tokenexchange.TOKEN_TYPE_ID_TOKEN {
+					w.WriteHeader(http.StatusBadRequest)
+					fmt.Fprint(w, `{"error":"invalid_grant"}`)
+					return
+				}
+				token = fmt.Sprintf("te-%s-%d", r.PostForm.Get("client_id"), n)
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprint(w, `{"error":"unsupported_grant_type"}`)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"access_token": token,
+				"token_type":   "Bearer",
+				"expires_in":   expiresIn,
+			})
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	tokenProps := func(t string) common.Properties {
+		return common.Properties{
+			cpi.ATTR_TOKEN:          t,
+			cpi.ATTR_IDENTITY_TOKEN: t,
+		}
+	}
+
+	basicProps := func(u, t string) common.Properties {
+		props := tokenProps(t)
+		props[cpi.ATTR_USERNAME] = u
+		props[cpi.ATTR_PASSWORD] = t
+		return props
+	}
+
+	It("serializes repo spec", func() {
+		spec := tokenexchange.NewRepositorySpec("https://auth.acme.org/token", "client", "secret", cpi.NewConsumerIdentity(oci.CONSUMER_TYPE, oci.ID_HOSTNAME, "ghcr.io"))
+		data := Must(json.Marshal(spec))
+		Expect(string(data)).To(Equal(`{"type":"TokenExchange","tokenEndpoint":"https://auth.acme.org/token","clientId":"client","clientSecret":"secret","consumers":[{"hostname":"ghcr.io","type":"OCIRegistry"}]}`))
+	})
+
+	It("uses client credentials and caches the token", func() {
+		spec := tokenexchange.NewRepositorySpec(server.URL, "client", "secret")
+		spec.Scopes = []string{"read"}
+		repo := Must(DefaultContext.RepositoryForSpec(spec))
+
+		creds := Must(repo.LookupCredentials("any"))
+		Expect(creds.Properties()).To(Equal(tokenProps("cc-read-1")))
+
+		repo = Must(DefaultContext.RepositoryForSpec(spec))
+		creds = Must(repo.LookupCredentials("any"))
+		Expect(creds.Properties()).To(Equal(tokenProps("cc-read-1")))
+		Expect(requests.Load()).To(Equal(int32(1)))
+	})
+
+	It("refreshes expired tokens", func() {
+		expiresIn = 5
+		repo := Must(DefaultContext.RepositoryForSpec(tokenexchange.NewRepositorySpec(server.URL, "client", "secret")))
+
+		Expect(Must(repo.LookupCredentials("any")).Properties()).To(Equal(tokenProps("cc--1")))
+		Expect(Must(repo.LookupCredentials("any")).Properties()).To(Equal(tokenProps("cc--2")))
+	})
+
+	It("exchanges an ID token", func() {
+		spec := &tokenexchange.RepositorySpec{
+			TokenEndpoint: server.URL,
+			ClientId:      "ci",
+			IdTokenFile:   "testdata/id-token",
+		}
+		spec.SetType(tokenexchange.Type)
+		repo := Must(DefaultContext.RepositoryForSpec(spec))
+		Expect(Must(repo.LookupCredentials("any")).Properties()).To(Equal(tokenProps("te-ci-1")))
+	})
+
+	It("propagates consumer ids", func() {
+		Must(DefaultContext.RepositoryForSpec(tokenexchange.NewRepositorySpec(server.URL, "client", "secret",
+			cpi.NewConsumerIdentity(oci.CONSUMER_TYPE, oci.ID_HOSTNAME, "registry.acme.org"),
+			cpi.NewConsumerIdentity(maven.CONSUMER_TYPE, "hostname", "maven.acme.org"),
+		)))
+
+		Expect(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("other.acme.org", "acme/image"))).To(BeNil())
+		Expect(requests.Load()).To(Equal(int32(0)))
+
+		creds := Must(cpi.CredentialsForConsumer(DefaultContext, oci.GetConsumerId("registry.acme.org", "acme/image")))
+		Expect(creds.Properties()).To(Equal(tokenProps("cc--1")))
+		creds = Must(cpi.CredentialsForConsumer(DefaultContext, Must(maven.GetConsumerId("https://maven.acme.org/repo", "org.acme"))))
+		Expect(creds.Properties()).To(Equal(basicProps(tokenexchange.DEFAULT_USERNAME, "cc--1")))
+		Expect(requests.Load()).To(Equal(int32(1)))
+	})
+
+	It("provides basic auth credentials for maven and helm repositories", func() {
+		spec := tokenexchange.NewRepositorySpec(server.URL, "client", "secret",
+			cpi.NewConsumerIdentity(maven.CONSUMER_TYPE, "hostname", "maven.acme.org"),
+			cpi.NewConsumerIdentity(helm.CONSUMER_TYPE, "hostname", "charts.acme.org"),
+		)
+		spec.Username = "ci"
+		Must(DefaultContext.RepositoryForSpec(spec))
+
+		repo := Must(mavenblob.NewUrlRepository("https://maven.acme.org/repo"))
+		creds := Must(mavenblob.GetCredentials(DefaultContext, repo, "org.acme"))
+		Expect(creds).NotTo(BeNil())
+		req := Must(http.NewRequest(http.MethodGet, "https://maven.acme.org/repo/org/acme", nil))
+		MustBeSuccessful(creds.SetForRequest(req))
+		user, pass, ok := req.BasicAuth()
+		Expect(ok).To(BeTrue())
+		Expect(user).To(Equal("ci"))
+		Expect(pass).To(Equal("cc--1"))
+
+		Expect(helm.GetCredentials(DefaultContext, "https://charts.acme.org/repo", "chart:1.0.0")).To(Equal(basicProps("ci", "cc--1")))
+	})
+
+	It("caches tokens without expiration time", func() {
+		expiresIn = 0
+		repo := Must(DefaultContext.RepositoryForSpec(tokenexchange.NewRepositorySpec(server.URL, "client", "secret")))
+
+		Expect(Must(repo.LookupCredentials("any")).Properties()).To(Equal(tokenProps("cc--1")))
+		Expect(Must(repo.LookupCredentials("any")).Properties()).To(Equal(tokenProps("cc--1")))
+		Expect(requests.Load()).To(Equal(int32(1)))
+	})
+
+	It("checks existence of credentials", func() {
+		repo := Must(DefaultContext.RepositoryForSpec(tokenexchange.NewRepositorySpec(server.URL, "client", "secret")))
+		Expect(repo.ExistsCredentials("any")).To(BeTrue())
+
+		repo = Must(DefaultContext.RepositoryForSpec(tokenexchange.NewRepositorySpec(server.URL, "client", "wrong")))
+		ok, err := repo.ExistsCredentials("any")
+		Expect(err).To(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("reports endpoint errors", func() {
+		repo := Must(DefaultContext.RepositoryForSpec(tokenexchange.NewRepositorySpec(server.URL, "client", "wrong")))
+		ExpectError(repo.LookupCredentials("any")).To(MatchError(fmt.Sprintf(`token request to %q failed: invalid_client: client authentication failed`, server.URL)))
+	})
+})
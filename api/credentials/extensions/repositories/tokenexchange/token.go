@@ -0,0 +1,150 @@
+package tokenexchange
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/utils"
+)
+
+const (
+	GRANT_TYPE_CLIENT_CREDENTIALS = "client_credentials"
+	GRANT_TYPE_TOKEN_EXCHANGE     = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	TOKEN_TYPE_ID_TOKEN     = "urn:ietf:params:oauth:token-type:id_token"
+	TOKEN_TYPE_ACCESS_TOKEN = "urn:ietf:params:oauth:token-type:access_token"
+)
+
+// expiryMargin is the period before the expiration of a token
+// it is not used anymore.
+const expiryMargin = 10 * time.Second
+
+// DefaultTokenLifetime is the period a token is cached, if the token
+// endpoint does not provide an expiration time.
+const DefaultTokenLifetime = 5 * time.Minute
+
+// token is a token obtained from a token endpoint.
+type token struct {
+	value  string
+	expiry time.Time
+}
+
+func (t *token) valid() bool {
+	return t != nil && time.Now().Add(expiryMargin).Before(t.expiry)
+}
+
+type tokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	TokenType        string `json:"token_type,omitempty"`
+	ExpiresIn        int64  `json:"expires_in,omitempty"`
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// requestToken requests a new token from the token endpoint.
+func requestToken(client *http.Client, spec *RepositorySpec) (*token, error) {
+	form := url.Values{}
+	secret, err := clientSecret(spec)
+	if err != nil {
+		return nil, err
+	}
+	if spec.IdTokenFile != "" {
+		path, err := utils.ResolvePath(spec.IdTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot read ID token file %q", spec.IdTokenFile)
+		}
+		typ := spec.SubjectTokenType
+		if typ == "" {
+			typ = TOKEN_TYPE_ID_TOKEN
+		}
+		form.Set("grant_type", GRANT_TYPE_TOKEN_EXCHANGE)
+		form.Set("subject_token", strings.TrimSpace(string(data)))
+		form.Set("subject_token_type", typ)
+		form.Set("requested_token_type", TOKEN_TYPE_ACCESS_TOKEN)
+	} else {
+		if spec.ClientId == "" || secret == "" {
+			return nil, errors.New("client id and secret required for client credentials grant")
+		}
+		form.Set("grant_type", GRANT_TYPE_CLIENT_CREDENTIALS)
+	}
+	if len(spec.Scopes) > 0 {
+		form.Set("scope", strings.Join(spec.Scopes, " "))
+	}
+	if spec.Audience != "" {
+		form.Set("audience", spec.Audience)
+	}
+	if spec.ClientId != "" && secret == "" {
+		form.Set("client_id", spec.ClientId)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, spec.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if secret != "" {
+		req.SetBasicAuth(url.QueryEscape(spec.ClientId), url.QueryEscape(secret))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "token request to %q failed", spec.TokenEndpoint)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	var result tokenResponse
+	jerr := json.Unmarshal(body, &result)
+	if resp.StatusCode != http.StatusOK {
+		msg := resp.Status
+		if jerr == nil && result.Error != "" {
+			msg = result.Error
+			if result.ErrorDescription != "" {
+				msg = fmt.Sprintf("%s: %s", msg, result.ErrorDescription)
+			}
+		}
+		return nil, errors.Newf("token request to %q failed: %s", spec.TokenEndpoint, msg)
+	}
+	if jerr != nil {
+		return nil, errors.Wrapf(jerr, "invalid token response from %q", spec.TokenEndpoint)
+	}
+	if result.AccessToken == "" {
+		return nil, errors.Newf("no access token provided by %q", spec.TokenEndpoint)
+	}
+	t := &token{value: result.AccessToken, expiry: time.Now().Add(DefaultTokenLifetime)}
+	if result.ExpiresIn > 0 {
+		t.expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	return t, nil
+}
+
+func clientSecret(spec *RepositorySpec) (string, error) {
+	if spec.ClientSecretFile == "" {
+		return spec.ClientSecret, nil
+	}
+	path, err := utils.ResolvePath(spec.ClientSecretFile)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot read client secret file %q", spec.ClientSecretFile)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
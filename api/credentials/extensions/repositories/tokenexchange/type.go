@@ -0,0 +1,70 @@
+package tokenexchange
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/utils"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	// Type is the type of the token exchange credential repository.
+	Type   = "TokenExchange"
+	TypeV1 = Type + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](Type))
+	cpi.RegisterRepositoryType(cpi.NewRepositoryType[*RepositorySpec](TypeV1, cpi.WithDescription(usage), cpi.WithFormatSpec(format)))
+}
+
+// RepositorySpec describes a credential repository providing short-lived
+// tokens obtained from an OAuth2 token endpoint.
+type RepositorySpec struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	TokenEndpoint               string                 `json:"tokenEndpoint"`
+	ClientId                    string                 `json:"clientId,omitempty"`
+	ClientSecret                string                 `json:"clientSecret,omitempty"`
+	ClientSecretFile            string                 `json:"clientSecretFile,omitempty"`
+	IdTokenFile                 string                 `json:"idTokenFile,omitempty"`
+	SubjectTokenType            string                 `json:"subjectTokenType,omitempty"`
+	Scopes                      []string               `json:"scopes,omitempty"`
+	Audience                    string                 `json:"audience,omitempty"`
+	Username                    string                 `json:"username,omitempty"`
+	Consumers                   []cpi.ConsumerIdentity `json:"consumers,omitempty"`
+	PropgateConsumerIdentity    *bool                  `json:"propagateConsumerIdentity,omitempty"`
+}
+
+// NewRepositorySpec creates a new token exchange RepositorySpec using the
+// client credentials grant. The resulting tokens are provided for the given
+// consumer ids.
+func NewRepositorySpec(endpoint string, clientId, clientSecret string, consumers ...cpi.ConsumerIdentity) *RepositorySpec {
+	return &RepositorySpec{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(Type),
+		TokenEndpoint:       endpoint,
+		ClientId:            clientId,
+		ClientSecret:        clientSecret,
+		Consumers:           consumers,
+	}
+}
+
+func (rs *RepositorySpec) GetType() string {
+	return Type
+}
+
+func (rs *RepositorySpec) Repository(ctx cpi.Context, _ cpi.Credentials) (cpi.Repository, error) {
+	r := ctx.GetAttributes().GetOrCreateAttribute(".tokenexchange", createCache)
+	cache, ok := r.(*Cache)
+	if !ok {
+		return nil, fmt.Errorf("failed to assert type %T to Cache", r)
+	}
+	data, err := json.Marshal(rs)
+	if err != nil {
+		return nil, err
+	}
+	return cache.GetRepository(ctx, digest.FromBytes(data).String(), rs, utils.AsBool(rs.PropgateConsumerIdentity, true))
+}
@@ -95,7 +95,8 @@ func (c *_consumers) Get(id ConsumerIdentity) (CredentialsSource, bool) {
 // identities.
 func (c *_consumers) Match(ectx EvaluationContext, pattern ConsumerIdentity, cur ConsumerIdentity, m IdentityMatcher) (CredentialsSource, ConsumerIdentity) {
 	var found *_consumer
-	for _, s := range c.data {
+	for _, k := range maputils.OrderedKeys(c.data) {
+		s := c.data[k]
 		if m(pattern, cur, s.identity) {
 			found = s
 			cur = s.identity
@@ -258,10 +259,12 @@ func (p *consumerProviderRegistry) Match(ectx EvaluationContext, pattern Consume
 	p.lock.RLock()
 	defer p.lock.RUnlock()
 
-	credsrc, cur := p.explicit.Match(ectx, pattern, cur, m)
-	for _, sub := range p.providers {
+	t := GetEvaluationContextFor[*tracing](ectx)
+	credsrc, cur := p.explicit.Match(ectx, pattern, cur, p.traceProvider(t, "explicit", m))
+	for _, pid := range maputils.OrderedKeys(p.providers) {
+		sub := p.providers[pid]
 		var f CredentialsSource
-		f, cur = p.catchedMatch(ectx, sub, pattern, cur, m)
+		f, cur = p.catchedMatch(ectx, sub, pattern, cur, p.traceProvider(t, string(pid), m))
 		if f != nil {
 			credsrc = f
 		}
@@ -284,6 +287,20 @@ func (p *consumerProviderRegistry) Match(ectx EvaluationContext, pattern Consume
 	return credsrc, cur
 }
 
+// traceProvider records the evaluation of a consumer provider
+// and provides a matcher recording the matched candidates, if tracing is enabled.
+func (p *consumerProviderRegistry) traceProvider(t *tracing, pid string, m IdentityMatcher) IdentityMatcher {
+	if t == nil {
+		return m
+	}
+	t.tracer.Trace(&TraceEvent{
+		Kind:     TRACE_PROVIDER,
+		Depth:    t.depth + 1,
+		Provider: pid,
+	})
+	return traceMatcher(t, m)
+}
+
 func (p *consumerProviderRegistry) Set(id ConsumerIdentity, pid ProviderIdentity, creds CredentialsSource) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
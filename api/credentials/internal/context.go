@@ -209,7 +209,14 @@ func (c *_context) RepositoryForSpec(spec RepositorySpec, creds ...CredentialsSo
 		return nil, err
 	}
 	c.Update()
-	return spec.Repository(out, cred)
+	repo, err := spec.Repository(out, cred)
+	Trace(c, &TraceEvent{
+		Kind:     TRACE_REPOSITORY,
+		Spec:     spec.GetType(),
+		Accepted: repo != nil,
+		Error:    err,
+	})
+	return repo, err
 }
 
 func (c *_context) RepositoryForConfig(data []byte, unmarshaler runtime.Unmarshaler, creds ...CredentialsSource) (Repository, error) {
@@ -227,7 +234,19 @@ func (c *_context) CredentialsForSpec(spec CredentialsSpec, creds ...Credentials
 	if err != nil {
 		return nil, err
 	}
-	return repo.LookupCredentials(spec.GetCredentialsName())
+	r, err := repo.LookupCredentials(spec.GetCredentialsName())
+	evt := &TraceEvent{
+		Kind:     TRACE_REPOSITORY,
+		Spec:     repospec.GetType(),
+		Name:     spec.GetCredentialsName(),
+		Accepted: r != nil,
+		Error:    err,
+	}
+	if r != nil {
+		evt.Properties = RedactedProperties(r.Properties())
+	}
+	Trace(c, evt)
+	return r, err
 }
 
 func (c *_context) CredentialsForConfig(data []byte, unmarshaler runtime.Unmarshaler, creds ...CredentialsSource) (Credentials, error) {
@@ -255,18 +274,52 @@ func (c *_context) getCredentialsForConsumer(ectx EvaluationContext, identity Co
 		ectx = &evaluationContext{}
 	}
 	m := c.defaultMatcher(identity, matchers...)
+
+	t := GetEvaluationContextFor[*tracing](ectx)
+	if t != nil {
+		t = &tracing{tracer: t.tracer, depth: t.depth + 1}
+	} else if tracer := GetTracer(c); tracer != nil {
+		t = &tracing{tracer: tracer}
+	}
+	if t != nil {
+		ectx = SetEvaluationContextFor(ectx, t)
+		t.tracer.Trace(&TraceEvent{
+			Kind:     TRACE_REQUEST,
+			Depth:    t.depth,
+			Identity: identity.Copy(),
+			Matcher:  c.matcherName(identity, matchers),
+		})
+	}
+
 	var credsrc CredentialsSource
+	var found ConsumerIdentity
 	if m == nil {
 		credsrc, _ = c.consumerProviders.Get(identity)
+		found = identity
 	} else {
-		credsrc, _ = c.consumerProviders.Match(ectx, identity, nil, m)
+		credsrc, found = c.consumerProviders.Match(ectx, identity, nil, m)
 	}
 	if credsrc == nil {
 		credsrc, _ = c.consumerProviders.Get(emptyIdentity)
+		found = emptyIdentity
+	}
+	if credsrc == nil {
+		found = identity
+	}
+	if t != nil {
+		t.tracer.Trace(&TraceEvent{
+			Kind:     TRACE_RESULT,
+			Depth:    t.depth,
+			Identity: found.Copy(),
+			Accepted: credsrc != nil,
+		})
 	}
 	if credsrc == nil {
 		return nil, ErrUnknownConsumer(identity.String())
 	}
+	if t != nil {
+		credsrc = &tracedCredentialsSource{credsrc, t}
+	}
 	return credsrc, nil
 }
 
@@ -0,0 +1,290 @@
+package internal
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mandelsoft/goutils/sliceutils"
+
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+// ATTR_TRACER is the name of the context attribute used to
+// store a Tracer for a credentials context.
+const ATTR_TRACER = "ocm.software/ocm/api/credentials/tracer"
+
+// REDACTED is used to replace secret credential values in trace events.
+const REDACTED = "***"
+
+type TraceKind string
+
+const (
+	// TRACE_REQUEST records a credential request for a consumer identity.
+	TRACE_REQUEST TraceKind = "request"
+	// TRACE_PROVIDER records the evaluation of a consumer provider.
+	TRACE_PROVIDER TraceKind = "provider"
+	// TRACE_CANDIDATE records the result of an identity matcher
+	// for a configured consumer identity.
+	TRACE_CANDIDATE TraceKind = "candidate"
+	// TRACE_ALIAS records the resolution of a repository alias.
+	TRACE_ALIAS TraceKind = "alias"
+	// TRACE_REPOSITORY records a credential lookup in a credential repository.
+	TRACE_REPOSITORY TraceKind = "repository"
+	// TRACE_RESULT records the result of a credential request.
+	TRACE_RESULT TraceKind = "result"
+	// TRACE_CREDENTIALS records the evaluation of the found credentials.
+	TRACE_CREDENTIALS TraceKind = "credentials"
+)
+
+// TraceEvent describes a single decision taken during
+// the resolution of credentials. Credential values are never
+// recorded in plain text, secret values are redacted.
+type TraceEvent struct {
+	Kind TraceKind
+	// Depth is the recursion depth of the credential request.
+	Depth int
+	// Identity is the requested or the configured consumer identity.
+	Identity ConsumerIdentity
+	// Matcher is the name of the used identity matcher.
+	Matcher string
+	// Provider is the identity of the used consumer provider.
+	Provider string
+	// Name is the name of an alias or the credentials name of a repository lookup.
+	Name string
+	// Spec is the description of the used repository specification.
+	Spec string
+	// Accepted reports whether a candidate has been accepted by the matcher,
+	// or a repository or request provided credentials.
+	Accepted bool
+	// Properties are the redacted credential properties.
+	Properties common.Properties
+	Error      error
+}
+
+func (e *TraceEvent) String() string {
+	s := string(e.Kind)
+	switch e.Kind {
+	case TRACE_REQUEST:
+		s += fmt.Sprintf(" consumer %s (matcher %s)", e.Identity, e.Matcher)
+	case TRACE_PROVIDER:
+		s += " " + e.Provider
+	case TRACE_CANDIDATE:
+		s += " " + e.Identity.String()
+		if e.Accepted {
+			s += ": accepted"
+		} else {
+			s += ": rejected"
+		}
+	case TRACE_ALIAS:
+		s += fmt.Sprintf(" %q", e.Name)
+		if e.Spec != "" {
+			s += " -> " + e.Spec
+		}
+	case TRACE_REPOSITORY:
+		s += " " + e.Spec
+		if e.Name != "" {
+			s += fmt.Sprintf(" credentials %q", e.Name)
+		}
+	case TRACE_RESULT:
+		if e.Accepted {
+			s += fmt.Sprintf(": credentials found for %s", e.Identity)
+		} else {
+			s += fmt.Sprintf(": no credentials found for %s", e.Identity)
+		}
+	}
+	if len(e.Properties) > 0 {
+		s += ": " + FormatRedactedProperties(e.Properties)
+	}
+	if e.Error != nil {
+		s += fmt.Sprintf(": failed: %s", e.Error)
+	}
+	return s
+}
+
+// Tracer is used to record the decisions taken during
+// the resolution of credentials.
+type Tracer interface {
+	Trace(evt *TraceEvent)
+}
+
+// TraceRecorder is a Tracer recording all trace events.
+type TraceRecorder struct {
+	lock   sync.Mutex
+	events []*TraceEvent
+}
+
+var _ Tracer = (*TraceRecorder)(nil)
+
+func NewTraceRecorder() *TraceRecorder {
+	return &TraceRecorder{}
+}
+
+func (r *TraceRecorder) Trace(evt *TraceEvent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, evt)
+}
+
+// Events returns the list of recorded events.
+func (r *TraceRecorder) Events() []*TraceEvent {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return sliceutils.CopyAppend(r.events)
+}
+
+// Reset discards all recorded events.
+func (r *TraceRecorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = nil
+}
+
+// String provides an indented multi-line representation
+// of the recorded trace.
+func (r *TraceRecorder) String() string {
+	s := ""
+	for _, e := range r.Events() {
+		s += strings.Repeat("  ", e.Depth) + e.String() + "\n"
+	}
+	return s
+}
+
+// SetTracer sets a tracer for a credentials context. It is used
+// for all credential requests of the context and its views.
+// A nil tracer disables the tracing.
+func SetTracer(ctx Context, t Tracer) {
+	ctx.GetAttributes().SetAttribute(ATTR_TRACER, t)
+}
+
+// GetTracer returns the tracer configured for a credentials context
+// or nil.
+func GetTracer(ctx Context) Tracer {
+	if t, ok := ctx.GetAttributes().GetAttribute(ATTR_TRACER).(Tracer); ok {
+		return t
+	}
+	return nil
+}
+
+// Trace records a trace event for a credentials context,
+// if a tracer is configured.
+func Trace(ctx Context, evt *TraceEvent) {
+	if t := GetTracer(ctx); t != nil {
+		t.Trace(evt)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+var nonSecretProperties = map[string]bool{
+	ATTR_TYPE:                  true,
+	ATTR_USERNAME:              true,
+	ATTR_EMAIL:                 true,
+	ATTR_SERVER_ADDRESS:        true,
+	ATTR_CERTIFICATE_AUTHORITY: true,
+	ATTR_CERTIFICATE:           true,
+}
+
+// RedactedProperties returns a copy of the given credential properties
+// with all secret values replaced by REDACTED.
+func RedactedProperties(props common.Properties) common.Properties {
+	if props == nil {
+		return nil
+	}
+	r := common.Properties{}
+	for k, v := range props {
+		if nonSecretProperties[k] || v == "" {
+			r[k] = v
+		} else {
+			r[k] = REDACTED
+		}
+	}
+	return r
+}
+
+// FormatRedactedProperties provides a sorted, redacted string
+// representation of credential properties.
+func FormatRedactedProperties(props common.Properties) string {
+	var list []string
+	for k, v := range RedactedProperties(props) {
+		if (k == ATTR_CERTIFICATE_AUTHORITY || k == ATTR_CERTIFICATE) && v != "" {
+			v = "..."
+		}
+		list = append(list, k+"="+v)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ", ")
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// tracing is the evaluation context entry used to
+// propagate the tracer through recursive credential requests.
+type tracing struct {
+	tracer Tracer
+	depth  int
+}
+
+func traceMatcher(t *tracing, m IdentityMatcher) IdentityMatcher {
+	return func(pattern, cur, id ConsumerIdentity) bool {
+		r := m(pattern, cur, id)
+		t.tracer.Trace(&TraceEvent{
+			Kind:     TRACE_CANDIDATE,
+			Depth:    t.depth + 2,
+			Identity: id.Copy(),
+			Accepted: r,
+		})
+		return r
+	}
+}
+
+// matcherName determines the name of the identity matcher used
+// for a credential request. Explicitly given matchers are looked up
+// in the matcher registry.
+func (c *_context) matcherName(id ConsumerIdentity, matchers []IdentityMatcher) string {
+	var names []string
+	for _, m := range matchers {
+		if m != nil {
+			name := "custom"
+			p := reflect.ValueOf(m).Pointer()
+			for _, i := range c.consumerIdentityMatchers.List() {
+				if reflect.ValueOf(i.Matcher).Pointer() == p {
+					name = i.Type
+					break
+				}
+			}
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		return strings.Join(names, "+")
+	}
+	if c.consumerIdentityMatchers.Get(id.Type()) != nil {
+		return id.Type()
+	}
+	return "partial"
+}
+
+// tracedCredentialsSource records the evaluation
+// of a credentials source found for a consumer.
+type tracedCredentialsSource struct {
+	CredentialsSource
+	tracing *tracing
+}
+
+func (s *tracedCredentialsSource) Credentials(ctx Context, creds ...CredentialsSource) (Credentials, error) {
+	r, err := s.CredentialsSource.Credentials(ctx, creds...)
+	evt := &TraceEvent{
+		Kind:     TRACE_CREDENTIALS,
+		Depth:    s.tracing.depth,
+		Accepted: r != nil,
+		Error:    err,
+	}
+	if r != nil {
+		evt.Properties = RedactedProperties(r.Properties())
+	}
+	s.tracing.tracer.Trace(evt)
+	return r, err
+}
@@ -0,0 +1,76 @@
+package internal_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/extensions/repositories/aliases"
+	"ocm.software/ocm/api/credentials/extensions/repositories/memory"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("credential tracing", func() {
+	var ctx credentials.Context
+	var trace *credentials.TraceRecorder
+
+	BeforeEach(func() {
+		ctx = credentials.New()
+		trace = credentials.NewTraceRecorder()
+		credentials.SetTracer(ctx, trace)
+	})
+
+	It("traces consumer requests", func() {
+		ctx.SetCredentialsForConsumer(credentials.ConsumerIdentity{"type": "test", "host": "acme.org"},
+			credentials.DirectCredentials{"username": "alice", "password": "secret"})
+
+		creds := Must(credentials.CredentialsForConsumer(ctx, credentials.ConsumerIdentity{"type": "test", "host": "acme.org", "path": "repo"}))
+		Expect(creds.GetProperty("password")).To(Equal("secret"))
+		Expect(trace.String()).To(Equal(`request consumer {"host":"acme.org","path":"repo","type":"test"} (matcher partial)
+  provider explicit
+    candidate {"host":"acme.org","type":"test"}: accepted
+result: credentials found for {"host":"acme.org","type":"test"}
+credentials: password=***, username=alice
+`))
+	})
+
+	It("traces unknown consumers", func() {
+		ctx.SetCredentialsForConsumer(credentials.ConsumerIdentity{"type": "test", "host": "acme.org"},
+			credentials.DirectCredentials{"username": "alice", "password": "secret"})
+
+		Expect(credentials.CredentialsForConsumer(ctx, credentials.ConsumerIdentity{"type": "test", "host": "other.org"})).To(BeNil())
+		Expect(trace.String()).To(Equal(`request consumer {"host":"other.org","type":"test"} (matcher partial)
+  provider explicit
+    candidate {"host":"acme.org","type":"test"}: rejected
+result: no credentials found for {"host":"other.org","type":"test"}
+`))
+	})
+
+	It("traces aliases and repositories", func() {
+		mem := Must(ctx.RepositoryForSpec(memory.NewRepositorySpec("test")))
+		Must(mem.WriteCredentials("cred", credentials.NewCredentials(common.Properties{"token": "secret"})))
+		MustBeSuccessful(ctx.SetAlias("alias", memory.NewRepositorySpec("test")))
+		trace.Reset()
+
+		creds := Must(ctx.CredentialsForSpec(credentials.NewCredentialsSpec("cred", aliases.NewRepositorySpec("alias"))))
+		Expect(creds.GetProperty("token")).To(Equal("secret"))
+		Expect(trace.String()).To(Equal(`alias "alias" -> Memory
+repository Memory
+repository Alias
+repository Alias credentials "cred": token=***
+`))
+	})
+
+	It("redacts secret values", func() {
+		Expect(credentials.RedactedProperties(common.Properties{
+			"username": "alice",
+			"password": "secret",
+			"token":    "",
+		})).To(Equal(common.Properties{
+			"username": "alice",
+			"password": credentials.REDACTED,
+			"token":    "",
+		}))
+	})
+})
@@ -0,0 +1,45 @@
+package credentials
+
+import (
+	"ocm.software/ocm/api/credentials/internal"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+type (
+	Tracer        = internal.Tracer
+	TraceEvent    = internal.TraceEvent
+	TraceKind     = internal.TraceKind
+	TraceRecorder = internal.TraceRecorder
+)
+
+const (
+	TRACE_REQUEST     = internal.TRACE_REQUEST
+	TRACE_PROVIDER    = internal.TRACE_PROVIDER
+	TRACE_CANDIDATE   = internal.TRACE_CANDIDATE
+	TRACE_ALIAS       = internal.TRACE_ALIAS
+	TRACE_REPOSITORY  = internal.TRACE_REPOSITORY
+	TRACE_RESULT      = internal.TRACE_RESULT
+	TRACE_CREDENTIALS = internal.TRACE_CREDENTIALS
+)
+
+const REDACTED = internal.REDACTED
+
+func NewTraceRecorder() *TraceRecorder {
+	return internal.NewTraceRecorder()
+}
+
+// SetTracer sets a tracer recording the credential resolution
+// decisions for a credentials context. A nil tracer disables the tracing.
+func SetTracer(ctx ContextProvider, t Tracer) {
+	internal.SetTracer(ctx.CredentialsContext(), t)
+}
+
+func GetTracer(ctx ContextProvider) Tracer {
+	return internal.GetTracer(ctx.CredentialsContext())
+}
+
+// RedactedProperties returns a copy of the given credential properties
+// with all secret values replaced by REDACTED.
+func RedactedProperties(props common.Properties) common.Properties {
+	return internal.RedactedProperties(props)
+}
@@ -3,6 +3,7 @@ package rootcertsattr
 import (
 	"crypto/x509"
 	"encoding/json"
+	"slices"
 	"sync"
 
 	"github.com/mandelsoft/goutils/errors"
@@ -47,10 +48,19 @@ format:
      {
        "path": ""&lt;file path>"
      }
+  ],
+  "tsaRootCertificates": [
+     {
+       "path": ""&lt;file path>"
+     }
   ]
 }
 </pre>
 
+The <code>tsaRootCertificates</code> are used to validate the certificate
+chain of timestamp authorities (TSA) for signature timestamps. If not
+given, the general root certificates are used.
+
 One of following data fields are possible:
 - <code>data</code>:       base64 encoded binary data
 - <code>stringdata</code>: plain text data
@@ -72,6 +82,10 @@ func (a AttributeType) Encode(v interface{}, marshaller runtime.Marshaler) ([]by
 		data := signutils.CertificateToPem(c)
 		cfg.AddRootCertificateData(data)
 	}
+	for _, c := range attr.tsaRootCertificates {
+		data := signutils.CertificateToPem(c)
+		cfg.AddTSARootCertificateData(data)
+	}
 
 	return json.Marshal(cfg)
 }
@@ -96,6 +110,8 @@ func (a AttributeType) Decode(data []byte, unmarshaller runtime.Unmarshaler) (in
 type Attribute struct {
 	lock             sync.Mutex
 	rootCertificates []*x509.Certificate
+
+	tsaRootCertificates []*x509.Certificate
 }
 
 func (a *Attribute) RegisterRootCertificates(cert signutils.GenericCertificateChain) error {
@@ -117,6 +133,13 @@ func (a *Attribute) HasRootCertificates() bool {
 	return len(a.rootCertificates) > 0
 }
 
+// GetRootCertificates provides the configured root certificates.
+func (a *Attribute) GetRootCertificates() []*x509.Certificate {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return slices.Clone(a.rootCertificates)
+}
+
 func (a *Attribute) GetRootCertPool(system bool) *x509.CertPool {
 	var pool *x509.CertPool
 
@@ -136,6 +159,44 @@ func (a *Attribute) GetRootCertPool(system bool) *x509.CertPool {
 	return pool
 }
 
+// RegisterTSARootCertificates registers root certificates
+// used to validate timestamp authorities.
+func (a *Attribute) RegisterTSARootCertificates(cert signutils.GenericCertificateChain) error {
+	certs, err := signutils.GetCertificateChain(cert, false)
+	if err != nil {
+		return err
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	a.tsaRootCertificates = append(a.tsaRootCertificates, certs...)
+	return nil
+}
+
+func (a *Attribute) HasTSARootCertificates() bool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return len(a.tsaRootCertificates) > 0
+}
+
+// GetTSARootCertPool provides a certificate pool with the root certificates
+// dedicated to timestamp authorities. If no such root certificates
+// are configured, nil is returned.
+func (a *Attribute) GetTSARootCertPool() *x509.CertPool {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if len(a.tsaRootCertificates) == 0 {
+		return nil
+	}
+	pool := x509.NewCertPool()
+	for _, c := range a.tsaRootCertificates {
+		pool.AddCert(c)
+	}
+	return pool
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func Get(ctx ContextProvider) *Attribute {
@@ -63,4 +63,14 @@ var _ = Describe("attribute", func() {
 		Expect(cfgctx.ApplyConfig(cfg, "from test")).To(Succeed())
 		Expect(me.Get(ctx).HasRootCertificates()).To(BeTrue())
 	})
+	It("applies TSA root certificate", func() {
+		cfg := me.New()
+		cfg.AddTSARootCertificateData(certdata)
+
+		Expect(cfgctx.ApplyConfig(cfg, "from test")).To(Succeed())
+		attr := me.Get(ctx)
+		Expect(attr.HasRootCertificates()).To(BeFalse())
+		Expect(attr.HasTSARootCertificates()).To(BeTrue())
+		Expect(attr.GetTSARootCertPool()).NotTo(BeNil())
+	})
 })
@@ -26,6 +26,7 @@ func init() {
 type Config struct {
 	runtime.ObjectVersionedType `json:",inline"`
 	RootCertificates            []cfgcpi.ContentSpec `json:"rootCertificates,omitempty"`
+	TSARootCertificates         []cfgcpi.ContentSpec `json:"tsaRootCertificates,omitempty"`
 }
 
 // New creates a new memory ConfigSpec.
@@ -56,6 +57,23 @@ func (a *Config) AddRootCertificate(chain signutils.GenericCertificateChain) err
 	return nil
 }
 
+func (a *Config) AddTSARootCertificateFile(name string, fss ...vfs.FileSystem) {
+	a.TSARootCertificates = append(a.TSARootCertificates, cfgcpi.ContentSpec{Path: name, FileSystem: utils.Optional(fss...)})
+}
+
+func (a *Config) AddTSARootCertificateData(data []byte) {
+	a.TSARootCertificates = append(a.TSARootCertificates, cfgcpi.ContentSpec{Data: data})
+}
+
+func (a *Config) AddTSARootCertificate(chain signutils.GenericCertificateChain) error {
+	certs, err := signutils.GetCertificateChain(chain, false)
+	if err != nil {
+		return err
+	}
+	a.TSARootCertificates = append(a.TSARootCertificates, cfgcpi.ContentSpec{Data: signutils.CertificateChainToPem(certs), Parsed: certs})
+	return nil
+}
+
 func (a *Config) ApplyTo(ctx cfgcpi.Context, target interface{}) error {
 	if t, ok := target.(Context); ok {
 		if t.AttributesContext().IsAttributesContext() { // apply only to root context
@@ -72,6 +90,12 @@ func (a *Config) ApplyToAttribute(attr *Attribute) error {
 			return errors.Wrapf(err, "invalid certificate %d", i)
 		}
 	}
+	for i, k := range a.TSARootCertificates {
+		err := attr.RegisterTSARootCertificates(k)
+		if err != nil {
+			return errors.Wrapf(err, "invalid TSA certificate %d", i)
+		}
+	}
 	return nil
 }
 
@@ -85,8 +109,14 @@ general root certificates. A certificate value might be given by one of the fiel
 <pre>
     rootCertificates:
       - path: &lt;file path>
+    tsaRootCertificates:
+      - path: &lt;file path>
 </pre>
 
+The optional <code>tsaRootCertificates</code> pin the root certificates
+accepted for timestamp authorities (TSA) used for signature timestamps.
+If not given, the general root certificates are used.
+
 `
 
 type Appliers struct {
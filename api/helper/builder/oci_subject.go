@@ -0,0 +1,22 @@
+package builder
+
+import (
+	"ocm.software/ocm/api/oci/artdesc"
+)
+
+// Subject sets the subject of the actual artifact, which
+// makes it a referrer of the described artifact.
+func (b *Builder) Subject(desc *artdesc.Descriptor) {
+	b.expect(b.oci_artacc, T_OCIARTIFACT)
+
+	d := *desc
+	d.Platform = nil
+	b.failOn(b.oci_artacc.GetDescriptor().SetSubject(&d))
+}
+
+// ArtifactType sets the artifact type of the actual artifact.
+func (b *Builder) ArtifactType(t string) {
+	b.expect(b.oci_artacc, T_OCIARTIFACT)
+
+	b.failOn(b.oci_artacc.GetDescriptor().SetArtifactType(t))
+}
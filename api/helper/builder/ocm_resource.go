@@ -80,6 +80,14 @@ func (b *Builder) Digest(value, algo, norm string) {
 	}
 }
 
+func (b *Builder) SourceRef(sel ...string) {
+	b.expect(b.ocm_rsc, T_OCMRESOURCE)
+	id := metav1.NewExtraIdentity(sel...)
+	b.ocm_rsc.SourceRefs = append(b.ocm_rsc.SourceRefs, compdesc.SourceRef{
+		IdentitySelector: metav1.StringMap(id),
+	})
+}
+
 func (b *Builder) ModificationOptions(opts ...ocm.ModificationOption) {
 	target := &b.def_modopts
 	if b.ocm_modopts != nil {
@@ -19,3 +19,8 @@ const OCITAG_ANNOTATION = "org.opencontainers.image.ref.name"
 // the OCM identity of the origin of an OCI artifact. This is the identity of a
 // component version `<component name>:<component version>`.
 const COMPVERS_ANNOTATION = "software.ocm/component-version"
+
+// SUBJECT_ANNOTATION is the name of the OCI manifest annotation used to describe
+// the digest of the subject of a manifest in an artifact set. It is used to
+// index the referrers of an artifact.
+const SUBJECT_ANNOTATION = "software.ocm/subject"
@@ -200,6 +200,58 @@ func (d *Artifact) DeleteAnnotation(name string) error {
 	})
 }
 
+// GetSubject returns the optional subject of the artifact, which
+// describes the artifact referred to by this artifact.
+func (d *Artifact) GetSubject() *Descriptor {
+	switch {
+	case d.manifest != nil:
+		return d.manifest.Subject
+	case d.index != nil:
+		return d.index.Subject
+	default:
+		return nil
+	}
+}
+
+// SetSubject sets the subject of the artifact. A nil value
+// removes the subject.
+func (d *Artifact) SetSubject(subject *Descriptor) error {
+	switch {
+	case d.manifest != nil:
+		d.manifest.Subject = subject
+	case d.index != nil:
+		d.index.Subject = subject
+	default:
+		return errors.Newf("void artifact access")
+	}
+	return nil
+}
+
+// GetArtifactType returns the explicitly set artifact type.
+func (d *Artifact) GetArtifactType() string {
+	switch {
+	case d.manifest != nil:
+		return d.manifest.ArtifactType
+	case d.index != nil:
+		return d.index.ArtifactType
+	default:
+		return ""
+	}
+}
+
+// SetArtifactType sets the artifact type.
+func (d *Artifact) SetArtifactType(t string) error {
+	switch {
+	case d.manifest != nil:
+		d.manifest.ArtifactType = t
+	case d.index != nil:
+		d.index.ArtifactType = t
+	default:
+		return errors.Newf("void artifact access")
+	}
+	return nil
+}
+
 func (d *Artifact) modifyAnnotation(mod func(annos *map[string]string)) error {
 	var annos map[string]string
 
@@ -0,0 +1,90 @@
+package artdesc
+
+import (
+	"maps"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/opencontainers/image-spec/specs-go"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+)
+
+// MediaTypeEmptyJSON is the media type of the empty JSON blob `{}`
+// used as config for artifacts without a dedicated config.
+const MediaTypeEmptyJSON = ociv1.MediaTypeEmptyJSON
+
+// ReferrersTag provides the tag used by the referrers tag schema
+// to store the referrers index for registries not supporting
+// the referrers API (see https://github.com/opencontainers/distribution-spec/blob/main/spec.md#referrers-tag-schema).
+func ReferrersTag(d digest.Digest) string {
+	alg := d.Algorithm().String()
+	if len(alg) > 32 {
+		alg = alg[:32]
+	}
+	ref := d.Encoded()
+	if len(ref) > 64 {
+		ref = ref[:64]
+	}
+	return alg + "-" + ref
+}
+
+// ReferrerDescriptor provides the descriptor used to describe an artifact
+// in a referrers list. According to the OCI distribution spec it
+// contains the artifact type and the annotations of the artifact.
+func ReferrerDescriptor(art *Artifact, blob blobaccess.BlobAccess) *Descriptor {
+	d := &Descriptor{
+		MediaType:    blob.MimeType(),
+		Digest:       blob.Digest(),
+		Size:         blob.Size(),
+		ArtifactType: art.GetArtifactType(),
+	}
+	switch {
+	case art.manifest != nil:
+		if d.ArtifactType == "" {
+			d.ArtifactType = art.manifest.Config.MediaType
+		}
+		d.Annotations = maps.Clone(art.manifest.Annotations)
+	case art.index != nil:
+		d.Annotations = maps.Clone(art.index.Annotations)
+	}
+	return d
+}
+
+// ReferrerDescriptorForData provides the referrer descriptor for
+// a serialized artifact.
+func ReferrerDescriptorForData(data []byte) (*Descriptor, error) {
+	art, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return ReferrerDescriptor(art, blobaccess.ForData(art.MimeType(), data)), nil
+}
+
+// FilterReferrers selects the referrers with the given artifact type.
+// An empty artifact type selects all referrers.
+func FilterReferrers(list []Descriptor, artifactType string) []Descriptor {
+	if artifactType == "" {
+		return list
+	}
+	var result []Descriptor
+	for _, d := range list {
+		if d.ArtifactType == artifactType {
+			result = append(result, d)
+		}
+	}
+	return result
+}
+
+// NewReferrersIndex provides an index describing a list of referrers
+// as used by the referrers API and the referrers tag schema.
+func NewReferrersIndex(list ...Descriptor) *Index {
+	if list == nil {
+		list = []Descriptor{}
+	}
+	return &Index{
+		Versioned: specs.Versioned{SchemaVersion: SchemeVersion},
+		MediaType: MediaTypeImageIndex,
+		Manifests: list,
+	}
+}
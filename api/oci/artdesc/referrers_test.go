@@ -0,0 +1,74 @@
+package artdesc_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/oci/artdesc"
+)
+
+var _ = Describe("referrers", func() {
+	subject := &artdesc.Descriptor{
+		MediaType: artdesc.MediaTypeImageManifest,
+		Digest:    digest.FromString("subject"),
+		Size:      7,
+	}
+
+	It("provides referrers tag", func() {
+		d := digest.FromString("subject")
+		Expect(artdesc.ReferrersTag(d)).To(Equal("sha256-" + d.Encoded()))
+	})
+
+	It("handles subject and artifact type", func() {
+		art := artdesc.NewManifestArtifact()
+		Expect(art.GetSubject()).To(BeNil())
+		MustBeSuccessful(art.SetSubject(subject))
+		MustBeSuccessful(art.SetArtifactType("application/vnd.test.signature"))
+		Expect(art.GetSubject()).To(Equal(subject))
+		Expect(art.GetArtifactType()).To(Equal("application/vnd.test.signature"))
+
+		Expect(artdesc.New().SetSubject(subject)).NotTo(Succeed())
+	})
+
+	It("provides referrer descriptor", func() {
+		art := artdesc.NewManifestArtifact()
+		m := Must(art.Manifest())
+		m.Config = artdesc.Descriptor{
+			MediaType: artdesc.MediaTypeEmptyJSON,
+			Digest:    digest.FromString("{}"),
+			Size:      2,
+		}
+		MustBeSuccessful(art.SetSubject(subject))
+		MustBeSuccessful(art.SetAnnotation("test", "value"))
+
+		data := Must(artdesc.Encode(art))
+		d := Must(artdesc.ReferrerDescriptorForData(data))
+		Expect(d.MediaType).To(Equal(artdesc.MediaTypeImageManifest))
+		Expect(d.Digest).To(Equal(digest.FromBytes(data)))
+		Expect(d.Size).To(Equal(int64(len(data))))
+		Expect(d.ArtifactType).To(Equal(artdesc.MediaTypeEmptyJSON))
+		Expect(d.Annotations).To(Equal(map[string]string{"test": "value"}))
+
+		MustBeSuccessful(art.SetArtifactType("application/vnd.test.signature"))
+		data = Must(artdesc.Encode(art))
+		d = Must(artdesc.ReferrerDescriptorForData(data))
+		Expect(d.ArtifactType).To(Equal("application/vnd.test.signature"))
+	})
+
+	It("filters referrers", func() {
+		list := []artdesc.Descriptor{
+			{Digest: digest.FromString("a"), ArtifactType: "a"},
+			{Digest: digest.FromString("b"), ArtifactType: "b"},
+		}
+		Expect(artdesc.FilterReferrers(list, "")).To(Equal(list))
+		Expect(artdesc.FilterReferrers(list, "b")).To(Equal(list[1:]))
+		Expect(artdesc.FilterReferrers(list, "c")).To(BeEmpty())
+
+		idx := artdesc.NewReferrersIndex()
+		Expect(idx.Manifests).NotTo(BeNil())
+		Expect(idx.MediaType).To(Equal(artdesc.MediaTypeImageIndex))
+	})
+})
@@ -35,6 +35,8 @@ type (
 	BlobSource                       = internal.BlobSource
 	BlobSink                         = internal.BlobSink
 	NamespaceLister                  = internal.NamespaceLister
+	ArtifactDeleter                  = internal.ArtifactDeleter
+	ReferrersLister                  = internal.ReferrersLister
 	NamespaceAccess                  = internal.NamespaceAccess
 	ManifestAccess                   = internal.ManifestAccess
 	IndexAccess                      = internal.IndexAccess
@@ -108,6 +108,24 @@ func (i *namespaceAccessImpl) AddArtifact(artifact cpi.Artifact, tags ...string)
 	return i.NamespaceContainer.AddArtifact(artifact, tags...)
 }
 
+// ArtifactDeleter provides the deletion functionality of the
+// namespace container, if it implements the cpi.ArtifactDeleter interface.
+func (i *namespaceAccessImpl) ArtifactDeleter() cpi.ArtifactDeleter {
+	if d, ok := i.NamespaceContainer.(cpi.ArtifactDeleter); ok {
+		return d
+	}
+	return nil
+}
+
+// ReferrersLister provides the referrers functionality of the
+// namespace container, if it implements the cpi.ReferrersLister interface.
+func (i *namespaceAccessImpl) ReferrersLister() cpi.ReferrersLister {
+	if l, ok := i.NamespaceContainer.(cpi.ReferrersLister); ok {
+		return l
+	}
+	return nil
+}
+
 func (i *namespaceAccessImpl) NewArtifact(arts ...cpi.Artifact) (cpi.ArtifactAccess, error) {
 	return i.NamespaceContainer.NewArtifact(i, arts...)
 }
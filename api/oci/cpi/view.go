@@ -75,6 +75,36 @@ func NewRepository(impl RepositoryImpl, name ...string) Repository {
 	return resource.NewResource[Repository](impl, repositoryViewCreator, utils.OptionalDefaulted("OCI repo", name...), true)
 }
 
+// BlobGarbageCollector is an optional interface for repository
+// implementations able to remove blobs not used anymore
+// by any artifact stored in the repository.
+type BlobGarbageCollector interface {
+	// CleanupBlobs removes all orphaned blobs and returns their digests.
+	// With dryrun=true, the blobs are only reported.
+	CleanupBlobs(dryrun bool) ([]digest.Digest, error)
+}
+
+// CleanupBlobs removes the orphaned blobs of a repository, if
+// supported by the repository implementation.
+func CleanupBlobs(r Repository, dryrun bool) (list []digest.Digest, err error) {
+	if gc, ok := r.(BlobGarbageCollector); ok {
+		return gc.CleanupBlobs(dryrun)
+	}
+	impl, err := GetRepositoryImplementation(r)
+	if err != nil {
+		return nil, err
+	}
+	gc, ok := impl.(BlobGarbageCollector)
+	if !ok {
+		return nil, errors.ErrNotSupported("blob garbage collection", r.GetSpecification().GetKind())
+	}
+	err = r.(*repositoryView).Execute(func() error {
+		list, err = gc.CleanupBlobs(dryrun)
+		return err
+	})
+	return list, err
+}
+
 func (r *repositoryView) GetConsumerId(uctx ...credentials.UsageContext) credentials.ConsumerIdentity {
 	return credentials.GetProvidedConsumerId(r.impl, uctx...)
 }
@@ -238,6 +268,56 @@ func (n *namespaceAccessView) ListTags() (list []string, err error) {
 	return list, err
 }
 
+func (n *namespaceAccessView) ArtifactDeleter() internal.ArtifactDeleter {
+	d := n.impl.ArtifactDeleter()
+	if d == nil {
+		return nil
+	}
+	return &artifactDeleterView{n, d}
+}
+
+// artifactDeleterView executes the deletion operations
+// on a valid namespace view.
+type artifactDeleterView struct {
+	view    *namespaceAccessView
+	deleter internal.ArtifactDeleter
+}
+
+func (d *artifactDeleterView) DeleteTag(tag string) error {
+	return d.view.Execute(func() error {
+		return d.deleter.DeleteTag(tag)
+	})
+}
+
+func (d *artifactDeleterView) DeleteArtifact(digest digest.Digest) error {
+	return d.view.Execute(func() error {
+		return d.deleter.DeleteArtifact(digest)
+	})
+}
+
+func (n *namespaceAccessView) ReferrersLister() internal.ReferrersLister {
+	l := n.impl.ReferrersLister()
+	if l == nil {
+		return nil
+	}
+	return &referrersListerView{n, l}
+}
+
+// referrersListerView executes the referrers operations
+// on a valid namespace view.
+type referrersListerView struct {
+	view   *namespaceAccessView
+	lister internal.ReferrersLister
+}
+
+func (l *referrersListerView) ListReferrers(digest digest.Digest, artifactType string) (list []artdesc.Descriptor, err error) {
+	err = l.view.Execute(func() error {
+		list, err = l.lister.ListReferrers(digest, artifactType)
+		return err
+	})
+	return list, err
+}
+
 func (n *namespaceAccessView) NewArtifact(artifact ...Artifact) (acc internal.ArtifactAccess, err error) {
 	err = n.Execute(func() error {
 		acc, err = n.impl.NewArtifact(artifact...)
@@ -0,0 +1,61 @@
+package referrersattr
+
+import (
+	"fmt"
+
+	"ocm.software/ocm/api/datacontext"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	ATTR_KEY   = "ocm.software/oci/referrers"
+	ATTR_SHORT = "ocireferrers"
+)
+
+func init() {
+	datacontext.RegisterAttributeType(ATTR_KEY, AttributeType{}, ATTR_SHORT)
+}
+
+type AttributeType struct{}
+
+func (a AttributeType) Name() string {
+	return ATTR_KEY
+}
+
+func (a AttributeType) Description() string {
+	return `
+*bool*
+Carry the referrers of OCI artifacts (artifacts referring to them by their
+<code>subject</code> field, like signatures, SBOMs or attestations) along
+with the artifacts when synthesizing artifact blobs for the
+<code>ociArtifact</code> access method and when uploading OCI artifacts
+to OCI registries during component transports.
+`
+}
+
+func (a AttributeType) Encode(v interface{}, marshaller runtime.Marshaler) ([]byte, error) {
+	if _, ok := v.(bool); !ok {
+		return nil, fmt.Errorf("boolean required")
+	}
+	return marshaller.Marshal(v)
+}
+
+func (a AttributeType) Decode(data []byte, unmarshaller runtime.Unmarshaler) (interface{}, error) {
+	var value bool
+	err := unmarshaller.Unmarshal(data, &value)
+	return value, err
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func Get(ctx datacontext.Context) bool {
+	a := ctx.GetAttributes().GetAttribute(ATTR_KEY)
+	if a == nil {
+		return false
+	}
+	return a.(bool)
+}
+
+func Set(ctx datacontext.Context, flag bool) error {
+	return ctx.GetAttributes().SetAttribute(ATTR_KEY, flag)
+}
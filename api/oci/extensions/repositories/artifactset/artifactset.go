@@ -23,6 +23,8 @@ const (
 	TYPE_ANNOTATION = annotations.TYPE_ANNOTATION
 
 	OCITAG_ANNOTATION = annotations.OCITAG_ANNOTATION
+
+	SUBJECT_ANNOTATION = annotations.SUBJECT_ANNOTATION
 )
 
 func RetrieveMainArtifactFromIndex(index *artdesc.Index) string {
@@ -132,6 +134,11 @@ type namespaceContainer struct {
 	impl cpi.NamespaceAccessImpl
 }
 
+var (
+	_ cpi.ArtifactDeleter = (*namespaceContainer)(nil)
+	_ cpi.ReferrersLister = (*namespaceContainer)(nil)
+)
+
 // New returns a new representation based element.
 func New(acc accessobj.AccessMode, fs vfs.FileSystem, setup accessobj.Setup, closer accessobj.Closer, mode vfs.FileMode, formatVersion string) (*ArtifactSet, error) {
 	return _Wrap(accessobj.NewAccessObject(NewAccessObjectInfo(formatVersion), acc, fs, setup, closer, mode))
@@ -437,17 +444,57 @@ func (a *namespaceContainer) AddPlatformArtifact(artifact cpi.Artifact, platform
 		return nil, err
 	}
 
-	idx.Manifests = append(idx.Manifests, cpi.Descriptor{
+	desc := cpi.Descriptor{
 		MediaType:   blob.MimeType(),
 		Digest:      blob.Digest(),
 		Size:        blob.Size(),
 		URLs:        nil,
 		Annotations: nil,
 		Platform:    platform,
-	})
+	}
+	if subject := artifact.Artifact().GetSubject(); subject != nil {
+		desc.ArtifactType = artifact.Artifact().GetArtifactType()
+		desc.Annotations = map[string]string{
+			SUBJECT_ANNOTATION: subject.Digest.String(),
+		}
+	}
+	idx.Manifests = append(idx.Manifests, desc)
 	return blob, nil
 }
 
+func (a *namespaceContainer) ListReferrers(digest digest.Digest, artifactType string) ([]artdesc.Descriptor, error) {
+	if a.IsClosed() {
+		return nil, accessio.ErrClosed
+	}
+	var list []artdesc.Descriptor
+	done := map[string]bool{}
+	for _, e := range a.GetIndex().Manifests {
+		if e.Annotations[SUBJECT_ANNOTATION] != digest.String() || done[e.Digest.String()] {
+			continue
+		}
+		done[e.Digest.String()] = true
+		d, err := a.referrerDescriptor(e.Digest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "referrer %s", e.Digest)
+		}
+		list = append(list, *d)
+	}
+	return artdesc.FilterReferrers(list, artifactType), nil
+}
+
+func (a *namespaceContainer) referrerDescriptor(digest digest.Digest) (*artdesc.Descriptor, error) {
+	_, acc, err := a.base.GetBlobData(digest)
+	if err != nil {
+		return nil, err
+	}
+	defer acc.Close()
+	data, err := acc.Get()
+	if err != nil {
+		return nil, err
+	}
+	return artdesc.ReferrerDescriptorForData(data)
+}
+
 func (a *namespaceContainer) NewArtifact(i support.NamespaceAccessImpl, artifact ...cpi.Artifact) (cpi.ArtifactAccess, error) {
 	if a.IsClosed() {
 		return nil, accessio.ErrClosed
@@ -457,3 +504,80 @@ func (a *namespaceContainer) NewArtifact(i support.NamespaceAccessImpl, artifact
 	}
 	return support.NewArtifact(i, artifact...)
 }
+
+func (a *namespaceContainer) DeleteTag(tag string) error {
+	if a.IsClosed() {
+		return accessio.ErrClosed
+	}
+	if a.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	a.base.Lock()
+	defer a.base.Unlock()
+
+	idx := a.GetIndex()
+	for i, e := range idx.Manifests {
+		if e.Annotations == nil {
+			continue
+		}
+		tags := strings.Split(RetrieveTags(e.Annotations), ",")
+		for j, t := range tags {
+			if t != tag {
+				continue
+			}
+			tags = append(tags[:j], tags[j+1:]...)
+			if len(tags) == 0 {
+				delete(idx.Manifests[i].Annotations, TAGS_ANNOTATION)
+			} else {
+				idx.Manifests[i].Annotations[TAGS_ANNOTATION] = strings.Join(tags, ",")
+			}
+			if e.Annotations[OCITAG_ANNOTATION] == tag {
+				if len(tags) == 0 {
+					delete(idx.Manifests[i].Annotations, OCITAG_ANNOTATION)
+				} else {
+					idx.Manifests[i].Annotations[OCITAG_ANNOTATION] = tags[0]
+				}
+			}
+			if idx.Annotations != nil && RetrieveMainArtifact(idx.Annotations) == tag {
+				idx.Annotations[MAINARTIFACT_ANNOTATION] = e.Digest.String()
+			}
+			return nil
+		}
+	}
+	return errors.ErrNotFound(cpi.KIND_OCIARTIFACT, tag)
+}
+
+func (a *namespaceContainer) DeleteArtifact(digest digest.Digest) error {
+	if a.IsClosed() {
+		return accessio.ErrClosed
+	}
+	if a.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	a.base.Lock()
+	defer a.base.Unlock()
+
+	idx := a.GetIndex()
+	main := ""
+	if idx.Annotations != nil {
+		main = RetrieveMainArtifact(idx.Annotations)
+	}
+	found := false
+	match := matcher(main)
+	for i := 0; i < len(idx.Manifests); i++ {
+		e := idx.Manifests[i]
+		if e.Digest != digest {
+			continue
+		}
+		if main != "" && match(&e) {
+			delete(idx.Annotations, MAINARTIFACT_ANNOTATION)
+		}
+		idx.Manifests = append(idx.Manifests[:i], idx.Manifests[i+1:]...)
+		found = true
+		i--
+	}
+	if !found {
+		return errors.ErrNotFound(cpi.KIND_OCIARTIFACT, digest.String())
+	}
+	return nil
+}
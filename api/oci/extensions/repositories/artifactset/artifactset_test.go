@@ -227,4 +227,34 @@ var _ = Describe("artifact management", func() {
 			Expect(blob.MimeType()).To(Equal(mime.MIME_OCTET))
 		})
 	})
+
+	Context("deletion", func() {
+		TestForAllFormats("deletes tags and artifacts", func(format string) {
+			opts := Must(accessio.AccessOptions(&artifactset.Options{}, opts, artifactset.StructureFormat(format)))
+
+			a := Must(artifactset.FormatDirectory.Create("test", opts, 0o700))
+			defaultManifestFill(a)
+			dig := a.GetIndex().Manifests[0].Digest
+			MustBeSuccessful(a.AddTags(dig, "v1", "v2"))
+			a.SetMainArtifact("v1")
+
+			d := a.ArtifactDeleter()
+			Expect(d).NotTo(BeNil())
+			MustBeSuccessful(d.DeleteTag("v1"))
+			Expect(a.ListTags()).To(ConsistOf("v2"))
+			Expect(a.GetMain()).To(Equal(dig))
+			Expect(d.DeleteTag("v1")).To(HaveOccurred())
+
+			MustBeSuccessful(d.DeleteArtifact(dig))
+			Expect(a.GetIndex().Manifests).To(BeEmpty())
+			Expect(a.GetMain()).To(BeEmpty())
+			Expect(a.ListTags()).To(BeEmpty())
+			Expect(d.DeleteArtifact(dig)).To(HaveOccurred())
+			Expect(a.Close()).To(Succeed())
+
+			a = Must(artifactset.FormatDirectory.Open(accessobj.ACC_READONLY, "test", opts))
+			defer Close(a, "artefactset")
+			Expect(a.GetIndex().Manifests).To(BeEmpty())
+		})
+	})
 })
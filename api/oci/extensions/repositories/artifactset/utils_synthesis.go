@@ -88,6 +88,17 @@ func SynthesizeArtifactBlobWithFilter(ns cpi.NamespaceAccess, ref string, filter
 }
 
 func SynthesizeArtifactBlobForArtifact(art cpi.ArtifactAccess, ref string, filter ...filters.Filter) (ArtifactBlob, error) {
+	return synthesizeArtifactBlobForArtifact(nil, art, ref, filter...)
+}
+
+// SynthesizeArtifactBlobWithReferrers synthesizes an artifact blob for an artifact
+// of the given namespace incorporating the referrers of the artifact
+// (for example signatures, SBOMs or attestations) found in the namespace.
+func SynthesizeArtifactBlobWithReferrers(ns cpi.NamespaceAccess, art cpi.ArtifactAccess, ref string, filter ...filters.Filter) (ArtifactBlob, error) {
+	return synthesizeArtifactBlobForArtifact(ns, art, ref, filter...)
+}
+
+func synthesizeArtifactBlobForArtifact(ns cpi.NamespaceAccess, art cpi.ArtifactAccess, ref string, filter ...filters.Filter) (ArtifactBlob, error) {
 	blob, err := art.Blob()
 	if err != nil {
 		return nil, err
@@ -104,6 +115,13 @@ func SynthesizeArtifactBlobForArtifact(art cpi.ArtifactAccess, ref string, filte
 			return "", fmt.Errorf("failed to transfer artifact: %w", err)
 		}
 
+		if ns != nil && *dig == art.Digest() {
+			err = transfer.TransferReferrers(ns, *dig, set)
+			if err != nil {
+				return "", fmt.Errorf("failed to transfer referrers: %w", err)
+			}
+		}
+
 		if ok := vers.IsTagged(); ok {
 			err = set.AddTags(*dig, vers.GetTag())
 			if err != nil {
@@ -18,6 +18,9 @@ type ArtifactMeta struct {
 	Repository string        `json:"repository"`
 	Tag        string        `json:"tag,omitempty"`
 	Digest     digest.Digest `json:"digest,omitempty"`
+	// Subject is the digest of the artifact referred to by this
+	// artifact (OCI subject field) used to list referrers.
+	Subject digest.Digest `json:"subject,omitempty"`
 }
 
 func Decode(data []byte) (*ArtifactIndex, error) {
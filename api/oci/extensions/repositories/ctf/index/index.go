@@ -128,6 +128,106 @@ func (r *RepositoryIndex) addArtifactInfo(m ArtifactMeta) {
 	}
 }
 
+// DeleteTag removes a tag from a repository. The tagged artifact
+// is still available by its digest.
+func (r *RepositoryIndex) DeleteTag(repo, tag string) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	versions := r.byRepository[repo]
+	if versions == nil || strings.HasPrefix(tag, "@") || versions[tag] == nil {
+		return cpi.ErrUnknownArtifact(repo, tag)
+	}
+	m := versions[tag]
+	delete(versions, tag)
+
+	list := r.byDigest[m.Digest]
+	var other *ArtifactMeta
+	for _, e := range list {
+		if e != m && e.Repository == repo {
+			other = e
+			break
+		}
+	}
+	if other == nil {
+		// keep the artifact as anonymous entry
+		m.Tag = ""
+		versions["@"+m.Digest.String()] = m
+		return nil
+	}
+	for i, e := range list {
+		if e == m {
+			r.byDigest[m.Digest] = append(list[:i], list[i+1:]...)
+			break
+		}
+	}
+	versions["@"+m.Digest.String()] = other
+	return nil
+}
+
+// DeleteArtifact removes an artifact together with all its tags
+// from a repository.
+func (r *RepositoryIndex) DeleteArtifact(repo string, digest digest.Digest) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	versions := r.byRepository[repo]
+	if versions == nil || versions["@"+digest.String()] == nil {
+		return cpi.ErrUnknownArtifact(repo, digest.String())
+	}
+	delete(versions, "@"+digest.String())
+
+	var list []*ArtifactMeta
+	for _, e := range r.byDigest[digest] {
+		if e.Repository != repo {
+			list = append(list, e)
+			continue
+		}
+		if e.Tag != "" && versions[e.Tag] == e {
+			delete(versions, e.Tag)
+		}
+	}
+	if len(list) == 0 {
+		delete(r.byDigest, digest)
+	} else {
+		r.byDigest[digest] = list
+	}
+	if len(versions) == 0 {
+		delete(r.byRepository, repo)
+	}
+	return nil
+}
+
+// GetDigests returns the digests of all artifacts
+// found in the index.
+func (r *RepositoryIndex) GetDigests() []digest.Digest {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	result := make([]digest.Digest, 0, len(r.byDigest))
+	for d := range r.byDigest {
+		result = append(result, d)
+	}
+	return result
+}
+
+// GetReferrers returns the artifacts of a repository
+// referring to the artifact with the given digest.
+func (r *RepositoryIndex) GetReferrers(repo string, digest digest.Digest) []*ArtifactMeta {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var result []*ArtifactMeta
+	for t, m := range r.byRepository[repo] {
+		if strings.HasPrefix(t, "@") && m.Subject == digest {
+			n := *m
+			result = append(result, &n)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Digest < result[j].Digest })
+	return result
+}
+
 func (r *RepositoryIndex) HasArtifact(repo, tag string) bool {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
@@ -241,6 +341,7 @@ func (r *RepositoryIndex) GetDescriptor() *ArtifactIndex {
 					Repository: vers.Repository,
 					Tag:        vers.Tag,
 					Digest:     vers.Digest,
+					Subject:    vers.Subject,
 				}
 				index.Index = append(index.Index, *d)
 			}
@@ -4,6 +4,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "ocm.software/ocm/api/oci/extensions/repositories/ctf/index"
+
+	"github.com/opencontainers/go-digest"
 )
 
 var _ = Describe("index", func() {
@@ -210,4 +212,57 @@ var _ = Describe("index", func() {
 			})
 		})
 	})
+
+	Context("deletion", func() {
+		It("deletes tag", func() {
+			a1 := NewMeta("repo1", "v1", "digest1")
+			rindex.AddArtifactInfo(a1)
+
+			Expect(rindex.DeleteTag("repo1", "v1")).To(Succeed())
+			Expect(rindex.GetArtifactInfo("repo1", "v1")).To(BeNil())
+			Expect(rindex.GetArtifactInfo("repo1", "digest1")).To(Equal(NewMeta("repo1", "", "digest1")))
+			Expect(rindex.GetTags("repo1")).To(BeEmpty())
+			Expect(rindex.GetDescriptor().Index).To(Equal([]ArtifactMeta{
+				*NewMeta("repo1", "", "digest1"),
+			}))
+		})
+
+		It("deletes one of two tags", func() {
+			rindex.AddArtifactInfo(NewMeta("repo1", "v1", "digest1"))
+			rindex.AddArtifactInfo(NewMeta("repo1", "v2", "digest1"))
+
+			Expect(rindex.DeleteTag("repo1", "v1")).To(Succeed())
+			Expect(rindex.GetArtifactInfo("repo1", "v1")).To(BeNil())
+			Expect(rindex.GetArtifactInfo("repo1", "digest1")).To(Equal(NewMeta("repo1", "v2", "digest1")))
+			Expect(rindex.GetTags("repo1")).To(ConsistOf("v2"))
+			Expect(rindex.GetDescriptor().Index).To(Equal([]ArtifactMeta{
+				*NewMeta("repo1", "v2", "digest1"),
+			}))
+		})
+
+		It("fails for unknown tag", func() {
+			rindex.AddArtifactInfo(NewMeta("repo1", "v1", "digest1"))
+			Expect(rindex.DeleteTag("repo1", "v2")).To(HaveOccurred())
+			Expect(rindex.DeleteTag("repo2", "v1")).To(HaveOccurred())
+		})
+
+		It("deletes artifact", func() {
+			rindex.AddArtifactInfo(NewMeta("repo1", "v1", "digest1"))
+			rindex.AddArtifactInfo(NewMeta("repo1", "v2", "digest1"))
+			rindex.AddArtifactInfo(NewMeta("repo1", "v3", "digest2"))
+			rindex.AddArtifactInfo(NewMeta("repo2", "v1", "digest1"))
+
+			Expect(rindex.DeleteArtifact("repo1", "digest1")).To(Succeed())
+			Expect(rindex.GetArtifactInfo("repo1", "v1")).To(BeNil())
+			Expect(rindex.GetArtifactInfo("repo1", "v2")).To(BeNil())
+			Expect(rindex.GetArtifactInfo("repo1", "digest1")).To(BeNil())
+			Expect(rindex.GetTags("repo1")).To(ConsistOf("v3"))
+			Expect(rindex.GetArtifactInfos("digest1")).To(ConsistOf(NewMeta("repo2", "v1", "digest1")))
+			Expect(rindex.GetDigests()).To(ConsistOf(digest.Digest("digest1"), digest.Digest("digest2")))
+
+			Expect(rindex.DeleteArtifact("repo1", "digest2")).To(Succeed())
+			Expect(rindex.RepositoryList()).To(ConsistOf("repo2"))
+			Expect(rindex.DeleteArtifact("repo1", "digest2")).To(HaveOccurred())
+		})
+	})
 })
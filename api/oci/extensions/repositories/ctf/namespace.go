@@ -4,6 +4,7 @@ import (
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/opencontainers/go-digest"
 
+	"ocm.software/ocm/api/oci/artdesc"
 	"ocm.software/ocm/api/oci/cpi"
 	"ocm.software/ocm/api/oci/cpi/support"
 	"ocm.software/ocm/api/oci/extensions/repositories/ctf/index"
@@ -20,7 +21,11 @@ type namespaceContainer struct {
 	repo *RepositoryImpl
 }
 
-var _ support.NamespaceContainer = (*namespaceContainer)(nil)
+var (
+	_ support.NamespaceContainer = (*namespaceContainer)(nil)
+	_ cpi.ArtifactDeleter        = (*namespaceContainer)(nil)
+	_ cpi.ReferrersLister        = (*namespaceContainer)(nil)
+)
 
 func newNamespaceContainer(repo *RepositoryImpl) support.NamespaceContainer {
 	return &namespaceContainer{
@@ -80,11 +85,15 @@ func (n *namespaceContainer) AddArtifact(artifact cpi.Artifact, tags ...string)
 	if err != nil {
 		return nil, err
 	}
-	n.repo.getIndex().AddArtifactInfo(&index.ArtifactMeta{
+	meta := &index.ArtifactMeta{
 		Repository: n.impl.GetNamespace(),
 		Tag:        "",
 		Digest:     blob.Digest(),
-	})
+	}
+	if subject := artifact.Artifact().GetSubject(); subject != nil {
+		meta.Subject = subject.Digest
+	}
+	n.repo.getIndex().AddArtifactInfo(meta)
 	return blob, n.AddTags(blob.Digest(), tags...)
 }
 
@@ -92,6 +101,51 @@ func (n *namespaceContainer) AddTags(digest digest.Digest, tags ...string) error
 	return n.repo.getIndex().AddTagsFor(n.impl.GetNamespace(), digest, tags...)
 }
 
+func (n *namespaceContainer) DeleteTag(tag string) error {
+	if n.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	n.repo.base.Lock()
+	defer n.repo.base.Unlock()
+
+	return n.repo.getIndex().DeleteTag(n.impl.GetNamespace(), tag)
+}
+
+func (n *namespaceContainer) DeleteArtifact(digest digest.Digest) error {
+	if n.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	n.repo.base.Lock()
+	defer n.repo.base.Unlock()
+
+	return n.repo.getIndex().DeleteArtifact(n.impl.GetNamespace(), digest)
+}
+
+func (n *namespaceContainer) ListReferrers(digest digest.Digest, artifactType string) ([]artdesc.Descriptor, error) {
+	var list []artdesc.Descriptor
+	for _, m := range n.repo.getIndex().GetReferrers(n.impl.GetNamespace(), digest) {
+		d, err := n.referrerDescriptor(m.Digest)
+		if err != nil {
+			return nil, errors.Wrapf(err, "referrer %s", m.Digest)
+		}
+		list = append(list, *d)
+	}
+	return artdesc.FilterReferrers(list, artifactType), nil
+}
+
+func (n *namespaceContainer) referrerDescriptor(digest digest.Digest) (*artdesc.Descriptor, error) {
+	_, acc, err := n.repo.base.GetBlobData(digest)
+	if err != nil {
+		return nil, err
+	}
+	defer acc.Close()
+	data, err := acc.Get()
+	if err != nil {
+		return nil, err
+	}
+	return artdesc.ReferrerDescriptorForData(data)
+}
+
 func (n *namespaceContainer) NewArtifact(i support.NamespaceAccessImpl, art ...cpi.Artifact) (cpi.ArtifactAccess, error) {
 	if n.IsReadOnly() {
 		return nil, accessio.ErrReadOnly
@@ -1,14 +1,18 @@
 package ctf
 
 import (
+	"github.com/mandelsoft/goutils/errors"
 	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
 
 	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	"ocm.software/ocm/api/oci/artdesc"
 	"ocm.software/ocm/api/oci/cpi"
 	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
 	"ocm.software/ocm/api/oci/extensions/repositories/ctf/index"
 	"ocm.software/ocm/api/utils/accessio"
 	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
 	"ocm.software/ocm/api/utils/refmgmt"
 )
 
@@ -36,6 +40,15 @@ func (r *Repository) Write(path string, mode vfs.FileMode, opts ...accessio.Opti
 	return r.impl.Write(path, mode, opts...)
 }
 
+// CleanupBlobs removes all blobs not used anymore by any artifact
+// stored in the repository.
+func (r *Repository) CleanupBlobs(dryrun bool) ([]digest.Digest, error) {
+	if r.IsClosed() {
+		return nil, cpi.ErrClosed
+	}
+	return r.impl.CleanupBlobs(dryrun)
+}
+
 func (r *Repository) Close() error { // why ???
 	return r.Repository.Close()
 }
@@ -50,7 +63,10 @@ type RepositoryImpl struct {
 	base *artifactset.FileSystemBlobAccess
 }
 
-var _ cpi.RepositoryImpl = (*RepositoryImpl)(nil)
+var (
+	_ cpi.RepositoryImpl       = (*RepositoryImpl)(nil)
+	_ cpi.BlobGarbageCollector = (*RepositoryImpl)(nil)
+)
 
 // New returns a new representation based repository.
 func New(ctx cpi.Context, spec *RepositorySpec, setup accessobj.Setup, closer accessobj.Closer, mode vfs.FileMode) (*Repository, error) {
@@ -141,3 +157,85 @@ func (r *RepositoryImpl) LookupArtifact(name string, ref string) (acc cpi.Artifa
 func (r *RepositoryImpl) LookupNamespace(name string) (cpi.NamespaceAccess, error) {
 	return NewNamespace(r, name)
 }
+
+////////////////////////////////////////////////////////////////////////////////
+// garbage collection
+
+// CleanupBlobs removes all blobs not referenced anymore by
+// an artifact found in the index. With dryrun=true, the
+// orphaned blobs are only reported.
+func (r *RepositoryImpl) CleanupBlobs(dryrun bool) ([]digest.Digest, error) {
+	if !dryrun && r.IsReadOnly() {
+		return nil, accessio.ErrReadOnly
+	}
+	r.base.Lock()
+	defer r.base.Unlock()
+
+	used := map[digest.Digest]bool{}
+	for _, d := range r.getIndex().GetDigests() {
+		err := r.collectBlobs(d, used)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	blobs, err := r.base.ListBlobs()
+	if err != nil {
+		return nil, err
+	}
+	var result []digest.Digest
+	for _, d := range blobs {
+		if used[d] {
+			continue
+		}
+		if !dryrun {
+			err = r.base.RemoveBlob(d)
+			if err != nil {
+				return result, errors.Wrapf(err, "cannot remove blob %s", d)
+			}
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+func (r *RepositoryImpl) collectBlobs(d digest.Digest, used map[digest.Digest]bool) error {
+	if used[d] {
+		return nil
+	}
+	used[d] = true
+
+	_, acc, err := r.base.GetBlobData(d)
+	if err != nil {
+		if blobaccess.IsErrBlobNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	data, err := acc.Get()
+	acc.Close()
+	if err != nil {
+		return err
+	}
+	art, err := artdesc.Decode(data)
+	if err != nil {
+		return errors.Wrapf(err, "artifact %s", d)
+	}
+	switch {
+	case art.IsManifest():
+		m, _ := art.Manifest()
+		used[m.Config.Digest] = true
+		for _, l := range m.Layers {
+			used[l.Digest] = true
+		}
+	case art.IsIndex():
+		idx, _ := art.Index()
+		for _, m := range idx.Manifests {
+			err := r.collectBlobs(m.Digest, used)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -5,5 +5,6 @@ import (
 	_ "ocm.software/ocm/api/oci/extensions/repositories/ctf"
 	_ "ocm.software/ocm/api/oci/extensions/repositories/docker"
 	_ "ocm.software/ocm/api/oci/extensions/repositories/empty"
+	_ "ocm.software/ocm/api/oci/extensions/repositories/ocilayout"
 	_ "ocm.software/ocm/api/oci/extensions/repositories/ocireg"
 )
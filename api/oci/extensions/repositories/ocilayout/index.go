@@ -0,0 +1,125 @@
+package ocilayout
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/oci/annotations"
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/grammar"
+)
+
+// RefName provides the reference name annotation value used
+// for a tag in a namespace.
+func RefName(namespace, tag string) string {
+	if namespace == "" {
+		return tag
+	}
+	return namespace + grammar.TagSeparator + tag
+}
+
+// SplitRefName splits a reference name annotation value into
+// the namespace and the tag.
+func SplitRefName(name string) (string, string) {
+	i := strings.LastIndex(name, grammar.TagSeparator)
+	if i < 0 || strings.Contains(name[i+1:], grammar.RepositorySeparator) {
+		return "", name
+	}
+	return name[:i], name[i+1:]
+}
+
+func refName(d *artdesc.Descriptor) string {
+	if d.Annotations == nil {
+		return ""
+	}
+	return d.Annotations[annotations.OCITAG_ANNOTATION]
+}
+
+// namespaces returns the namespaces described by tagged
+// index entries.
+func namespaces(idx *artdesc.Index) []string {
+	set := map[string]struct{}{}
+	for i := range idx.Manifests {
+		if n := refName(&idx.Manifests[i]); n != "" {
+			ns, _ := SplitRefName(n)
+			set[ns] = struct{}{}
+		}
+	}
+	result := make([]string, 0, len(set))
+	for ns := range set {
+		result = append(result, ns)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// tags returns the tags found for a namespace.
+func tags(idx *artdesc.Index, namespace string) []string {
+	var result []string
+	for i := range idx.Manifests {
+		if n := refName(&idx.Manifests[i]); n != "" {
+			ns, tag := SplitRefName(n)
+			if ns == namespace {
+				result = append(result, tag)
+			}
+		}
+	}
+	return result
+}
+
+// lookupTag returns the index entry for a tag in a namespace.
+// Layouts typically only use plain tags, therefore a plain tag
+// is used as fallback for any namespace.
+func lookupTag(idx *artdesc.Index, namespace, tag string) *artdesc.Descriptor {
+	if d := lookupRefName(idx, RefName(namespace, tag)); d != nil {
+		return d
+	}
+	if namespace != "" {
+		return lookupRefName(idx, tag)
+	}
+	return nil
+}
+
+func lookupRefName(idx *artdesc.Index, name string) *artdesc.Descriptor {
+	for i := range idx.Manifests {
+		if refName(&idx.Manifests[i]) == name {
+			return &idx.Manifests[i]
+		}
+	}
+	return nil
+}
+
+// lookupDigest returns an index entry for a digest, preferably
+// an untagged one.
+func lookupDigest(idx *artdesc.Index, dig digest.Digest) *artdesc.Descriptor {
+	var found *artdesc.Descriptor
+	for i := range idx.Manifests {
+		d := &idx.Manifests[i]
+		if d.Digest == dig {
+			if refName(d) == "" {
+				return d
+			}
+			if found == nil {
+				found = d
+			}
+		}
+	}
+	return found
+}
+
+// removeEntries removes all index entries matching the given condition.
+func removeEntries(idx *artdesc.Index, match func(d *artdesc.Descriptor) bool) bool {
+	var list []artdesc.Descriptor
+	for i := range idx.Manifests {
+		if !match(&idx.Manifests[i]) {
+			list = append(list, idx.Manifests[i])
+		}
+	}
+	if len(list) == len(idx.Manifests) {
+		return false
+	}
+	idx.Manifests = list
+	return true
+}
@@ -0,0 +1,238 @@
+package ocilayout
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/oci/annotations"
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/cpi"
+	"ocm.software/ocm/api/oci/cpi/support"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+)
+
+func NewNamespace(repo *RepositoryImpl, name string) (cpi.NamespaceAccess, error) {
+	return support.NewNamespaceAccess(name, newNamespaceContainer(repo), repo, "OCI image layout namespace")
+}
+
+type namespaceContainer struct {
+	impl support.NamespaceAccessImpl
+	repo *RepositoryImpl
+}
+
+var (
+	_ support.NamespaceContainer = (*namespaceContainer)(nil)
+	_ cpi.ArtifactDeleter        = (*namespaceContainer)(nil)
+)
+
+func newNamespaceContainer(repo *RepositoryImpl) support.NamespaceContainer {
+	return &namespaceContainer{
+		repo: repo,
+	}
+}
+
+func (n *namespaceContainer) SetImplementation(impl support.NamespaceAccessImpl) {
+	n.impl = impl
+}
+
+func (n *namespaceContainer) IsReadOnly() bool {
+	return n.repo.IsReadOnly()
+}
+
+func (n *namespaceContainer) Close() error {
+	return nil
+}
+
+func (n *namespaceContainer) GetBlobDescriptor(digest digest.Digest) *cpi.Descriptor {
+	return nil
+}
+
+func (n *namespaceContainer) ListTags() ([]string, error) {
+	return tags(n.repo.getIndex(), n.impl.GetNamespace()), nil
+}
+
+func (n *namespaceContainer) GetBlobData(digest digest.Digest) (int64, cpi.DataAccess, error) {
+	return n.repo.base.GetBlobData(digest)
+}
+
+func (n *namespaceContainer) AddBlob(blob cpi.BlobAccess) error {
+	n.repo.base.Lock()
+	defer n.repo.base.Unlock()
+
+	return n.repo.base.AddBlob(blob)
+}
+
+// lookup resolves a version (tag or digest) to the digest of
+// an artifact. Because the layout is a shared content store,
+// digests are resolved by the blob store, this covers also
+// nested artifacts of indices, which typically have no own
+// index entry.
+func (n *namespaceContainer) lookup(vers string) (digest.Digest, error) {
+	if ok, dig := artdesc.IsDigest(vers); ok {
+		_, acc, err := n.repo.base.GetBlobData(dig)
+		if err != nil {
+			if blobaccess.IsErrBlobNotFound(err) {
+				return "", nil
+			}
+			return "", err
+		}
+		acc.Close()
+		return dig, nil
+	}
+	d := lookupTag(n.repo.getIndex(), n.impl.GetNamespace(), vers)
+	if d == nil {
+		return "", nil
+	}
+	return d.Digest, nil
+}
+
+func (n *namespaceContainer) GetArtifact(i support.NamespaceAccessImpl, vers string) (cpi.ArtifactAccess, error) {
+	dig, err := n.lookup(vers)
+	if err != nil {
+		return nil, err
+	}
+	if dig == "" {
+		return nil, errors.ErrNotFound(cpi.KIND_OCIARTIFACT, vers, n.impl.GetNamespace())
+	}
+	return n.repo.base.GetArtifact(i, dig)
+}
+
+func (n *namespaceContainer) HasArtifact(vers string) (bool, error) {
+	dig, err := n.lookup(vers)
+	return dig != "", err
+}
+
+func (n *namespaceContainer) AddArtifact(artifact cpi.Artifact, tags ...string) (access blobaccess.BlobAccess, err error) {
+	n.repo.base.Lock()
+	defer n.repo.base.Unlock()
+
+	blob, err := n.repo.base.AddArtifactBlob(artifact)
+	if err != nil {
+		return nil, err
+	}
+	idx := n.repo.getIndex()
+	if len(tags) == 0 && lookupDigest(idx, blob.Digest()) == nil {
+		idx.AddManifest(&artdesc.Descriptor{
+			MediaType: blob.MimeType(),
+			Digest:    blob.Digest(),
+			Size:      blob.Size(),
+		})
+	}
+	return blob, n.addTags(blob.Digest(), tags...)
+}
+
+func (n *namespaceContainer) AddTags(digest digest.Digest, tags ...string) error {
+	n.repo.base.Lock()
+	defer n.repo.base.Unlock()
+
+	return n.addTags(digest, tags...)
+}
+
+func (n *namespaceContainer) addTags(dig digest.Digest, tags ...string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	if n.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	desc, err := n.descriptor(dig)
+	if err != nil {
+		return err
+	}
+	idx := n.repo.getIndex()
+	for _, tag := range tags {
+		name := RefName(n.impl.GetNamespace(), tag)
+		removeEntries(idx, func(d *artdesc.Descriptor) bool {
+			return refName(d) == name
+		})
+		// reuse an untagged entry for the first tag.
+		if d := lookupDigest(idx, dig); d != nil && refName(d) == "" {
+			if d.Annotations == nil {
+				d.Annotations = map[string]string{}
+			}
+			d.Annotations[annotations.OCITAG_ANNOTATION] = name
+			continue
+		}
+		e := *desc
+		e.Annotations = map[string]string{annotations.OCITAG_ANNOTATION: name}
+		idx.AddManifest(&e)
+	}
+	return nil
+}
+
+// descriptor provides the index descriptor for an artifact stored
+// in the layout.
+func (n *namespaceContainer) descriptor(dig digest.Digest) (*artdesc.Descriptor, error) {
+	if d := lookupDigest(n.repo.getIndex(), dig); d != nil {
+		return &artdesc.Descriptor{
+			MediaType: d.MediaType,
+			Digest:    d.Digest,
+			Size:      d.Size,
+			Platform:  d.Platform,
+		}, nil
+	}
+	_, acc, err := n.repo.base.GetBlobData(dig)
+	if err != nil {
+		return nil, err
+	}
+	defer acc.Close()
+	data, err := acc.Get()
+	if err != nil {
+		return nil, err
+	}
+	art, err := artdesc.Decode(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "artifact %s", dig)
+	}
+	return &artdesc.Descriptor{
+		MediaType: art.MimeType(),
+		Digest:    dig,
+		Size:      int64(len(data)),
+	}, nil
+}
+
+func (n *namespaceContainer) DeleteTag(tag string) error {
+	if n.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	n.repo.base.Lock()
+	defer n.repo.base.Unlock()
+
+	name := RefName(n.impl.GetNamespace(), tag)
+	if !removeEntries(n.repo.getIndex(), func(d *artdesc.Descriptor) bool { return refName(d) == name }) {
+		return cpi.ErrUnknownArtifact(n.impl.GetNamespace(), tag)
+	}
+	return nil
+}
+
+func (n *namespaceContainer) DeleteArtifact(dig digest.Digest) error {
+	if n.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	n.repo.base.Lock()
+	defer n.repo.base.Unlock()
+
+	ns := n.impl.GetNamespace()
+	if !removeEntries(n.repo.getIndex(), func(d *artdesc.Descriptor) bool {
+		if d.Digest != dig {
+			return false
+		}
+		name := refName(d)
+		if name == "" {
+			return true
+		}
+		dns, _ := SplitRefName(name)
+		return dns == ns
+	}) {
+		return cpi.ErrUnknownArtifact(ns, dig.String())
+	}
+	return nil
+}
+
+func (n *namespaceContainer) NewArtifact(i support.NamespaceAccessImpl, art ...cpi.Artifact) (cpi.ArtifactAccess, error) {
+	if n.IsReadOnly() {
+		return nil, accessio.ErrReadOnly
+	}
+	return support.NewArtifact(i, art...)
+}
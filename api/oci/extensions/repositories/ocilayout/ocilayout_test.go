@@ -0,0 +1,224 @@
+package ocilayout_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/oci/extensions/repositories/ctf/testhelper"
+
+	"github.com/mandelsoft/goutils/finalizer"
+	"github.com/mandelsoft/vfs/pkg/osfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	"ocm.software/ocm/api/oci"
+	"ocm.software/ocm/api/oci/annotations"
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/cpi"
+	"ocm.software/ocm/api/oci/extensions/repositories/ocilayout"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+)
+
+const NAMESPACE = "mandelsoft/test"
+
+func readIndex(fs vfs.FileSystem, path string) *artdesc.Index {
+	return Must(artdesc.DecodeIndex(Must(vfs.ReadFile(fs, path))))
+}
+
+var _ = Describe("oci image layout", func() {
+	var tempfs vfs.FileSystem
+	var ctx oci.Context
+
+	BeforeEach(func() {
+		tempfs = Must(osfs.NewTempFileSystem())
+		ctx = oci.New()
+		vfsattr.Set(ctx, tempfs)
+	})
+
+	AfterEach(func() {
+		vfs.Cleanup(tempfs)
+	})
+
+	It("maps ref names", func() {
+		Expect(ocilayout.RefName("", "v1")).To(Equal("v1"))
+		Expect(ocilayout.RefName(NAMESPACE, "v1")).To(Equal(NAMESPACE + ":v1"))
+
+		ns, tag := ocilayout.SplitRefName("v1")
+		Expect([]string{ns, tag}).To(Equal([]string{"", "v1"}))
+		ns, tag = ocilayout.SplitRefName(NAMESPACE + ":v1")
+		Expect([]string{ns, tag}).To(Equal([]string{NAMESPACE, "v1"}))
+		ns, tag = ocilayout.SplitRefName("localhost:5000/repo")
+		Expect([]string{ns, tag}).To(Equal([]string{"", "localhost:5000/repo"}))
+	})
+
+	It("creates a directory layout", func() {
+		var finalize finalizer.Finalizer
+		defer Defer(finalize.Finalize)
+
+		r := Must(ocilayout.Create(ctx, accessobj.ACC_CREATE, "test", 0o700, accessio.PathFileSystem(tempfs)))
+		finalize.Close(r)
+
+		sub := finalize.Nested()
+		n := Must(r.LookupNamespace(NAMESPACE))
+		sub.Close(n)
+		DefaultManifestFill(n)
+		Expect(sub.Finalize()).To(Succeed())
+
+		Expect(r.ExistsArtifact(NAMESPACE, TAG)).To(BeTrue())
+		Expect(r.ExistsArtifact(NAMESPACE, "sha256:"+DIGEST_MANIFEST)).To(BeTrue())
+		Expect(r.ExistsArtifact("other", TAG)).To(BeFalse())
+		Expect(r.NamespaceLister().GetNamespaces("", true)).To(Equal([]string{NAMESPACE}))
+		Expect(finalize.Finalize()).To(Succeed())
+
+		Expect(vfs.ReadFile(tempfs, "test/"+ocilayout.LayoutFileName)).To(MatchJSON(`{"imageLayoutVersion":"1.0.0"}`))
+		Expect(vfs.FileExists(tempfs, "test/blobs/sha256/"+DIGEST_MANIFEST)).To(BeTrue())
+		Expect(vfs.FileExists(tempfs, "test/blobs/sha256/"+DIGEST_CONFIG)).To(BeTrue())
+		Expect(vfs.FileExists(tempfs, "test/blobs/sha256/"+DIGEST_LAYER)).To(BeTrue())
+
+		idx := readIndex(tempfs, "test/"+ocilayout.IndexFileName)
+		Expect(len(idx.Manifests)).To(Equal(1))
+		Expect(idx.Manifests[0].Digest).To(Equal(digest.Digest("sha256:" + DIGEST_MANIFEST)))
+		Expect(idx.Manifests[0].MediaType).To(Equal(artdesc.MediaTypeImageManifest))
+		Expect(idx.Manifests[0].Annotations).To(Equal(map[string]string{annotations.OCITAG_ANNOTATION: NAMESPACE + ":" + TAG}))
+	})
+
+	It("reads a tgz layout", func() {
+		var finalize finalizer.Finalizer
+		defer Defer(finalize.Finalize)
+
+		spec := Must(ocilayout.NewRepositorySpec(accessobj.ACC_CREATE, "test.tgz", accessio.PathFileSystem(tempfs)))
+		r := Must(spec.Repository(ctx, nil))
+		n := Must(r.LookupNamespace(""))
+		DefaultManifestFill(n)
+		MustBeSuccessful(n.Close())
+		MustBeSuccessful(r.Close())
+
+		file := Must(tempfs.Open("test.tgz"))
+		finalize.Close(file)
+		zip := Must(gzip.NewReader(file))
+		finalize.Close(zip)
+		tr := tar.NewReader(zip)
+
+		files := []string{}
+		for {
+			header, err := tr.Next()
+			if err == io.EOF {
+				break
+			}
+			MustBeSuccessful(err)
+			if header.Typeflag == tar.TypeReg {
+				files = append(files, header.Name)
+			}
+		}
+		Expect(files).To(ContainElements(
+			ocilayout.IndexFileName,
+			ocilayout.LayoutFileName,
+			"blobs/sha256/"+DIGEST_MANIFEST,
+			"blobs/sha256/"+DIGEST_CONFIG,
+			"blobs/sha256/"+DIGEST_LAYER))
+
+		r = Must(ocilayout.Open(ctx, accessobj.ACC_READONLY, "test.tgz", 0, accessio.PathFileSystem(tempfs)))
+		finalize.Close(r)
+		Expect(r.NamespaceLister().GetNamespaces("", true)).To(Equal([]string{""}))
+		art := Must(r.LookupArtifact("", TAG))
+		finalize.Close(art)
+		CheckArtifact(art)
+
+		// plain tags are found for any namespace
+		art = Must(r.LookupArtifact(NAMESPACE, TAG))
+		finalize.Close(art)
+		CheckArtifact(art)
+	})
+
+	It("handles tags", func() {
+		var finalize finalizer.Finalizer
+		defer Defer(finalize.Finalize)
+
+		r := Must(ocilayout.Create(ctx, accessobj.ACC_CREATE, "test", 0o700, accessio.PathFileSystem(tempfs)))
+		n := Must(r.LookupNamespace(NAMESPACE))
+
+		art := NewArtifact(n, &finalize)
+		blob := Must(n.AddArtifact(art))
+		Expect(n.ListTags()).To(BeEmpty())
+		Expect(n.HasArtifact(blob.Digest().String())).To(BeTrue())
+
+		MustBeSuccessful(n.AddTags(blob.Digest(), "v1", "v2"))
+		Expect(n.ListTags()).To(ConsistOf("v1", "v2"))
+
+		deleter := n.ArtifactDeleter()
+		Expect(deleter).NotTo(BeNil())
+		MustBeSuccessful(deleter.DeleteTag("v1"))
+		Expect(n.ListTags()).To(ConsistOf("v2"))
+		Expect(deleter.DeleteTag("v1")).To(MatchError(cpi.ErrUnknownArtifact(NAMESPACE, "v1")))
+		MustBeSuccessful(finalize.Finalize())
+		MustBeSuccessful(n.Close())
+		MustBeSuccessful(r.Close())
+
+		idx := readIndex(tempfs, "test/"+ocilayout.IndexFileName)
+		Expect(len(idx.Manifests)).To(Equal(1))
+		Expect(idx.Manifests[0].Annotations).To(Equal(map[string]string{annotations.OCITAG_ANNOTATION: NAMESPACE + ":v2"}))
+
+		r = Must(ocilayout.Open(ctx, accessobj.ACC_WRITABLE, "test", 0o700, accessio.PathFileSystem(tempfs)))
+		n = Must(r.LookupNamespace(NAMESPACE))
+		MustBeSuccessful(n.ArtifactDeleter().DeleteArtifact(blob.Digest()))
+		Expect(n.ListTags()).To(BeEmpty())
+		MustBeSuccessful(n.Close())
+		MustBeSuccessful(r.Close())
+		Expect(readIndex(tempfs, "test/"+ocilayout.IndexFileName).Manifests).To(BeEmpty())
+	})
+
+	It("cleans up orphaned blobs", func() {
+		var finalize finalizer.Finalizer
+		defer Defer(finalize.Finalize)
+
+		r := Must(ocilayout.Create(ctx, accessobj.ACC_CREATE, "test", 0o700, accessio.PathFileSystem(tempfs)))
+		n := Must(r.LookupNamespace(NAMESPACE))
+
+		art := NewArtifact(n, &finalize)
+		blob := Must(n.AddArtifact(art, "v1"))
+		MustBeSuccessful(finalize.Finalize())
+		path := "test/blobs/" + blob.Digest().Algorithm().String() + "/" + blob.Digest().Encoded()
+
+		Expect(r.CleanupBlobs(false)).To(BeEmpty())
+		MustBeSuccessful(n.ArtifactDeleter().DeleteArtifact(blob.Digest()))
+		Expect(vfs.FileExists(tempfs, path)).To(BeTrue())
+
+		list := Must(r.CleanupBlobs(true))
+		Expect(list).To(ContainElement(blob.Digest()))
+		Expect(vfs.FileExists(tempfs, path)).To(BeTrue())
+
+		Expect(r.CleanupBlobs(false)).To(ConsistOf(list))
+		Expect(vfs.FileExists(tempfs, path)).To(BeFalse())
+		Expect(r.CleanupBlobs(true)).To(BeEmpty())
+
+		MustBeSuccessful(n.Close())
+		MustBeSuccessful(r.Close())
+	})
+
+	It("maps an OCILayout reference", func() {
+		r := Must(ocilayout.Create(ctx, accessobj.ACC_CREATE, "test", 0o700, accessio.PathFileSystem(tempfs)))
+		n := Must(r.LookupNamespace(NAMESPACE))
+		DefaultManifestFill(n)
+		MustBeSuccessful(n.Close())
+		MustBeSuccessful(r.Close())
+
+		ref := Must(oci.ParseRef("OCILayout::test//" + NAMESPACE + ":" + TAG))
+		spec := Must(ctx.MapUniformRepositorySpec(&ref.UniformRepositorySpec))
+		Expect(spec.GetType()).To(Equal(ocilayout.Type))
+		data := Must(json.Marshal(spec))
+		Expect(data).To(MatchJSON(`{"type":"OCILayout","filePath":"test"}`))
+
+		repo := Must(ctx.RepositoryForSpec(spec))
+		defer Close(repo, "repo")
+		art := Must(repo.LookupArtifact(ref.Repository, ref.Version()))
+		defer Close(art, "art")
+		CheckArtifact(art)
+	})
+})
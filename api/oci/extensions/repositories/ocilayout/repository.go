@@ -0,0 +1,288 @@
+package ocilayout
+
+import (
+	"path/filepath"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/cpi"
+	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/refmgmt"
+)
+
+/*
+   An OCI image layout is a folder (or archive) with an oci-layout
+   file, an index.json and a folder blobs containing the blobs
+   in algorithm specific sub folders named by the encoded digest.
+
+   Tagged artifacts are described by the index entries using the
+   annotation org.opencontainers.image.ref.name. A reference name
+   of the form <namespace>:<tag> is mapped to the given namespace,
+   a plain tag is mapped to the anonymous namespace. For lookups
+   plain tags are used as fallback for any namespace.
+
+   Deleting artifacts or tags only updates the index, the blobs
+   are kept, because they might be shared with other artifacts.
+   Orphaned blobs can be removed with CleanupBlobs.
+*/
+
+type Repository struct {
+	cpi.Repository
+	impl *RepositoryImpl
+}
+
+func (r *Repository) Write(path string, mode vfs.FileMode, opts ...accessio.Option) error {
+	if r.IsClosed() {
+		return cpi.ErrClosed
+	}
+	return r.impl.Write(path, mode, opts...)
+}
+
+// CleanupBlobs removes all blobs not used anymore by any artifact
+// stored in the image layout.
+func (r *Repository) CleanupBlobs(dryrun bool) ([]digest.Digest, error) {
+	if r.IsClosed() {
+		return nil, cpi.ErrClosed
+	}
+	return r.impl.CleanupBlobs(dryrun)
+}
+
+// GetIndex returns the index of the image layout.
+func (r *Repository) GetIndex() *artdesc.Index {
+	return r.impl.getIndex()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// RepositoryImpl is closed, if all views are released.
+type RepositoryImpl struct {
+	cpi.RepositoryImplBase
+
+	spec *RepositorySpec
+	base *artifactset.FileSystemBlobAccess
+}
+
+var (
+	_ cpi.RepositoryImpl       = (*RepositoryImpl)(nil)
+	_ cpi.BlobGarbageCollector = (*RepositoryImpl)(nil)
+)
+
+// New returns a new representation based repository.
+func New(ctx cpi.Context, spec *RepositorySpec, setup accessobj.Setup, closer accessobj.Closer, mode vfs.FileMode) (*Repository, error) {
+	if spec.GetPathFileSystem() == nil {
+		spec.SetPathFileSystem(vfsattr.Get(ctx))
+	}
+	base, err := accessobj.NewAccessObject(accessObjectInfo, spec.AccessMode, spec.GetRepresentation(), setup, closer, mode)
+	return _Wrap(ctx, spec, base, err)
+}
+
+func _Wrap(ctx cpi.ContextProvider, spec *RepositorySpec, obj *accessobj.AccessObject, err error) (*Repository, error) {
+	if err != nil {
+		return nil, err
+	}
+	impl := &RepositoryImpl{
+		RepositoryImplBase: cpi.NewRepositoryImplBase(cpi.FromProvider(ctx)),
+		spec:               spec,
+		base:               artifactset.NewFileSystemBlobAccess(obj),
+	}
+	r := cpi.NewRepository(impl, "OCI image layout")
+	return &Repository{r, impl}, nil
+}
+
+func (r *RepositoryImpl) GetSpecification() cpi.RepositorySpec {
+	return r.spec
+}
+
+func (r *RepositoryImpl) NamespaceLister() cpi.NamespaceLister {
+	return r
+}
+
+func (r *RepositoryImpl) NumNamespaces(prefix string) (int, error) {
+	return len(cpi.FilterByNamespacePrefix(prefix, namespaces(r.getIndex()))), nil
+}
+
+func (r *RepositoryImpl) GetNamespaces(prefix string, closure bool) ([]string, error) {
+	return cpi.FilterChildren(closure, prefix, namespaces(r.getIndex())), nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// forward
+
+func (r *RepositoryImpl) IsReadOnly() bool {
+	return r.base.IsReadOnly()
+}
+
+func (r *RepositoryImpl) Write(path string, mode vfs.FileMode, opts ...accessio.Option) error {
+	return r.base.Write(path, mode, opts...)
+}
+
+func (r *RepositoryImpl) Update() (bool, error) {
+	return r.base.Update()
+}
+
+func (r *RepositoryImpl) Close() error {
+	return r.base.Close()
+}
+
+func (r *RepositoryImpl) getIndex() *artdesc.Index {
+	if r.IsReadOnly() {
+		return r.base.GetState().GetOriginalState().(*artdesc.Index)
+	}
+	return r.base.GetState().GetState().(*artdesc.Index)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// cpi.Repository methods
+
+func (r *RepositoryImpl) ExistsArtifact(name string, ref string) (bool, error) {
+	ns, err := NewNamespace(r, name)
+	if err != nil {
+		return false, err
+	}
+	defer ns.Close()
+	return ns.HasArtifact(ref)
+}
+
+func (r *RepositoryImpl) LookupArtifact(name string, ref string) (acc cpi.ArtifactAccess, err error) {
+	ns, err := NewNamespace(r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	defer refmgmt.PropagateCloseTemporary(&err, ns) // temporary namespace object not exposed.
+
+	ok, err := ns.HasArtifact(ref)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, cpi.ErrUnknownArtifact(name, ref)
+	}
+	return ns.GetArtifact(ref)
+}
+
+func (r *RepositoryImpl) LookupNamespace(name string) (cpi.NamespaceAccess, error) {
+	return NewNamespace(r, name)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// garbage collection
+
+// CleanupBlobs removes all blobs not referenced anymore by
+// an artifact found in the index. With dryrun=true, the
+// orphaned blobs are only reported.
+func (r *RepositoryImpl) CleanupBlobs(dryrun bool) ([]digest.Digest, error) {
+	if !dryrun && r.IsReadOnly() {
+		return nil, accessio.ErrReadOnly
+	}
+	r.base.Lock()
+	defer r.base.Unlock()
+
+	used := map[digest.Digest]bool{}
+	for _, d := range r.getIndex().Manifests {
+		err := r.collectBlobs(d.Digest, used)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	blobs, err := r.listBlobs()
+	if err != nil {
+		return nil, err
+	}
+	var result []digest.Digest
+	for _, d := range blobs {
+		if used[d] {
+			continue
+		}
+		if !dryrun {
+			err = r.base.RemoveBlob(d)
+			if err != nil {
+				return result, errors.Wrapf(err, "cannot remove blob %s", d)
+			}
+		}
+		result = append(result, d)
+	}
+	return result, nil
+}
+
+// listBlobs lists the blobs found in the algorithm specific
+// sub directories of the blob folder.
+func (r *RepositoryImpl) listBlobs() ([]digest.Digest, error) {
+	fs := r.base.Access().GetFileSystem()
+	if ok, err := vfs.DirExists(fs, BlobsDirectoryName); !ok || err != nil {
+		return nil, err
+	}
+	algs, err := vfs.ReadDir(fs, BlobsDirectoryName)
+	if err != nil {
+		return nil, err
+	}
+	var result []digest.Digest
+	for _, a := range algs {
+		if !a.IsDir() {
+			continue
+		}
+		entries, err := vfs.ReadDir(fs, filepath.Join(BlobsDirectoryName, a.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			d := digest.NewDigestFromEncoded(digest.Algorithm(a.Name()), e.Name())
+			if d.Validate() == nil {
+				result = append(result, d)
+			}
+		}
+	}
+	return result, nil
+}
+
+func (r *RepositoryImpl) collectBlobs(d digest.Digest, used map[digest.Digest]bool) error {
+	if used[d] {
+		return nil
+	}
+	used[d] = true
+
+	_, acc, err := r.base.GetBlobData(d)
+	if err != nil {
+		if blobaccess.IsErrBlobNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	data, err := acc.Get()
+	acc.Close()
+	if err != nil {
+		return err
+	}
+	art, err := artdesc.Decode(data)
+	if err != nil {
+		return errors.Wrapf(err, "artifact %s", d)
+	}
+	switch {
+	case art.IsManifest():
+		m, _ := art.Manifest()
+		used[m.Config.Digest] = true
+		for _, l := range m.Layers {
+			used[l.Digest] = true
+		}
+	case art.IsIndex():
+		idx, _ := art.Index()
+		for _, m := range idx.Manifests {
+			err := r.collectBlobs(m.Digest, used)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
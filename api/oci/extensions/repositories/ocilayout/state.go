@@ -0,0 +1,14 @@
+package ocilayout
+
+import (
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/oci/cpi"
+	"ocm.software/ocm/api/utils/accessobj"
+)
+
+// NewStateHandler implements the factory interface for the
+// image layout state, which is basically an OCI index.
+func NewStateHandler(fs vfs.FileSystem) accessobj.StateHandler {
+	return &cpi.IndexStateHandler{}
+}
@@ -0,0 +1,55 @@
+package ocilayout
+
+import (
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
+	"ocm.software/ocm/api/oci/cpi"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+)
+
+// The handler is only registered for the explicit types, a
+// plain path to an image layout is still handled by the
+// artifact set, which is able to read the index.json, also.
+func init() {
+	h := &repospechandler{}
+	cpi.RegisterRepositorySpecHandler(h, Type)
+	cpi.RegisterRepositorySpecHandler(h, ShortType)
+}
+
+type repospechandler struct{}
+
+func (h *repospechandler) MapReference(ctx cpi.Context, u *cpi.UniformRepositorySpec) (cpi.RepositorySpec, error) {
+	return MapReference(ctx, u)
+}
+
+func MapReference(ctx cpi.Context, u *cpi.UniformRepositorySpec) (cpi.RepositorySpec, error) {
+	path := u.Info
+	if u.Info == "" {
+		if u.Host == "" || u.Type == "" {
+			return nil, nil
+		}
+		path = u.Host
+	}
+	fs := vfsattr.Get(ctx)
+
+	hint, f := accessobj.MapType(u.TypeHint, Type, accessio.FormatDirectory, false, ShortType)
+	if hint == "" {
+		// the type hint might be preset for another type by a command,
+		// but the explicit type always describes an image layout.
+		hint, f = Type, accessio.FormatDirectory
+	}
+	if !u.CreateIfMissing {
+		hint = ""
+	}
+	create, ok, err := accessobj.CheckFile(Type, hint, true, path, fs, IndexFileName)
+	if !ok || err != nil {
+		return nil, err
+	}
+	mode := accessobj.ACC_WRITABLE
+	createHint := accessio.FormatNone
+	if create {
+		mode |= accessobj.ACC_CREATE
+		createHint = f
+	}
+	return NewRepositorySpec(mode, path, createHint, accessio.PathFileSystem(fs))
+}
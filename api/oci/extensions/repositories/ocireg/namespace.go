@@ -30,7 +30,11 @@ type NamespaceContainer struct {
 	checked  bool
 }
 
-var _ support.NamespaceContainer = (*NamespaceContainer)(nil)
+var (
+	_ support.NamespaceContainer = (*NamespaceContainer)(nil)
+	_ cpi.ArtifactDeleter        = (*NamespaceContainer)(nil)
+	_ cpi.ReferrersLister        = (*NamespaceContainer)(nil)
+)
 
 func NewNamespace(repo *RepositoryImpl, name string) (cpi.NamespaceAccess, error) {
 	ref := repo.GetRef(name, "")
@@ -222,6 +226,12 @@ func (n *NamespaceContainer) AddArtifact(artifact cpi.Artifact, tags ...string)
 		}
 	}
 
+	if subject := artifact.Artifact().GetSubject(); subject != nil {
+		err = n.updateReferrersTag(subject.Digest, artdesc.ReferrerDescriptor(artifact.Artifact(), blob))
+		if err != nil {
+			return nil, fmt.Errorf("unable to update referrers for %s: %w", subject.Digest, err)
+		}
+	}
 	return blob, err
 }
 
@@ -247,6 +257,122 @@ func (n *NamespaceContainer) AddTags(digest digest.Digest, tags ...string) error
 	return nil
 }
 
+// DeleteTag deletes a tag. The OCI distribution spec does not provide
+// a dedicated operation to remove a tag, only, therefore this requires the
+// registry to support the deletion of manifests by tag.
+func (n *NamespaceContainer) DeleteTag(tag string) error {
+	return n.delete(tag)
+}
+
+// DeleteArtifact deletes the manifest with the given digest. This deletes
+// all tags referring to this manifest, also.
+func (n *NamespaceContainer) DeleteArtifact(digest digest.Digest) error {
+	return n.delete(digest.String())
+}
+
+func (n *NamespaceContainer) delete(ref string) error {
+	if n.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	n.repo.GetContext().Logger().Debug("deleting artifact", "namespace", n.impl.GetNamespace(), "ref", ref)
+	d, err := n.resolver.Deleter(dummyContext, n.repo.GetRef(n.impl.GetNamespace(), ""))
+	if err != nil {
+		return err
+	}
+	err = d.Delete(dummyContext, ref)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return errors.ErrNotFound(cpi.KIND_OCIARTIFACT, ref, n.impl.GetNamespace())
+		}
+		if errdefs.IsNotImplemented(err) {
+			return errors.ErrNotSupported("artifact deletion", ref, n.impl.GetNamespace())
+		}
+		return err
+	}
+	return nil
+}
+
+// ListReferrers lists the referrers of an artifact. If the registry does
+// not support the referrers API, the referrers tag schema is used as
+// fallback.
+func (n *NamespaceContainer) ListReferrers(digest digest.Digest, artifactType string) ([]artdesc.Descriptor, error) {
+	list, err := n.listReferrers(digest)
+	if err != nil {
+		if !errdefs.IsNotImplemented(err) {
+			return nil, err
+		}
+		idx, err := n.getReferrersIndex(digest)
+		if err != nil {
+			return nil, err
+		}
+		list = idx.Manifests
+	}
+	return artdesc.FilterReferrers(list, artifactType), nil
+}
+
+func (n *NamespaceContainer) listReferrers(digest digest.Digest) ([]artdesc.Descriptor, error) {
+	n.repo.GetContext().Logger().Debug("listing referrers", "namespace", n.impl.GetNamespace(), "digest", digest)
+	r, err := n.resolver.Referrers(dummyContext, n.repo.GetRef(n.impl.GetNamespace(), ""))
+	if err != nil {
+		return nil, err
+	}
+	return r.ListReferrers(dummyContext, digest)
+}
+
+// getReferrersIndex provides the index used by the referrers tag schema.
+// If there is no such index, an empty one is returned.
+func (n *NamespaceContainer) getReferrersIndex(digest digest.Digest) (*artdesc.Index, error) {
+	ref := n.repo.GetRef(n.impl.GetNamespace(), artdesc.ReferrersTag(digest))
+	_, desc, err := n.resolver.Resolve(dummyContext, ref)
+	if err != nil {
+		if errdefs.IsNotFound(err) {
+			return artdesc.NewReferrersIndex(), nil
+		}
+		return nil, err
+	}
+	blobData, err := n.blobs.Get(desc.MediaType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve blob data: %w", err)
+	}
+	_, acc, err := blobData.GetBlobData(desc.Digest)
+	if err != nil {
+		return nil, err
+	}
+	defer acc.Close()
+	data, err := acc.Get()
+	if err != nil {
+		return nil, err
+	}
+	art, err := artdesc.Decode(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid referrers index %s", ref)
+	}
+	return art.Index()
+}
+
+// updateReferrersTag adds a referrer to the index maintained
+// for the referrers tag schema, if the registry does not support
+// the referrers API.
+func (n *NamespaceContainer) updateReferrersTag(subject digest.Digest, referrer *artdesc.Descriptor) error {
+	_, err := n.listReferrers(subject)
+	if err == nil || !errdefs.IsNotImplemented(err) {
+		// the registry maintains the referrers by itself.
+		return nil
+	}
+	idx, err := n.getReferrersIndex(subject)
+	if err != nil {
+		return err
+	}
+	for _, d := range idx.Manifests {
+		if d.Digest == referrer.Digest {
+			return nil
+		}
+	}
+	idx.Manifests = append(idx.Manifests, *referrer)
+	_, err = n.AddArtifact(idx, artdesc.ReferrersTag(subject))
+	return err
+}
+
 func (n *NamespaceContainer) NewArtifact(i support.NamespaceAccessImpl, art ...cpi.Artifact) (cpi.ArtifactAccess, error) {
 	if n.IsReadOnly() {
 		return nil, accessio.ErrReadOnly
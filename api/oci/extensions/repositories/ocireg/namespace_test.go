@@ -0,0 +1,174 @@
+package ocireg_test
+
+import (
+	"net/http/httptest"
+
+	"github.com/mandelsoft/goutils/finalizer"
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+	. "ocm.software/ocm/api/oci/testhelper"
+
+	"ocm.software/ocm/api/oci"
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/extensions/repositories/ctf"
+	"ocm.software/ocm/api/oci/extensions/repositories/ocireg"
+	"ocm.software/ocm/api/oci/tools/transfer"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	OCIPATH   = "/tmp/oci"
+	SIGNATURE = "application/vnd.test.signature"
+)
+
+var _ = Describe("referrers", func() {
+	var env *Builder
+	var mdesc *artdesc.Descriptor
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		env.OCICommonTransport(OCIPATH, accessio.FormatDirectory, func() {
+			mdesc, _ = OCIManifest1For(env, OCINAMESPACE, OCIVERSION)
+			env.Namespace(OCINAMESPACE, func() {
+				env.Manifest("signature", func() {
+					env.Subject(mdesc)
+					env.ArtifactType(SIGNATURE)
+					env.Config(func() {
+						env.BlobStringData(artdesc.MediaTypeEmptyJSON, "{}")
+					})
+					env.Layer(func() {
+						env.BlobStringData(mime.MIME_TEXT, "signature")
+					})
+				})
+				env.Manifest("other", func() {
+					env.Subject(mdesc)
+					env.Config(func() {
+						env.BlobStringData(mime.MIME_JSON, "{}")
+					})
+					env.Layer(func() {
+						env.BlobStringData(mime.MIME_TEXT, "other")
+					})
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	// upload transfers the test artifact and the requested referrers
+	// to the registry and provides the target namespace.
+	upload := func(finalize *finalizer.Finalizer, server *httptest.Server, artifactTypes ...string) oci.NamespaceAccess {
+		src := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OCIPATH, 0, env))
+		finalize.Close(src, "source")
+		sns := Must(src.LookupNamespace(OCINAMESPACE))
+		finalize.Close(sns, "source namespace")
+		art := Must(sns.GetArtifact(OCIVERSION))
+		finalize.Close(art, "source artifact")
+
+		repo := Must(env.OCIContext().RepositoryForSpec(ocireg.NewRepositorySpec(server.URL)))
+		finalize.Close(repo, "target")
+		ns := Must(repo.LookupNamespace(OCINAMESPACE))
+		finalize.Close(ns, "target namespace")
+
+		MustBeSuccessful(transfer.TransferArtifact(art, ns, OCIVERSION))
+		MustBeSuccessful(transfer.TransferReferrers(sns, mdesc.Digest, ns, artifactTypes...))
+		return ns
+	}
+
+	Context("with referrers API", func() {
+		var registry *registry
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			registry = newRegistry(OCINAMESPACE, true)
+			server = httptest.NewServer(registry)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("lists referrers", func() {
+			var finalize finalizer.Finalizer
+			defer Defer(finalize.Finalize)
+
+			ns := upload(&finalize, server)
+
+			lister := ns.ReferrersLister()
+			Expect(lister).NotTo(BeNil())
+			list := Must(lister.ListReferrers(mdesc.Digest, ""))
+			Expect(len(list)).To(Equal(2))
+			list = Must(lister.ListReferrers(mdesc.Digest, SIGNATURE))
+			Expect(len(list)).To(Equal(1))
+			Expect(list[0].ArtifactType).To(Equal(SIGNATURE))
+
+			Expect(registry.HasTag(artdesc.ReferrersTag(mdesc.Digest))).To(BeFalse())
+		})
+	})
+
+	Context("without referrers API", func() {
+		var registry *registry
+		var server *httptest.Server
+
+		BeforeEach(func() {
+			registry = newRegistry(OCINAMESPACE, false)
+			server = httptest.NewServer(registry)
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("maintains the referrers tag", func() {
+			var finalize finalizer.Finalizer
+			defer Defer(finalize.Finalize)
+
+			ns := upload(&finalize, server, SIGNATURE)
+			Expect(registry.HasTag(artdesc.ReferrersTag(mdesc.Digest))).To(BeTrue())
+
+			list := Must(ns.ReferrersLister().ListReferrers(mdesc.Digest, ""))
+			Expect(len(list)).To(Equal(1))
+			Expect(list[0].ArtifactType).To(Equal(SIGNATURE))
+			Expect(registry.Requests("referrers")).To(BeNumerically(">", 0))
+
+			ref := Must(ns.GetArtifact(list[0].Digest.String()))
+			finalize.Close(ref, "referrer")
+			Expect(ref.GetDescriptor().GetSubject().Digest).To(Equal(mdesc.Digest))
+		})
+
+		It("updates the referrers tag", func() {
+			var finalize finalizer.Finalizer
+			defer Defer(finalize.Finalize)
+
+			ns := upload(&finalize, server, SIGNATURE)
+			Expect(len(Must(ns.ReferrersLister().ListReferrers(mdesc.Digest, "")))).To(Equal(1))
+
+			src := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OCIPATH, 0, env))
+			finalize.Close(src, "source")
+			sns := Must(src.LookupNamespace(OCINAMESPACE))
+			finalize.Close(sns, "source namespace")
+
+			// already known referrers are not added twice.
+			MustBeSuccessful(transfer.TransferReferrers(sns, mdesc.Digest, ns))
+			list := Must(ns.ReferrersLister().ListReferrers(mdesc.Digest, ""))
+			Expect(len(list)).To(Equal(2))
+			list = Must(ns.ReferrersLister().ListReferrers(mdesc.Digest, SIGNATURE))
+			Expect(len(list)).To(Equal(1))
+		})
+
+		It("lists no referrers for artifacts without referrers tag", func() {
+			var finalize finalizer.Finalizer
+			defer Defer(finalize.Finalize)
+
+			ns := upload(&finalize, server, "none")
+			Expect(registry.HasTag(artdesc.ReferrersTag(mdesc.Digest))).To(BeFalse())
+			Expect(Must(ns.ReferrersLister().ListReferrers(mdesc.Digest, ""))).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,179 @@
+package ocireg_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/oci/artdesc"
+)
+
+type manifest struct {
+	mediaType string
+	data      []byte
+}
+
+// registry is a minimal in-memory OCI registry serving a single
+// namespace. The referrers API is only supported if enabled.
+type registry struct {
+	lock      sync.Mutex
+	namespace string
+	referrers bool
+	uploads   int
+	blobs     map[digest.Digest][]byte
+	manifests map[string]*manifest
+	requests  map[string]int
+}
+
+func newRegistry(namespace string, referrers bool) *registry {
+	return &registry{
+		namespace: namespace,
+		referrers: referrers,
+		blobs:     map[digest.Digest][]byte{},
+		manifests: map[string]*manifest{},
+		requests:  map[string]int{},
+	}
+}
+
+func (r *registry) HasTag(tag string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.manifests[tag] != nil
+}
+
+func (r *registry) Requests(kind string) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.requests[kind]
+}
+
+func (r *registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if req.URL.Path == "/v2/" || req.URL.Path == "/v2" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	rest, ok := strings.CutPrefix(req.URL.Path, "/v2/"+r.namespace+"/")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	kind, ref, _ := strings.Cut(rest, "/")
+	r.requests[kind]++
+	switch kind {
+	case "blobs":
+		r.serveBlob(w, req, ref)
+	case "manifests":
+		r.serveManifest(w, req, ref)
+	case "referrers":
+		r.serveReferrers(w, req, digest.Digest(ref))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *registry) serveBlob(w http.ResponseWriter, req *http.Request, ref string) {
+	switch {
+	case req.Method == http.MethodPost && ref == "uploads/":
+		r.uploads++
+		w.Header().Set("Location", fmt.Sprintf("/v2/%s/blobs/uploads/%d", r.namespace, r.uploads))
+		w.WriteHeader(http.StatusAccepted)
+	case req.Method == http.MethodPut && strings.HasPrefix(ref, "uploads/"):
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		d := digest.FromBytes(data)
+		if d.String() != req.URL.Query().Get("digest") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.blobs[d] = data
+		w.Header().Set("Docker-Content-Digest", d.String())
+		w.WriteHeader(http.StatusCreated)
+	case req.Method == http.MethodGet || req.Method == http.MethodHead:
+		data, ok := r.blobs[digest.Digest(ref)]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		r.write(w, req, "application/octet-stream", data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *registry) serveManifest(w http.ResponseWriter, req *http.Request, ref string) {
+	switch req.Method {
+	case http.MethodPut:
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		m := &manifest{mediaType: req.Header.Get("Content-Type"), data: data}
+		d := digest.FromBytes(data)
+		r.manifests[d.String()] = m
+		r.manifests[ref] = m
+		w.Header().Set("Docker-Content-Digest", d.String())
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodGet, http.MethodHead:
+		m := r.manifests[ref]
+		if m == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		r.write(w, req, m.mediaType, m.data)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *registry) serveReferrers(w http.ResponseWriter, req *http.Request, subject digest.Digest) {
+	if !r.referrers || req.Method != http.MethodGet {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	list := []artdesc.Descriptor{}
+	for ref, m := range r.manifests {
+		d := digest.FromBytes(m.data)
+		if ref != d.String() {
+			continue
+		}
+		art, err := artdesc.Decode(m.data)
+		if err != nil || art.GetSubject() == nil || art.GetSubject().Digest != subject {
+			continue
+		}
+		desc, err := artdesc.ReferrerDescriptorForData(m.data)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		list = append(list, *desc)
+	}
+	data, err := json.Marshal(artdesc.NewReferrersIndex(list...))
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	r.write(w, req, artdesc.MediaTypeImageIndex, data)
+}
+
+func (r *registry) write(w http.ResponseWriter, req *http.Request, mime string, data []byte) {
+	w.Header().Set("Content-Type", mime)
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	w.Header().Set("Docker-Content-Digest", digest.FromBytes(data).String())
+	w.WriteHeader(http.StatusOK)
+	if req.Method == http.MethodGet {
+		w.Write(data)
+	}
+}
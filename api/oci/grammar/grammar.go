@@ -24,7 +24,10 @@ const (
 
 var (
 	// TypeRegexp describes a type name for a repository.
-	TypeRegexp = Optional(Identifier)
+	// Dash-separated names are only accepted for the well-known
+	// type oci-layout, to keep references starting with dashed
+	// host or file names (like my-host::...) unchanged.
+	TypeRegexp = Optional(Or(Sequence(Literal("oci-layout"), Optional(Literal("+"), Alpha)), Identifier))
 
 	// CapturedSchemeRegexp matches an optional scheme.
 	CapturedSchemeRegexp = Sequence(Capture(Match(`[a-z]+`)), Match("://"))
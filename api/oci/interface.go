@@ -32,6 +32,8 @@ type (
 	ArtifactAccess                   = internal.ArtifactAccess
 	Artifact                         = internal.Artifact
 	NamespaceLister                  = internal.NamespaceLister
+	ArtifactDeleter                  = internal.ArtifactDeleter
+	ReferrersLister                  = internal.ReferrersLister
 	NamespaceAccess                  = internal.NamespaceAccess
 	ManifestAccess                   = internal.ManifestAccess
 	IndexAccess                      = internal.IndexAccess
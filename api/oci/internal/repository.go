@@ -70,9 +70,40 @@ type NamespaceAccessImpl interface {
 	HasArtifact(vers string) (bool, error)
 
 	NewArtifact(...Artifact) (ArtifactAccess, error)
+
+	// ArtifactDeleter provides the optional deletion functionality
+	// of a namespace. It returns nil, if deletion is not supported.
+	ArtifactDeleter() ArtifactDeleter
+
+	// ReferrersLister provides the optional referrers functionality
+	// of a namespace. It returns nil, if listing referrers is not supported.
+	ReferrersLister() ReferrersLister
+
 	io.Closer
 }
 
+// ArtifactDeleter provides the optional deletion functionality
+// of a namespace.
+type ArtifactDeleter interface {
+	// DeleteTag removes a tag. The tagged artifact is still
+	// accessible by its digest.
+	DeleteTag(tag string) error
+	// DeleteArtifact removes the artifact with the given digest
+	// together with all tags referring to it.
+	// Blobs used by the artifact are not deleted.
+	DeleteArtifact(digest digest.Digest) error
+}
+
+// ReferrersLister provides the optional functionality of a namespace
+// to list the artifacts referring to another artifact by their
+// subject field (see https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers).
+type ReferrersLister interface {
+	// ListReferrers lists the descriptors of all artifacts referring to the
+	// artifact with the given digest. If an artifact type is given, only
+	// referrers with this artifact type are returned.
+	ListReferrers(digest digest.Digest, artifactType string) ([]artdesc.Descriptor, error)
+}
+
 type NamespaceAccess interface {
 	resource.ResourceView[NamespaceAccess]
 
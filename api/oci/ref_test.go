@@ -651,6 +651,49 @@ var _ = Describe("ref parsing", func() {
 				Repository: "",
 			},
 		})
+		CheckRef("OCILayout+tgz::a/b//repo:v1", &oci.RefSpec{
+			UniformRepositorySpec: oci.UniformRepositorySpec{
+				Type:     "OCILayout",
+				Scheme:   "",
+				Host:     "",
+				Info:     "a/b",
+				TypeHint: "OCILayout+tgz",
+			},
+			ArtSpec: oci.ArtSpec{
+				Repository: "repo",
+				ArtVersion: oci.ArtVersion{
+					Tag: &tag,
+				},
+			},
+		})
+		CheckRef("oci-layout+tgz::a/b//repo:v1", &oci.RefSpec{
+			UniformRepositorySpec: oci.UniformRepositorySpec{
+				Type:     "oci-layout",
+				Scheme:   "",
+				Host:     "",
+				Info:     "a/b",
+				TypeHint: "oci-layout+tgz",
+			},
+			ArtSpec: oci.ArtSpec{
+				Repository: "repo",
+				ArtVersion: oci.ArtVersion{
+					Tag: &tag,
+				},
+			},
+		})
+		CheckRef("+oci-layout::./out", &oci.RefSpec{
+			UniformRepositorySpec: oci.UniformRepositorySpec{
+				Type:            "oci-layout",
+				Scheme:          "",
+				Host:            "",
+				Info:            "./out",
+				CreateIfMissing: true,
+				TypeHint:        "oci-layout",
+			},
+			ArtSpec: oci.ArtSpec{
+				Repository: "",
+			},
+		})
 		CheckRef("+ctf+directory::a/b", &oci.RefSpec{
 			UniformRepositorySpec: oci.UniformRepositorySpec{
 				Type:            "ctf",
@@ -777,6 +820,58 @@ var _ = Describe("ref parsing", func() {
 		CheckRef("test/ubuntu:v1@4711", nil)
 		CheckRef("ghcr.io/test/ubuntu:v1@4711", nil)
 	})
+	It("dashed names", func() {
+		CheckRef("my-host::x//repo:v1", &oci.RefSpec{
+			UniformRepositorySpec: oci.UniformRepositorySpec{
+				Info: "my-host::x",
+			},
+			ArtSpec: oci.ArtSpec{
+				Repository: "repo",
+				ArtVersion: oci.ArtVersion{
+					Tag: &tag,
+				},
+			},
+		})
+		CheckRef("oci-layoutx::x//repo:v1", &oci.RefSpec{
+			UniformRepositorySpec: oci.UniformRepositorySpec{
+				Info: "oci-layoutx::x",
+			},
+			ArtSpec: oci.ArtSpec{
+				Repository: "repo",
+				ArtVersion: oci.ArtVersion{
+					Tag: &tag,
+				},
+			},
+		})
+		CheckRef("a-b//c", &oci.RefSpec{
+			UniformRepositorySpec: oci.UniformRepositorySpec{
+				Host: "a-b",
+			},
+			ArtSpec: oci.ArtSpec{
+				Repository: "c",
+			},
+		})
+		CheckRef("my-host:5000/repo:v1", &oci.RefSpec{
+			UniformRepositorySpec: oci.UniformRepositorySpec{
+				Host: "my-host:5000",
+			},
+			ArtSpec: oci.ArtSpec{
+				Repository: "repo",
+				ArtVersion: oci.ArtVersion{
+					Tag: &tag,
+				},
+			},
+		})
+		CheckRepo("my-host::x", &oci.UniformRepositorySpec{
+			Info: "my-host::x",
+		})
+		CheckRepo("oci-layout::./out", &oci.UniformRepositorySpec{
+			Type:     "oci-layout",
+			Info:     "./out",
+			TypeHint: "oci-layout",
+		})
+	})
+
 	It("repo", func() {
 		CheckRepo("ghcr.io", &oci.UniformRepositorySpec{
 			Host: "ghcr.io",
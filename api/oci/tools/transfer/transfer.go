@@ -1,6 +1,8 @@
 package transfer
 
 import (
+	"slices"
+
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/mandelsoft/goutils/finalizer"
 	"github.com/mandelsoft/goutils/generics"
@@ -140,3 +142,55 @@ func TransferManifest(art cpi.ManifestAccess, set cpi.ArtifactSink, tags ...stri
 	}
 	return blob.Close()
 }
+
+// TransferReferrers transfers the artifacts referring to the artifact with
+// the given digest (for example signatures, SBOMs or attestations) from the
+// source namespace to the target. The referrers of the transferred referrers
+// are transferred, also. If artifact types are given, only referrers with
+// these types are transferred.
+// If the source namespace does not support referrers, nothing is transferred.
+func TransferReferrers(src cpi.NamespaceAccess, dig digest.Digest, set cpi.ArtifactSink, artifactTypes ...string) error {
+	return transferReferrers(src, dig, set, map[digest.Digest]bool{}, artifactTypes)
+}
+
+func transferReferrers(src cpi.NamespaceAccess, dig digest.Digest, set cpi.ArtifactSink, done map[digest.Digest]bool, artifactTypes []string) (err error) {
+	lister := src.ReferrersLister()
+	if lister == nil {
+		return nil
+	}
+	list, err := lister.ListReferrers(dig, "")
+	if err != nil {
+		return errors.Wrapf(err, "listing referrers for %s", dig)
+	}
+
+	var finalize finalizer.Finalizer
+	defer finalize.FinalizeWithErrorPropagation(&err)
+
+	for _, d := range list {
+		if done[d.Digest] || (len(artifactTypes) > 0 && !slices.Contains(artifactTypes, d.ArtifactType)) {
+			continue
+		}
+		done[d.Digest] = true
+
+		logging.Logger().Debug("transfer OCI referrer", "subject", dig, "digest", d.Digest, "artifactType", d.ArtifactType)
+		loop := finalize.Nested()
+		art, err := src.GetArtifact("@" + d.Digest.String())
+		if err != nil {
+			return errors.Wrapf(err, "getting referrer %s", d.Digest)
+		}
+		loop.Close(art)
+		err = TransferArtifact(art, set)
+		if err != nil {
+			return errors.Wrapf(err, "transferring referrer %s", d.Digest)
+		}
+		err = transferReferrers(src, d.Digest, set, done, artifactTypes)
+		if err != nil {
+			return err
+		}
+		err = loop.Finalize()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 	"ocm.software/ocm/api/oci/tools/transfer/filters"
 	"ocm.software/ocm/api/utils/accessio"
 	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
 )
 
 const (
@@ -182,4 +183,85 @@ var _ = Describe("transfer OCI artifacts", func() {
 			Expect(string(data)).To(Equal(OCILAYER))
 		})
 	})
+
+	Context("with referrers", func() {
+		var mdesc *artdesc.Descriptor
+
+		BeforeEach(func() {
+			env.OCICommonTransport(OCIPATH, accessio.FormatDirectory, func() {
+				mdesc, _ = OCIManifest1For(env, OCINAMESPACE, OCIVERSION)
+				env.Namespace(OCINAMESPACE, func() {
+					env.Manifest("signature", func() {
+						env.Subject(mdesc)
+						env.ArtifactType("application/vnd.test.signature")
+						env.Config(func() {
+							env.BlobStringData(artdesc.MediaTypeEmptyJSON, "{}")
+						})
+						env.Layer(func() {
+							env.BlobStringData(mime.MIME_TEXT, "signature")
+						})
+					})
+					env.Manifest("other", func() {
+						env.Subject(mdesc)
+						env.Config(func() {
+							env.BlobStringData(mime.MIME_JSON, "{}")
+						})
+						env.Layer(func() {
+							env.BlobStringData(mime.MIME_TEXT, "other")
+						})
+					})
+				})
+			})
+		})
+
+		It("lists referrers", func() {
+			src := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OCIPATH, 0, env))
+			defer Close(src, "source")
+			ns := Must(src.LookupNamespace(OCINAMESPACE))
+			defer Close(ns, "source namespace")
+
+			lister := ns.ReferrersLister()
+			Expect(lister).NotTo(BeNil())
+			list := Must(lister.ListReferrers(mdesc.Digest, ""))
+			Expect(len(list)).To(Equal(2))
+			list = Must(lister.ListReferrers(mdesc.Digest, "application/vnd.test.signature"))
+			Expect(len(list)).To(Equal(1))
+			Expect(list[0].ArtifactType).To(Equal("application/vnd.test.signature"))
+		})
+
+		It("transfers referrers", func() {
+			var finalize finalizer.Finalizer
+			defer Defer(finalize.Finalize)
+
+			src := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OCIPATH, 0, env))
+			finalize.Close(src, "source")
+			sns := Must(src.LookupNamespace(OCINAMESPACE))
+			finalize.Close(sns, "source namespace")
+			art := Must(sns.GetArtifact(OCIVERSION))
+			finalize.Close(art, "source artifact")
+
+			tgt := Must(ctf.Create(env.OCIContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+			defer Close(tgt, "target")
+			ns := Must(tgt.LookupNamespace(OCINAMESPACE))
+			defer Close(ns, "target namespace")
+
+			MustBeSuccessful(transfer.TransferArtifact(art, ns, OCIVERSION))
+			Expect(Must(ns.ReferrersLister().ListReferrers(mdesc.Digest, ""))).To(BeEmpty())
+
+			MustBeSuccessful(transfer.TransferReferrers(sns, mdesc.Digest, ns, "application/vnd.test.signature"))
+			list := Must(ns.ReferrersLister().ListReferrers(mdesc.Digest, ""))
+			Expect(len(list)).To(Equal(1))
+			Expect(list[0].ArtifactType).To(Equal("application/vnd.test.signature"))
+
+			MustBeSuccessful(transfer.TransferReferrers(sns, mdesc.Digest, ns))
+			list = Must(ns.ReferrersLister().ListReferrers(mdesc.Digest, ""))
+			Expect(len(list)).To(Equal(2))
+
+			MustBeSuccessful(finalize.Finalize())
+
+			ref := Must(ns.GetArtifact(list[0].Digest.String()))
+			defer Close(ref, "referrer")
+			Expect(ref.GetDescriptor().GetSubject().Digest).To(Equal(mdesc.Digest))
+		})
+	})
 })
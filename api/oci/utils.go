@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/opencontainers/go-digest"
+
 	"ocm.software/ocm/api/credentials/cpi"
 	"ocm.software/ocm/api/oci/artdesc"
+	ocicpi "ocm.software/ocm/api/oci/cpi"
 	"ocm.software/ocm/api/oci/grammar"
 	ociidentity "ocm.software/ocm/api/tech/oci/identity"
 	"ocm.software/ocm/api/utils/runtime"
@@ -51,3 +54,12 @@ func GetConsumerIdForRef(ref string) (cpi.ConsumerIdentity, error) {
 	}
 	return ociidentity.GetConsumerId(r.Host, r.Repository), nil
 }
+
+// CleanupBlobs removes the blobs of a repository not used anymore
+// by any artifact, for example after artifacts have been deleted.
+// With dryrun=true, the orphaned blobs are only reported.
+// If the repository does not support a garbage collection,
+// an errors.ErrNotSupported error is returned.
+func CleanupBlobs(repo Repository, dryrun bool) ([]digest.Digest, error) {
+	return ocicpi.CleanupBlobs(repo, dryrun)
+}
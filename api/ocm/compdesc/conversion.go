@@ -0,0 +1,223 @@
+package compdesc
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/utils/errkind"
+)
+
+// ConversionLoss describes an element of a serialized component
+// descriptor, which cannot be kept by a scheme conversion.
+type ConversionLoss struct {
+	// Field is the path of the affected field in the
+	// original serialization.
+	Field string
+	// Reason describes the kind of loss.
+	Reason string
+}
+
+func (l ConversionLoss) String() string {
+	return fmt.Sprintf("%s: %s", l.Field, l.Reason)
+}
+
+// SchemeConversion is the result of the conversion of a component
+// descriptor into another scheme version.
+type SchemeConversion struct {
+	// Source is the scheme version of the original descriptor.
+	Source string
+	// Target is the requested scheme version.
+	Target string
+	// Descriptor is the converted descriptor configured for
+	// the target scheme.
+	Descriptor *ComponentDescriptor
+	// Data is the validated serialization in the target scheme.
+	Data []byte
+	// Losses describes the information lost by the conversion.
+	Losses []ConversionLoss
+}
+
+// IsLossy reports whether information is lost by the conversion.
+func (c *SchemeConversion) IsLossy() bool {
+	return len(c.Losses) > 0
+}
+
+// ConvertScheme converts a component descriptor into the given
+// scheme version. It is serialized with its configured scheme version
+// and converted with ConvertSchemeData. The given descriptor is not modified.
+func ConvertScheme(cd *ComponentDescriptor, scheme string) (*SchemeConversion, error) {
+	data, err := Encode(cd.Copy(), DefaultJSONCodec)
+	if err != nil {
+		return nil, err
+	}
+	return ConvertSchemeData(data, scheme)
+}
+
+// ConvertSchemeData converts a serialized component descriptor into the
+// given scheme version. Possible targets are the scheme versions
+// registered in DefaultSchemes.
+// The serialization in the target scheme is validated against the
+// JSON scheme of the target version. Information of the original
+// serialization, which does not survive a round trip through the target
+// scheme, is reported as loss. This covers fields not representable in
+// the target scheme as well as signatures and reference digests,
+// which depend on the scheme specific normalization.
+func ConvertSchemeData(data []byte, scheme string, opts ...DecodeOption) (*SchemeConversion, error) {
+	target := DefaultSchemes[scheme]
+	if target == nil {
+		return nil, errors.ErrNotSupported(errkind.KIND_SCHEMAVERSION, scheme)
+	}
+
+	cd, err := Decode(data, opts...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot decode component descriptor")
+	}
+	result := &SchemeConversion{
+		Source: cd.SchemaVersion(),
+		Target: scheme,
+	}
+	if result.Source == "" {
+		result.Source = DefaultSchemeVersion
+	}
+
+	cd.Metadata.ConfiguredVersion = scheme
+	result.Data, err = Encode(cd, DefaultJSONCodec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot convert component descriptor to scheme %s", scheme)
+	}
+
+	// validate the converted serialization (JSON scheme and semantic checks).
+	_, err = target.Decode(result.Data, &DecodeOptions{Codec: DefaultJSONCodec})
+	if err != nil {
+		return nil, errors.Wrapf(err, "converted component descriptor is invalid for scheme %s", scheme)
+	}
+	result.Descriptor, err = Decode(result.Data, DefaultJSONCodec)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Source != scheme {
+		result.Losses, err = determineLosses(data, result.Descriptor, result.Source, opts...)
+		if err != nil {
+			return nil, err
+		}
+		result.Losses = append(result.Losses, normalizationLosses(result.Descriptor)...)
+	}
+	return result, nil
+}
+
+// determineLosses compares the original serialization with the
+// serialization after a round trip through the target scheme.
+func determineLosses(data []byte, converted *ComponentDescriptor, scheme string, opts ...DecodeOption) ([]ConversionLoss, error) {
+	o := (&DecodeOptions{Codec: DefaultYAMLCodec}).ApplyOptions(opts)
+
+	var orig interface{}
+	if err := o.Codec.Decode(data, &orig); err != nil {
+		return nil, err
+	}
+
+	cd := converted.Copy()
+	cd.Metadata.ConfiguredVersion = scheme
+	back, err := Encode(cd, DefaultJSONCodec)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot convert component descriptor back to scheme %s", scheme)
+	}
+	var cur interface{}
+	if err := DefaultJSONCodec.Decode(back, &cur); err != nil {
+		return nil, err
+	}
+
+	var losses []ConversionLoss
+	compareFields("", orig, cur, &losses)
+	return losses, nil
+}
+
+func compareFields(path string, orig, cur interface{}, losses *[]ConversionLoss) {
+	switch o := orig.(type) {
+	case map[string]interface{}:
+		c, ok := cur.(map[string]interface{})
+		if !ok {
+			*losses = append(*losses, ConversionLoss{fieldPath(path), "structure not representable"})
+			return
+		}
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			sub := k
+			if path != "" {
+				sub = path + "." + k
+			}
+			v, ok := c[k]
+			if !ok {
+				if !isEmpty(o[k]) {
+					*losses = append(*losses, ConversionLoss{sub, "field not representable"})
+				}
+				continue
+			}
+			compareFields(sub, o[k], v, losses)
+		}
+	case []interface{}:
+		c, ok := cur.([]interface{})
+		if !ok || len(c) != len(o) {
+			*losses = append(*losses, ConversionLoss{fieldPath(path), "list not representable"})
+			return
+		}
+		for i := range o {
+			compareFields(fmt.Sprintf("%s[%d]", path, i), o[i], c[i], losses)
+		}
+	default:
+		if !reflect.DeepEqual(orig, cur) {
+			*losses = append(*losses, ConversionLoss{fieldPath(path), "value not representable"})
+		}
+	}
+}
+
+func fieldPath(path string) string {
+	if path == "" {
+		return "<root>"
+	}
+	return path
+}
+
+func isEmpty(v interface{}) bool {
+	switch e := v.(type) {
+	case nil:
+		return true
+	case string:
+		return e == ""
+	case []interface{}:
+		return len(e) == 0
+	case map[string]interface{}:
+		return len(e) == 0
+	}
+	return false
+}
+
+// normalizationLosses reports digests based on the scheme specific
+// normalization, which are invalidated by a scheme conversion.
+func normalizationLosses(cd *ComponentDescriptor) []ConversionLoss {
+	var losses []ConversionLoss
+	for _, s := range cd.Signatures {
+		if s.Digest.NormalisationAlgorithm == JsonNormalisationV1 {
+			losses = append(losses, ConversionLoss{
+				fmt.Sprintf("signature %q", s.Name),
+				"digest uses scheme specific normalization " + JsonNormalisationV1,
+			})
+		}
+	}
+	for _, r := range cd.References {
+		if r.Digest != nil && r.Digest.NormalisationAlgorithm == JsonNormalisationV1 {
+			losses = append(losses, ConversionLoss{
+				fmt.Sprintf("reference %q", r.Name),
+				"digest uses scheme specific normalization " + JsonNormalisationV1,
+			})
+		}
+	}
+	return losses
+}
@@ -0,0 +1,185 @@
+package compdesc_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	compdescv3final "ocm.software/ocm/api/ocm/compdesc/versions/ocm.software/v3"
+	compdescv3 "ocm.software/ocm/api/ocm/compdesc/versions/ocm.software/v3alpha1"
+	compdescv2 "ocm.software/ocm/api/ocm/compdesc/versions/v2"
+)
+
+var _ = Describe("scheme conversion", func() {
+	CDv2 := `
+component:
+  componentReferences: []
+  name: acme.org/test
+  provider: acme.org
+  repositoryContexts: []
+  labels:
+  - name: purpose
+    value: test
+  resources:
+  - access:
+      imageReference: ghcr.io/acme/image:1.0.0
+      type: ociArtifact
+    name: image
+    relation: external
+    type: ociImage
+    version: 1.0.0
+    srcRefs:
+    - identitySelector:
+        name: source
+  sources:
+  - access:
+      repository: github.com/acme/test
+      type: git
+    name: source
+    type: git
+    version: 1.0.0
+  version: 1.0.0
+meta:
+  schemaVersion: v2
+`
+
+	CDv3 := `
+apiVersion: ` + compdescv3.SchemaVersion + `
+kind: ComponentVersion
+metadata:
+  name: acme.org/test
+  version: 1.0.0
+  provider:
+    name: acme.org
+    labels:
+    - name: city
+      value: Karlsruhe
+repositoryContexts: []
+spec:
+  resources:
+  - access:
+      imageReference: ghcr.io/acme/image:1.0.0
+      type: ociArtifact
+    name: image
+    relation: external
+    type: ociImage
+    version: 1.0.0
+`
+
+	It("converts a descriptor without loss", func() {
+		r := Must(compdesc.ConvertSchemeData([]byte(CDv2), compdescv3.SchemaVersion))
+		Expect(r.Source).To(Equal(compdescv2.SchemaVersion))
+		Expect(r.Target).To(Equal(compdescv3.SchemaVersion))
+		Expect(r.Losses).To(BeEmpty())
+		Expect(r.IsLossy()).To(BeFalse())
+		Expect(r.Descriptor.SchemaVersion()).To(Equal(compdescv3.SchemaVersion))
+
+		cd := Must(compdesc.Decode(r.Data))
+		Expect(cd).To(Equal(r.Descriptor))
+
+		back := Must(compdesc.ConvertScheme(cd, compdescv2.SchemaVersion))
+		Expect(back.Losses).To(BeEmpty())
+		Expect(back.Descriptor).To(Equal(Must(compdesc.Decode([]byte(CDv2)))))
+	})
+
+	It("converts provider labels without loss", func() {
+		r := Must(compdesc.ConvertSchemeData([]byte(CDv3), compdescv2.SchemaVersion))
+		Expect(r.Source).To(Equal(compdescv3.SchemaVersion))
+		Expect(r.Losses).To(BeEmpty())
+		Expect(r.Descriptor.Provider.Labels.GetIndex("city")).To(Equal(0))
+
+		back := Must(compdesc.ConvertSchemeData(r.Data, compdescv3.SchemaVersion))
+		Expect(back.Losses).To(BeEmpty())
+		Expect(back.Descriptor).To(Equal(Must(compdesc.Decode([]byte(CDv3)))))
+	})
+
+	It("converts to the stable v3 scheme without loss", func() {
+		r := Must(compdesc.ConvertSchemeData([]byte(CDv3), compdescv3final.SchemaVersion))
+		Expect(r.Source).To(Equal(compdescv3.SchemaVersion))
+		Expect(r.Target).To(Equal(compdescv3final.SchemaVersion))
+		Expect(r.Losses).To(BeEmpty())
+		Expect(r.Descriptor.SchemaVersion()).To(Equal(compdescv3final.SchemaVersion))
+		Expect(string(r.Data)).To(ContainSubstring(`"apiVersion":"` + compdescv3final.SchemaVersion + `"`))
+
+		back := Must(compdesc.ConvertSchemeData(r.Data, compdescv3.SchemaVersion))
+		Expect(back.Losses).To(BeEmpty())
+		Expect(back.Descriptor).To(Equal(Must(compdesc.Decode([]byte(CDv3)))))
+	})
+
+	It("converts v2 to the stable v3 scheme", func() {
+		r := Must(compdesc.ConvertSchemeData([]byte(CDv2), compdescv3final.SchemaVersion))
+		Expect(r.Source).To(Equal(compdescv2.SchemaVersion))
+		Expect(r.Losses).To(BeEmpty())
+		Expect(r.Descriptor.Sources[0].Name).To(Equal("source"))
+
+		back := Must(compdesc.ConvertScheme(r.Descriptor, compdescv2.SchemaVersion))
+		Expect(back.Losses).To(BeEmpty())
+		Expect(back.Descriptor).To(Equal(Must(compdesc.Decode([]byte(CDv2)))))
+	})
+
+	It("keeps the scheme", func() {
+		r := Must(compdesc.ConvertSchemeData([]byte(CDv2), compdescv2.SchemaVersion))
+		Expect(r.Losses).To(BeEmpty())
+		Expect(r.Descriptor).To(Equal(Must(compdesc.Decode([]byte(CDv2)))))
+	})
+
+	It("reports fields not representable", func() {
+		cd := `
+component:
+  name: acme.org/test
+  version: 1.0.0
+  provider: acme.org
+  repositoryContexts: []
+  componentReferences: []
+  sources: []
+  resources:
+  - access:
+      imageReference: ghcr.io/acme/image:1.0.0
+      type: ociArtifact
+    name: image
+    relation: external
+    type: ociImage
+    version: 1.0.0
+    srcRef:
+    - identitySelector:
+        name: source
+meta:
+  schemaVersion: v2
+`
+		r := Must(compdesc.ConvertSchemeData([]byte(cd), compdescv3.SchemaVersion))
+		Expect(r.Losses).To(ConsistOf(compdesc.ConversionLoss{
+			Field:  "component.resources[0].srcRef",
+			Reason: "field not representable",
+		}))
+		Expect(r.IsLossy()).To(BeTrue())
+		Expect(r.Descriptor.Resources[0].SourceRefs).To(HaveLen(1))
+	})
+
+	It("reports scheme specific digests", func() {
+		cd := Must(compdesc.Decode([]byte(CDv2)))
+		cd.Signatures = append(cd.Signatures, compdesc.Signature{
+			Name: "acme",
+			Digest: compdesc.DigestSpec{
+				HashAlgorithm:          "SHA-256",
+				NormalisationAlgorithm: compdesc.JsonNormalisationV1,
+				Value:                  "0123456789abcdef",
+			},
+			Signature: compdesc.SignatureSpec{
+				Algorithm: "RSASSA-PKCS1-V1_5",
+				Value:     "0123456789abcdef",
+				MediaType: "application/vnd.ocm.signature.rsa",
+			},
+		})
+		r := Must(compdesc.ConvertScheme(cd, compdescv3.SchemaVersion))
+		Expect(r.Losses).To(ConsistOf(compdesc.ConversionLoss{
+			Field:  `signature "acme"`,
+			Reason: "digest uses scheme specific normalization jsonNormalisation/v1",
+		}))
+	})
+
+	It("rejects unknown schemes", func() {
+		_, err := compdesc.ConvertSchemeData([]byte(CDv2), "v0")
+		Expect(err).To(MatchError(`schema version "v0" not supported`))
+	})
+})
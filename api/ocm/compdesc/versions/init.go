@@ -1,6 +1,7 @@
 package versions
 
 import (
+	_ "ocm.software/ocm/api/ocm/compdesc/versions/ocm.software/v3"
 	_ "ocm.software/ocm/api/ocm/compdesc/versions/ocm.software/v3alpha1"
 	_ "ocm.software/ocm/api/ocm/compdesc/versions/v2"
 )
@@ -22,6 +22,7 @@ type (
 
 	ComponentVersionAccess = internal.ComponentVersionAccess
 	DigestSpecProvider     = internal.DigestSpecProvider
+	BlobSizeProvider       = internal.BlobSizeProvider
 )
 
 var (
@@ -70,6 +70,7 @@ type (
 	RepositoryType                   = internal.RepositoryType
 	ComponentReference               = internal.ComponentReference
 	DigestSpecProvider               = internal.DigestSpecProvider
+	BlobSizeProvider                 = internal.BlobSizeProvider
 )
 
 type ArtifactAccess[M any] interface {
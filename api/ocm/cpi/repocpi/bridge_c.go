@@ -10,6 +10,7 @@ import (
 	"ocm.software/ocm/api/ocm/compdesc"
 	"ocm.software/ocm/api/ocm/cpi"
 	"ocm.software/ocm/api/ocm/extensions/attrs/compositionmodeattr"
+	"ocm.software/ocm/api/utils/accessio"
 	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
 	"ocm.software/ocm/api/utils/refmgmt"
 	"ocm.software/ocm/api/utils/refmgmt/resource"
@@ -39,6 +40,16 @@ type ComponentAccessImpl interface {
 	io.Closer
 }
 
+// ComponentVersionDeleter is an optional interface for
+// component implementations supporting the deletion of
+// component versions.
+type ComponentVersionDeleter interface {
+	// IsVersionDeletionSupported reports whether the underlying
+	// repository supports the deletion of component versions.
+	IsVersionDeletionSupported() bool
+	DeleteVersion(version string) error
+}
+
 type _componentAccessBridgeBase = resource.ResourceImplBase[cpi.ComponentAccess]
 
 type componentAccessBridge struct {
@@ -125,6 +136,23 @@ func (b *componentAccessBridge) NewVersion(version string, overrides ...bool) (c
 	return NewComponentVersionAccess(b.GetName(), version, i.Impl, i.Lazy, false, !compositionmodeattr.Get(b.GetContext()))
 }
 
+func (b *componentAccessBridge) IsVersionDeletionSupported() bool {
+	if d, ok := b.impl.(ComponentVersionDeleter); ok {
+		return d.IsVersionDeletionSupported()
+	}
+	return false
+}
+
+func (b *componentAccessBridge) DeleteVersion(version string) error {
+	if !b.IsVersionDeletionSupported() {
+		return errors.ErrNotSupported("component version deletion")
+	}
+	if b.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	return b.impl.(ComponentVersionDeleter).DeleteVersion(version)
+}
+
 func (c *componentAccessBridge) AddVersion(cv cpi.ComponentVersionAccess, opts *cpi.AddVersionOptions) (ferr error) {
 	var finalize finalizer.Finalizer
 	defer finalize.FinalizeWithErrorPropagation(&ferr)
@@ -4,6 +4,7 @@ import (
 	"io"
 
 	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
 
 	"ocm.software/ocm/api/ocm/cpi"
 	"ocm.software/ocm/api/utils"
@@ -34,6 +35,15 @@ type RepositoryImpl interface {
 	io.Closer
 }
 
+// BlobGarbageCollector is an optional interface for repository
+// implementations able to remove blobs not used anymore by any
+// component version stored in the repository.
+type BlobGarbageCollector interface {
+	// CleanupBlobs removes all orphaned blobs and returns their digests.
+	// With dryrun=true, the blobs are only reported.
+	CleanupBlobs(dryrun bool) ([]digest.Digest, error)
+}
+
 type _repositoryBridgeBase = resource.ResourceImplBase[cpi.Repository]
 
 type repositoryBridge struct {
@@ -32,6 +32,9 @@ type ComponentAccessBridge interface {
 	HasVersion(vers string) (bool, error)
 	NewVersion(version string, overrides ...bool) (cpi.ComponentVersionAccess, error)
 
+	IsVersionDeletionSupported() bool
+	DeleteVersion(version string) error
+
 	Close() error
 	AddVersion(cv cpi.ComponentVersionAccess, opts *cpi.AddVersionOptions) (ferr error)
 }
@@ -147,3 +150,13 @@ func (c *componentAccessView) HasVersion(vers string) (ok bool, err error) {
 	})
 	return ok, err
 }
+
+func (c *componentAccessView) IsVersionDeletionSupported() bool {
+	return c.bridge.IsVersionDeletionSupported()
+}
+
+func (c *componentAccessView) DeleteVersion(version string) error {
+	return c.Execute(func() error {
+		return c.bridge.DeleteVersion(version)
+	})
+}
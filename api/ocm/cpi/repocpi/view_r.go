@@ -5,6 +5,7 @@ import (
 	"io"
 
 	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
 
 	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/ocm/cpi"
@@ -73,6 +74,24 @@ func GetRepositoryImplementation(n cpi.Repository) (RepositoryImpl, error) {
 	return nil, errors.ErrNotSupported("repository implementation type", fmt.Sprintf("%T", n))
 }
 
+// CleanupBlobs removes the orphaned blobs of a repository, if
+// supported by the repository implementation.
+func CleanupBlobs(n cpi.Repository, dryrun bool) (list []digest.Digest, err error) {
+	impl, err := GetRepositoryImplementation(n)
+	if err != nil {
+		return nil, err
+	}
+	gc, ok := impl.(BlobGarbageCollector)
+	if !ok {
+		return nil, errors.ErrNotSupported("blob garbage collection", n.GetSpecification().GetKind())
+	}
+	err = n.(*repositoryView).Execute(func() error {
+		list, err = gc.CleanupBlobs(dryrun)
+		return err
+	})
+	return list, err
+}
+
 func repositoryViewCreator(i RepositoryBridge, v resource.CloserView, d RepositoryViewManager) cpi.Repository {
 	return &repositoryView{
 		_repositoryView: resource.NewView[cpi.Repository](v, d),
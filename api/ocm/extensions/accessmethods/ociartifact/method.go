@@ -15,6 +15,7 @@ import (
 	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/oci"
 	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
 	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
 	"ocm.software/ocm/api/oci/extensions/repositories/ocireg"
 	"ocm.software/ocm/api/oci/grammar"
@@ -355,7 +356,11 @@ func (m *accessMethod) getBlob() (artifactset.ArtifactBlob, error) {
 	}
 	logger := Logger(WrapContextProvider(m.ctx))
 	logger.Info("synthesize artifact blob", "ref", m.reference)
-	m.blob, err = artifactset.SynthesizeArtifactBlobForArtifact(m.art, m.ref.VersionSpec())
+	if referrersattr.Get(m.ctx) {
+		m.blob, err = m.synthesizeWithReferrers()
+	} else {
+		m.blob, err = artifactset.SynthesizeArtifactBlobForArtifact(m.art, m.ref.VersionSpec())
+	}
 	logger.Info("synthesize artifact blob done", "ref", m.reference, "error", logging.ErrorMessage(err))
 	if err != nil {
 		m.err = err
@@ -363,3 +368,24 @@ func (m *accessMethod) getBlob() (artifactset.ArtifactBlob, error) {
 	}
 	return m.blob, nil
 }
+
+func (m *accessMethod) synthesizeWithReferrers() (artifactset.ArtifactBlob, error) {
+	ns, err := m.repo.LookupNamespace(m.ref.Repository)
+	if err != nil {
+		return nil, err
+	}
+	defer ns.Close()
+	return artifactset.SynthesizeArtifactBlobWithReferrers(ns, m.art, m.ref.VersionSpec())
+}
+
+// GetNamespace provides access to the OCI namespace hosting the artifact.
+// It must be closed by the caller.
+func (m *accessMethod) GetNamespace() (oci.NamespaceAccess, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.ref == nil {
+		return nil, errors.ErrClosed("access method")
+	}
+	return m.repo.LookupNamespace(m.ref.Repository)
+}
@@ -89,7 +89,10 @@ type accessMethod struct {
 	spec *AccessSpec
 }
 
-var _ accspeccpi.AccessMethodImpl = (*accessMethod)(nil)
+var (
+	_ accspeccpi.AccessMethodImpl = (*accessMethod)(nil)
+	_ accspeccpi.BlobSizeProvider = (*accessMethod)(nil)
+)
 
 func (_ *accessMethod) IsLocal() bool {
 	return false
@@ -127,6 +130,13 @@ func (m *accessMethod) MimeType() string {
 	return m.spec.MediaType
 }
 
+// GetBlobSize provides the blob size given by the access specification.
+func (m *accessMethod) GetBlobSize() (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.spec.Size, nil
+}
+
 func (m *accessMethod) getBlob() (blobaccess.BlobAccess, error) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -38,6 +38,17 @@ const (
 	PLAIN_TEXT = "plainText"
 	// OCM_PLUGIN describes an OS executable OCM plugin.
 	OCM_PLUGIN = "ocmPlugin"
+	// ATTESTATION describes a signed in-toto attestation (DSSE envelope)
+	// for a component version.
+	ATTESTATION = "attestation"
+	// SBOM describes a software bill of materials. The format
+	// (SPDX JSON or CycloneDX JSON) is derived from the media type or
+	// the document content.
+	SBOM = "sbom"
+	// SPDX_SBOM describes a software bill of materials in SPDX JSON format.
+	SPDX_SBOM = "spdxSbom"
+	// CYCLONEDX_SBOM describes a software bill of materials in CycloneDX JSON format.
+	CYCLONEDX_SBOM = "cyclonedxSbom"
 
 	// OCM_FILE describes a generic file or unspecified byte stream.
 	OCM_FILE = "file"
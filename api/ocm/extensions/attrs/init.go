@@ -9,4 +9,5 @@ import (
 	_ "ocm.software/ocm/api/ocm/extensions/attrs/plugincacheattr"
 	_ "ocm.software/ocm/api/ocm/extensions/attrs/plugindirattr"
 	_ "ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
+	_ "ocm.software/ocm/api/ocm/extensions/attrs/verificationattr"
 )
@@ -19,9 +19,16 @@ func Get(ctxp ocm.ContextProvider) plugins.Set {
 	path := plugindirattr.Get(ctx)
 
 	// avoid dead lock reading attribute during attribute creation
-	return ctx.GetAttributes().GetOrCreateAttribute(ATTR_KEY, func(ctx datacontext.Context) interface{} {
+	set := ctx.GetAttributes().GetOrCreateAttribute(ATTR_KEY, func(ctx datacontext.Context) interface{} {
 		return plugins.New(ctx.(ocm.Context), path)
 	}).(plugins.Set)
+	if set.Path() != path {
+		// the plugin directory has been changed after the plugins have been used.
+		set.Finalize()
+		set = plugins.New(ctx, path)
+		ctx.GetAttributes().SetAttribute(ATTR_KEY, set)
+	}
+	return set
 }
 
 func Set(ctx ocm.Context, cache cache.PluginDir) error {
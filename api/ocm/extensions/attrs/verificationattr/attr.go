@@ -0,0 +1,70 @@
+package verificationattr
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/datacontext"
+	"ocm.software/ocm/api/ocm/tools/signing/policy"
+	ocm "ocm.software/ocm/api/ocm/types"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	ATTR_KEY   = "ocm.software/ocm/verification"
+	ATTR_SHORT = "verification"
+)
+
+type (
+	Context         = ocm.Context
+	ContextProvider = ocm.ContextProvider
+)
+
+func init() {
+	datacontext.RegisterAttributeType(ATTR_KEY, AttributeType{}, ATTR_SHORT)
+}
+
+type AttributeType struct{}
+
+func (a AttributeType) Name() string {
+	return ATTR_KEY
+}
+
+func (a AttributeType) Description() string {
+	return `
+*JSON*
+Verification policy used to verify component versions given as JSON
+document. It uses the format of the config type <code>` + ConfigType + `</code>.
+`
+}
+
+func (a AttributeType) Encode(v interface{}, marshaller runtime.Marshaler) ([]byte, error) {
+	if p, ok := v.(*policy.Policy); ok {
+		return marshaller.Marshal(p)
+	}
+	return nil, errors.ErrInvalid("verification policy")
+}
+
+func (a AttributeType) Decode(data []byte, unmarshaller runtime.Unmarshaler) (interface{}, error) {
+	var value policy.Policy
+	err := unmarshaller.Unmarshal(data, &value)
+	if err != nil {
+		return nil, err
+	}
+	return &value, value.Validate()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Get provides the verification policy configured for a context.
+// If no policy is configured, nil is returned.
+func Get(ctx ContextProvider) *policy.Policy {
+	a := ctx.OCMContext().GetAttributes().GetAttribute(ATTR_KEY)
+	if a == nil {
+		return nil
+	}
+	return a.(*policy.Policy)
+}
+
+func Set(ctx ContextProvider, p *policy.Policy) error {
+	return ctx.OCMContext().GetAttributes().SetAttribute(ATTR_KEY, p)
+}
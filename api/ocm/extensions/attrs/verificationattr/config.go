@@ -0,0 +1,112 @@
+package verificationattr
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+
+	cfgcpi "ocm.software/ocm/api/config/cpi"
+	"ocm.software/ocm/api/ocm/tools/signing/policy"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	ConfigType   = "verification" + cfgcpi.OCM_CONFIG_TYPE_SUFFIX
+	ConfigTypeV1 = ConfigType + runtime.VersionSeparator + "v1"
+)
+
+func init() {
+	cfgcpi.RegisterConfigType(cfgcpi.NewConfigType[*Config](ConfigType, usage))
+	cfgcpi.RegisterConfigType(cfgcpi.NewConfigType[*Config](ConfigTypeV1, usage))
+}
+
+// Config describes a verification policy.
+type Config struct {
+	runtime.ObjectVersionedType `json:",inline"`
+	policy.Policy               `json:",inline"`
+}
+
+// New creates a new verification policy config.
+func New() *Config {
+	return &Config{
+		ObjectVersionedType: runtime.NewVersionedTypedObject(ConfigType),
+	}
+}
+
+func (a *Config) GetType() string {
+	return ConfigType
+}
+
+func (a *Config) ApplyTo(ctx cfgcpi.Context, target interface{}) error {
+	t, ok := target.(Context)
+	if !ok {
+		return cfgcpi.ErrNoContext(ConfigType)
+	}
+	p := Merge(Get(t), &a.Policy)
+	if err := p.Validate(); err != nil {
+		return errors.Wrapf(err, "invalid verification policy")
+	}
+	return errors.Wrapf(Set(t, p), "applying config failed")
+}
+
+// Merge provides a new policy combining the signers and
+// rules of both policies. Signers of the second policy replace
+// signers with the same name, its rules are evaluated after
+// the rules of the first one.
+func Merge(a, b *policy.Policy) *policy.Policy {
+	p := policy.New()
+	for _, s := range []*policy.Policy{a, b} {
+		if s == nil {
+			continue
+		}
+		for n, signer := range s.Signers {
+			p.AddSigner(n, signer)
+		}
+		p.Rules = append(p.Rules, s.Rules...)
+	}
+	return p
+}
+
+const usage = `
+The config type <code>` + ConfigType + `</code> can be used to define
+a verification policy for component versions. It describes accepted signers
+and rules for the required signers for component versions.
+
+<pre>
+    type: ` + ConfigType + `
+    signers:
+       &lt;name>:
+         signature: &lt;signature name (default: signer name)>
+         publicKey:
+           path: &lt;file path>
+         issuer:
+           commonName: acme.org
+         rootCertificates:
+           - path: &lt;file path>
+       ...
+    rules:
+      - components:
+          - acme.org/*
+        required:
+          - &lt;signer name>
+        signers:
+          - &lt;signer name>
+          ...
+        threshold: 2
+</pre>
+
+A signer describes an accepted signature by its name in the component
+descriptor. The public key may be given by the field <code>publicKey</code>.
+Otherwise, the public key configured for the signature name is used, or the
+certificate provided together with the signature. The fields <code>issuer</code>
+and <code>rootCertificates</code> restrict the accepted key certificates.
+At least one of <code>publicKey</code> or <code>issuer</code> must be given.
+
+Rules are evaluated in the given order. The first rule with a component
+name pattern matching the name of a component version is used to verify
+the component version (<code>*</code> matches any sequence of characters).
+A rule without component patterns matches all component versions.
+All <code>required</code> signers and at least <code>threshold</code>
+(default 1) of the <code>signers</code> must have provided a valid signature.
+
+Multiple policy config documents are merged. Component versions not matched
+by any rule are not checked, unless they are used as root component versions.
+`
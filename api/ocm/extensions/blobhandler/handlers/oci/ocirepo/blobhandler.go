@@ -13,6 +13,7 @@ import (
 
 	"ocm.software/ocm/api/oci"
 	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
 	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
 	"ocm.software/ocm/api/oci/extensions/repositories/ocireg"
 	"ocm.software/ocm/api/oci/grammar"
@@ -220,11 +221,13 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 	}
 
 	var art oci.ArtifactAccess
+	var src oci.NamespaceAccess
 	var err error
 	var finalizer Finalizer
 	defer finalizer.Finalize()
 
 	keep := keepblobattr.Get(ctx.GetContext())
+	referrers := referrersattr.Get(ctx.GetContext())
 
 	if m, ok := blob.(blobaccess.AnnotatedBlobAccess[accspeccpi.AccessMethodView]); ok {
 		// prepare for optimized point to point implementation
@@ -238,6 +241,13 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 			}
 			if art != nil {
 				defer art.Close()
+				if referrers {
+					src, err = ocimeth.GetNamespace()
+					if err != nil {
+						return nil, errors.Wrapf(err, "cannot access source namespace")
+					}
+					defer src.Close()
+				}
 			}
 		}
 	} else {
@@ -311,6 +321,7 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 			return nil, wrap(err, errhint, "get artifact from blob")
 		}
 		defer art.Close()
+		src = set
 	} else {
 		log.Debug("using direct transfer mode")
 		digest = art.Digest()
@@ -328,6 +339,12 @@ func (b *artifactHandler) StoreBlob(blob cpi.BlobAccess, artType, hint string, g
 	if err != nil {
 		return nil, wrap(err, errhint, "transfer artifact")
 	}
+	if referrers {
+		err = transfer.TransferReferrers(src, digest, namespace)
+		if err != nil {
+			return nil, wrap(err, errhint, "transfer referrers")
+		}
+	}
 	match := grammar.AnchoredSchemedRegexp.FindStringSubmatch(base)
 	scheme := ""
 	if match != nil {
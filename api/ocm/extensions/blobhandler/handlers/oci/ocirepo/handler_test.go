@@ -12,6 +12,7 @@ import (
 
 	"ocm.software/ocm/api/oci"
 	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/extensions/attrs/referrersattr"
 	ocictf "ocm.software/ocm/api/oci/extensions/repositories/ctf"
 	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 	"ocm.software/ocm/api/ocm/cpi"
@@ -40,6 +41,8 @@ const (
 	OUT        = "/tmp/res"
 	OCIPATH    = "/tmp/oci"
 	OCIHOST    = "alias"
+
+	SIGNATURE = "application/vnd.test.signature"
 )
 
 func FakeOCIRegBaseFunction(ctx *storagecontext.StorageContext) string {
@@ -445,4 +448,75 @@ var _ = Describe("oci artifact transfer", func() {
 			Expect(string(data)).To(Equal(OCILAYER))
 		})
 	})
+
+	Context("with referrers", func() {
+		var mdesc *artdesc.Descriptor
+
+		BeforeEach(func() {
+			env.OCICommonTransport(OCIPATH, accessio.FormatDirectory, func() {
+				mdesc, ldesc = OCIManifest1For(env, OCINAMESPACE, OCIVERSION)
+				env.Namespace(OCINAMESPACE, func() {
+					env.Manifest("signature", func() {
+						env.Subject(mdesc)
+						env.ArtifactType(SIGNATURE)
+						env.Config(func() {
+							env.BlobStringData(artdesc.MediaTypeEmptyJSON, "{}")
+						})
+						env.Layer(func() {
+							env.BlobStringData(mime.MIME_TEXT, "signature")
+						})
+					})
+				})
+			})
+		})
+
+		transport := func() cpi.Repository {
+			env.OCMContext().BlobHandlers().Register(ocirepo.NewArtifactHandler(FakeOCIRegBaseFunction),
+				cpi.ForRepo(oci.CONTEXT_TYPE, ocictf.Type), cpi.ForMimeType(artdesc.ToContentMediaType(artdesc.MediaTypeImageManifest)))
+
+			src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+			defer Close(src, "source")
+			cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+			defer Close(cv, "source version")
+			tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+
+			opts := &standard.Options{}
+			opts.SetResourcesByValue(true)
+			handler := standard.NewDefaultHandler(opts)
+
+			MustBeSuccessful(transfer.TransferVersion(nil, nil, cv, tgt, handler))
+			return tgt
+		}
+
+		It("it should export the referrers of the OCI image", func() {
+			MustBeSuccessful(referrersattr.Set(env.OCMContext(), true))
+
+			tgt := transport()
+			defer Close(tgt, "target")
+
+			ocirepo := genericocireg.GetOCIRepository(tgt)
+			ns := Must(ocirepo.LookupNamespace(OCINAMESPACE))
+			defer Close(ns, "target namespace")
+
+			list := Must(ns.ReferrersLister().ListReferrers(mdesc.Digest, ""))
+			Expect(len(list)).To(Equal(1))
+			Expect(list[0].ArtifactType).To(Equal(SIGNATURE))
+
+			ref := Must(ns.GetArtifact(list[0].Digest.String()))
+			defer Close(ref, "referrer")
+			Expect(ref.GetDescriptor().GetSubject().Digest).To(Equal(mdesc.Digest))
+		})
+
+		It("it should not export the referrers of the OCI image by default", func() {
+			tgt := transport()
+			defer Close(tgt, "target")
+
+			ocirepo := genericocireg.GetOCIRepository(tgt)
+			ns := Must(ocirepo.LookupNamespace(OCINAMESPACE))
+			defer Close(ns, "target namespace")
+
+			Expect(Must(ocirepo.ExistsArtifact(OCINAMESPACE, OCIVERSION))).To(BeTrue())
+			Expect(Must(ns.ReferrersLister().ListReferrers(mdesc.Digest, ""))).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,8 @@
+// Package plugin is an adapter implementation that provides a generic handling of all
+// pub/sub types provided by plugins.
+// It includes a generic PubSubType object (responsible for un-/marshaling
+// Spec objects), a Spec object and a PubSubMethod implementation
+// forwarding the notifications to the plugin.
+// The types are registered for the plugins found in the plugin
+// directory of an OCM context by the plugin registration.
+package plugin
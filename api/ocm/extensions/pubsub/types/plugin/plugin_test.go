@@ -0,0 +1,91 @@
+//go:build unix
+
+package plugin_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/ocm/plugin/testutils"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/extensions/pubsub"
+	"ocm.software/ocm/api/ocm/extensions/pubsub/providers/ocireg"
+	"ocm.software/ocm/api/ocm/extensions/repositories/composition"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/plugin/registration"
+	"ocm.software/ocm/api/utils/accessio"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	COMP = "acme.org/component"
+	VERS = "v1"
+)
+
+var _ = Describe("plugin pub/sub types", func() {
+	var ctx ocm.Context
+	var plugins TempPluginDir
+	var tmp string
+	var out string
+	var spec string
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		plugins = Must(ConfigureTestPlugins(ctx, "testdata"))
+		MustBeSuccessful(registration.RegisterExtensions(ctx))
+
+		tmp = Must(os.MkdirTemp("", "pubsub-"))
+		out = filepath.Join(tmp, "notifications")
+		spec = `{"type":"test","path":"` + out + `"}`
+	})
+
+	AfterEach(func() {
+		plugins.Cleanup()
+		os.RemoveAll(tmp)
+	})
+
+	It("registers pub/sub types", func() {
+		t := pubsub.For(ctx).TypeScheme.GetType("test")
+		Expect(t).NotTo(BeNil())
+		Expect(t.Description()).To(Equal("test pub/sub"))
+		Expect(t.Format()).To(Equal("\ntest format"))
+		Expect(pubsub.For(ctx).TypeScheme.GetType("test/v1")).NotTo(BeNil())
+	})
+
+	It("describes specification", func() {
+		s := Must(pubsub.SpecForData(ctx, []byte(spec)))
+		Expect(s.Describe(ctx)).To(Equal("test pub/sub writing to " + out))
+	})
+
+	It("notifies with credentials", func() {
+		ctx.CredentialsContext().SetCredentialsForConsumer(credentials.NewConsumerIdentity("PubSubTest"),
+			credentials.DirectCredentials{"token": "secret"})
+
+		repo := composition.NewRepository(ctx, "testrepo")
+		defer Close(repo)
+
+		s := Must(pubsub.SpecForData(ctx, []byte(spec)))
+		m := Must(s.PubSubMethod(repo))
+		MustBeSuccessful(m.NotifyComponentVersion(common.NewNameVersion(COMP, VERS)))
+		Expect(string(Must(os.ReadFile(out)))).To(Equal(COMP + ":" + VERS + ` {"token":"secret"}` + "\n"))
+	})
+
+	It("notifies for added component versions", func() {
+		pubsub.For(ctx).ProviderRegistry.Register(ctf.Type, &ocireg.Provider{})
+
+		repo := Must(ctf.Open(ctx, ctf.ACC_WRITABLE|ctf.ACC_CREATE, filepath.Join(tmp, "ctf"), 0o700, accessio.FormatDirectory))
+		defer Close(repo)
+
+		MustBeSuccessful(pubsub.SetForRepo(repo, Must(pubsub.SpecForData(ctx, []byte(spec)))))
+
+		cv := composition.NewComponentVersion(ctx, COMP, VERS)
+		defer Close(cv)
+		MustBeSuccessful(repo.AddComponentVersion(cv))
+		Expect(string(Must(os.ReadFile(out)))).To(Equal(COMP + ":" + VERS + " \n"))
+	})
+})
@@ -0,0 +1,82 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/extensions/pubsub"
+	"ocm.software/ocm/api/ocm/plugin"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+// Spec is the specification for pub/sub types provided by plugins.
+// The content is completely handled by the plugin.
+type Spec struct {
+	runtime.UnstructuredVersionedTypedObject `json:",inline"`
+	plug                                     plugin.Plugin
+}
+
+var _ pubsub.PubSubSpec = (*Spec)(nil)
+
+func (s *Spec) PubSubMethod(repo cpi.Repository) (pubsub.PubSubMethod, error) {
+	data, info, err := s.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var creds json.RawMessage
+	if len(info.ConsumerId) > 0 {
+		c, err := credentials.CredentialsForConsumer(repo.GetContext().CredentialsContext(), info.ConsumerId)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			creds, err = json.Marshal(c.Properties())
+			if err != nil {
+				return nil, errors.Wrapf(err, "cannot marshal credentials")
+			}
+		}
+	}
+	return &Method{plug: s.plug, spec: data, creds: creds}, nil
+}
+
+func (s *Spec) Describe(_ cpi.Context) string {
+	_, info, err := s.validate()
+	if err != nil || info.Short == "" {
+		return fmt.Sprintf("pub/sub type %q provided by plugin", s.GetType())
+	}
+	return info.Short
+}
+
+func (s *Spec) validate() ([]byte, *plugin.PubSubSpecInfo, error) {
+	if s.plug == nil {
+		return nil, nil, errors.ErrUnknown(plugin.KIND_PUBSUBTYPE, s.GetType())
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "cannot marshal pub/sub specification")
+	}
+	info, err := s.plug.ValidatePubSub(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, info, nil
+}
+
+// Method forwards the notifications to the plugin.
+type Method struct {
+	plug  plugin.Plugin
+	spec  []byte
+	creds json.RawMessage
+}
+
+var _ pubsub.PubSubMethod = (*Method)(nil)
+
+func (m *Method) NotifyComponentVersion(version common.NameVersion) error {
+	return m.plug.NotifyComponentVersion(m.spec, m.creds, version)
+}
@@ -0,0 +1,35 @@
+package plugin
+
+import (
+	"ocm.software/ocm/api/ocm/extensions/pubsub"
+	"ocm.software/ocm/api/ocm/plugin"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+type pubSubType struct {
+	pubsub.PubSubType
+	plug plugin.Plugin
+}
+
+var _ pubsub.PubSubType = (*pubSubType)(nil)
+
+func NewType(name string, p plugin.Plugin, desc *plugin.PubSubTypeDescriptor) pubsub.PubSubType {
+	format := desc.Format
+	if format != "" {
+		format = "\n" + format
+	}
+
+	return &pubSubType{
+		PubSubType: pubsub.NewPubSubType[*Spec](name, pubsub.WithDesciption(desc.Description), pubsub.WithFormatSpec(format)),
+		plug:       p,
+	}
+}
+
+func (t *pubSubType) Decode(data []byte, unmarshaler runtime.Unmarshaler) (pubsub.PubSubSpec, error) {
+	spec, err := t.PubSubType.Decode(data, unmarshaler)
+	if err != nil {
+		return nil, err
+	}
+	spec.(*Spec).plug = t.plug
+	return spec, nil
+}
@@ -21,15 +21,20 @@ type localFilesystemBlobAccessMethod struct {
 	spec       *localblob.AccessSpec
 	base       repocpi.ComponentVersionAccessImpl
 	err        error
+	size       int64
 	blobAccess blobaccess.BlobAccess
 }
 
-var _ accspeccpi.AccessMethodImpl = (*localFilesystemBlobAccessMethod)(nil)
+var (
+	_ accspeccpi.AccessMethodImpl = (*localFilesystemBlobAccessMethod)(nil)
+	_ accspeccpi.BlobSizeProvider = (*localFilesystemBlobAccessMethod)(nil)
+)
 
 func newLocalFilesystemBlobAccessMethod(a *localblob.AccessSpec, base repocpi.ComponentVersionAccessImpl, ref refmgmt.ExtendedAllocatable) (accspeccpi.AccessMethod, error) {
 	m := &localFilesystemBlobAccessMethod{
 		spec: a,
 		base: base,
+		size: blobaccess.BLOB_UNKNOWN_SIZE,
 	}
 	ref.BeforeCleanup(refmgmt.CleanupHandlerFunc(m.Cache))
 	return accspeccpi.AccessMethodForImplementation(m, nil)
@@ -86,11 +91,31 @@ func (m *localFilesystemBlobAccessMethod) getBlob() (blobaccess.BlobAccess, erro
 		if err != nil {
 			return nil, err
 		}
-		m.blobAccess = blobaccess.ForDataAccess(blobaccess.BLOB_UNKNOWN_DIGEST, blobaccess.BLOB_UNKNOWN_SIZE, m.MimeType(), data)
+		if l, ok := data.(blobaccess.FileLocation); ok {
+			if fi, err := l.FileSystem().Stat(l.Path()); err == nil {
+				m.size = fi.Size()
+			}
+		}
+		m.blobAccess = blobaccess.ForDataAccess(blobaccess.BLOB_UNKNOWN_DIGEST, m.size, m.MimeType(), data)
 	}
 	return m.blobAccess, m.err
 }
 
+// GetBlobSize provides the size of the local blob, if it is
+// stored as file in the archive, without reading the blob content.
+func (m *localFilesystemBlobAccessMethod) GetBlobSize() (int64, error) {
+	m.Lock()
+	defer m.Unlock()
+
+	if m.closed {
+		return blobaccess.BLOB_UNKNOWN_SIZE, accessio.ErrClosed
+	}
+	if _, err := m.getBlob(); err != nil {
+		return blobaccess.BLOB_UNKNOWN_SIZE, err
+	}
+	return m.size, nil
+}
+
 func (m *localFilesystemBlobAccessMethod) Get() ([]byte, error) {
 	m.Lock()
 	defer m.Unlock()
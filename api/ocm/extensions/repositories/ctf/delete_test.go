@@ -0,0 +1,93 @@
+package ctf_test
+
+import (
+	. "github.com/mandelsoft/goutils/finalizer"
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/ocmutils"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/blobaccess"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+var _ = Describe("deletion", func() {
+	var fs vfs.FileSystem
+	ctx := ocm.DefaultContext()
+
+	const VERSION2 = "1.0.1"
+
+	BeforeEach(func() {
+		fs = memoryfs.New()
+
+		final := Finalizer{}
+		defer Defer(final.Finalize)
+
+		a := Must(ctf.Create(ctx, accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, "ctf", 0o700, accessio.PathFileSystem(fs)))
+		final.Close(a, "repository")
+		c := Must(a.LookupComponent(COMPONENT))
+		final.Close(c, "component")
+
+		for _, v := range []string{VERSION, VERSION2} {
+			cv := Must(c.NewVersion(v))
+			final.Close(cv, "version")
+			MustBeSuccessful(cv.SetResourceBlob(compdesc.NewResourceMeta("text", resourcetypes.PLAIN_TEXT, metav1.LocalRelation), blobaccess.ForString(mime.MIME_TEXT, "data of "+v), "", nil))
+			MustBeSuccessful(c.AddVersion(cv))
+		}
+	})
+
+	It("deletes component version and cleans up blobs", func() {
+		final := Finalizer{}
+		defer Defer(final.Finalize)
+
+		a := Must(ctf.Open(ctx, accessobj.ACC_WRITABLE, "ctf", 0o700, accessio.PathFileSystem(fs)))
+		final.Close(a, "repository")
+		c := Must(a.LookupComponent(COMPONENT))
+		final.Close(c, "component")
+
+		Expect(c.IsVersionDeletionSupported()).To(BeTrue())
+		MustBeSuccessful(c.DeleteVersion(VERSION))
+		Expect(c.ListVersions()).To(ConsistOf(VERSION2))
+		Expect(c.DeleteVersion(VERSION)).To(MatchError(ContainSubstring("not found")))
+
+		orphaned := Must(ocm.CleanupBlobs(a, true))
+		// manifest, config, component descriptor and local blob
+		Expect(len(orphaned)).To(Equal(4))
+		Expect(orphaned).To(ContainElement(digest.FromString("data of " + VERSION)))
+		Expect(Must(ocm.CleanupBlobs(a, false))).To(ConsistOf(orphaned))
+		Expect(Must(ocm.CleanupBlobs(a, true))).To(BeEmpty())
+		MustBeSuccessful(final.Finalize())
+
+		a = Must(ctf.Open(ctx, accessobj.ACC_READONLY, "ctf", 0o700, accessio.PathFileSystem(fs)))
+		final.Close(a, "repository")
+		_, err := a.LookupComponentVersion(COMPONENT, VERSION)
+		Expect(err).To(HaveOccurred())
+		cv := Must(a.LookupComponentVersion(COMPONENT, VERSION2))
+		final.Close(cv, "version")
+		r := Must(cv.GetResource(metav1.NewIdentity("text")))
+		Expect(string(Must(ocmutils.GetResourceData(r)))).To(Equal("data of " + VERSION2))
+	})
+
+	It("rejects deletion for readonly repository", func() {
+		final := Finalizer{}
+		defer Defer(final.Finalize)
+
+		a := Must(ctf.Open(ctx, accessobj.ACC_READONLY, "ctf", 0o700, accessio.PathFileSystem(fs)))
+		final.Close(a, "repository")
+		c := Must(a.LookupComponent(COMPONENT))
+		final.Close(c, "component")
+
+		Expect(c.DeleteVersion(VERSION)).To(MatchError(accessio.ErrReadOnly))
+	})
+})
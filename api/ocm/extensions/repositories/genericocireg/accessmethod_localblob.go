@@ -24,7 +24,10 @@ type localBlobAccessMethod struct {
 	artifact  oci.ArtifactAccess
 }
 
-var _ accspeccpi.AccessMethodImpl = (*localBlobAccessMethod)(nil)
+var (
+	_ accspeccpi.AccessMethodImpl = (*localBlobAccessMethod)(nil)
+	_ accspeccpi.BlobSizeProvider = (*localBlobAccessMethod)(nil)
+)
 
 func newLocalBlobAccessMethod(a *localblob.AccessSpec, ns oci.NamespaceAccess, art oci.ArtifactAccess, ref refmgmt.ExtendedAllocatable) (accspeccpi.AccessMethod, error) {
 	return accspeccpi.AccessMethodForImplementation(newLocalBlobAccessMethodImpl(a, ns, art, ref))
@@ -96,6 +99,22 @@ func (m *localBlobAccessMethod) getBlob() (blobaccess.DataAccess, error) {
 	return m.data, err
 }
 
+// GetBlobSize provides the size of the local blob as stored
+// in the OCI namespace without reading the blob content.
+func (m *localBlobAccessMethod) GetBlobSize() (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.namespace == nil || m.spec.LocalReference == "" {
+		return blobaccess.BLOB_UNKNOWN_SIZE, nil
+	}
+	size, data, err := m.namespace.GetBlobData(digest.Digest(m.spec.LocalReference))
+	if err != nil {
+		return blobaccess.BLOB_UNKNOWN_SIZE, err
+	}
+	return size, data.Close()
+}
+
 func (m *localBlobAccessMethod) Reader() (io.ReadCloser, error) {
 	blob, err := m.getBlob()
 	if err != nil {
@@ -27,6 +27,8 @@ type componentAccessImpl struct {
 	namespace oci.NamespaceAccess
 }
 
+var _ repocpi.ComponentVersionDeleter = (*componentAccessImpl)(nil)
+
 func newComponentAccess(repo *RepositoryImpl, name string, main bool) (*repocpi.ComponentAccessInfo, error) {
 	mapped, err := repo.MapComponentNameToNamespace(name)
 	if err != nil {
@@ -69,6 +71,12 @@ func (c *componentAccessImpl) GetName() string {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+// VersionToTag maps a component version to the OCI tag used to
+// store the component descriptor artifact.
+func VersionToTag(v string) (string, error) {
+	return toTag(v)
+}
+
 func toTag(v string) (string, error) {
 	_, err := semver.NewVersion(v)
 	if err != nil {
@@ -171,3 +179,34 @@ func (c *componentAccessImpl) NewVersion(version string, overrides ...bool) (*re
 	}
 	return newComponentVersionAccess(accessobj.ACC_CREATE, c, version, acc, false)
 }
+
+func (c *componentAccessImpl) IsVersionDeletionSupported() bool {
+	return c.namespace.ArtifactDeleter() != nil
+}
+
+func (c *componentAccessImpl) DeleteVersion(version string) error {
+	if c.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	d := c.namespace.ArtifactDeleter()
+	if d == nil {
+		return errors.ErrNotSupported("component version deletion")
+	}
+	tag, err := toTag(version)
+	if err != nil {
+		return err
+	}
+	acc, err := c.namespace.GetArtifact(tag)
+	if err != nil {
+		if errors.IsErrNotFound(err) {
+			return cpi.ErrComponentVersionNotFoundWrap(err, c.name, version)
+		}
+		return err
+	}
+	digest := acc.Digest()
+	err = acc.Close()
+	if err != nil {
+		return err
+	}
+	return d.DeleteArtifact(digest)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/mandelsoft/goutils/general"
+	"github.com/opencontainers/go-digest"
 
 	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/datacontext"
@@ -48,6 +49,7 @@ type RepositoryImpl struct {
 
 var (
 	_ repocpi.RepositoryImpl               = (*RepositoryImpl)(nil)
+	_ repocpi.BlobGarbageCollector         = (*RepositoryImpl)(nil)
 	_ credentials.ConsumerIdentityProvider = (*RepositoryImpl)(nil)
 )
 
@@ -105,6 +107,12 @@ func (r *RepositoryImpl) OCIRepository() ocicpi.Repository {
 	return r.ocirepo
 }
 
+// CleanupBlobs removes the orphaned blobs of the underlying
+// OCI repository, if supported.
+func (r *RepositoryImpl) CleanupBlobs(dryrun bool) ([]digest.Digest, error) {
+	return ocicpi.CleanupBlobs(r.ocirepo, dryrun)
+}
+
 func (r *RepositoryImpl) Meta() ComponentRepositoryMeta {
 	return r.meta
 }
@@ -17,7 +17,10 @@ type localBlobAccessMethod struct {
 	spec *localblob.AccessSpec
 }
 
-var _ accspeccpi.AccessMethodImpl = (*localBlobAccessMethod)(nil)
+var (
+	_ accspeccpi.AccessMethodImpl = (*localBlobAccessMethod)(nil)
+	_ accspeccpi.BlobSizeProvider = (*localBlobAccessMethod)(nil)
+)
 
 func newLocalBlobAccessMethod(a *localblob.AccessSpec, data blobaccess.DataAccess) (*localBlobAccessMethod, error) {
 	return &localBlobAccessMethod{
@@ -62,3 +65,22 @@ func (m *localBlobAccessMethod) Get() (data []byte, ferr error) {
 func (m *localBlobAccessMethod) MimeType() string {
 	return m.spec.MediaType
 }
+
+// GetBlobSize provides the size of the local blob, if it is
+// stored as file, without reading the blob content.
+func (m *localBlobAccessMethod) GetBlobSize() (int64, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.data == nil {
+		return blobaccess.BLOB_UNKNOWN_SIZE, blobaccess.ErrClosed
+	}
+	if l, ok := m.data.(blobaccess.FileLocation); ok {
+		fi, err := l.FileSystem().Stat(l.Path())
+		if err != nil {
+			return blobaccess.BLOB_UNKNOWN_SIZE, err
+		}
+		return fi.Size(), nil
+	}
+	return blobaccess.BLOB_UNKNOWN_SIZE, nil
+}
@@ -70,6 +70,15 @@ type ComponentAccess interface {
 	AddVersion(cv ComponentVersionAccess, overrides ...bool) error
 	AddVersionOpt(cv ComponentVersionAccess, opts ...AddVersionOption) error
 
+	// IsVersionDeletionSupported reports whether the repository
+	// supports the deletion of component versions.
+	IsVersionDeletionSupported() bool
+	// DeleteVersion deletes a component version from the repository.
+	// Local blobs of the component version are not necessarily deleted.
+	// Depending on the repository type, a garbage collection is required
+	// to remove orphaned blobs.
+	DeleteVersion(version string) error
+
 	io.Closer
 }
 
@@ -13,20 +13,21 @@ import (
 )
 
 type Result struct {
-	Missing   Missing           `json:"missing,omitempty"`
-	Resources []metav1.Identity `json:"resources,omitempty"`
-	Sources   []metav1.Identity `json:"sources,omitempty"`
+	Missing    Missing           `json:"missing,omitempty"`
+	Resources  []metav1.Identity `json:"resources,omitempty"`
+	Sources    []metav1.Identity `json:"sources,omitempty"`
+	Violations Violations        `json:"violations,omitempty"`
 }
 
 func newResult() *Result {
-	return &Result{Missing: Missing{}}
+	return &Result{Missing: Missing{}, Violations: Violations{}}
 }
 
 func (r *Result) IsEmpty() bool {
 	if r == nil {
 		return true
 	}
-	return len(r.Missing) == 0 && len(r.Resources) == 0 && len(r.Sources) == 0
+	return len(r.Missing) == 0 && len(r.Resources) == 0 && len(r.Sources) == 0 && len(r.Violations) == 0
 }
 
 type Missing map[common.NameVersion]common.History
@@ -49,6 +50,8 @@ type Cache = map[common.NameVersion]*Result
 // to be in the same repository.
 // Optionally, it is possible to check for inlined
 // resources and sources, also.
+// Additionally, policy rules (see WithRules) can be evaluated
+// for all component versions of the reference graph.
 func Check(opts ...Option) *Options {
 	return optionutils.EvalOptions(opts...)
 }
@@ -86,7 +89,7 @@ func (a *Options) check(cache Cache, repo ocm.Repository, id common.NameVersion,
 
 	var r *Result
 	if cv == nil {
-		r = &Result{Missing: Missing{id: h}}
+		r = &Result{Missing: Missing{id: h}, Violations: Violations{}}
 	} else {
 		defer cv.Close()
 		r, err = a.handle(cache, cv, h)
@@ -104,10 +107,13 @@ func (a *Options) handle(cache Cache, cv ocm.ComponentVersionAccess, h common.Hi
 		if err != nil {
 			return result, err
 		}
-		if n != nil && len(n.Missing) > 0 {
+		if n != nil {
 			for k, v := range n.Missing {
 				result.Missing[k] = v
 			}
+			for k, v := range n.Violations {
+				result.Violations[k] = v
+			}
 		}
 	}
 
@@ -122,6 +128,16 @@ func (a *Options) handle(cache Cache, cv ocm.ComponentVersionAccess, h common.Hi
 		result.Sources, err = a.checkArtifacts(cv.GetContext(), cv.GetDescriptor().Sources)
 		list.Add(err)
 	}
+	for _, rule := range a.Rules {
+		v, err := rule.Check(cv)
+		if err != nil {
+			list.Add(errors.Wrapf(err, "rule %s", rule.Name()))
+		}
+		if len(v) > 0 {
+			key := common.VersionedElementKey(cv)
+			result.Violations[key] = append(result.Violations[key], v...)
+		}
+	}
 	if result.IsEmpty() {
 		result = nil
 	}
@@ -0,0 +1,115 @@
+package check
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/ocm"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/utils"
+)
+
+// DigestDenyList is a list of denied digests. An entry is either a plain
+// digest value or a digest value prefixed by the hash algorithm
+// (<algorithm>:<value>, for example sha256:6d2f...).
+type DigestDenyList []string
+
+// ParseDigestDenyList parses a deny list. Every line contains
+// a digest. Empty lines and lines starting with # are ignored.
+func ParseDigestDenyList(data []byte) (DigestDenyList, error) {
+	var result DigestDenyList
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		result = append(result, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrapf(err, "invalid digest deny list")
+	}
+	return result, nil
+}
+
+// ReadDigestDenyList reads a deny list from a file.
+// If no filesystem is given, the OS filesystem is used.
+func ReadDigestDenyList(path string, fss ...vfs.FileSystem) (DigestDenyList, error) {
+	data, err := vfs.ReadFile(utils.FileSystem(fss...), path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read digest deny list %q", path)
+	}
+	return ParseDigestDenyList(data)
+}
+
+// Denies checks whether a digest is denied by the deny list.
+func (l DigestDenyList) Denies(d *metav1.DigestSpec) bool {
+	if d == nil || d.Value == "" {
+		return false
+	}
+	for _, e := range l {
+		algo, value, ok := strings.Cut(e, ":")
+		if !ok {
+			value = algo
+			algo = ""
+		}
+		if value != d.Value {
+			continue
+		}
+		if algo == "" || normalizeAlgorithm(algo) == normalizeAlgorithm(d.HashAlgorithm) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeAlgorithm(algo string) string {
+	return strings.ReplaceAll(strings.ToLower(algo), "-", "")
+}
+
+// DeniedDigests provides a rule rejecting resources and component
+// references with a digest contained in the given deny list.
+func DeniedDigests(list DigestDenyList) Rule {
+	return &deniedDigests{list}
+}
+
+type deniedDigests struct {
+	list DigestDenyList
+}
+
+func (r *deniedDigests) Name() string {
+	return RULE_DENIED_DIGEST
+}
+
+func (r *deniedDigests) Check(cv ocm.ComponentVersionAccess) ([]Violation, error) {
+	var result []Violation
+	cd := cv.GetDescriptor()
+	for i := range cd.Resources {
+		res := &cd.Resources[i]
+		if r.list.Denies(res.Digest) {
+			result = append(result, Violation{
+				Rule:     r.Name(),
+				Kind:     ocm.KIND_RESOURCE,
+				Identity: res.GetIdentity(cd.Resources),
+				Message:  fmt.Sprintf("digest %s:%s is denied", res.Digest.HashAlgorithm, res.Digest.Value),
+			})
+		}
+	}
+	for i := range cd.References {
+		ref := &cd.References[i]
+		if r.list.Denies(ref.Digest) {
+			result = append(result, Violation{
+				Rule:     r.Name(),
+				Kind:     ocm.KIND_REFERENCE,
+				Identity: ref.GetIdentity(cd.References),
+				Message:  fmt.Sprintf("digest %s:%s is denied", ref.Digest.HashAlgorithm, ref.Digest.Value),
+			})
+		}
+	}
+	return result, nil
+}
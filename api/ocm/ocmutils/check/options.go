@@ -11,6 +11,7 @@ type Option = optionutils.Option[*Options]
 type Options struct {
 	CheckLocalResources *bool
 	CheckLocalSources   *bool
+	Rules               []Rule
 }
 
 var _ Option = (*Options)(nil)
@@ -18,6 +19,7 @@ var _ Option = (*Options)(nil)
 func (o *Options) ApplyTo(opts *Options) {
 	optionutils.ApplyOption(o.CheckLocalResources, &opts.CheckLocalResources)
 	optionutils.ApplyOption(o.CheckLocalSources, &opts.CheckLocalSources)
+	opts.Rules = append(opts.Rules, o.Rules...)
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -43,3 +45,17 @@ func LocalResourcesOnly(b ...bool) Option {
 func (l localResources) ApplyTo(t *Options) {
 	t.CheckLocalResources = optionutils.PointerTo(bool(l))
 }
+
+////////////////////////////////////////////////////////////////////////////////
+
+type rules []Rule
+
+// WithRules adds policy rules evaluated for all component versions
+// of the checked component graph.
+func WithRules(r ...Rule) Option {
+	return rules(r)
+}
+
+func (r rules) ApplyTo(t *Options) {
+	t.Rules = append(t.Rules, r...)
+}
@@ -0,0 +1,342 @@
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/oci"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/ociartifact"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	RULE_FORBIDDEN_ACCESS_TYPE = "forbidden-access-type"
+	RULE_DENIED_REGISTRY       = "denied-registry"
+	RULE_REQUIRED_LABEL        = "required-label"
+	RULE_REQUIRED_SIGNATURE    = "required-signature"
+	RULE_MAX_RESOURCE_SIZE     = "max-resource-size"
+	RULE_DENIED_DIGEST         = "denied-digest"
+)
+
+// Rule is a policy rule evaluated for every component version
+// of a checked component graph.
+type Rule interface {
+	// Name provides the name of the rule used to report violations.
+	Name() string
+	// Check evaluates the rule for a component version and provides
+	// the found violations.
+	Check(cv ocm.ComponentVersionAccess) ([]Violation, error)
+}
+
+// Violation describes a rule violation found for a component version.
+// If the violation is caused by an element (resource, source or
+// reference) of the component version, its kind and identity is given.
+type Violation struct {
+	Rule     string          `json:"rule"`
+	Kind     string          `json:"kind,omitempty"`
+	Identity metav1.Identity `json:"identity,omitempty"`
+	Message  string          `json:"message"`
+}
+
+func (v Violation) String() string {
+	if v.Kind == "" {
+		return fmt.Sprintf("%s: %s", v.Rule, v.Message)
+	}
+	return fmt.Sprintf("%s: %s %s: %s", v.Rule, v.Kind, v.Identity, v.Message)
+}
+
+// Violations lists the rule violations per component version.
+type Violations map[common.NameVersion][]Violation
+
+func (n Violations) MarshalJSON() ([]byte, error) {
+	m := map[string][]Violation{}
+	for k, v := range n {
+		m[k.String()] = v
+	}
+	return json.Marshal(m)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// ForbiddenAccessTypes provides a rule rejecting resources and sources
+// using one of the given access types. Access types given without version
+// match all versions of the type.
+func ForbiddenAccessTypes(types ...string) Rule {
+	return &forbiddenAccessTypes{types}
+}
+
+type forbiddenAccessTypes struct {
+	types []string
+}
+
+func (r *forbiddenAccessTypes) Name() string {
+	return RULE_FORBIDDEN_ACCESS_TYPE
+}
+
+func (r *forbiddenAccessTypes) Check(cv ocm.ComponentVersionAccess) ([]Violation, error) {
+	var result []Violation
+	cd := cv.GetDescriptor()
+	check := func(kind string, accessor compdesc.ElementListAccessor) {
+		for i := 0; i < accessor.Len(); i++ {
+			e := accessor.Get(i).(compdesc.ElementArtifactAccessor)
+			if e.GetAccess() == nil {
+				continue
+			}
+			typ := e.GetAccess().GetType()
+			k, _ := runtime.KindVersion(typ)
+			if slices.Contains(r.types, typ) || slices.Contains(r.types, k) {
+				result = append(result, Violation{
+					Rule:     r.Name(),
+					Kind:     kind,
+					Identity: e.GetMeta().GetIdentity(accessor),
+					Message:  fmt.Sprintf("access type %q is forbidden", typ),
+				})
+			}
+		}
+	}
+	check(ocm.KIND_RESOURCE, cd.Resources)
+	check(ocm.KIND_SOURCE, cd.Sources)
+	return result, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// DeniedRegistries provides a rule rejecting resources and sources
+// described by an OCI image reference (access type ociArtifact) located
+// in one of the given registries. The registries are given as host
+// patterns, which may contain shell wildcards (for example *.example.com).
+// A pattern without port matches the host for all ports.
+func DeniedRegistries(registries ...string) Rule {
+	return &deniedRegistries{registries}
+}
+
+type deniedRegistries struct {
+	registries []string
+}
+
+func (r *deniedRegistries) Name() string {
+	return RULE_DENIED_REGISTRY
+}
+
+func (r *deniedRegistries) Check(cv ocm.ComponentVersionAccess) ([]Violation, error) {
+	var result []Violation
+	list := errors.ErrorList{}
+	cd := cv.GetDescriptor()
+	check := func(kind string, accessor compdesc.ElementListAccessor) {
+		for i := 0; i < accessor.Len(); i++ {
+			e := accessor.Get(i).(compdesc.ElementArtifactAccessor)
+			if e.GetAccess() == nil {
+				continue
+			}
+			spec, err := cv.GetContext().AccessSpecForSpec(e.GetAccess())
+			if err != nil {
+				list.Add(err)
+				continue
+			}
+			o, ok := spec.(*ociartifact.AccessSpec)
+			if !ok || !ociartifact.Is(o) {
+				continue
+			}
+			ref, err := oci.ParseRef(o.ImageReference)
+			if err != nil {
+				list.Add(errors.Wrapf(err, "%s %s", kind, e.GetMeta().GetIdentity(accessor)))
+				continue
+			}
+			if r.denied(ref.Host) {
+				result = append(result, Violation{
+					Rule:     r.Name(),
+					Kind:     kind,
+					Identity: e.GetMeta().GetIdentity(accessor),
+					Message:  fmt.Sprintf("registry %q is denied", ref.Host),
+				})
+			}
+		}
+	}
+	check(ocm.KIND_RESOURCE, cd.Resources)
+	check(ocm.KIND_SOURCE, cd.Sources)
+	return result, list.Result()
+}
+
+func (r *deniedRegistries) denied(host string) bool {
+	hostname, _, _ := strings.Cut(host, ":")
+	for _, p := range r.registries {
+		if ok, _ := path.Match(p, host); ok {
+			return true
+		}
+		if !strings.Contains(p, ":") {
+			if ok, _ := path.Match(p, hostname); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// RequiredLabels provides a rule requiring the given labels for component
+// versions.
+func RequiredLabels(names ...string) Rule {
+	return &requiredLabels{names: names}
+}
+
+// RequiredResourceLabels provides a rule requiring the given labels for all
+// resources of component versions.
+func RequiredResourceLabels(names ...string) Rule {
+	return &requiredLabels{names: names, resources: true}
+}
+
+type requiredLabels struct {
+	names     []string
+	resources bool
+}
+
+func (r *requiredLabels) Name() string {
+	return RULE_REQUIRED_LABEL
+}
+
+func (r *requiredLabels) Check(cv ocm.ComponentVersionAccess) ([]Violation, error) {
+	var result []Violation
+	cd := cv.GetDescriptor()
+	if !r.resources {
+		for _, n := range r.names {
+			if cd.Labels.GetIndex(n) < 0 {
+				result = append(result, Violation{
+					Rule:    r.Name(),
+					Message: fmt.Sprintf("label %q missing", n),
+				})
+			}
+		}
+		return result, nil
+	}
+	for i := range cd.Resources {
+		res := &cd.Resources[i]
+		for _, n := range r.names {
+			if res.Labels.GetIndex(n) < 0 {
+				result = append(result, Violation{
+					Rule:     r.Name(),
+					Kind:     ocm.KIND_RESOURCE,
+					Identity: res.GetIdentity(cd.Resources),
+					Message:  fmt.Sprintf("label %q missing", n),
+				})
+			}
+		}
+	}
+	return result, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// RequiredSignatures provides a rule requiring signatures with the given
+// names for component versions. If no name is given, at least one signature
+// is required. The rule only checks the presence of the signatures,
+// they are not verified.
+func RequiredSignatures(names ...string) Rule {
+	return &requiredSignatures{names}
+}
+
+type requiredSignatures struct {
+	names []string
+}
+
+func (r *requiredSignatures) Name() string {
+	return RULE_REQUIRED_SIGNATURE
+}
+
+func (r *requiredSignatures) Check(cv ocm.ComponentVersionAccess) ([]Violation, error) {
+	var result []Violation
+	cd := cv.GetDescriptor()
+	if len(r.names) == 0 {
+		if len(cd.Signatures) == 0 {
+			result = append(result, Violation{
+				Rule:    r.Name(),
+				Message: "no signature found",
+			})
+		}
+		return result, nil
+	}
+	for _, n := range r.names {
+		if cd.GetSignatureIndex(n) < 0 {
+			result = append(result, Violation{
+				Rule:    r.Name(),
+				Message: fmt.Sprintf("signature %q missing", n),
+			})
+		}
+	}
+	return result, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// MaxResourceSize provides a rule rejecting resources with a blob size
+// larger than the given number of bytes. If the size is not provided by
+// the access method, the resource content is read to determine its size.
+func MaxResourceSize(size int64) Rule {
+	return &maxResourceSize{size}
+}
+
+type maxResourceSize struct {
+	size int64
+}
+
+func (r *maxResourceSize) Name() string {
+	return RULE_MAX_RESOURCE_SIZE
+}
+
+func (r *maxResourceSize) Check(cv ocm.ComponentVersionAccess) ([]Violation, error) {
+	var result []Violation
+	list := errors.ErrorList{}
+	cd := cv.GetDescriptor()
+	for i := range cd.Resources {
+		res := &cd.Resources[i]
+		id := res.GetIdentity(cd.Resources)
+		size, err := resourceSize(cv, i)
+		if err != nil {
+			list.Add(errors.Wrapf(err, "%s %s", ocm.KIND_RESOURCE, id))
+			continue
+		}
+		if size > r.size {
+			result = append(result, Violation{
+				Rule:     r.Name(),
+				Kind:     ocm.KIND_RESOURCE,
+				Identity: id,
+				Message:  fmt.Sprintf("size %d exceeds limit %d", size, r.size),
+			})
+		}
+	}
+	return result, list.Result()
+}
+
+func resourceSize(cv ocm.ComponentVersionAccess, i int) (int64, error) {
+	ra, err := cv.GetResourceByIndex(i)
+	if err != nil {
+		return 0, err
+	}
+	m, err := ra.AccessMethod()
+	if err != nil {
+		return 0, err
+	}
+	defer m.Close()
+
+	size := m.AsBlobAccess().Size()
+	if size != blobaccess.BLOB_UNKNOWN_SIZE {
+		return size, nil
+	}
+	reader, err := m.Reader()
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+	return io.Copy(io.Discard, reader)
+}
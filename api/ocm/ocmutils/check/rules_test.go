@@ -0,0 +1,120 @@
+package check_test
+
+import (
+	"encoding/json"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+	. "ocm.software/ocm/api/ocm/testhelper"
+
+	"ocm.software/ocm/api/ocm"
+	v1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/ociartifact"
+	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/ocmutils/check"
+	"ocm.software/ocm/api/utils/accessio"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("rules", func() {
+	var env *Builder
+	var repo ocm.Repository
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMP, VERSION, func() {
+				env.Reference("ref", COMP2, VERSION)
+				env.Resource("image", VERSION, resourcetypes.OCI_IMAGE, v1.ExternalRelation, func() {
+					env.ModificationOptions(ocm.SkipDigest())
+					env.Access(ociartifact.New("ghcr.io/acme/nginx:1.0"))
+				})
+			})
+			env.ComponentVersion(COMP2, VERSION, func() {
+				env.Label("owner", "acme")
+				TestDataResource(env)
+			})
+		})
+		spec := Must(ctf.NewRepositorySpec(ctf.ACC_READONLY, ARCH, env))
+		repo = Must(env.OCMContext().RepositoryForSpec(spec))
+	})
+
+	AfterEach(func() {
+		MustBeSuccessful(repo.Close())
+		env.Cleanup()
+	})
+
+	It("finds forbidden access types and registries", func() {
+		result := Must(check.Check(check.WithRules(
+			check.ForbiddenAccessTypes(ociartifact.Type),
+			check.DeniedRegistries("*.io"),
+		)).ForId(repo, common.NewNameVersion(COMP, VERSION)))
+		Expect(json.Marshal(result)).To(YAMLEqual(`
+violations:
+  test.de/x:v1:
+  - rule: forbidden-access-type
+    kind: component resource
+    identity:
+      name: image
+    message: access type "ociArtifact" is forbidden
+  - rule: denied-registry
+    kind: component resource
+    identity:
+      name: image
+    message: registry "ghcr.io" is denied
+`))
+	})
+
+	It("accepts allowed registries", func() {
+		result := Must(check.Check(check.WithRules(
+			check.DeniedRegistries("docker.io", "ghcr.io:8080"),
+		)).ForId(repo, common.NewNameVersion(COMP, VERSION)))
+		Expect(result).To(BeNil())
+	})
+
+	It("finds missing labels and signatures over the component graph", func() {
+		result := Must(check.Check(check.WithRules(
+			check.RequiredLabels("owner"),
+			check.RequiredSignatures(),
+		)).ForId(repo, common.NewNameVersion(COMP, VERSION)))
+		Expect(json.Marshal(result)).To(YAMLEqual(`
+violations:
+  test.de/x:v1:
+  - rule: required-label
+    message: label "owner" missing
+  - rule: required-signature
+    message: no signature found
+  test.de/y:v1:
+  - rule: required-signature
+    message: no signature found
+`))
+	})
+
+	It("finds large resources and denied digests", func() {
+		list := Must(check.ParseDigestDenyList([]byte(`
+# known bad artifacts
+sha256:` + D_TESTDATA + `
+`)))
+		result := Must(check.Check(check.WithRules(
+			check.MaxResourceSize(4),
+			check.DeniedDigests(list),
+		)).ForId(repo, common.NewNameVersion(COMP2, VERSION)))
+		Expect(json.Marshal(result)).To(YAMLEqual(`
+violations:
+  test.de/y:v1:
+  - rule: max-resource-size
+    kind: component resource
+    identity:
+      name: testdata
+    message: size 8 exceeds limit 4
+  - rule: denied-digest
+    kind: component resource
+    identity:
+      name: testdata
+    message: digest SHA-256:` + D_TESTDATA + ` is denied
+`))
+	})
+})
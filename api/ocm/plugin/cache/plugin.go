@@ -155,6 +155,80 @@ func (p *pluginImpl) GetAccessMethodDescriptor(name, version string) *descriptor
 	return nil
 }
 
+func (p *pluginImpl) GetCredentialRepositoryDescriptor(name, version string) *descriptor.CredentialRepositoryDescriptor {
+	if !p.IsValid() {
+		return nil
+	}
+
+	var fallback descriptor.CredentialRepositoryDescriptor
+	fallbackFound := false
+	for _, r := range p.descriptor.CredentialRepositories {
+		if r.Name == name {
+			if r.Version == version {
+				return &r
+			}
+			if r.Version == "" || r.Version == "v1" {
+				fallback = r
+				fallbackFound = true
+			}
+		}
+	}
+	if fallbackFound && (version == "" || version == "v1") {
+		return &fallback
+	}
+	return nil
+}
+
+func (p *pluginImpl) GetPubSubTypeDescriptor(name, version string) *descriptor.PubSubTypeDescriptor {
+	if !p.IsValid() {
+		return nil
+	}
+
+	var fallback descriptor.PubSubTypeDescriptor
+	fallbackFound := false
+	for _, t := range p.descriptor.PubSubTypes {
+		if t.Name == name {
+			if t.Version == version {
+				return &t
+			}
+			if t.Version == "" || t.Version == "v1" {
+				fallback = t
+				fallbackFound = true
+			}
+		}
+	}
+	if fallbackFound && (version == "" || version == "v1") {
+		return &fallback
+	}
+	return nil
+}
+
+func (p *pluginImpl) GetSigningHandlerDescriptor(name string) *descriptor.SigningHandlerDescriptor {
+	if !p.IsValid() {
+		return nil
+	}
+
+	for _, h := range p.descriptor.SigningHandlers {
+		if h.Name == name {
+			return &h
+		}
+	}
+	return nil
+}
+
+func (p *pluginImpl) GetInputTypeDescriptor(name string) *descriptor.InputTypeDescriptor {
+	if !p.IsValid() {
+		return nil
+	}
+
+	for _, t := range p.descriptor.InputTypes {
+		if t.Name == name {
+			return &t
+		}
+	}
+	return nil
+}
+
 func (p *pluginImpl) GetValueSetDescriptor(purpose, name, version string) *descriptor.ValueSetDescriptor {
 	if !p.IsValid() {
 		return nil
@@ -40,6 +40,26 @@ func DescribePluginDescriptorCapabilities(reg api.ActionTypeRegistry, d *descrip
 		out.Printf("Access Methods:\n")
 		DescribeAccessMethods(d, out)
 	}
+	if len(d.CredentialRepositories) > 0 {
+		out.Printf("\n")
+		out.Printf("Credential Repositories:\n")
+		DescribeCredentialRepositories(d, out)
+	}
+	if len(d.PubSubTypes) > 0 {
+		out.Printf("\n")
+		out.Printf("PubSub Types:\n")
+		DescribePubSubTypes(d, out)
+	}
+	if len(d.SigningHandlers) > 0 {
+		out.Printf("\n")
+		out.Printf("Signing Handlers:\n")
+		DescribeSigningHandlers(d, out)
+	}
+	if len(d.InputTypes) > 0 {
+		out.Printf("\n")
+		out.Printf("Input Types:\n")
+		DescribeInputTypes(d, out)
+	}
 	if len(d.Uploaders) > 0 {
 		out.Printf("\n")
 		// a working type inference would be really great
@@ -285,6 +305,62 @@ func DescribeValueMergeHandlers(d *descriptor.Descriptor, out common.Printer) {
 	}
 }
 
+func DescribeSigningHandlers(d *descriptor.Descriptor, out common.Printer) {
+	handlers := map[string]descriptor.SigningHandlerDescriptor{}
+	for _, h := range d.SigningHandlers {
+		handlers[h.GetName()] = h
+	}
+
+	for _, n := range utils.StringMapKeys(handlers) {
+		h := handlers[n]
+		out.Printf("- Algorithm: %s\n", n)
+		if h.Description != "" {
+			out.Printf("%s\n", utils.IndentLines(h.Description, "    "))
+		}
+		if h.ConsumerType != "" {
+			out.Printf("  Consumer type: %s\n", h.ConsumerType)
+		}
+	}
+}
+
+func DescribeInputTypes(d *descriptor.Descriptor, out common.Printer) {
+	types := map[string]descriptor.InputTypeDescriptor{}
+	for _, t := range d.InputTypes {
+		types[t.GetName()] = t
+	}
+
+	for _, n := range utils.StringMapKeys(types) {
+		t := types[n]
+		out.Printf("- Name: %s\n", n)
+		if t.Description != "" {
+			out.Printf("%s\n", utils.IndentLines(t.Description, "    "))
+		}
+		out := out.AddGap("  ")
+		if t.Format != "" {
+			out.Printf("%s\n", t.Format)
+		}
+		opts := map[string]options.OptionType{}
+		for _, o := range t.CLIOptions {
+			if o.Name == "" {
+				continue
+			}
+			opt := options.DefaultRegistry.GetOptionType(o.Name)
+			if opt == nil {
+				ot, err := options.DefaultRegistry.CreateOptionType(o.Type, o.Name, o.Description)
+				if err != nil {
+					continue
+				}
+				opt = ot
+			}
+			opts[opt.GetName()] = opt
+		}
+		if len(opts) > 0 {
+			out.Printf("Command Line Options:")
+			out.Printf("%s\n", utils.FormatMap("", opts))
+		}
+	}
+}
+
 func DescribeLabelMergeSpecifications(d *descriptor.Descriptor, out common.Printer) {
 	handlers := map[string]descriptor.LabelMergeSpecification{}
 	for _, h := range d.LabelMergeSpecifications {
@@ -496,8 +572,26 @@ func GetTypeInfo(types []descriptor.ConfigTypeDescriptor) map[string]*TypeInfo {
 }
 
 func DescribeConfigTypes(d *descriptor.Descriptor, out common.Printer) {
-	types := GetTypeInfo(d.ConfigTypes)
+	describeTypes(GetTypeInfo(d.ConfigTypes), out)
+}
+
+func DescribeCredentialRepositories(d *descriptor.Descriptor, out common.Printer) {
+	var types []descriptor.ValueTypeDefinition
+	for _, r := range d.CredentialRepositories {
+		types = append(types, r.ValueTypeDefinition)
+	}
+	describeTypes(GetTypeInfo(types), out)
+}
+
+func DescribePubSubTypes(d *descriptor.Descriptor, out common.Printer) {
+	var types []descriptor.ValueTypeDefinition
+	for _, t := range d.PubSubTypes {
+		types = append(types, t.ValueTypeDefinition)
+	}
+	describeTypes(GetTypeInfo(types), out)
+}
 
+func describeTypes(types map[string]*TypeInfo, out common.Printer) {
 	for _, n := range utils.StringMapKeys(types) {
 		out.Printf("- Name: %s\n", n)
 		m := types[n]
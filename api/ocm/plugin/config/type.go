@@ -24,6 +24,20 @@ type Config struct {
 	Plugin                      string          `json:"plugin"`
 	Config                      json.RawMessage `json:"config,omitempty"`
 	DisableAutoRegistration     bool            `json:"disableAutoRegistration,omitempty"`
+	ServerMode                  *ServerMode     `json:"serverMode,omitempty"`
+}
+
+// ServerMode configures the usage of the persistent server mode
+// for plugins supporting it.
+type ServerMode struct {
+	// Disabled disables the server mode. Every plugin
+	// request is executed by a new plugin process.
+	Disabled bool `json:"disabled,omitempty"`
+	// Instances is the maximum number of plugin processes
+	// used in parallel for a context.
+	Instances int `json:"instances,omitempty"`
+	// Transport enforces the usage of a dedicated transport (stdio or unix).
+	Transport string `json:"transport,omitempty"`
 }
 
 // New creates a new memory ConfigSpec.
@@ -46,12 +60,14 @@ func (a *Config) ApplyTo(ctx config.Context, target interface{}) error {
 	}
 	t.ConfigurePlugin(a.Plugin, a.Config)
 	t.DisableAutoConfiguration(a.Plugin, a.DisableAutoRegistration)
+	t.ConfigureServerMode(a.Plugin, a.ServerMode)
 	return nil
 }
 
 type Target interface {
 	ConfigurePlugin(name string, config json.RawMessage)
 	DisableAutoConfiguration(name string, flag bool)
+	ConfigureServerMode(name string, mode *ServerMode)
 }
 
 const usage = `
@@ -63,5 +79,20 @@ plugin.
     plugin: &lt;plugin name>
     config: &lt;arbitrary configuration structure>
     disableAutoRegistration: &lt;boolean flag to disable auto registration for up- and download handlers>
+    serverMode:
+      disabled: &lt;boolean flag to disable the persistent server mode>
+      instances: &lt;maximum number of plugin processes used in parallel (default 4)>
+      transport: &lt;enforced transport (stdio or unix)>
 </pre>
+
+If a plugin supports a persistent server mode, a pool of long-running
+plugin processes is used to serve the plugin requests of a context
+instead of starting a new process for every request.
+
+Every plugin process executes only one request at a time, the processes
+are just reused for subsequent requests. Requests executed in parallel
+use different processes, up to the configured number of instances.
+Further requests wait for a free process. The processes are stopped when
+the context is finalized, for the command line client at the end of
+a command.
 `
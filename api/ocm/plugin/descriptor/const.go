@@ -14,6 +14,19 @@ const (
 	KIND_ACTION       = action.KIND_ACTION
 	KIND_VALUESET     = "value set"
 	KIND_PURPOSE      = "purposet"
+
+	KIND_CREDENTIALREPOSITORY = "credential repository"
+	KIND_SIGNINGHANDLER       = "signing handler"
+	KIND_INPUTTYPE            = "input type"
+	KIND_PUBSUBTYPE           = "pub/sub type"
+)
+
+const (
+	// TRANSPORT_STDIO serves plugin requests over the standard input and output
+	// of the plugin process.
+	TRANSPORT_STDIO = "stdio"
+	// TRANSPORT_UNIX serves plugin requests over a unix domain socket.
+	TRANSPORT_UNIX = "unix"
 )
 
 var REALM = ocmlog.DefineSubRealm("OCM plugin handling", "plugins")
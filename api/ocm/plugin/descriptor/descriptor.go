@@ -2,6 +2,7 @@ package descriptor
 
 import (
 	"encoding/json"
+	"slices"
 
 	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 )
@@ -16,8 +17,16 @@ type Descriptor struct {
 	Long           string `json:"description"`
 	ForwardLogging bool   `json:"forwardLogging"`
 
+	// Server describes the support of a persistent server mode.
+	// If set, the plugin can serve multiple requests with a single process.
+	Server *ServerDescriptor `json:"server,omitempty"`
+
 	Actions                  []ActionDescriptor                `json:"actions,omitempty"`
 	AccessMethods            []AccessMethodDescriptor          `json:"accessMethods,omitempty"`
+	CredentialRepositories   []CredentialRepositoryDescriptor  `json:"credentialRepositories,omitempty"`
+	SigningHandlers          List[SigningHandlerDescriptor]    `json:"signingHandlers,omitempty"`
+	InputTypes               List[InputTypeDescriptor]         `json:"inputTypes,omitempty"`
+	PubSubTypes              []PubSubTypeDescriptor            `json:"pubsubTypes,omitempty"`
 	Uploaders                List[UploaderDescriptor]          `json:"uploaders,omitempty"`
 	Downloaders              List[DownloaderDescriptor]        `json:"downloaders,omitempty"`
 	ValueMergeHandlers       List[ValueMergeHandlerDescriptor] `json:"valueMergeHandlers,omitempty"`
@@ -34,6 +43,18 @@ func (d *Descriptor) Capabilities() []string {
 	if len(d.AccessMethods) > 0 {
 		caps = append(caps, "Access Methods")
 	}
+	if len(d.CredentialRepositories) > 0 {
+		caps = append(caps, "Credential Repositories")
+	}
+	if len(d.SigningHandlers) > 0 {
+		caps = append(caps, "Signing Handlers")
+	}
+	if len(d.InputTypes) > 0 {
+		caps = append(caps, "Input Types")
+	}
+	if len(d.PubSubTypes) > 0 {
+		caps = append(caps, "PubSub Types")
+	}
 	if len(d.Uploaders) > 0 {
 		caps = append(caps, "Repository Uploaders")
 	}
@@ -58,11 +79,30 @@ func (d *Descriptor) Capabilities() []string {
 	if len(d.ConfigTypes) > 0 {
 		caps = append(caps, "Config Types")
 	}
+	if d.Server != nil {
+		caps = append(caps, "Server Mode")
+	}
 	return caps
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 
+// ServerDescriptor describes the persistent server mode of a plugin.
+type ServerDescriptor struct {
+	// Transports lists the supported transports (stdio or unix).
+	// If empty, stdio is used.
+	Transports []string `json:"transports,omitempty"`
+}
+
+func (d *ServerDescriptor) SupportsTransport(t string) bool {
+	if len(d.Transports) == 0 {
+		return t == TRANSPORT_STDIO
+	}
+	return slices.Contains(d.Transports, t)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type DownloaderKey = ArtifactContext
 
 func NewDownloaderKey(arttype, mediatype string) DownloaderKey {
@@ -121,6 +161,39 @@ type AccessMethodDescriptor struct {
 	ValueSetDefinition `json:",inline"`
 }
 
+// CredentialRepositoryDescriptor describes a credential repository
+// type provided by a plugin.
+type CredentialRepositoryDescriptor struct {
+	ValueTypeDefinition `json:",inline"`
+}
+
+// SigningHandlerDescriptor describes a signing algorithm provided by a plugin.
+type SigningHandlerDescriptor struct {
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	ConsumerType string `json:"consumerType,omitempty"`
+}
+
+func (d SigningHandlerDescriptor) GetName() string {
+	return d.Name
+}
+
+func (d SigningHandlerDescriptor) GetDescription() string {
+	return d.Description
+}
+
+// PubSubTypeDescriptor describes a publish/subscribe
+// specification type provided by a plugin.
+type PubSubTypeDescriptor struct {
+	ValueTypeDefinition `json:",inline"`
+}
+
+// InputTypeDescriptor describes a resource or source input type
+// provided by a plugin for the composition of component versions.
+type InputTypeDescriptor struct {
+	ValueSetDefinition `json:",inline"`
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type ValueTypeDefinition struct {
@@ -10,6 +10,11 @@ const (
 	KIND_UPLOADER     = descriptor.KIND_UPLOADER
 	KIND_ACCESSMETHOD = descriptor.KIND_ACCESSMETHOD
 	KIND_ACTION       = descriptor.KIND_ACTION
+
+	KIND_CREDENTIALREPOSITORY = descriptor.KIND_CREDENTIALREPOSITORY
+	KIND_SIGNINGHANDLER       = descriptor.KIND_SIGNINGHANDLER
+	KIND_INPUTTYPE            = descriptor.KIND_INPUTTYPE
+	KIND_PUBSUBTYPE           = descriptor.KIND_PUBSUBTYPE
 )
 
 var TAG = descriptor.REALM
@@ -30,4 +35,18 @@ type (
 
 	AccessSpecInfo       = internal.AccessSpecInfo
 	UploadTargetSpecInfo = internal.UploadTargetSpecInfo
+
+	CredentialRepositoryDescriptor = descriptor.CredentialRepositoryDescriptor
+	CredentialRepositorySpecInfo   = internal.CredentialRepositorySpecInfo
+
+	SigningHandlerDescriptor = descriptor.SigningHandlerDescriptor
+	SigningRequest           = internal.SigningRequest
+	VerificationRequest      = internal.VerificationRequest
+	Signature                = internal.Signature
+
+	InputTypeDescriptor = descriptor.InputTypeDescriptor
+	InputSpecInfo       = internal.InputSpecInfo
+
+	PubSubTypeDescriptor = descriptor.PubSubTypeDescriptor
+	PubSubSpecInfo       = internal.PubSubSpecInfo
 )
@@ -0,0 +1,10 @@
+package internal
+
+import (
+	"ocm.software/ocm/api/credentials"
+)
+
+type CredentialRepositorySpecInfo struct {
+	Short       string                         `json:"description"`
+	ConsumerIds []credentials.ConsumerIdentity `json:"consumerIds,omitempty"`
+}
@@ -0,0 +1,12 @@
+package internal
+
+// InputSpecInfo is the result of the validation of an
+// input specification by a plugin based input type.
+type InputSpecInfo struct {
+	// Short is a short textual description of the described input.
+	Short string `json:"short"`
+	// MediaType is the media type of the blob provided by the input.
+	MediaType string `json:"mediaType"`
+	// Hint is an optional reference hint for the provided blob.
+	Hint string `json:"hint"`
+}
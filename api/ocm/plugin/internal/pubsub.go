@@ -0,0 +1,15 @@
+package internal
+
+import (
+	"ocm.software/ocm/api/credentials"
+)
+
+// PubSubSpecInfo is the result of the validation of a
+// pub/sub specification by a plugin based pub/sub type.
+type PubSubSpecInfo struct {
+	// Short is a short textual description of the described pub/sub system.
+	Short string `json:"description"`
+	// ConsumerId is the optional consumer id used to look up the
+	// credentials passed to the notification.
+	ConsumerId credentials.ConsumerIdentity `json:"consumerId,omitempty"`
+}
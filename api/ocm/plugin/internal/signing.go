@@ -0,0 +1,53 @@
+package internal
+
+import (
+	"ocm.software/ocm/api/credentials"
+)
+
+// SigningRequest is passed on stdin to the sign command
+// of a plugin based signing handler.
+// Keys and certificates are passed in the form configured for the
+// OCM CLI, typically PEM encoded. Keys configured as objects
+// are converted to PEM.
+type SigningRequest struct {
+	// Digest is the hex encoded digest to sign.
+	Digest string `json:"digest"`
+	// Hash is the name of the hash algorithm used to calculate the digest.
+	Hash string `json:"hash,omitempty"`
+	// PrivateKey is the key (or key reference) configured for signing.
+	PrivateKey []byte `json:"privateKey,omitempty"`
+	// PublicKey is an optional public key or certificate (chain).
+	PublicKey []byte `json:"publicKey,omitempty"`
+	// RootCerts are optional root certificates.
+	RootCerts []byte `json:"rootCerts,omitempty"`
+	// Issuer is the optional expected issuer of the signature.
+	Issuer string `json:"issuer,omitempty"`
+	// Credentials are the credentials found for the consumer type
+	// declared by the signing handler.
+	Credentials credentials.DirectCredentials `json:"credentials,omitempty"`
+}
+
+// VerificationRequest is passed on stdin to the verify command
+// of a plugin based signing handler.
+type VerificationRequest struct {
+	// Digest is the hex encoded digest the signature should be verified for.
+	Digest string `json:"digest"`
+	// Hash is the name of the hash algorithm used to calculate the digest.
+	Hash string `json:"hash,omitempty"`
+	// Signature is the signature to verify.
+	Signature Signature `json:"signature"`
+	// PublicKey is the public key or certificate (chain) configured for verification.
+	PublicKey []byte `json:"publicKey,omitempty"`
+	// RootCerts are optional root certificates.
+	RootCerts []byte `json:"rootCerts,omitempty"`
+	// Issuer is the optional expected issuer of the signature.
+	Issuer string `json:"issuer,omitempty"`
+}
+
+// Signature is a signature created by a plugin based signing handler.
+type Signature struct {
+	Value     string `json:"value"`
+	MediaType string `json:"mediaType"`
+	Algorithm string `json:"algorithm,omitempty"`
+	Issuer    string `json:"issuer,omitempty"`
+}
@@ -18,6 +18,7 @@ import (
 	"ocm.software/ocm/api/datacontext/attrs/clicfgattr"
 	"ocm.software/ocm/api/ocm"
 	"ocm.software/ocm/api/ocm/plugin/cache"
+	"ocm.software/ocm/api/ocm/plugin/config"
 	"ocm.software/ocm/api/ocm/plugin/ppi"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/accessmethod"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/accessmethod/compose"
@@ -26,9 +27,22 @@ import (
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/action"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/action/execute"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/command"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/credentialrepository"
+	credget "ocm.software/ocm/api/ocm/plugin/ppi/cmds/credentialrepository/get"
+	credval "ocm.software/ocm/api/ocm/plugin/ppi/cmds/credentialrepository/validate"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/download"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/input"
+	inpcompose "ocm.software/ocm/api/ocm/plugin/ppi/cmds/input/compose"
+	inpget "ocm.software/ocm/api/ocm/plugin/ppi/cmds/input/get"
+	inpval "ocm.software/ocm/api/ocm/plugin/ppi/cmds/input/validate"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/mergehandler"
 	merge "ocm.software/ocm/api/ocm/plugin/ppi/cmds/mergehandler/execute"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/pubsub"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/pubsub/notify"
+	psval "ocm.software/ocm/api/ocm/plugin/ppi/cmds/pubsub/validate"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/signing"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/signing/sign"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/signing/verify"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/upload"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/upload/put"
 	uplval "ocm.software/ocm/api/ocm/plugin/ppi/cmds/upload/validate"
@@ -38,6 +52,7 @@ import (
 	"ocm.software/ocm/api/ocm/valuemergehandler"
 	"ocm.software/ocm/api/utils/cobrautils/flagsets"
 	"ocm.software/ocm/api/utils/cobrautils/logopts/logging"
+	common "ocm.software/ocm/api/utils/misc"
 	"ocm.software/ocm/api/utils/runtime"
 )
 
@@ -52,6 +67,8 @@ type pluginImpl struct {
 	impl
 	config                   json.RawMessage
 	disableAutoConfiguration bool
+	serverMode               *config.ServerMode
+	servers                  *serverPool
 }
 
 func NewPlugin(ctx ocm.Context, impl cache.Plugin, config json.RawMessage) Plugin {
@@ -80,6 +97,59 @@ func (p *pluginImpl) SetConfig(config json.RawMessage) {
 	p.config = config
 }
 
+// SetServerMode configures the usage of the persistent server mode.
+// Running plugin servers are stopped.
+func (p *pluginImpl) SetServerMode(mode *config.ServerMode) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.serverMode = mode
+	if p.servers != nil {
+		p.servers.Close()
+		p.servers = nil
+	}
+}
+
+// Close stops the plugin servers started for the plugin.
+func (p *pluginImpl) Close() error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.servers == nil {
+		return nil
+	}
+	err := p.servers.Close()
+	p.servers = nil
+	return err
+}
+
+func (p *pluginImpl) getServerPool() *serverPool {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if p.servers == nil {
+		transport := selectTransport(p.GetDescriptor().Server, p.serverMode)
+		if transport == "" {
+			return nil
+		}
+		instances := 0
+		if p.serverMode != nil {
+			instances = p.serverMode.Instances
+		}
+		p.servers = newServerPool(p, transport, instances)
+		p.ctx.Finalizer().Close(p)
+	}
+	return p.servers
+}
+
+func (p *pluginImpl) execute(r io.Reader, w io.Writer, args ...string) ([]byte, error) {
+	if pool := p.getServerPool(); pool != nil {
+		ok, data, err := pool.Exec(p.config, r, w, args...)
+		if ok {
+			return data, err
+		}
+	}
+	return cache.Exec(p.Path(), p.config, r, w, args...)
+}
+
 func (p *pluginImpl) Exec(r io.Reader, w io.Writer, args ...string) (result []byte, rerr error) {
 	var (
 		finalize finalizer.Finalizer
@@ -117,7 +187,7 @@ func (p *pluginImpl) Exec(r io.Reader, w io.Writer, args ...string) (result []by
 	} else {
 		p.ctx.Logger(TAG).Debug("execute plugin action", "path", p.Path(), "args", args, "config", p.config)
 	}
-	data, err := cache.Exec(p.Path(), p.config, r, w, args...)
+	data, err := p.execute(r, w, args...)
 
 	if logfile != nil {
 		r, oerr := os.OpenFile(logfile.Name(), vfs.O_RDONLY, 0o600)
@@ -270,6 +340,181 @@ func (p *pluginImpl) ComposeAccessMethod(name string, opts flagsets.ConfigOption
 	return nil
 }
 
+func (p *pluginImpl) ValidateCredentialRepository(spec []byte) (*ppi.CredentialRepositorySpecInfo, error) {
+	result, err := p.Exec(nil, nil, credentialrepository.Name, credval.Name, string(spec))
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s", p.Name())
+	}
+
+	var info ppi.CredentialRepositorySpecInfo
+	err = json.Unmarshal(result, &info)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s: cannot unmarshal credential repository info", p.Name())
+	}
+	return &info, nil
+}
+
+// GetCredentials requests the credentials with the given name or, if no name is
+// given, for the given consumer id from a plugin based credential repository.
+// If no credentials are found, nil is returned.
+func (p *pluginImpl) GetCredentials(spec []byte, name string, consumer credentials.ConsumerIdentity) (credentials.DirectCredentials, error) {
+	args := []string{credentialrepository.Name, credget.Name, string(spec)}
+	if name != "" {
+		args = append(args, name)
+	}
+	if len(consumer) > 0 {
+		data, err := json.Marshal(consumer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "cannot marshal consumer id")
+		}
+		args = append(args, "--"+credget.OptConsumer, string(data))
+	}
+	result, err := p.Exec(nil, nil, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s", p.Name())
+	}
+
+	var creds credentials.DirectCredentials
+	err = json.Unmarshal(result, &creds)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s: cannot unmarshal credentials", p.Name())
+	}
+	return creds, nil
+}
+
+// Sign signs the digest of the given request with the plugin
+// based signing algorithm with the given name.
+func (p *pluginImpl) Sign(name string, req *ppi.SigningRequest) (*ppi.Signature, error) {
+	if p.GetSigningHandlerDescriptor(name) == nil {
+		return nil, errors.ErrNotSupported(KIND_SIGNINGHANDLER, name, KIND_PLUGIN, p.Name())
+	}
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot marshal signing request")
+	}
+
+	result, err := p.Exec(bytes.NewReader(input), nil, signing.Name, sign.Name, name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s", p.Name())
+	}
+
+	var sig ppi.Signature
+	err = json.Unmarshal(result, &sig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s: cannot unmarshal signature", p.Name())
+	}
+	return &sig, nil
+}
+
+// Verify verifies a signature with the plugin based
+// signing algorithm with the given name.
+func (p *pluginImpl) Verify(name string, req *ppi.VerificationRequest) error {
+	if p.GetSigningHandlerDescriptor(name) == nil {
+		return errors.ErrNotSupported(KIND_SIGNINGHANDLER, name, KIND_PLUGIN, p.Name())
+	}
+	input, err := json.Marshal(req)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal verification request")
+	}
+
+	_, err = p.Exec(bytes.NewReader(input), nil, signing.Name, verify.Name, name)
+	if err != nil {
+		return errors.Wrapf(err, "plugin %s", p.Name())
+	}
+	return nil
+}
+
+// ValidateInput validates an input specification for a plugin based
+// input type. Relative paths are resolved relative to the given directory.
+func (p *pluginImpl) ValidateInput(dir string, spec []byte) (*ppi.InputSpecInfo, error) {
+	args := []string{input.Name, inpval.Name, string(spec)}
+	if dir != "" {
+		args = append(args, "--"+inpval.OptDir, dir)
+	}
+	result, err := p.Exec(nil, nil, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s", p.Name())
+	}
+
+	var info ppi.InputSpecInfo
+	err = json.Unmarshal(result, &info)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s: cannot unmarshal input spec info", p.Name())
+	}
+	return &info, nil
+}
+
+// GetInput writes the blob described by an input specification
+// for a plugin based input type to the given writer.
+func (p *pluginImpl) GetInput(w io.Writer, dir string, spec json.RawMessage) error {
+	args := []string{input.Name, inpget.Name, string(spec)}
+	if dir != "" {
+		args = append(args, "--"+inpget.OptDir, dir)
+	}
+	_, err := p.Exec(nil, w, args...)
+	return err
+}
+
+func (p *pluginImpl) ComposeInput(name string, opts flagsets.ConfigOptions, base flagsets.Config) error {
+	cfg := flagsets.Config{}
+	for _, o := range opts.Options() {
+		cfg[o.GetName()] = o.Value()
+	}
+	optsdata, err := json.Marshal(cfg)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal option values")
+	}
+	basedata, err := json.Marshal(base)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal input specification base value")
+	}
+	result, err := p.Exec(nil, nil, input.Name, inpcompose.Name, name, string(optsdata), string(basedata))
+	if err != nil {
+		return err
+	}
+	var r flagsets.Config
+	err = json.Unmarshal(result, &r)
+	if err != nil {
+		return errors.Wrapf(err, "cannot unmarshal composition result")
+	}
+
+	for k := range base {
+		delete(base, k)
+	}
+	for k, v := range r {
+		base[k] = v
+	}
+	return nil
+}
+
+func (p *pluginImpl) ValidatePubSub(spec []byte) (*ppi.PubSubSpecInfo, error) {
+	result, err := p.Exec(nil, nil, pubsub.Name, psval.Name, string(spec))
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s", p.Name())
+	}
+
+	var info ppi.PubSubSpecInfo
+	err = json.Unmarshal(result, &info)
+	if err != nil {
+		return nil, errors.Wrapf(err, "plugin %s: cannot unmarshal pub/sub spec info", p.Name())
+	}
+	return &info, nil
+}
+
+// NotifyComponentVersion notifies the pub/sub system described by
+// the given specification about a component version.
+func (p *pluginImpl) NotifyComponentVersion(spec []byte, creds json.RawMessage, nv common.NameVersion) error {
+	args := []string{pubsub.Name, notify.Name, string(spec), nv.GetName(), nv.GetVersion()}
+	if creds != nil {
+		args = append(args, "--"+notify.OptCreds, string(creds))
+	}
+	_, err := p.Exec(nil, nil, args...)
+	if err != nil {
+		return errors.Wrapf(err, "plugin %s", p.Name())
+	}
+	return nil
+}
+
 func (p *pluginImpl) ValidateUploadTarget(name string, spec []byte) (*ppi.UploadTargetSpecInfo, error) {
 	result, err := p.Exec(nil, nil, upload.Name, uplval.Name, name, string(spec))
 	if err != nil {
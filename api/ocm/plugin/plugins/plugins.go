@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"sync"
 
+	"github.com/mandelsoft/goutils/errors"
+
 	cfgcpi "ocm.software/ocm/api/config/cpi"
 	"ocm.software/ocm/api/ocm/cpi"
 	"ocm.software/ocm/api/ocm/plugin"
@@ -18,12 +20,14 @@ type Set = *pluginsImpl
 type pluginSettings struct {
 	config                  json.RawMessage
 	disableAutoRegistration bool
+	serverMode              *config.ServerMode
 }
 type pluginsImpl struct {
 	lock sync.RWMutex
 
 	updater cfgcpi.Updater
 	ctx     cpi.Context
+	path    string
 	base    cache.PluginDir
 	configs map[string]*pluginSettings
 	plugins map[string]plugin.Plugin
@@ -34,6 +38,7 @@ var _ config.Target = (*pluginsImpl)(nil)
 func New(ctx cpi.Context, path string) Set {
 	pi := &pluginsImpl{
 		ctx:     ctx,
+		path:    path,
 		configs: map[string]*pluginSettings{},
 		plugins: map[string]plugin.Plugin{},
 	}
@@ -47,6 +52,7 @@ func New(ctx cpi.Context, path string) Set {
 		} else {
 			p := plugin.NewPlugin(ctx, pi.base.Get(n), cfg.config)
 			p.DisableAutoConfiguration(cfg.disableAutoRegistration)
+			p.SetServerMode(cfg.serverMode)
 			pi.plugins[n] = p
 		}
 	}
@@ -57,6 +63,11 @@ func (pi *pluginsImpl) GetContext() cpi.Context {
 	return pi.ctx
 }
 
+// Path provides the plugin directory used for the plugin set.
+func (pi *pluginsImpl) Path() string {
+	return pi.path
+}
+
 func (pi *pluginsImpl) Update() {
 	err := pi.updater.Update()
 	if err != nil {
@@ -93,6 +104,28 @@ func (pi *pluginsImpl) ConfigurePlugin(name string, config json.RawMessage) {
 	}
 }
 
+func (pi *pluginsImpl) ConfigureServerMode(name string, mode *config.ServerMode) {
+	pi.lock.Lock()
+	defer pi.lock.Unlock()
+
+	pi.getSettings(name).serverMode = mode
+	if pi.plugins[name] != nil {
+		pi.plugins[name].SetServerMode(mode)
+	}
+}
+
+// Finalize stops all plugin server processes started for the context.
+func (pi *pluginsImpl) Finalize() error {
+	pi.lock.RLock()
+	defer pi.lock.RUnlock()
+
+	list := errors.ErrListf("stopping plugin servers")
+	for n, p := range pi.plugins {
+		list.Addf(nil, p.Close(), "plugin %s", n)
+	}
+	return list.Result()
+}
+
 func (pi *pluginsImpl) PluginNames() []string {
 	pi.lock.RLock()
 	defer pi.lock.RUnlock()
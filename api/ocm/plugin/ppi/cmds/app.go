@@ -4,6 +4,7 @@ package cmds
 
 import (
 	"encoding/json"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -12,10 +13,15 @@ import (
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/accessmethod"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/action"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/command"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/credentialrepository"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/describe"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/download"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/info"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/input"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/mergehandler"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/pubsub"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/server"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/signing"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/topics/descriptor"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/upload"
 	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/valueset"
@@ -62,10 +68,17 @@ func NewPluginCommand(p ppi.Plugin) *PluginCommand {
 	cmd.AddCommand(action.New(p))
 	cmd.AddCommand(mergehandler.New(p))
 	cmd.AddCommand(accessmethod.New(p))
+	cmd.AddCommand(credentialrepository.New(p))
+	cmd.AddCommand(signing.New(p))
+	cmd.AddCommand(input.New(p))
+	cmd.AddCommand(pubsub.New(p))
 	cmd.AddCommand(upload.New(p))
 	cmd.AddCommand(download.New(p))
 	cmd.AddCommand(valueset.New(p))
 	cmd.AddCommand(command.New(p))
+	if p.Descriptor().Server != nil {
+		cmd.AddCommand(server.New(p, pcmd.serve))
+	}
 
 	cmd.InitDefaultHelpCmd()
 	help := cobrautils.GetHelpCommand(cmd)
@@ -92,6 +105,16 @@ func (p *PluginCommand) PreRunE(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// serve executes a single request in server mode with a fresh
+// command tree using the request's standard streams.
+func (p *PluginCommand) serve(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	c := NewPluginCommand(p.plugin)
+	c.command.SetIn(stdin)
+	c.command.SetOut(stdout)
+	c.command.SetErr(stderr)
+	return c.Execute(args)
+}
+
 func (p *PluginCommand) Execute(args []string) error {
 	p.command.SetArgs(args)
 	err := p.command.Execute()
@@ -0,0 +1,24 @@
+package credentialrepository
+
+import (
+	"github.com/spf13/cobra"
+
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/credentialrepository/get"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/credentialrepository/validate"
+)
+
+const Name = "credentialrepository"
+
+func New(p ppi.Plugin) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   Name,
+		Short: "credential repository operations",
+		Long: `This command group provides all commands used to implement a credential repository
+described by a credential repository descriptor (<CMD>` + p.Name() + ` descriptor</CMD>.`,
+	}
+
+	cmd.AddCommand(validate.New(p))
+	cmd.AddCommand(get.New(p))
+	return cmd
+}
@@ -0,0 +1,92 @@
+package get
+
+import (
+	"encoding/json"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+	"ocm.software/ocm/api/utils/cobrautils/flag"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	Name        = "get"
+	OptConsumer = "consumer"
+)
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " [<flags>] <repository spec> [<name>]",
+		Short: "get credentials",
+		Long: `
+Evaluate the given credential repository specification and return the
+credentials with the given name as JSON object with the credential
+properties on *stdout*. If no name is given, the credentials for the
+consumer id given with option <code>--consumer</code> are requested.
+It is the consumer id of the actual credential request, which matched
+one of the consumer ids reported by the <CMD>validate</CMD> command.
+
+If no credentials are found, <code>null</code> has to be returned.`,
+		Args: cobra.RangeArgs(1, 2),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Consumer      credentials.ConsumerIdentity
+	Specification json.RawMessage
+	Name          string
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	flag.YAMLVarP(fs, &o.Consumer, OptConsumer, "", nil, "consumer id")
+}
+
+func (o *Options) Complete(args []string) error {
+	if err := runtime.DefaultYAMLEncoding.Unmarshal([]byte(args[0]), &o.Specification); err != nil {
+		return errors.Wrapf(err, "invalid credential repository specification")
+	}
+	if len(args) > 1 {
+		o.Name = args[1]
+	}
+	if o.Name == "" && len(o.Consumer) == 0 {
+		return errors.Newf("credential name or consumer id required")
+	}
+	return nil
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	spec, err := p.DecodeCredentialRepositorySpecification(opts.Specification)
+	if err != nil {
+		return errors.Wrapf(err, "credential repository specification")
+	}
+
+	r := p.GetCredentialRepository(runtime.KindVersion(spec.GetType()))
+	if r == nil {
+		return errors.ErrUnknown(descriptor.KIND_CREDENTIALREPOSITORY, spec.GetType())
+	}
+	creds, err := r.Credentials(p, spec, opts.Name, opts.Consumer)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(creds)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("%s\n", string(data))
+	return nil
+}
@@ -0,0 +1,95 @@
+package validate
+
+import (
+	"encoding/json"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const Name = "validate"
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " <spec>",
+		Short: "validate credential repository specification",
+		Long: `
+This command accepts a credential repository specification as argument.
+It is used to validate the specification and to provide some metadata for
+the given specification.
+
+This metadata has to be provided as JSON string on *stdout* and has the 
+following fields: 
+
+- **<code>description</code>** *string*
+
+  A short textual description of the described repository.
+
+- **<code>consumerIds</code>** *[]map[string]string*
+
+  The list of consumer ids the repository provides credentials for.
+  If specified, the repository is used to resolve credential requests
+  for matching consumers. Every consumer id must at least provide the
+  <code>type</code> field.
+`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Specification json.RawMessage
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+}
+
+func (o *Options) Complete(args []string) error {
+	if err := runtime.DefaultYAMLEncoding.Unmarshal([]byte(args[0]), &o.Specification); err != nil {
+		return errors.Wrapf(err, "invalid credential repository specification")
+	}
+	return nil
+}
+
+type Result struct {
+	Short       string                         `json:"description"`
+	ConsumerIds []credentials.ConsumerIdentity `json:"consumerIds,omitempty"`
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	spec, err := p.DecodeCredentialRepositorySpecification(opts.Specification)
+	if err != nil {
+		return errors.Wrapf(err, "credential repository specification")
+	}
+
+	r := p.GetCredentialRepository(runtime.KindVersion(spec.GetType()))
+	if r == nil {
+		return errors.ErrUnknown(descriptor.KIND_CREDENTIALREPOSITORY, spec.GetType())
+	}
+	info, err := r.ValidateSpecification(p, spec)
+	if err != nil {
+		return err
+	}
+	result := Result{Short: info.Short, ConsumerIds: info.ConsumerIds}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("%s\n", string(data))
+	return nil
+}
@@ -19,6 +19,10 @@ A plugin may contribute to the following extension points:
 - **access methods**: describe access to resource locations in foreign repositories.
 - **blob uploaders**: export uploaded local blobs to foreign repositories.
 - **blob downloaders**: transform downloaded resources to an applicable file system representation.
+- **credential repositories**: provide credentials from foreign credential sources.
+- **signing handlers**: sign and verify digests, for example with keys kept in a HSM or KMS.
+- **input types**: provide resource and source content for the composition of component versions.
+- **pub/sub types**: notify external systems about new or updated component versions.
 
 ## Commands
 
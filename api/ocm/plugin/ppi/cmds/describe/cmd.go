@@ -1,8 +1,6 @@
 package describe
 
 import (
-	"os"
-
 	"github.com/spf13/cobra"
 
 	"ocm.software/ocm/api/datacontext/action"
@@ -21,7 +19,7 @@ func New(p ppi.Plugin) *cobra.Command {
 		Args:  cobra.MaximumNArgs(0),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			d := p.Descriptor()
-			common.DescribePluginDescriptor(action.DefaultRegistry(), &d, misc.NewPrinter(os.Stdout))
+			common.DescribePluginDescriptor(action.DefaultRegistry(), &d, misc.NewPrinter(cmd.OutOrStdout()))
 			return nil
 		},
 	}
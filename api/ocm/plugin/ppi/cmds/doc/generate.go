@@ -21,6 +21,7 @@ func main() {
 	p := ppi.NewPlugin("plugin", version.Get().String())
 	p.SetLong(cmds.Description(p.Name()))
 	p.SetShort("OCM Plugin")
+	p.EnableServerMode()
 	cmd := cmds.NewPluginCommand(p).Command()
 	cmd.DisableAutoGenTag = true
 	cobradoc.Generate("OCM Plugin", cmd, os.Args[1], true)
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/spf13/cobra"
@@ -88,7 +87,7 @@ func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
 	if err != nil {
 		return err
 	}
-	_, err = io.Copy(w, os.Stdin)
+	_, err = io.Copy(w, cmd.InOrStdin())
 	if err != nil {
 		w.Close()
 		return err
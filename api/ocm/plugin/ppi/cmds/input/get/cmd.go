@@ -0,0 +1,81 @@
+package get
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+	commonppi "ocm.software/ocm/api/ocm/plugin/ppi/cmds/common"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	Name   = "get"
+	OptDir = commonppi.OptDir
+)
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " [<flags>] <spec>",
+		Short: "get blob",
+		Long: `
+Evaluate the given input specification and return the described blob on
+*stdout*. Relative file system paths used in the specification
+have to be resolved relative to the directory given by option <code>--` + OptDir + `</code>.`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Dir           string
+	Specification json.RawMessage
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Dir, OptDir, "d", "", "base directory for relative file system paths")
+}
+
+func (o *Options) Complete(args []string) error {
+	if err := runtime.DefaultYAMLEncoding.Unmarshal([]byte(args[0]), &o.Specification); err != nil {
+		return errors.Wrapf(err, "invalid input specification")
+	}
+	return nil
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	spec, err := p.DecodeInputSpecification(opts.Specification)
+	if err != nil {
+		return errors.Wrapf(err, "input specification")
+	}
+
+	t := p.GetInputType(spec.GetType())
+	if t == nil {
+		return errors.ErrUnknown(descriptor.KIND_INPUTTYPE, spec.GetType())
+	}
+	_, err = t.ValidateSpecification(p, opts.Dir, spec)
+	if err != nil {
+		return err
+	}
+	r, err := t.Reader(p, opts.Dir, spec)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(cmd.OutOrStdout(), r)
+	r.Close()
+	return err
+}
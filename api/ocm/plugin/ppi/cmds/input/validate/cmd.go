@@ -0,0 +1,99 @@
+package validate
+
+import (
+	"encoding/json"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+	commonppi "ocm.software/ocm/api/ocm/plugin/ppi/cmds/common"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	Name   = "validate"
+	OptDir = commonppi.OptDir
+)
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " [<flags>] <spec>",
+		Short: "validate input specification",
+		Long: `
+This command accepts an input specification as argument. It is used to
+validate the specification and to provide some metadata for the given
+specification. Relative file system paths used in the specification
+have to be resolved relative to the directory given by option <code>--` + OptDir + `</code>.
+It is the directory of the file the input specification has been taken from.
+
+This metadata has to be provided as JSON string on *stdout* and has the
+following fields:
+
+- **<code>mediaType</code>** *string*
+
+  The media type of the blob described by the specification. It may be part
+  of the specification or implicitly determined by the input type.
+
+- **<code>short</code>** *string*
+
+  A short textual description of the described input.
+
+- **<code>hint</code>** *string*
+
+  An optional reference hint used to reconstruct a useful name for the
+  blob if it is uploaded to a dedicated repository technology.
+`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Dir           string
+	Specification json.RawMessage
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Dir, OptDir, "d", "", "base directory for relative file system paths")
+}
+
+func (o *Options) Complete(args []string) error {
+	if err := runtime.DefaultYAMLEncoding.Unmarshal([]byte(args[0]), &o.Specification); err != nil {
+		return errors.Wrapf(err, "invalid input specification")
+	}
+	return nil
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	spec, err := p.DecodeInputSpecification(opts.Specification)
+	if err != nil {
+		return errors.Wrapf(err, "input specification")
+	}
+
+	t := p.GetInputType(spec.GetType())
+	if t == nil {
+		return errors.ErrUnknown(descriptor.KIND_INPUTTYPE, spec.GetType())
+	}
+	info, err := t.ValidateSpecification(p, opts.Dir, spec)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("%s\n", string(data))
+	return nil
+}
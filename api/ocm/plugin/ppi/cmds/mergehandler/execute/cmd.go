@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"os"
 
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/spf13/cobra"
@@ -92,7 +91,7 @@ func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
 		return errors.ErrUnknown(hpi.KIND_VALUE_MERGE_ALGORITHM, opts.Name)
 	}
 
-	data, err := io.ReadAll(os.Stdin)
+	data, err := io.ReadAll(cmd.InOrStdin())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,24 @@
+package pubsub
+
+import (
+	"github.com/spf13/cobra"
+
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/pubsub/notify"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/pubsub/validate"
+)
+
+const Name = "pubsub"
+
+func New(p ppi.Plugin) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   Name,
+		Short: "pub/sub type operations",
+		Long: `This command group provides all commands used to implement a pub/sub type
+described by a pub/sub type descriptor (<CMD>` + p.Name() + ` descriptor</CMD>.`,
+	}
+
+	cmd.AddCommand(validate.New(p))
+	cmd.AddCommand(notify.New(p))
+	return cmd
+}
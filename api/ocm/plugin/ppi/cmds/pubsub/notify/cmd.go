@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"encoding/json"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+	commonppi "ocm.software/ocm/api/ocm/plugin/ppi/cmds/common"
+	"ocm.software/ocm/api/utils/cobrautils/flag"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	Name     = "notify"
+	OptCreds = commonppi.OptCreds
+)
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " [<flags>] <spec> <component> <version>",
+		Short: "notify about a component version",
+		Long: `
+Notify the pub/sub system described by the given specification about a new
+or updated component version. The command is called by a repository after
+a component version has been added or updated, if the pub/sub specification
+has been configured for the repository (see <code>ocm set pubsub</code>).
+
+Credentials found for the consumer id reported by the <CMD>validate</CMD>
+command are passed with option <code>--` + OptCreds + `</code>.`,
+		Args: cobra.ExactArgs(3),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Credentials   credentials.DirectCredentials
+	Specification json.RawMessage
+	Version       common.NameVersion
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	flag.YAMLVarP(fs, &o.Credentials, OptCreds, "c", nil, "credentials")
+	flag.StringToStringVarPFA(fs, &o.Credentials, "credential", "C", nil, "dedicated credential value")
+}
+
+func (o *Options) Complete(args []string) error {
+	if err := runtime.DefaultYAMLEncoding.Unmarshal([]byte(args[0]), &o.Specification); err != nil {
+		return errors.Wrapf(err, "invalid pub/sub specification")
+	}
+	o.Version = common.NewNameVersion(args[1], args[2])
+	return nil
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	spec, err := p.DecodePubSubSpecification(opts.Specification)
+	if err != nil {
+		return errors.Wrapf(err, "pub/sub specification")
+	}
+
+	t := p.GetPubSubType(runtime.KindVersion(spec.GetType()))
+	if t == nil {
+		return errors.ErrUnknown(descriptor.KIND_PUBSUBTYPE, spec.GetType())
+	}
+	_, err = t.ValidateSpecification(p, spec)
+	if err != nil {
+		return err
+	}
+	return t.NotifyComponentVersion(p, spec, opts.Version, opts.Credentials)
+}
@@ -0,0 +1,87 @@
+package validate
+
+import (
+	"encoding/json"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const Name = "validate"
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " <spec>",
+		Short: "validate pub/sub specification",
+		Long: `
+This command accepts a pub/sub specification as argument. It is used to
+validate the specification and to provide some metadata for the given
+specification.
+
+This metadata has to be provided as JSON string on *stdout* and has the
+following fields:
+
+- **<code>description</code>** *string*
+
+  A short textual description of the described pub/sub system.
+
+- **<code>consumerId</code>** *map[string]string*
+
+  The consumer id used to determine optional credentials for the
+  pub/sub system. If specified, at least the <code>type</code> field must be set.
+  Found credentials are passed to the command <CMD>notify</CMD>.
+`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Specification json.RawMessage
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+}
+
+func (o *Options) Complete(args []string) error {
+	if err := runtime.DefaultYAMLEncoding.Unmarshal([]byte(args[0]), &o.Specification); err != nil {
+		return errors.Wrapf(err, "invalid pub/sub specification")
+	}
+	return nil
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	spec, err := p.DecodePubSubSpecification(opts.Specification)
+	if err != nil {
+		return errors.Wrapf(err, "pub/sub specification")
+	}
+
+	t := p.GetPubSubType(runtime.KindVersion(spec.GetType()))
+	if t == nil {
+		return errors.ErrUnknown(descriptor.KIND_PUBSUBTYPE, spec.GetType())
+	}
+	info, err := t.ValidateSpecification(p, spec)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("%s\n", string(data))
+	return nil
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+	"ocm.software/ocm/api/ocm/plugin/server"
+)
+
+const (
+	Name      = "server"
+	OptSocket = "socket"
+)
+
+func New(p ppi.Plugin, h server.Handler) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " [<flags>]",
+		Short: "serve plugin requests",
+		Long: `
+Serve plugin requests in a persistent process. Every request consists of
+the arguments of a regular plugin command together with its standard input.
+The standard and error output and the result of the command are returned
+to the caller. The streams of the requests are multiplexed over a single
+connection and the requests are executed sequentially.
+
+By default, the requests are read from *stdin* and the responses are
+written to *stdout*. With option <code>--socket</code> a unix domain socket
+is used instead. In this case serving stops when *stdin* is closed.
+
+This command is only supported if the plugin announces the server mode in
+its plugin descriptor.`,
+		Args: cobra.MaximumNArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts, h)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Socket string
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Socket, OptSocket, "", "", "unix domain socket used to serve requests")
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options, h server.Handler) error {
+	if opts.Socket != "" {
+		return server.ServeSocket(opts.Socket, cmd.InOrStdin(), h)
+	}
+
+	conn := &stdio{Reader: cmd.InOrStdin(), Writer: cmd.OutOrStdout()}
+	// keep regular output of the plugin code away from the protocol stream.
+	if os.Stdout != os.Stderr {
+		stdout := os.Stdout
+		os.Stdout = os.Stderr
+		defer func() { os.Stdout = stdout }()
+	}
+	return server.Serve(conn, h)
+}
+
+type stdio struct {
+	io.Reader
+	io.Writer
+}
@@ -0,0 +1,24 @@
+package signing
+
+import (
+	"github.com/spf13/cobra"
+
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/signing/sign"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/signing/verify"
+)
+
+const Name = "signing"
+
+func New(p ppi.Plugin) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   Name,
+		Short: "signing handler operations",
+		Long: `This command group provides all commands used to implement a signing handler
+described by a signing handler descriptor (<CMD>` + p.Name() + ` descriptor</CMD>.`,
+	}
+
+	cmd.AddCommand(sign.New(p))
+	cmd.AddCommand(verify.New(p))
+	return cmd
+}
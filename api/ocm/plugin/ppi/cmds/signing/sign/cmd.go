@@ -0,0 +1,143 @@
+package sign
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+)
+
+const (
+	Name = "sign"
+)
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " <name>",
+		Short: "sign a digest",
+		Long: `
+This command signs a digest with the signing algorithm with the given name.
+The signing request is taken from *stdin* as JSON string. It has the following
+fields:
+
+- **<code>digest</code>** *string*
+
+  The hex encoded digest to sign.
+
+- **<code>hash</code>** *string*
+
+  The name of the hash algorithm used to calculate the digest
+  (for example <code>SHA-256</code>).
+
+- **<code>privateKey</code>** *[]byte*
+
+  The private key configured for the signature name. It is passed as
+  configured for the OCM CLI, so it might also be a reference to
+  a key managed by an external system, for example a HSM or KMS.
+  Key objects are passed PEM encoded.
+
+- **<code>publicKey</code>** *[]byte*
+
+  An optional public key or certificate (chain).
+
+- **<code>rootCerts</code>** *[]byte*
+
+  Optional PEM encoded root certificates.
+
+- **<code>issuer</code>** *string*
+
+  The optional expected issuer of the signature as distinguished name.
+
+- **<code>credentials</code>** *map[string]string*
+
+  The credentials found for the consumer type declared by the signing
+  handler descriptor.
+
+Byte sequences are encoded as base64 strings.
+
+The command has to provide the signature as JSON string on *stdout*. It has
+the following fields:
+
+- **<code>value</code>** *string*
+
+  The signature value.
+
+- **<code>mediaType</code>** *string*
+
+  The media type of the signature value.
+
+- **<code>algorithm</code>** *string*
+
+  The signature algorithm. By default, the name of the signing handler is used.
+  The algorithm is used to select the handler to verify the signature.
+
+- **<code>issuer</code>** *string*
+
+  The optional issuer of the signature.
+`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Name string
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+}
+
+func (o *Options) Complete(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("signing algorithm name missing")
+	}
+	o.Name = args[0]
+	return nil
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	h := p.GetSigningHandler(opts.Name)
+	if h == nil {
+		return errors.ErrUnknown(descriptor.KIND_SIGNINGHANDLER, opts.Name)
+	}
+
+	data, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	var req ppi.SigningRequest
+	err = json.Unmarshal(data, &req)
+	if err != nil {
+		return errors.Wrapf(err, "invalid signing request")
+	}
+
+	sig, err := h.Sign(p, &req)
+	if err != nil {
+		return err
+	}
+	if sig.Algorithm == "" {
+		sig.Algorithm = h.Name()
+	}
+	data, err = json.Marshal(sig)
+	if err != nil {
+		return err
+	}
+	cmd.Printf("%s\n", string(data))
+	return nil
+}
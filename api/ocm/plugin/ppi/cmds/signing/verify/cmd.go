@@ -0,0 +1,107 @@
+package verify
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+)
+
+const (
+	Name = "verify"
+)
+
+func New(p ppi.Plugin) *cobra.Command {
+	opts := Options{}
+
+	cmd := &cobra.Command{
+		Use:   Name + " <name>",
+		Short: "verify a signature",
+		Long: `
+This command verifies a signature with the signing algorithm with the given
+name. The verification request is taken from *stdin* as JSON string. It has
+the following fields:
+
+- **<code>digest</code>** *string*
+
+  The hex encoded digest the signature should be verified for.
+
+- **<code>hash</code>** *string*
+
+  The name of the hash algorithm used to calculate the digest
+  (for example <code>SHA-256</code>).
+
+- **<code>signature</code>** *object*
+
+  The signature to verify with the fields <code>value</code>,
+  <code>mediaType</code>, <code>algorithm</code> and <code>issuer</code>
+  as provided by the <CMD>sign</CMD> command.
+
+- **<code>publicKey</code>** *[]byte*
+
+  The public key or certificate (chain) configured for the signature name.
+
+- **<code>rootCerts</code>** *[]byte*
+
+  Optional PEM encoded root certificates.
+
+- **<code>issuer</code>** *string*
+
+  The optional expected issuer of the signature as distinguished name.
+
+Byte sequences are encoded as base64 strings.
+
+If the verification fails, the command has to fail with an appropriate
+error message. There is no output on *stdout*.
+`,
+		Args: cobra.ExactArgs(1),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return opts.Complete(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return Command(p, cmd, &opts)
+		},
+	}
+	opts.AddFlags(cmd.Flags())
+	return cmd
+}
+
+type Options struct {
+	Name string
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+}
+
+func (o *Options) Complete(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("signing algorithm name missing")
+	}
+	o.Name = args[0]
+	return nil
+}
+
+func Command(p ppi.Plugin, cmd *cobra.Command, opts *Options) error {
+	h := p.GetSigningHandler(opts.Name)
+	if h == nil {
+		return errors.ErrUnknown(descriptor.KIND_SIGNINGHANDLER, opts.Name)
+	}
+
+	data, err := io.ReadAll(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	var req ppi.VerificationRequest
+	err = json.Unmarshal(data, &req)
+	if err != nil {
+		return errors.Wrapf(err, "invalid verification request")
+	}
+	return h.Verify(p, &req)
+}
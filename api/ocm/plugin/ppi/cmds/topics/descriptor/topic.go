@@ -76,6 +76,32 @@ following fields:
   This feature is already used to establish new access types, if
   the plugins are registered at an OCM context.
 
+- **<code>credentialRepositories</code>** *[]CredentialRepositoryDescriptor*
+
+  The list of credential repository types provided by this plugin.
+  They are registered as repository types for the credential context,
+  if the plugins are registered at an OCM context.
+
+- **<code>pubsubTypes</code>** *[]PubSubTypeDescriptor*
+
+  The list of pub/sub specification types provided by this plugin.
+  They are registered as pub/sub types for an OCM context,
+  if the plugins are registered at this context, and can be configured
+  for repositories with <code>ocm set pubsub</code>.
+
+- **<code>signingHandlers</code>** *[]SigningHandlerDescriptor*
+
+  The list of signing algorithms provided by this plugin.
+  They are registered at the signing registry of an OCM context,
+  if the plugins are registered at this context, and can be used
+  by the <code>--algorithm</code> option of the signing commands.
+
+- **<code>inputTypes</code>** *[]InputTypeDescriptor*
+
+  The list of resource/source input types provided by this plugin.
+  They are offered by the OCM CLI commands used to compose
+  component versions, for example <code>ocm add resources</code>.
+
 - **<code>uploaders</code>** *[]UploaderDescriptor*
 
   The list of supported uploaders. Uploaders will be used in a future
@@ -108,6 +134,12 @@ following fields:
 
   The list of assignments of label merge specification to labels.
 
+- **<code>server</code>** *ServerDescriptor* (optional)
+
+  If set, the plugin supports the server mode. Instead of starting a new
+  plugin process for every request, the OCM library keeps plugin processes
+  running and uses the <code>server</code> command to execute requests.
+
 #### Access Method Descriptor
 
 An access method descriptor describes a dedicated supported access method.
@@ -194,6 +226,91 @@ The descriptor for an uploader has the following fields:
     Restrict the usage to a dedicated implementation of the backend technology.
     If specified, the attribute <code>contextType</code> must be set, also.
 
+#### Credential Repository Descriptor
+
+A credential repository descriptor describes a dedicated supported credential
+repository type. It uses the following fields:
+
+- **<code>name</code>** *string*
+
+  The name of the repository type.
+
+- **<code>version</code>** *string*
+
+  The version of the repository type (default: v1).
+
+- **<code>description</code>** *string*
+
+  The description of the repository type.
+
+- **<code>format</code>** *string*
+
+  The description of the specification format of the dedicated version.
+
+#### PubSub Type Descriptor
+
+A pub/sub type descriptor describes a dedicated supported pub/sub
+specification type. It uses the following fields:
+
+- **<code>name</code>** *string*
+
+  The name of the pub/sub type.
+
+- **<code>version</code>** *string*
+
+  The version of the pub/sub type (default: v1).
+
+- **<code>description</code>** *string*
+
+  The description of the pub/sub type.
+
+- **<code>format</code>** *string*
+
+  The description of the specification format of the dedicated version.
+
+#### Signing Handler Descriptor
+
+A signing handler descriptor describes a signing algorithm provided
+by a plugin. It uses the following fields:
+
+- **<code>name</code>** *string*
+
+  The name of the signing algorithm.
+
+- **<code>description</code>** *string*
+
+  The description of the signing algorithm.
+
+- **<code>consumerType</code>** *string*
+
+  An optional consumer type used to look up credentials for the signing
+  operation. The consumer id additionally contains the attribute
+  <code>algorithm</code> with the name of the signing algorithm.
+  Found credentials are passed to the command <CMD>plugin signing sign</CMD>.
+
+#### Input Type Descriptor
+
+An input type descriptor describes a resource/source input type provided
+by a plugin. It uses the following fields:
+
+- **<code>name</code>** *string*
+
+  The name of the input type.
+
+- **<code>description</code>** *string*
+
+  The description of the input type.
+
+- **<code>format</code>** *string*
+
+  The description of the input specification format.
+
+- **<code>options</code>** *[]Option]*
+
+  Optional list of options provided for the command <code>ocm add resources</code>.
+  If options are given, the plugin must support the command <CMD>plugin input compose</CMD>.
+  Options are described like for access methods.
+
 #### Downloader Descriptor
 
 The descriptor for a downloader has the following fields:
@@ -292,6 +409,17 @@ The descriptor for a label merge specification has the following fields:
 
   The configuration settings used for the algorithm. It may contain nested
   merge specifications.
+
+### Server Descriptor
+
+The descriptor for the server mode has the following fields:
+
+- **<code>transports</code>** *[]string* (optional)
+
+  The list of supported transports used to communicate with a plugin server.
+  Possible values are <code>stdio</code> (the standard streams of the plugin
+  process) and <code>unix</code> (a unix domain socket). If not specified,
+  only <code>stdio</code> is supported.
 `,
 	}
 }
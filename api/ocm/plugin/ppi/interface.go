@@ -12,6 +12,7 @@ import (
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/options"
 	"ocm.software/ocm/api/ocm/plugin/descriptor"
 	"ocm.software/ocm/api/ocm/plugin/internal"
+	common "ocm.software/ocm/api/utils/misc"
 	"ocm.software/ocm/api/utils/runtime"
 )
 
@@ -24,10 +25,25 @@ type (
 	AccessMethodDescriptor = descriptor.AccessMethodDescriptor
 	CLIOption              = descriptor.CLIOption
 
+	CredentialRepositoryDescriptor = descriptor.CredentialRepositoryDescriptor
+
 	ActionSpecInfo       = internal.ActionSpecInfo
 	AccessSpecInfo       = internal.AccessSpecInfo
 	ValueSetInfo         = internal.ValueSetInfo
 	UploadTargetSpecInfo = internal.UploadTargetSpecInfo
+
+	CredentialRepositorySpecInfo = internal.CredentialRepositorySpecInfo
+
+	SigningHandlerDescriptor = descriptor.SigningHandlerDescriptor
+	SigningRequest           = internal.SigningRequest
+	VerificationRequest      = internal.VerificationRequest
+	Signature                = internal.Signature
+
+	InputTypeDescriptor = descriptor.InputTypeDescriptor
+	InputSpecInfo       = internal.InputSpecInfo
+
+	PubSubTypeDescriptor = descriptor.PubSubTypeDescriptor
+	PubSubSpecInfo       = internal.PubSubSpecInfo
 )
 
 var REALM = descriptor.REALM
@@ -43,6 +59,7 @@ type Plugin interface {
 	SetLong(s string)
 	SetConfigParser(config func(raw json.RawMessage) (interface{}, error))
 	ForwardLogging(b ...bool)
+	EnableServerMode(transports ...string)
 
 	RegisterDownloader(arttype, mediatype string, u Downloader) error
 	GetDownloader(name string) Downloader
@@ -57,6 +74,21 @@ type Plugin interface {
 	DecodeAccessSpecification(data []byte) (AccessSpec, error)
 	GetAccessMethod(name string, version string) AccessMethod
 
+	RegisterCredentialRepository(r CredentialRepository) error
+	DecodeCredentialRepositorySpecification(data []byte) (CredentialRepositorySpec, error)
+	GetCredentialRepository(name string, version string) CredentialRepository
+
+	RegisterSigningHandler(h SigningHandler) error
+	GetSigningHandler(name string) SigningHandler
+
+	RegisterInputType(t InputType) error
+	DecodeInputSpecification(data []byte) (InputSpec, error)
+	GetInputType(name string) InputType
+
+	RegisterPubSubType(t PubSubType) error
+	DecodePubSubSpecification(data []byte) (PubSubSpec, error)
+	GetPubSubType(name string, version string) PubSubType
+
 	RegisterAction(a Action) error
 	DecodeAction(data []byte) (ActionSpec, error)
 	GetAction(name string) Action
@@ -104,6 +136,30 @@ type AccessSpec = runtime.TypedObject
 
 type AccessSpecProvider func() AccessSpec
 
+type CredentialRepositorySpec = runtime.TypedObject
+
+// CredentialRepository is the interface for a credential repository type
+// provided by a plugin.
+type CredentialRepository interface {
+	runtime.TypedObjectDecoder[CredentialRepositorySpec]
+
+	Name() string
+	Version() string
+
+	// Description provides a general description for the repository type.
+	Description() string
+	// Format describes the attributes of the dedicated version.
+	Format() string
+
+	// ValidateSpecification validates a repository specification and provides
+	// the consumer ids the repository provides credentials for.
+	ValidateSpecification(p Plugin, spec CredentialRepositorySpec) (info *CredentialRepositorySpecInfo, err error)
+	// Credentials provides the credentials for the given name. If no name is
+	// given, the credentials for the given consumer id are requested.
+	// If no credentials are found, nil is returned.
+	Credentials(p Plugin, spec CredentialRepositorySpec, name string, consumer credentials.ConsumerIdentity) (credentials.DirectCredentials, error)
+}
+
 type UploadFormats runtime.KnownTypes[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]]
 
 type Uploader interface {
@@ -141,6 +197,73 @@ type Action interface {
 	Execute(p Plugin, spec ActionSpec, creds credentials.DirectCredentials) (result ActionResult, err error)
 }
 
+// SigningHandler is the interface for a signing algorithm
+// provided by a plugin.
+type SigningHandler interface {
+	// Name is the name of the signing algorithm.
+	Name() string
+	Description() string
+	// ConsumerType is optional and describes the consumer type
+	// used to look up credentials passed to the Sign method.
+	ConsumerType() string
+
+	// Sign creates a signature for the digest of the given request.
+	Sign(p Plugin, req *SigningRequest) (*Signature, error)
+	// Verify checks a signature and returns an error on verification failure.
+	Verify(p Plugin, req *VerificationRequest) error
+}
+
+type InputSpec = runtime.TypedObject
+
+// InputType is the interface for a resource or source input type
+// provided by a plugin. It is used by the OCM CLI to compose
+// component versions (for example <code>ocm add resources</code>).
+type InputType interface {
+	runtime.TypedObjectDecoder[InputSpec]
+
+	Name() string
+
+	// Options provides the list of CLI options supported to compose the input
+	// specification.
+	Options() []options.OptionType
+
+	// Description provides a general description for the input type.
+	Description() string
+	// Format describes the attributes of the input specification.
+	Format() string
+
+	// ValidateSpecification validates an input specification. Relative
+	// file system paths are resolved relative to the given directory.
+	ValidateSpecification(p Plugin, dir string, spec InputSpec) (info *InputSpecInfo, err error)
+	// Reader provides the blob described by the input specification.
+	Reader(p Plugin, dir string, spec InputSpec) (io.ReadCloser, error)
+	ComposeSpecification(p Plugin, opts Config, config Config) error
+}
+
+type PubSubSpec = runtime.TypedObject
+
+// PubSubType is the interface for a publish/subscribe specification
+// type provided by a plugin. It is used to notify an external
+// system about new or updated component versions.
+type PubSubType interface {
+	runtime.TypedObjectDecoder[PubSubSpec]
+
+	Name() string
+	Version() string
+
+	// Description provides a general description for the pub/sub type.
+	Description() string
+	// Format describes the attributes of the dedicated version.
+	Format() string
+
+	// ValidateSpecification validates a pub/sub specification and provides
+	// the consumer id used to look up credentials for the notification.
+	ValidateSpecification(p Plugin, spec PubSubSpec) (info *PubSubSpecInfo, err error)
+	// NotifyComponentVersion notifies the described system about a new or
+	// updated component version.
+	NotifyComponentVersion(p Plugin, spec PubSubSpec, version common.NameVersion, creds credentials.DirectCredentials) error
+}
+
 type Value = runtime.RawValue
 
 type ValueMergeResult struct {
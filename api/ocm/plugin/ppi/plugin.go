@@ -39,6 +39,17 @@ type plugin struct {
 	methods      map[string]AccessMethod
 	accessScheme runtime.Scheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]]
 
+	credrepos  map[string]CredentialRepository
+	credScheme runtime.Scheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]]
+
+	signers map[string]SigningHandler
+
+	inputs      map[string]InputType
+	inputScheme runtime.Scheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]]
+
+	pubsubs      map[string]PubSubType
+	pubsubScheme runtime.Scheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]]
+
 	actions       map[string]Action
 	mergehandlers map[string]ValueMergeHandler
 	mergespecs    map[string]*descriptor.LabelMergeSpecification
@@ -66,6 +77,17 @@ func NewPlugin(name string, version string) Plugin {
 		accessScheme:   runtime.MustNewDefaultScheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]](&runtime.UnstructuredVersionedTypedObject{}, false, nil),
 		uploaderScheme: runtime.MustNewDefaultScheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]](&runtime.UnstructuredVersionedTypedObject{}, false, nil),
 
+		credrepos:  map[string]CredentialRepository{},
+		credScheme: runtime.MustNewDefaultScheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]](&runtime.UnstructuredVersionedTypedObject{}, false, nil),
+
+		signers: map[string]SigningHandler{},
+
+		inputs:      map[string]InputType{},
+		inputScheme: runtime.MustNewDefaultScheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]](&runtime.UnstructuredVersionedTypedObject{}, false, nil),
+
+		pubsubs:      map[string]PubSubType{},
+		pubsubScheme: runtime.MustNewDefaultScheme[runtime.TypedObject, runtime.TypedObjectDecoder[runtime.TypedObject]](&runtime.UnstructuredVersionedTypedObject{}, false, nil),
+
 		actions:       map[string]Action{},
 		mergehandlers: map[string]ValueMergeHandler{},
 		mergespecs:    map[string]*descriptor.LabelMergeSpecification{},
@@ -122,6 +144,15 @@ func (p *plugin) ForwardLogging(b ...bool) {
 	p.descriptor.ForwardLogging = general.OptionalDefaultedBool(true, b...)
 }
 
+// EnableServerMode announces the support of the persistent server mode
+// for the given transports. Without transports all transports are supported.
+func (p *plugin) EnableServerMode(transports ...string) {
+	if len(transports) == 0 {
+		transports = []string{descriptor.TRANSPORT_STDIO, descriptor.TRANSPORT_UNIX}
+	}
+	p.descriptor.Server = &descriptor.ServerDescriptor{Transports: transports}
+}
+
 func (p *plugin) GetConfig() (interface{}, error) {
 	if len(p.options.Config) == 0 {
 		return nil, nil
@@ -352,6 +383,178 @@ func (p *plugin) GetAccessMethod(name string, version string) AccessMethod {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+func (p *plugin) RegisterCredentialRepository(r CredentialRepository) error {
+	if p.GetCredentialRepository(r.Name(), r.Version()) != nil {
+		n := r.Name()
+		if r.Version() != "" {
+			n += runtime.VersionSeparator + r.Version()
+		}
+		return errors.ErrAlreadyExists(descriptor.KIND_CREDENTIALREPOSITORY, n)
+	}
+
+	vers := r.Version()
+	if vers == "" {
+		desc := descriptor.CredentialRepositoryDescriptor{
+			ValueTypeDefinition: descriptor.ValueTypeDefinition{
+				Name:        r.Name(),
+				Description: r.Description(),
+				Format:      r.Format(),
+			},
+		}
+		p.descriptor.CredentialRepositories = append(p.descriptor.CredentialRepositories, desc)
+		p.credScheme.RegisterByDecoder(r.Name(), r)
+		p.credrepos[r.Name()] = r
+		vers = "v1"
+	}
+	desc := descriptor.CredentialRepositoryDescriptor{
+		ValueTypeDefinition: descriptor.ValueTypeDefinition{
+			Name:        r.Name(),
+			Version:     vers,
+			Description: r.Description(),
+			Format:      r.Format(),
+		},
+	}
+	p.descriptor.CredentialRepositories = append(p.descriptor.CredentialRepositories, desc)
+	p.credScheme.RegisterByDecoder(r.Name()+"/"+vers, r)
+	p.credrepos[r.Name()+"/"+vers] = r
+	return nil
+}
+
+func (p *plugin) DecodeCredentialRepositorySpecification(data []byte) (CredentialRepositorySpec, error) {
+	return p.credScheme.Decode(data, nil)
+}
+
+func (p *plugin) GetCredentialRepository(name string, version string) CredentialRepository {
+	n := name
+	if version != "" {
+		n += "/" + version
+	}
+	return p.credrepos[n]
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (p *plugin) RegisterSigningHandler(h SigningHandler) error {
+	if p.GetSigningHandler(h.Name()) != nil {
+		return errors.ErrAlreadyExists(descriptor.KIND_SIGNINGHANDLER, h.Name())
+	}
+
+	desc := descriptor.SigningHandlerDescriptor{
+		Name:         h.Name(),
+		Description:  h.Description(),
+		ConsumerType: h.ConsumerType(),
+	}
+	p.descriptor.SigningHandlers = append(p.descriptor.SigningHandlers, desc)
+	p.signers[h.Name()] = h
+	return nil
+}
+
+func (p *plugin) GetSigningHandler(name string) SigningHandler {
+	return p.signers[name]
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (p *plugin) RegisterInputType(t InputType) error {
+	if p.GetInputType(t.Name()) != nil {
+		return errors.ErrAlreadyExists(descriptor.KIND_INPUTTYPE, t.Name())
+	}
+
+	var optlist []CLIOption
+	for _, o := range t.Options() {
+		known := options.DefaultRegistry.GetOptionType(o.GetName())
+		if known != nil {
+			if o.ValueType() != known.ValueType() {
+				return fmt.Errorf("option type %s[%s] conflicts with standard option type using value type %s", o.GetName(), o.ValueType(), known.ValueType())
+			}
+			optlist = append(optlist, CLIOption{
+				Name: o.GetName(),
+			})
+		} else {
+			optlist = append(optlist, CLIOption{
+				Name:        o.GetName(),
+				Type:        o.ValueType(),
+				Description: o.GetDescriptionText(),
+			})
+		}
+	}
+	desc := descriptor.InputTypeDescriptor{
+		ValueSetDefinition: descriptor.ValueSetDefinition{
+			ValueTypeDefinition: descriptor.ValueTypeDefinition{
+				Name:        t.Name(),
+				Description: t.Description(),
+				Format:      t.Format(),
+			},
+			CLIOptions: optlist,
+		},
+	}
+	p.descriptor.InputTypes = append(p.descriptor.InputTypes, desc)
+	p.inputScheme.RegisterByDecoder(t.Name(), t)
+	p.inputs[t.Name()] = t
+	return nil
+}
+
+func (p *plugin) DecodeInputSpecification(data []byte) (InputSpec, error) {
+	return p.inputScheme.Decode(data, nil)
+}
+
+func (p *plugin) GetInputType(name string) InputType {
+	return p.inputs[name]
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (p *plugin) RegisterPubSubType(t PubSubType) error {
+	if p.GetPubSubType(t.Name(), t.Version()) != nil {
+		n := t.Name()
+		if t.Version() != "" {
+			n += runtime.VersionSeparator + t.Version()
+		}
+		return errors.ErrAlreadyExists(descriptor.KIND_PUBSUBTYPE, n)
+	}
+
+	vers := t.Version()
+	if vers == "" {
+		desc := descriptor.PubSubTypeDescriptor{
+			ValueTypeDefinition: descriptor.ValueTypeDefinition{
+				Name:        t.Name(),
+				Description: t.Description(),
+				Format:      t.Format(),
+			},
+		}
+		p.descriptor.PubSubTypes = append(p.descriptor.PubSubTypes, desc)
+		p.pubsubScheme.RegisterByDecoder(t.Name(), t)
+		p.pubsubs[t.Name()] = t
+		vers = "v1"
+	}
+	desc := descriptor.PubSubTypeDescriptor{
+		ValueTypeDefinition: descriptor.ValueTypeDefinition{
+			Name:        t.Name(),
+			Version:     vers,
+			Description: t.Description(),
+			Format:      t.Format(),
+		},
+	}
+	p.descriptor.PubSubTypes = append(p.descriptor.PubSubTypes, desc)
+	p.pubsubScheme.RegisterByDecoder(t.Name()+"/"+vers, t)
+	p.pubsubs[t.Name()+"/"+vers] = t
+	return nil
+}
+
+func (p *plugin) DecodePubSubSpecification(data []byte) (PubSubSpec, error) {
+	return p.pubsubScheme.Decode(data, nil)
+}
+
+func (p *plugin) GetPubSubType(name string, version string) PubSubType {
+	n := name
+	if version != "" {
+		n += "/" + version
+	}
+	return p.pubsubs[n]
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 func (p *plugin) RegisterAction(a Action) error {
 	if p.GetAction(a.Name()) != nil {
 		return errors.ErrAlreadyExists("action", a.Name())
@@ -48,6 +48,22 @@ func (b *AccessMethodBase) Format() string {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+type CredentialRepositoryBase = AccessMethodBase
+
+func MustNewCredentialRepositoryBase(name, version string, proto CredentialRepositorySpec, desc string, format string) CredentialRepositoryBase {
+	return MustNewAccessMethodBase(name, version, proto, desc, format)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type PubSubTypeBase = AccessMethodBase
+
+func MustNewPubSubTypeBase(name, version string, proto PubSubSpec, desc string, format string) PubSubTypeBase {
+	return MustNewAccessMethodBase(name, version, proto, desc, format)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type UploaderBase = nameDescription
 
 func MustNewUploaderBase(name, desc string) UploaderBase {
@@ -4,6 +4,7 @@ import (
 	"golang.org/x/exp/slices"
 
 	"ocm.software/ocm/api/config/plugin"
+	plugincreds "ocm.software/ocm/api/credentials/extensions/repositories/plugin"
 	"ocm.software/ocm/api/datacontext/action"
 	"ocm.software/ocm/api/datacontext/action/handlers"
 	"ocm.software/ocm/api/ocm"
@@ -11,15 +12,20 @@ import (
 	pluginaccess "ocm.software/ocm/api/ocm/extensions/accessmethods/plugin"
 	pluginaction "ocm.software/ocm/api/ocm/extensions/actionhandler/plugin"
 	"ocm.software/ocm/api/ocm/extensions/attrs/plugincacheattr"
+	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
 	pluginupload "ocm.software/ocm/api/ocm/extensions/blobhandler/handlers/generic/plugin"
 	"ocm.software/ocm/api/ocm/extensions/download"
 	plugindownload "ocm.software/ocm/api/ocm/extensions/download/handlers/plugin"
 	"ocm.software/ocm/api/ocm/extensions/labels/routingslip/spi"
 	pluginroutingslip "ocm.software/ocm/api/ocm/extensions/labels/routingslip/types/plugin"
+	"ocm.software/ocm/api/ocm/extensions/pubsub"
+	pluginpubsub "ocm.software/ocm/api/ocm/extensions/pubsub/types/plugin"
 	"ocm.software/ocm/api/ocm/plugin/descriptor"
 	"ocm.software/ocm/api/ocm/valuemergehandler"
 	pluginmerge "ocm.software/ocm/api/ocm/valuemergehandler/handlers/plugin"
 	"ocm.software/ocm/api/ocm/valuemergehandler/hpi"
+	"ocm.software/ocm/api/tech/signing"
+	pluginsigning "ocm.software/ocm/api/tech/signing/handlers/plugin"
 	"ocm.software/ocm/api/utils/runtime"
 )
 
@@ -30,6 +36,10 @@ func RegisterExtensions(ctxp ocm.ContextProvider) error {
 
 	logger := Logger(ctx)
 	vmreg := valuemergehandler.For(ctx)
+
+	// signing handlers are registered in a context specific registry
+	// to avoid modifications of the default registry.
+	var signers signing.HandlerRegistry
 	for _, n := range pi.PluginNames() {
 		p := pi.Get(n)
 		if !p.IsValid() {
@@ -70,6 +80,43 @@ func RegisterExtensions(ctxp ocm.ContextProvider) error {
 			pi.GetContext().AccessMethods().Register(pluginaccess.NewType(name, p, &m))
 		}
 
+		for _, m := range p.GetDescriptor().CredentialRepositories {
+			name := m.Name
+			if m.Version != "" {
+				name = name + runtime.VersionSeparator + m.Version
+			}
+			logger.Info("registering credential repository type",
+				"plugin", p.Name(),
+				"type", name)
+			ctx.CredentialsContext().RepositoryTypes().Register(plugincreds.NewType(name, p, &m))
+		}
+
+		for _, m := range p.GetDescriptor().PubSubTypes {
+			name := m.Name
+			if m.Version != "" {
+				name = name + runtime.VersionSeparator + m.Version
+			}
+			logger.Info("registering pub/sub type",
+				"plugin", p.Name(),
+				"type", name)
+			pubsub.For(ctx).TypeScheme.Register(pluginpubsub.NewType(name, p, &m))
+		}
+
+		for _, s := range p.GetDescriptor().SigningHandlers {
+			h, err := pluginsigning.New(p, s.Name)
+			if err != nil {
+				logger.Error("cannot create signing handler for plugin", "plugin", p.Name(), "algorithm", s.Name)
+			} else {
+				logger.Info("registering signing handler",
+					"plugin", p.Name(),
+					"algorithm", s.Name)
+				if signers == nil {
+					signers = signing.NewHandlerRegistry(signingattr.Get(ctx).HandlerRegistry())
+				}
+				signers.RegisterSignatureHandler(h)
+			}
+		}
+
 		for _, m := range p.GetDescriptor().ValueSets {
 			if !slices.Contains(m.Purposes, descriptor.PURPOSE_ROUTINGSLIP) {
 				continue
@@ -150,5 +197,8 @@ func RegisterExtensions(ctxp ocm.ContextProvider) error {
 			registry.Register(t)
 		}
 	}
+	if signers != nil {
+		return signingattr.SetHandlerRegistry(ctx, signers)
+	}
 	return nil
 }
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/mandelsoft/goutils/errors"
+)
+
+// Client executes plugin requests on a connection to a plugin server.
+// Requests are executed sequentially.
+type Client struct {
+	lock   sync.Mutex
+	conn   io.ReadWriteCloser
+	frames *frameWriter
+	broken error
+}
+
+func NewClient(conn io.ReadWriteCloser) *Client {
+	return &Client{
+		conn:   conn,
+		frames: newFrameWriter(conn),
+	}
+}
+
+// Broken returns the transport error, if the connection
+// cannot be used anymore.
+func (c *Client) Broken() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.broken
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Exec executes a plugin command with the given arguments. The given
+// reader is used as standard input, the standard and error output
+// of the command is written to the given writers.
+// The error returned by the plugin command is returned as error.
+// Transport errors are returned, also, and mark the client as broken.
+func (c *Client) Exec(r io.Reader, stdout, stderr io.Writer, args ...string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.broken != nil {
+		return c.broken
+	}
+	err := c.exec(r, stdout, stderr, args)
+	if c.broken != nil {
+		return c.broken
+	}
+	return err
+}
+
+func (c *Client) exec(r io.Reader, stdout, stderr io.Writer, args []string) error {
+	data, err := json.Marshal(&Request{Args: args})
+	if err != nil {
+		return err
+	}
+	if err = c.frames.write(frameRequest, data); err != nil {
+		c.broken = errors.Wrapf(err, "plugin server connection")
+		return err
+	}
+
+	in := &input{frames: c.frames}
+	go in.send(r)
+
+	var werr error
+	for {
+		t, data, err := readFrame(c.conn)
+		if err != nil {
+			c.broken = errors.Wrapf(err, "plugin server connection")
+			break
+		}
+		switch t {
+		case frameStdout:
+			werr = write(stdout, data, werr)
+		case frameStderr:
+			werr = write(stderr, data, werr)
+		case frameExit:
+			var result Result
+			if err = json.Unmarshal(data, &result); err != nil {
+				c.broken = errors.Wrapf(err, "invalid plugin result")
+				break
+			}
+			in.finish()
+			if result.Error != "" {
+				return errors.New(result.Error)
+			}
+			return werr
+		default:
+			c.broken = fmt.Errorf("unexpected plugin protocol frame %q", t)
+		}
+		if c.broken != nil {
+			break
+		}
+	}
+	in.finish()
+	c.conn.Close()
+	return c.broken
+}
+
+// input forwards the standard input of a request.
+// After the request has been finished, no more frames are written,
+// so that the connection can be used for the next request, even if
+// the forwarding is still blocked reading the input.
+type input struct {
+	lock     sync.Mutex
+	frames   *frameWriter
+	finished bool
+}
+
+func (i *input) finish() {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	i.finished = true
+}
+
+func (i *input) write(data []byte) bool {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	if i.finished {
+		return false
+	}
+	return i.frames.write(frameStdin, data) == nil
+}
+
+// send forwards the given reader, until the input is exhausted
+// or the request has been finished.
+func (i *input) send(r io.Reader) {
+	if r != nil {
+		buf := make([]byte, maxFrameSize)
+		for {
+			n, err := r.Read(buf)
+			if n > 0 && !i.write(buf[:n]) {
+				return
+			}
+			if err != nil {
+				break
+			}
+		}
+	}
+	i.write(nil)
+}
+
+func write(w io.Writer, data []byte, err error) error {
+	if w == nil || err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
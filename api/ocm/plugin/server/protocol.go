@@ -0,0 +1,116 @@
+package server
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+)
+
+const (
+	TRANSPORT_STDIO = descriptor.TRANSPORT_STDIO
+	TRANSPORT_UNIX  = descriptor.TRANSPORT_UNIX
+)
+
+// frameType is the type of a protocol frame. The protocol multiplexes
+// the standard streams of sequentially executed plugin requests
+// over a single connection.
+type frameType byte
+
+const (
+	// frameRequest starts a new request. The payload is a JSON encoded Request.
+	frameRequest frameType = 'R'
+	// frameStdin forwards standard input. An empty payload closes the stream.
+	frameStdin frameType = 'I'
+	// frameStdout forwards standard output.
+	frameStdout frameType = 'O'
+	// frameStderr forwards error output.
+	frameStderr frameType = 'E'
+	// frameExit finishes a request. The payload is a JSON encoded Result.
+	frameExit frameType = 'X'
+)
+
+const (
+	headerSize   = 5
+	maxFrameSize = 64 * 1024
+)
+
+// Request describes a plugin command execution.
+type Request struct {
+	Args []string `json:"args"`
+}
+
+// Result describes the outcome of a plugin command execution.
+type Result struct {
+	Error string `json:"error,omitempty"`
+}
+
+func readFrame(r io.Reader) (frameType, []byte, error) {
+	var header [headerSize]byte
+
+	_, err := io.ReadFull(r, header[:])
+	if err != nil {
+		return 0, nil, err
+	}
+	n := binary.BigEndian.Uint32(header[1:])
+	if n > maxFrameSize {
+		return 0, nil, fmt.Errorf("plugin protocol frame size %d exceeds limit", n)
+	}
+	data := make([]byte, n)
+	_, err = io.ReadFull(r, data)
+	if err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, nil, err
+	}
+	return frameType(header[0]), data, nil
+}
+
+// frameWriter serializes frames written by concurrent streams.
+type frameWriter struct {
+	lock sync.Mutex
+	w    io.Writer
+}
+
+func newFrameWriter(w io.Writer) *frameWriter {
+	return &frameWriter{w: w}
+}
+
+func (f *frameWriter) write(t frameType, data []byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var header [headerSize]byte
+	header[0] = byte(t)
+	binary.BigEndian.PutUint32(header[1:], uint32(len(data)))
+	_, err := f.w.Write(header[:])
+	if err == nil && len(data) > 0 {
+		_, err = f.w.Write(data)
+	}
+	return err
+}
+
+// streamWriter is an io.Writer sending the written data
+// as frames of a dedicated type.
+type streamWriter struct {
+	frames *frameWriter
+	typ    frameType
+}
+
+var _ io.Writer = (*streamWriter)(nil)
+
+func (s *streamWriter) Write(data []byte) (int, error) {
+	n := 0
+	for len(data) > 0 {
+		l := min(len(data), maxFrameSize)
+		if err := s.frames.write(s.typ, data[:l]); err != nil {
+			return n, err
+		}
+		n += l
+		data = data[l:]
+	}
+	return n, nil
+}
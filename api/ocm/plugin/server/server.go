@@ -0,0 +1,125 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/mandelsoft/goutils/errors"
+)
+
+// Handler executes a single plugin request with the given arguments
+// and standard streams.
+type Handler func(args []string, stdin io.Reader, stdout, stderr io.Writer) error
+
+var errRequestFinished = errors.New("plugin request finished")
+
+// Serve serves plugin requests received on the given connection until
+// the connection is closed. The requests are executed sequentially,
+// their standard streams are multiplexed over the connection.
+func Serve(conn io.ReadWriter, h Handler) error {
+	frames := newFrameWriter(conn)
+	var stdin *io.PipeWriter
+	var done chan struct{}
+
+	defer func() {
+		if stdin != nil {
+			stdin.Close()
+			<-done
+		}
+	}()
+	for {
+		t, data, err := readFrame(conn)
+		if err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		switch t {
+		case frameRequest:
+			if done != nil {
+				// the client always waits for the result of the previous request,
+				// so the previous handler has already finished.
+				<-done
+			}
+			var req Request
+			if err := json.Unmarshal(data, &req); err != nil {
+				return fmt.Errorf("invalid plugin request: %w", err)
+			}
+			r, w := io.Pipe()
+			stdin = w
+			done = make(chan struct{})
+			go execute(frames, h, req.Args, r, done)
+		case frameStdin:
+			// input for an already finished request is discarded.
+			if stdin == nil {
+				continue
+			}
+			if len(data) == 0 {
+				stdin.Close()
+			} else {
+				stdin.Write(data)
+			}
+		default:
+			return fmt.Errorf("unexpected plugin protocol frame %q", t)
+		}
+	}
+}
+
+func execute(frames *frameWriter, h Handler, args []string, stdin *io.PipeReader, done chan struct{}) {
+	defer close(done)
+
+	err := h(args, stdin, &streamWriter{frames, frameStdout}, &streamWriter{frames, frameStderr})
+	stdin.CloseWithError(errRequestFinished)
+
+	var result Result
+	if err != nil {
+		result.Error = err.Error()
+	}
+	data, err := json.Marshal(&result)
+	if err == nil {
+		frames.write(frameExit, data)
+	}
+}
+
+// ServeSocket listens on a unix domain socket and serves the requests of
+// all accepted connections. The requests of all connections are
+// executed sequentially. Serving is stopped, if the given
+// control stream (typically the standard input) is closed.
+func ServeSocket(path string, control io.Reader, h Handler) error {
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(path)
+
+	go func() {
+		io.Copy(io.Discard, control)
+		l.Close()
+	}()
+
+	var lock sync.Mutex
+	serial := func(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+		lock.Lock()
+		defer lock.Unlock()
+		return h(args, stdin, stdout, stderr)
+	}
+
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer conn.Close()
+			Serve(conn, serial)
+		}()
+	}
+}
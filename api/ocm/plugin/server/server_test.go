@@ -0,0 +1,93 @@
+package server_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm/plugin/server"
+)
+
+func handler(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	switch args[0] {
+	case "upper":
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return err
+		}
+		_, err = stdout.Write([]byte(strings.ToUpper(string(data))))
+		return err
+	case "args":
+		fmt.Fprintf(stdout, "%s", strings.Join(args[1:], ","))
+		fmt.Fprintf(stderr, "%d", len(args)-1)
+		return nil
+	default:
+		return errors.Newf("unknown command %q", args[0])
+	}
+}
+
+var _ = Describe("plugin server protocol", func() {
+	var client *server.Client
+	var served chan error
+
+	BeforeEach(func() {
+		c, s := net.Pipe()
+		served = make(chan error, 1)
+		go func() {
+			served <- server.Serve(s, handler)
+			s.Close()
+		}()
+		client = server.NewClient(c)
+	})
+
+	AfterEach(func() {
+		MustBeSuccessful(client.Close())
+		Expect(<-served).To(Or(BeNil(), MatchError(ContainSubstring("closed pipe"))))
+	})
+
+	It("executes sequential requests", func() {
+		for i := 0; i < 3; i++ {
+			stdout := bytes.NewBuffer(nil)
+			stderr := bytes.NewBuffer(nil)
+			MustBeSuccessful(client.Exec(nil, stdout, stderr, "args", "a", "b"))
+			Expect(stdout.String()).To(Equal("a,b"))
+			Expect(stderr.String()).To(Equal("2"))
+		}
+		Expect(client.Broken()).To(BeNil())
+	})
+
+	It("transfers large input", func() {
+		in := strings.Repeat("0123456789abcdef", 10000)
+		stdout := bytes.NewBuffer(nil)
+		MustBeSuccessful(client.Exec(strings.NewReader(in), stdout, nil, "upper"))
+		Expect(stdout.String()).To(Equal(strings.ToUpper(in)))
+	})
+
+	It("handles unconsumed input", func() {
+		in := strings.Repeat("0123456789abcdef", 10000)
+		stdout := bytes.NewBuffer(nil)
+		MustBeSuccessful(client.Exec(strings.NewReader(in), stdout, nil, "args", "x"))
+		Expect(stdout.String()).To(Equal("x"))
+
+		stdout.Reset()
+		MustBeSuccessful(client.Exec(strings.NewReader("next"), stdout, nil, "upper"))
+		Expect(stdout.String()).To(Equal("NEXT"))
+	})
+
+	It("reports command errors", func() {
+		ExpectError(client.Exec(nil, nil, nil, "unknown")).To(MatchError(`unknown command "unknown"`))
+		Expect(client.Broken()).To(BeNil())
+
+		stdout := bytes.NewBuffer(nil)
+		MustBeSuccessful(client.Exec(strings.NewReader("ok"), stdout, nil, "upper"))
+		Expect(stdout.String()).To(Equal("OK"))
+	})
+})
@@ -0,0 +1,291 @@
+package plugin
+
+import (
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm/plugin/config"
+	"ocm.software/ocm/api/ocm/plugin/descriptor"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds/server"
+	protocol "ocm.software/ocm/api/ocm/plugin/server"
+	"ocm.software/ocm/api/utils/accessio"
+)
+
+// DefaultServerInstances is the default maximum number of
+// plugin server processes used in parallel per plugin and context.
+const DefaultServerInstances = 4
+
+// socketTimeout is the maximum time to wait for a plugin server
+// to provide its unix domain socket.
+const socketTimeout = 10 * time.Second
+
+// serverPool manages the long-running plugin processes
+// used for a plugin supporting the server mode.
+// A plugin process executes only one request at a time, pooling
+// means reusing processes for subsequent requests. Parallel requests
+// use different processes, up to the configured number of instances.
+// The pool is closed when the context of the plugin is finalized.
+type serverPool struct {
+	lock      sync.Mutex
+	cond      *sync.Cond
+	plugin    *pluginImpl
+	transport string
+	instances int
+	count     int
+	idle      []*serverInstance
+	disabled  bool
+}
+
+func newServerPool(p *pluginImpl, transport string, instances int) *serverPool {
+	if instances <= 0 {
+		instances = DefaultServerInstances
+	}
+	pool := &serverPool{
+		plugin:    p,
+		transport: transport,
+		instances: instances,
+	}
+	pool.cond = sync.NewCond(&pool.lock)
+	return pool
+}
+
+// Exec executes a plugin command with a pooled plugin server.
+// If no server could be started, the server mode is disabled
+// and false is returned to request a regular plugin execution.
+func (s *serverPool) Exec(config []byte, r io.Reader, w io.Writer, args ...string) (bool, []byte, error) {
+	inst, err := s.acquire()
+	if inst == nil {
+		if err != nil {
+			s.plugin.ctx.Logger(TAG).Warn("cannot start plugin server, falling back to plugin execution", "plugin", s.plugin.Name(), "error", err.Error())
+		}
+		return false, nil, nil
+	}
+
+	if len(config) > 0 {
+		args = append([]string{"-c", string(config)}, args...)
+	}
+	stdout := w
+	if w == nil {
+		stdout = accessio.LimitBuffer(accessio.DESCRIPTOR_LIMIT)
+	}
+	stderr := accessio.LimitBuffer(accessio.DESCRIPTOR_LIMIT)
+
+	err = inst.client.Exec(r, stdout, stderr, args...)
+	s.release(inst)
+	if err != nil {
+		return true, nil, err
+	}
+	if l, ok := stdout.(*accessio.LimitedBuffer); ok {
+		if l.Exceeded() {
+			return true, nil, errors.Newf("stdout limit exceeded")
+		}
+		return true, l.Bytes(), nil
+	}
+	return true, nil, nil
+}
+
+func (s *serverPool) acquire() (*serverInstance, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for {
+		if s.disabled {
+			return nil, nil
+		}
+		if len(s.idle) > 0 {
+			inst := s.idle[len(s.idle)-1]
+			s.idle = s.idle[:len(s.idle)-1]
+			return inst, nil
+		}
+		if s.count < s.instances {
+			s.count++
+			s.lock.Unlock()
+			inst, err := startServer(s.plugin.Path(), s.transport)
+			s.lock.Lock()
+			if err != nil {
+				s.count--
+				s.disabled = true
+				s.cond.Broadcast()
+				return nil, err
+			}
+			s.plugin.ctx.Logger(TAG).Debug("started plugin server", "plugin", s.plugin.Name(), "transport", s.transport, "instances", s.count)
+			return inst, nil
+		}
+		s.cond.Wait()
+	}
+}
+
+func (s *serverPool) release(inst *serverInstance) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.disabled || inst.client.Broken() != nil {
+		if err := inst.client.Broken(); err != nil {
+			s.plugin.ctx.Logger(TAG).Debug("discarding plugin server", "plugin", s.plugin.Name(), "error", err.Error())
+		}
+		inst.Close()
+		s.count--
+	} else {
+		s.idle = append(s.idle, inst)
+	}
+	s.cond.Signal()
+}
+
+// Close stops all idle plugin servers and disables the pool.
+// Servers in use are stopped when they are released.
+func (s *serverPool) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	list := errors.ErrListf("stopping plugin servers")
+	for _, inst := range s.idle {
+		list.Add(inst.Close())
+		s.count--
+	}
+	s.idle = nil
+	s.disabled = true
+	s.cond.Broadcast()
+	return list.Result()
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// serverInstance is a running plugin server process.
+type serverInstance struct {
+	cmd     *exec.Cmd
+	client  *protocol.Client
+	control io.Closer
+	dir     string
+	exited  chan struct{}
+}
+
+func (i *serverInstance) Close() error {
+	list := errors.ErrListf("stopping plugin server")
+	list.Add(i.client.Close())
+	if i.control != nil {
+		list.Add(i.control.Close())
+	}
+	select {
+	case <-i.exited:
+	case <-time.After(socketTimeout):
+		list.Add(i.cmd.Process.Kill())
+		<-i.exited
+	}
+	if i.dir != "" {
+		list.Add(os.RemoveAll(i.dir))
+	}
+	return list.Result()
+}
+
+// pipeConn is a connection based on the standard
+// streams of a plugin process.
+type pipeConn struct {
+	io.Reader
+	io.WriteCloser
+}
+
+func startServer(path string, transport string) (*serverInstance, error) {
+	var err error
+
+	stderr := accessio.LimitBuffer(accessio.DESCRIPTOR_LIMIT)
+	inst := &serverInstance{
+		exited: make(chan struct{}),
+	}
+
+	socket := ""
+	args := []string{server.Name}
+	if transport == descriptor.TRANSPORT_UNIX {
+		inst.dir, err = os.MkdirTemp("", "ocm-plugin-*")
+		if err != nil {
+			return nil, err
+		}
+		socket = filepath.Join(inst.dir, "plugin.sock")
+		args = append(args, "--"+server.OptSocket, socket)
+	}
+
+	inst.cmd = exec.Command(path, args...)
+	inst.cmd.Stderr = stderr
+	stdin, err := inst.cmd.StdinPipe()
+	if err == nil {
+		var stdout io.Reader
+		if socket == "" {
+			stdout, err = inst.cmd.StdoutPipe()
+		}
+		if err == nil {
+			err = inst.cmd.Start()
+		}
+		if err == nil {
+			go func() {
+				inst.cmd.Wait()
+				close(inst.exited)
+			}()
+			if socket == "" {
+				inst.client = protocol.NewClient(&pipeConn{stdout, stdin})
+				return inst, nil
+			}
+			inst.control = stdin
+			var conn net.Conn
+			conn, err = connect(socket, inst.exited)
+			if err == nil {
+				inst.client = protocol.NewClient(conn)
+				return inst, nil
+			}
+			stdin.Close()
+			<-inst.exited
+		}
+	}
+	if inst.dir != "" {
+		os.RemoveAll(inst.dir)
+	}
+	if msg := strings.TrimSpace(string(stderr.Bytes())); msg != "" {
+		return nil, errors.Wrapf(err, "%s", msg)
+	}
+	return nil, err
+}
+
+func connect(socket string, exited chan struct{}) (net.Conn, error) {
+	timeout := time.After(socketTimeout)
+	for {
+		conn, err := net.Dial("unix", socket)
+		if err == nil {
+			return conn, nil
+		}
+		select {
+		case <-exited:
+			return nil, errors.Newf("plugin server terminated")
+		case <-timeout:
+			return nil, errors.Wrapf(err, "plugin server socket not available")
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
+
+// selectTransport determines the transport to use for a plugin server.
+// An empty string is returned, if the server mode cannot be used.
+func selectTransport(desc *descriptor.ServerDescriptor, mode *config.ServerMode) string {
+	if desc == nil || (mode != nil && mode.Disabled) {
+		return ""
+	}
+	if mode != nil && mode.Transport != "" {
+		if desc.SupportsTransport(mode.Transport) {
+			return mode.Transport
+		}
+		return ""
+	}
+	if desc.SupportsTransport(descriptor.TRANSPORT_STDIO) {
+		return descriptor.TRANSPORT_STDIO
+	}
+	if desc.SupportsTransport(descriptor.TRANSPORT_UNIX) && runtime.GOOS != "windows" {
+		return descriptor.TRANSPORT_UNIX
+	}
+	return ""
+}
@@ -0,0 +1,188 @@
+//go:build unix
+
+package plugin_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/plugin"
+	"ocm.software/ocm/api/ocm/plugin/cache"
+	"ocm.software/ocm/api/ocm/plugin/config"
+	"ocm.software/ocm/api/ocm/plugin/ppi"
+	"ocm.software/ocm/api/ocm/plugin/ppi/clicmd"
+	"ocm.software/ocm/api/ocm/plugin/ppi/cmds"
+)
+
+// ENV_SERVER_TEST is used to run the test binary as plugin.
+const ENV_SERVER_TEST = "OCM_PLUGIN_SERVER_TEST"
+
+func init() {
+	if os.Getenv(ENV_SERVER_TEST) != "" {
+		if err := cmds.NewPluginCommand(serverTestPlugin()).Execute(os.Args[1:]); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+}
+
+func serverTestPlugin() ppi.Plugin {
+	p := ppi.NewPlugin("servertest", "v1")
+	p.SetShort("server test plugin")
+	p.EnableServerMode()
+
+	echo := &cobra.Command{
+		Use:   "echo",
+		Short: "echo stdin",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			data, err := io.ReadAll(cmd.InOrStdin())
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "%d:%s", os.Getpid(), strings.ToUpper(string(data)))
+			return nil
+		},
+	}
+	registerCommand(p, echo)
+
+	fail := &cobra.Command{
+		Use:   "fail",
+		Short: "always fail",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return errors.New("request failed")
+		},
+	}
+	registerCommand(p, fail)
+	return p
+}
+
+// registerCommand is used outside of ginkgo, also, therefore
+// no gomega assertions can be used.
+func registerCommand(p ppi.Plugin, cmd *cobra.Command) {
+	c, err := clicmd.NewCLICommand(cmd)
+	if err == nil {
+		err = p.RegisterCommand(c)
+	}
+	if err != nil {
+		panic(err)
+	}
+}
+
+var _ = Describe("plugin server mode", func() {
+	var p plugin.Plugin
+
+	BeforeEach(func() {
+		os.Setenv(ENV_SERVER_TEST, "true")
+		desc := serverTestPlugin().Descriptor()
+		p = plugin.NewPlugin(ocm.New(), cache.NewPlugin("servertest", os.Args[0], &desc, ""), nil)
+	})
+
+	AfterEach(func() {
+		MustBeSuccessful(p.Close())
+		os.Unsetenv(ENV_SERVER_TEST)
+	})
+
+	echo := func(in string) (string, string) {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(p.Command("echo", strings.NewReader(in), buf, nil))
+		pid, out, _ := strings.Cut(buf.String(), ":")
+		Expect(pid).NotTo(Equal(fmt.Sprintf("%d", os.Getpid())))
+		return pid, out
+	}
+
+	It("announces server mode", func() {
+		Expect(p.GetDescriptor().Server).NotTo(BeNil())
+		Expect(p.GetDescriptor().Capabilities()).To(ContainElement("Server Mode"))
+	})
+
+	It("reuses plugin process", func() {
+		pid, out := echo("hello")
+		Expect(out).To(Equal("HELLO"))
+		pid2, out := echo("world")
+		Expect(out).To(Equal("WORLD"))
+		Expect(pid2).To(Equal(pid))
+	})
+
+	It("transfers large streams", func() {
+		in := strings.Repeat("0123456789abcdef", 20000)
+		_, out := echo(in)
+		Expect(out).To(Equal(strings.ToUpper(in)))
+	})
+
+	It("propagates errors", func() {
+		ExpectError(p.Command("fail", nil, io.Discard, nil)).To(MatchError(ContainSubstring("request failed")))
+		pid, _ := echo("after error")
+		pid2, _ := echo("still alive")
+		Expect(pid2).To(Equal(pid))
+	})
+
+	It("restarts plugin process after close", func() {
+		pid, _ := echo("first")
+		MustBeSuccessful(p.Close())
+		pid2, _ := echo("second")
+		Expect(pid2).NotTo(Equal(pid))
+	})
+
+	It("uses unix domain socket", func() {
+		p.SetServerMode(&config.ServerMode{Transport: "unix"})
+		pid, out := echo("socket")
+		Expect(out).To(Equal("SOCKET"))
+		pid2, _ := echo("socket")
+		Expect(pid2).To(Equal(pid))
+	})
+
+	It("does not wait for unread input after the request", func() {
+		r, w := io.Pipe()
+		defer w.Close()
+		ExpectError(p.Command("fail", r, io.Discard, nil)).To(MatchError(ContainSubstring("request failed")))
+		_, out := echo("next")
+		Expect(out).To(Equal("NEXT"))
+	})
+
+	It("stops plugin servers on context finalization", func() {
+		tmp := GinkgoT().TempDir()
+		old, ok := os.LookupEnv("TMPDIR")
+		os.Setenv("TMPDIR", tmp)
+		DeferCleanup(func() {
+			if ok {
+				os.Setenv("TMPDIR", old)
+			} else {
+				os.Unsetenv("TMPDIR")
+			}
+		})
+
+		ctx := ocm.New()
+		desc := serverTestPlugin().Descriptor()
+		p = plugin.NewPlugin(ctx, cache.NewPlugin("servertest", os.Args[0], &desc, ""), nil)
+		p.SetServerMode(&config.ServerMode{Transport: "unix"})
+		spid, _ := echo("socket")
+		pid := Must(strconv.Atoi(spid))
+		Expect(os.ReadDir(tmp)).To(HaveLen(1))
+		Expect(syscall.Kill(pid, 0)).To(Succeed())
+
+		MustBeSuccessful(ctx.Finalize())
+		Expect(os.ReadDir(tmp)).To(BeEmpty())
+		Expect(syscall.Kill(pid, 0)).To(MatchError(syscall.ESRCH))
+	})
+
+	It("executes plugin per request with disabled server mode", func() {
+		p.SetServerMode(&config.ServerMode{Disabled: true})
+		pid, out := echo("plain")
+		Expect(out).To(Equal("PLAIN"))
+		pid2, _ := echo("plain")
+		Expect(pid2).NotTo(Equal(pid))
+	})
+})
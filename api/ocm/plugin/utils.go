@@ -28,3 +28,22 @@ func (d *AccessDataWriter) WriteTo(w accessio.Writer) (int64, digest.Digest, err
 	}
 	return dw.Size(), dw.Digest(), nil
 }
+
+type InputDataWriter struct {
+	plugin Plugin
+	dir    string
+	spec   json.RawMessage
+}
+
+func NewInputDataWriter(p Plugin, dir string, spec json.RawMessage) *InputDataWriter {
+	return &InputDataWriter{p, dir, spec}
+}
+
+func (d *InputDataWriter) WriteTo(w accessio.Writer) (int64, digest.Digest, error) {
+	dw := iotools.NewDefaultDigestWriter(accessio.NopWriteCloser(w))
+	err := d.plugin.GetInput(dw, d.dir, d.spec)
+	if err != nil {
+		return blobaccess.BLOB_UNKNOWN_SIZE, blobaccess.BLOB_UNKNOWN_DIGEST, err
+	}
+	return dw.Size(), dw.Digest(), nil
+}
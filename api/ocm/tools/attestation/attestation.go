@@ -0,0 +1,241 @@
+package attestation
+
+import (
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
+	"ocm.software/ocm/api/ocm/tools/signing"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+// Create creates an in-toto statement for the given component version.
+// The subjects of the statement are the digest of the normalized component
+// descriptor, as used for OCM signatures, and the digests of all resources
+// with a digest. Component references are described as resolved dependencies
+// of the provenance predicate.
+func Create(cv ocm.ComponentVersionAccess, opts ...Option) (*Statement, error) {
+	stmt, _, _, err := create(cv, EvalOptions(opts...))
+	return stmt, err
+}
+
+func create(cv ocm.ComponentVersionAccess, eff *Options) (*Statement, *compdesc.ComponentDescriptor, *signing.Options, error) {
+	started := time.Now().UTC()
+
+	sopts := signing.NewOptions(
+		signing.Resolver(eff.Resolver),
+		signing.VerifyDigests(),
+		signing.Recursive(),
+		&signing.Options{
+			HashAlgo:          eff.HashAlgo,
+			NormalizationAlgo: eff.NormalizationAlgo,
+		},
+	)
+	err := sopts.Complete(cv.GetContext())
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "invalid options")
+	}
+
+	state := signing.DefaultWalkingState(cv.GetContext())
+	digest, err := signing.Apply(nil, state, cv, sopts)
+	if err != nil {
+		return nil, nil, nil, errors.Wrapf(err, "cannot determine digests for %s", common.VersionedElementKey(cv))
+	}
+
+	nv := common.VersionedElementKey(cv)
+	cd := state.GetContext(nv, nv).Descriptor
+	subject := componentVersionSubject(cd, digest)
+
+	stmt := &Statement{
+		Type:          StatementType,
+		Subject:       []ResourceDescriptor{*subject},
+		PredicateType: PredicateTypeSLSAProvenance,
+		Predicate: &Provenance{
+			BuildDefinition: BuildDefinition{
+				BuildType: BuildType,
+				ExternalParameters: map[string]interface{}{
+					"component": cd.GetName(),
+					"version":   cd.GetVersion(),
+				},
+			},
+			RunDetails: RunDetails{
+				Builder: Builder{ID: eff.BuilderID},
+			},
+		},
+	}
+
+	for _, r := range cd.Resources {
+		if r.Type == artifacttypes.ATTESTATION || r.Digest == nil || r.Digest.IsNone() || r.Digest.IsExcluded() {
+			continue
+		}
+		stmt.Subject = append(stmt.Subject, ResourceDescriptor{
+			Name:        ResourceSubjectName(cd, &r),
+			Digest:      Digest(r.Digest),
+			Annotations: resourceAnnotations(cd, &r),
+		})
+	}
+
+	for _, r := range cd.References {
+		if r.Digest == nil || r.Digest.IsNone() || r.Digest.IsExcluded() {
+			continue
+		}
+		stmt.Predicate.BuildDefinition.ResolvedDependencies = append(stmt.Predicate.BuildDefinition.ResolvedDependencies,
+			ResourceDescriptor{
+				Name:   common.NewNameVersion(r.ComponentName, r.Version).String(),
+				Digest: Digest(r.Digest),
+				Annotations: map[string]string{
+					ANNOTATION_KIND:          KIND_REFERENCE,
+					ANNOTATION_NORMALISATION: r.Digest.NormalisationAlgorithm,
+				},
+			})
+	}
+
+	finished := time.Now().UTC()
+	stmt.Predicate.RunDetails.Metadata = &BuildMetadata{
+		InvocationID: eff.InvocationID,
+		StartedOn:    &started,
+		FinishedOn:   &finished,
+	}
+	return stmt, cd, sopts, nil
+}
+
+// Validate checks whether the subjects of the given statement match
+// the actual digests of the component version.
+// A statement stored as attestation resource of the component version
+// (see AddResource) has been created before the resource has been added.
+// Therefore, for the component version subject, the digest of the
+// component version without its attestation resources is accepted, also.
+func Validate(cv ocm.ComponentVersionAccess, stmt *Statement, opts ...Option) error {
+	eff := EvalOptions(opts...)
+	norm, hash := "", ""
+	if s := stmt.GetSubject(ComponentVersionSubjectName(cv)); s != nil {
+		norm = s.Annotations[ANNOTATION_NORMALISATION]
+		for k := range s.Digest {
+			hash = k
+		}
+	}
+	if eff.NormalizationAlgo == "" && norm != "" {
+		opts = append(opts, WithNormalizationAlgorithm(norm))
+	}
+	if eff.HashAlgo == "" && hash != "" {
+		if h := signingHashAlgorithm(cv, hash); h != "" {
+			opts = append(opts, WithHashAlgorithm(h))
+		}
+	}
+	act, cd, sopts, err := create(cv, EvalOptions(opts...))
+	if err != nil {
+		return err
+	}
+	for i := range stmt.Subject {
+		s := &stmt.Subject[i]
+		a := act.GetSubject(s.Name)
+		if a == nil {
+			return errors.ErrNotFound(KIND_SUBJECT, s.Name, common.VersionedElementKey(cv).String())
+		}
+		if !matchDigest(s, a) && s.Name == ComponentVersionSubjectName(cv) {
+			a, err = unattestedSubject(cd, sopts)
+			if err != nil {
+				return err
+			}
+		}
+		if !matchDigest(s, a) {
+			return errors.Newf("digest mismatch for subject %q", s.Name)
+		}
+	}
+	return nil
+}
+
+// ComponentVersionSubjectName provides the subject name used for
+// a component version.
+func ComponentVersionSubjectName(cv common.VersionedElement) string {
+	return common.VersionedElementKey(cv).String()
+}
+
+// ResourceSubjectName provides the subject name used for a resource
+// of a component version.
+func ResourceSubjectName(cd *compdesc.ComponentDescriptor, r *compdesc.Resource) string {
+	name := ComponentVersionSubjectName(cd) + "/" + r.Name
+	if len(r.ExtraIdentity) > 0 {
+		name += "[" + r.ExtraIdentity.String() + "]"
+	}
+	return name
+}
+
+// Digest maps an OCM digest specification to an in-toto digest set.
+func Digest(d *metav1.DigestSpec) map[string]string {
+	return map[string]string{DigestAlgorithm(d.HashAlgorithm): d.Value}
+}
+
+func componentVersionSubject(cd *compdesc.ComponentDescriptor, digest *metav1.DigestSpec) *ResourceDescriptor {
+	return &ResourceDescriptor{
+		Name:   ComponentVersionSubjectName(cd),
+		Digest: Digest(digest),
+		Annotations: map[string]string{
+			ANNOTATION_KIND:          KIND_COMPONENTVERSION,
+			ANNOTATION_NORMALISATION: digest.NormalisationAlgorithm,
+		},
+	}
+}
+
+// unattestedSubject provides the component version subject for the
+// component version without its attestation resources.
+func unattestedSubject(cd *compdesc.ComponentDescriptor, opts *signing.Options) (*ResourceDescriptor, error) {
+	cd = cd.Copy()
+	resources := cd.Resources[:0]
+	for _, r := range cd.Resources {
+		if r.Type != artifacttypes.ATTESTATION {
+			resources = append(resources, r)
+		}
+	}
+	cd.Resources = resources
+
+	digest, err := compdesc.Hash(cd, opts.NormalizationAlgo, opts.Hasher.Create())
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot determine digest for %s", common.VersionedElementKey(cd))
+	}
+	return componentVersionSubject(cd, &metav1.DigestSpec{
+		HashAlgorithm:          opts.Hasher.Algorithm(),
+		NormalisationAlgorithm: opts.NormalizationAlgo,
+		Value:                  digest,
+	}), nil
+}
+
+// matchDigest checks whether all digests of the expected subject
+// are provided by the actual subject. A subject without any
+// digest never matches.
+func matchDigest(expected, actual *ResourceDescriptor) bool {
+	if len(expected.Digest) == 0 {
+		return false
+	}
+	for k, v := range expected.Digest {
+		if actual.Digest[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func resourceAnnotations(cd *compdesc.ComponentDescriptor, r *compdesc.Resource) map[string]string {
+	annos := map[string]string{
+		ANNOTATION_KIND:          KIND_RESOURCE,
+		ANNOTATION_NORMALISATION: r.Digest.NormalisationAlgorithm,
+	}
+	if len(r.ExtraIdentity) > 0 {
+		annos[ANNOTATION_IDENTITY] = r.GetIdentity(cd.Resources).String()
+	}
+	return annos
+}
+
+func signingHashAlgorithm(cv ocm.ComponentVersionAccess, algo string) string {
+	for _, n := range signingattr.Get(cv.GetContext()).HandlerRegistry().HasherRegistry().HasherNames() {
+		if DigestAlgorithm(n) == algo {
+			return n
+		}
+	}
+	return ""
+}
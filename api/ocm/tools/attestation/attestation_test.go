@@ -0,0 +1,180 @@
+package attestation_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+	. "ocm.software/ocm/api/ocm/testhelper"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/resolvers"
+	"ocm.software/ocm/api/ocm/tools/attestation"
+	"ocm.software/ocm/api/ocm/tools/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+)
+
+const (
+	ARCH       = "/tmp/ctf"
+	PROVIDER   = "mandelsoft"
+	VERSION    = "v1"
+	COMPONENTA = "github.com/mandelsoft/test"
+	COMPONENTB = "github.com/mandelsoft/ref"
+	SIGNATURE  = "test"
+	SIGNATURE2 = "other"
+)
+
+var _ = Describe("attestations", func() {
+	var env *Builder
+	var repo ocm.Repository
+	var resolver ocm.ComponentVersionResolver
+
+	BeforeEach(func() {
+		env = NewBuilder()
+		env.RSAKeyPair(SIGNATURE)
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMPONENTA, VERSION, func() {
+				env.Provider(PROVIDER)
+				TestDataResource(env)
+			})
+			env.ComponentVersion(COMPONENTB, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("ref", COMPONENTA, VERSION)
+				TestDataResource(env)
+			})
+		})
+
+		repo = Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+		resolver = resolvers.NewCompoundResolver(repo)
+	})
+
+	AfterEach(func() {
+		MustBeSuccessful(repo.Close())
+		env.Cleanup()
+	})
+
+	It("creates statement", func() {
+		cv := Must(repo.LookupComponentVersion(COMPONENTB, VERSION))
+		defer Close(cv)
+
+		stmt := Must(attestation.Create(cv, attestation.WithResolver(resolver), attestation.WithBuilderID("https://acme.org/builder")))
+		Expect(stmt.Type).To(Equal(attestation.StatementType))
+		Expect(stmt.PredicateType).To(Equal(attestation.PredicateTypeSLSAProvenance))
+		Expect(len(stmt.Subject)).To(Equal(2))
+
+		digest := Must(signing.Apply(nil, nil, cv, signing.NewOptions(signing.Resolver(resolver), signing.VerifyDigests())))
+		s := stmt.GetSubject(COMPONENTB + ":" + VERSION)
+		Expect(s).NotTo(BeNil())
+		Expect(s.Digest).To(Equal(map[string]string{"sha256": digest.Value}))
+		Expect(s.Annotations[attestation.ANNOTATION_NORMALISATION]).To(Equal(compdesc.JsonNormalisationV1))
+
+		s = stmt.GetSubject(COMPONENTB + ":" + VERSION + "/testdata")
+		Expect(s).NotTo(BeNil())
+		Expect(s.Digest).To(Equal(map[string]string{"sha256": D_TESTDATA}))
+		Expect(s.Annotations[attestation.ANNOTATION_KIND]).To(Equal(attestation.KIND_RESOURCE))
+
+		Expect(stmt.Predicate.RunDetails.Builder.ID).To(Equal("https://acme.org/builder"))
+		deps := stmt.Predicate.BuildDefinition.ResolvedDependencies
+		Expect(len(deps)).To(Equal(1))
+		Expect(deps[0].Name).To(Equal(COMPONENTA + ":" + VERSION))
+	})
+
+	It("uses the signature digest for the component version", func() {
+		cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+		defer Close(cv)
+
+		MustBeSuccessful(attestation.AddResource(cv, Must(attestation.Sign(env, Must(attestation.Create(cv)), SIGNATURE)), ""))
+		sig := Must(signing.Apply(nil, nil, cv, signing.NewOptions(signing.SignByAlgo(rsa.Algorithm, SIGNATURE), signing.Resolver(resolver), signing.Update(), signing.VerifyDigests())))
+
+		stmt := Must(attestation.Create(cv))
+		s := stmt.GetSubject(COMPONENTA + ":" + VERSION)
+		Expect(s).NotTo(BeNil())
+		Expect(s.Digest).To(Equal(map[string]string{"sha256": sig.Value}))
+		Expect(s.Digest).To(Equal(map[string]string{"sha256": cv.GetDescriptor().Signatures[0].Digest.Value}))
+	})
+
+	It("signs and verifies statement", func() {
+		cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+		defer Close(cv)
+
+		stmt := Must(attestation.Create(cv))
+		envelope := Must(attestation.Sign(env, stmt, SIGNATURE))
+		Expect(envelope.PayloadType).To(Equal(attestation.PayloadType))
+		Expect(len(envelope.Signatures)).To(Equal(1))
+		Expect(envelope.Signatures[0].KeyID).To(Equal(SIGNATURE))
+		Expect(envelope.Signatures[0].Algorithm).To(Equal(rsa.Algorithm))
+
+		act := Must(attestation.Verify(env, envelope, ""))
+		Expect(act.Subject).To(Equal(stmt.Subject))
+		MustBeSuccessful(attestation.Validate(cv, act))
+
+		_, pub := Must2(rsa.Handler{}.CreateKeyPair())
+		ExpectError(attestation.Verify(env, envelope, SIGNATURE, attestation.WithPublicKey(SIGNATURE, pub))).To(MatchError(ContainSubstring("signature \"test\"")))
+
+		ExpectError(attestation.Sign(env, stmt, SIGNATURE2)).To(MatchError("private key \"other\" not found"))
+	})
+
+	It("rejects subjects without digest", func() {
+		cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+		defer Close(cv)
+
+		stmt := Must(attestation.Create(cv))
+		MustBeSuccessful(attestation.Validate(cv, stmt))
+
+		for i := range stmt.Subject {
+			stmt.Subject[i].Digest = map[string]string{}
+		}
+		ExpectError(attestation.Validate(cv, stmt)).To(MatchError(ContainSubstring("digest mismatch for subject")))
+	})
+
+	It("rejects subjects with unknown digest algorithm", func() {
+		cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+		defer Close(cv)
+
+		stmt := Must(attestation.Create(cv))
+		s := stmt.GetSubject(COMPONENTA + ":" + VERSION)
+		s.Digest = map[string]string{"other": "0815"}
+		ExpectError(attestation.Validate(cv, stmt)).To(MatchError(`digest mismatch for subject "` + COMPONENTA + ":" + VERSION + `"`))
+	})
+
+	It("stores statement as resource", func() {
+		cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+		defer Close(cv)
+
+		stmt := Must(attestation.Create(cv))
+		envelope := Must(attestation.Sign(env, stmt, SIGNATURE))
+		MustBeSuccessful(attestation.AddResource(cv, envelope, ""))
+		MustBeSuccessful(cv.Update())
+
+		cv2 := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+		defer Close(cv2)
+		Expect(len(cv2.GetDescriptor().Resources)).To(Equal(2))
+		act := Must(attestation.GetResource(cv2, ""))
+		Expect(act).To(Equal(envelope))
+		MustBeSuccessful(attestation.Validate(cv2, Must(attestation.Verify(env, act, SIGNATURE))))
+
+		// the statement describes the component version without the attestation.
+		Expect(Must(attestation.Create(cv2)).GetSubject(COMPONENTA + ":" + VERSION).Digest).NotTo(Equal(stmt.GetSubject(COMPONENTA + ":" + VERSION).Digest))
+	})
+
+	It("stores statement as referrer", func() {
+		cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+		defer Close(cv)
+
+		stmt := Must(attestation.Create(cv))
+		envelope := Must(attestation.Sign(env, stmt, SIGNATURE))
+		dig := Must(attestation.AddReferrer(cv, envelope))
+
+		act := Must(attestation.GetReferrer(cv, dig))
+		Expect(act).To(Equal(envelope))
+
+		vers := Must(repo.LookupComponent(COMPONENTA))
+		defer Close(vers)
+		Expect(vers.ListVersions()).To(Equal([]string{VERSION}))
+	})
+})
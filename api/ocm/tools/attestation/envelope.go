@@ -0,0 +1,201 @@
+package attestation
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/tech/signing"
+)
+
+// MediaTypeEnvelope is the media type of a serialized DSSE envelope.
+const MediaTypeEnvelope = "application/vnd.dsse.envelope.v1+json"
+
+// Envelope is a DSSE envelope (https://github.com/secure-systems-lab/dsse)
+// for a signed in-toto statement.
+// In addition to the standard fields, the signatures keep the information
+// required by the OCM signing handlers to verify the signature.
+type Envelope struct {
+	PayloadType string      `json:"payloadType"`
+	Payload     string      `json:"payload"`
+	Signatures  []Signature `json:"signatures"`
+}
+
+// Signature is a signature in a DSSE envelope. Sig is the base64
+// encoded signature value provided by the used OCM signing handler.
+type Signature struct {
+	KeyID     string `json:"keyid,omitempty"`
+	Sig       string `json:"sig"`
+	Algorithm string `json:"algorithm,omitempty"`
+	MediaType string `json:"mediaType,omitempty"`
+	Issuer    string `json:"issuer,omitempty"`
+}
+
+// PAE provides the DSSE pre-authentication encoding for a payload.
+func PAE(payloadType string, payload []byte) []byte {
+	return []byte(fmt.Sprintf("DSSEv1 %d %s %d %s", len(payloadType), payloadType, len(payload), payload))
+}
+
+// GetPayload provides the decoded payload of the envelope.
+func (e *Envelope) GetPayload() ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(e.Payload)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid envelope payload")
+	}
+	return data, nil
+}
+
+// GetStatement provides the in-toto statement of the envelope
+// without verifying the signatures.
+func (e *Envelope) GetStatement() (*Statement, error) {
+	if e.PayloadType != PayloadType {
+		return nil, errors.Newf("unexpected payload type %q", e.PayloadType)
+	}
+	data, err := e.GetPayload()
+	if err != nil {
+		return nil, err
+	}
+	return ParseStatement(data)
+}
+
+// GetSignature provides the signature with the given key id.
+func (e *Envelope) GetSignature(keyid string) *Signature {
+	for i, s := range e.Signatures {
+		if s.KeyID == keyid {
+			return &e.Signatures[i]
+		}
+	}
+	return nil
+}
+
+// ParseEnvelope parses a serialized DSSE envelope.
+func ParseEnvelope(data []byte) (*Envelope, error) {
+	var e Envelope
+	err := json.Unmarshal(data, &e)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid DSSE envelope")
+	}
+	return &e, nil
+}
+
+// Sign signs an in-toto statement with the signing handler selected by
+// the options using the key registered for the given name.
+// The name is used as key id for the envelope signature.
+func Sign(ctx ocm.ContextProvider, stmt *Statement, name string, opts ...Option) (*Envelope, error) {
+	payload, err := json.Marshal(stmt)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot marshal in-toto statement")
+	}
+	env := &Envelope{
+		PayloadType: PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(payload),
+	}
+	err = AddSignature(ctx, env, name, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return env, nil
+}
+
+// AddSignature adds a signature for the given name to a DSSE envelope.
+func AddSignature(ctx ocm.ContextProvider, env *Envelope, name string, opts ...Option) error {
+	eff := EvalOptions(opts...)
+	reg := eff.Registry(ctx)
+
+	signer := reg.GetSigner(eff.SignAlgo)
+	if signer == nil {
+		return errors.ErrUnknown(compdesc.KIND_SIGN_ALGORITHM, eff.SignAlgo)
+	}
+	priv, err := signing.ResolvePrivateKey(reg, name)
+	if err != nil {
+		return err
+	}
+	if priv == nil {
+		return errors.ErrNotFound(compdesc.KIND_PRIVATE_KEY, name)
+	}
+	payload, err := env.GetPayload()
+	if err != nil {
+		return err
+	}
+
+	sctx := &signing.DefaultSigningContext{
+		Hash:       crypto.SHA256,
+		PrivateKey: priv,
+		PublicKey:  reg.GetPublicKey(name),
+		Issuer:     reg.GetIssuer(name),
+	}
+	sig, err := signer.Sign(ctx.OCMContext().CredentialsContext(), payloadDigest(env.PayloadType, payload), sctx)
+	if err != nil {
+		return errors.Wrapf(err, "cannot sign attestation")
+	}
+	s := Signature{
+		KeyID:     name,
+		Sig:       base64.StdEncoding.EncodeToString([]byte(sig.Value)),
+		Algorithm: sig.Algorithm,
+		MediaType: sig.MediaType,
+		Issuer:    sig.Issuer,
+	}
+	if old := env.GetSignature(name); old != nil {
+		*old = s
+	} else {
+		env.Signatures = append(env.Signatures, s)
+	}
+	return nil
+}
+
+// Verify verifies the signature with the given key id using the public key
+// registered for this name and provides the signed in-toto statement.
+// If no name is given, the envelope must contain exactly one signature.
+func Verify(ctx ocm.ContextProvider, env *Envelope, name string, opts ...Option) (*Statement, error) {
+	eff := EvalOptions(opts...)
+	reg := eff.Registry(ctx)
+
+	if name == "" && len(env.Signatures) == 1 {
+		name = env.Signatures[0].KeyID
+	}
+	s := env.GetSignature(name)
+	if s == nil {
+		return nil, errors.ErrNotFound(compdesc.KIND_SIGNATURE, name)
+	}
+	verifier := reg.GetVerifier(s.Algorithm)
+	if verifier == nil {
+		return nil, errors.ErrUnknown(compdesc.KIND_VERIFY_ALGORITHM, s.Algorithm)
+	}
+	value, err := base64.StdEncoding.DecodeString(s.Sig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid signature %q", name)
+	}
+	payload, err := env.GetPayload()
+	if err != nil {
+		return nil, err
+	}
+
+	sctx := &signing.DefaultSigningContext{
+		Hash:      crypto.SHA256,
+		PublicKey: reg.GetPublicKey(name),
+		Issuer:    reg.GetIssuer(name),
+	}
+	sig := &signing.Signature{
+		Value:     string(value),
+		MediaType: s.MediaType,
+		Algorithm: s.Algorithm,
+		Issuer:    s.Issuer,
+	}
+	err = verifier.Verify(payloadDigest(env.PayloadType, payload), sig, sctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signature %q", name)
+	}
+	return env.GetStatement()
+}
+
+func payloadDigest(payloadType string, payload []byte) string {
+	d := sha256.Sum256(PAE(payloadType, payload))
+	return hex.EncodeToString(d[:])
+}
@@ -0,0 +1,187 @@
+package attestation
+
+import (
+	"github.com/mandelsoft/goutils/optionutils"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+)
+
+type Option = optionutils.Option[*Options]
+
+type Options struct {
+	Resolver          ocm.ComponentVersionResolver
+	BuilderID         string
+	InvocationID      string
+	HashAlgo          string
+	NormalizationAlgo string
+	SignAlgo          string
+	Keys              signing.KeyRegistry
+}
+
+var _ Option = (*Options)(nil)
+
+func (o *Options) ApplyTo(opts *Options) {
+	if o.Resolver != nil {
+		opts.Resolver = o.Resolver
+	}
+	if o.BuilderID != "" {
+		opts.BuilderID = o.BuilderID
+	}
+	if o.InvocationID != "" {
+		opts.InvocationID = o.InvocationID
+	}
+	if o.HashAlgo != "" {
+		opts.HashAlgo = o.HashAlgo
+	}
+	if o.NormalizationAlgo != "" {
+		opts.NormalizationAlgo = o.NormalizationAlgo
+	}
+	if o.SignAlgo != "" {
+		opts.SignAlgo = o.SignAlgo
+	}
+	if o.Keys != nil {
+		opts.Keys = o.Keys
+	}
+}
+
+func (o *Options) Apply(opts ...Option) {
+	optionutils.ApplyOptions(o, opts...)
+}
+
+// EvalOptions evaluates the option list and provides defaults.
+func EvalOptions(opts ...Option) *Options {
+	var eff Options
+	eff.Apply(opts...)
+	if eff.BuilderID == "" {
+		eff.BuilderID = DefaultBuilderID
+	}
+	if eff.SignAlgo == "" {
+		eff.SignAlgo = rsa.Algorithm
+	}
+	return &eff
+}
+
+// Registry provides the signing registry for the given context
+// enriched by the keys provided by the options.
+func (o *Options) Registry(ctx ocm.ContextProvider) signing.Registry {
+	reg := signingattr.Get(ctx)
+	if o.Keys != nil && (o.Keys.HasKeys() || o.Keys.HasIssuers()) {
+		return signing.RegistryWithPreferredKeys(reg, o.Keys)
+	}
+	return reg
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// Local options
+
+type resolver struct {
+	ocm.ComponentVersionResolver
+}
+
+func (r resolver) ApplyTo(opts *Options) {
+	opts.Resolver = r.ComponentVersionResolver
+}
+
+// WithResolver provides a resolver used to resolve component references.
+func WithResolver(r ocm.ComponentVersionResolver) Option {
+	return resolver{r}
+}
+
+type builderid string
+
+func (b builderid) ApplyTo(opts *Options) {
+	opts.BuilderID = string(b)
+}
+
+// WithBuilderID sets the builder id used in the provenance predicate.
+func WithBuilderID(id string) Option {
+	return builderid(id)
+}
+
+type invocationid string
+
+func (i invocationid) ApplyTo(opts *Options) {
+	opts.InvocationID = string(i)
+}
+
+// WithInvocationID sets the invocation id used in the provenance predicate.
+func WithInvocationID(id string) Option {
+	return invocationid(id)
+}
+
+type hashalgo string
+
+func (h hashalgo) ApplyTo(opts *Options) {
+	opts.HashAlgo = string(h)
+}
+
+// WithHashAlgorithm sets the hash algorithm used for the component version digest.
+func WithHashAlgorithm(algo string) Option {
+	return hashalgo(algo)
+}
+
+type normalgo string
+
+func (n normalgo) ApplyTo(opts *Options) {
+	opts.NormalizationAlgo = string(n)
+}
+
+// WithNormalizationAlgorithm sets the normalization algorithm used for the
+// component version digest.
+func WithNormalizationAlgorithm(algo string) Option {
+	return normalgo(algo)
+}
+
+type signalgo string
+
+func (s signalgo) ApplyTo(opts *Options) {
+	opts.SignAlgo = string(s)
+}
+
+// WithSignAlgorithm selects the signing handler used to sign attestations.
+func WithSignAlgorithm(algo string) Option {
+	return signalgo(algo)
+}
+
+type keys struct {
+	signing.KeyRegistry
+}
+
+func (k keys) ApplyTo(opts *Options) {
+	opts.Keys = k.KeyRegistry
+}
+
+// WithKeys provides dedicated keys used to sign or verify attestations.
+func WithKeys(reg signing.KeyRegistry) Option {
+	return keys{reg}
+}
+
+type key struct {
+	name    string
+	key     interface{}
+	private bool
+}
+
+func (k key) ApplyTo(opts *Options) {
+	if opts.Keys == nil {
+		opts.Keys = signing.NewKeyRegistry()
+	}
+	if k.private {
+		opts.Keys.RegisterPrivateKey(k.name, k.key)
+	} else {
+		opts.Keys.RegisterPublicKey(k.name, k.key)
+	}
+}
+
+// WithPrivateKey provides a private key used to sign attestations.
+func WithPrivateKey(name string, k interface{}) Option {
+	return key{name: name, key: k, private: true}
+}
+
+// WithPublicKey provides a public key used to verify attestations.
+func WithPublicKey(name string, k interface{}) Option {
+	return key{name: name, key: k}
+}
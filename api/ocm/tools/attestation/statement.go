@@ -0,0 +1,116 @@
+package attestation
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+)
+
+const (
+	// StatementType is the type of in-toto statements.
+	StatementType = "https://in-toto.io/Statement/v1"
+	// PayloadType is the DSSE payload type of in-toto statements.
+	PayloadType = "application/vnd.in-toto+json"
+	// PredicateTypeSLSAProvenance is the predicate type for SLSA provenance.
+	PredicateTypeSLSAProvenance = "https://slsa.dev/provenance/v1"
+
+	// BuildType describes the build type used for OCM component versions.
+	BuildType = "https://ocm.software/attestation/componentversion/v1"
+	// DefaultBuilderID is the builder id used if no explicit id is given.
+	DefaultBuilderID = "https://ocm.software/ocm"
+
+	// ANNOTATION_NORMALISATION is the subject annotation describing the
+	// normalisation algorithm used to calculate the subject digest.
+	ANNOTATION_NORMALISATION = "ocm.software/normalisationAlgorithm"
+	// ANNOTATION_KIND is the subject annotation describing the kind of the
+	// OCM element.
+	ANNOTATION_KIND = "ocm.software/kind"
+	// ANNOTATION_IDENTITY is the subject annotation describing the
+	// identity of a resource, if it uses an extra identity.
+	ANNOTATION_IDENTITY = "ocm.software/identity"
+
+	KIND_COMPONENTVERSION = "componentversion"
+	KIND_RESOURCE         = "resource"
+	KIND_REFERENCE        = "reference"
+
+	KIND_SUBJECT     = "attestation subject"
+	KIND_ATTESTATION = "attestation"
+)
+
+// Statement is an in-toto statement with a SLSA provenance predicate.
+type Statement struct {
+	Type          string               `json:"_type"`
+	Subject       []ResourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     *Provenance          `json:"predicate"`
+}
+
+// ResourceDescriptor describes an artifact by name and digests.
+type ResourceDescriptor struct {
+	Name        string            `json:"name,omitempty"`
+	URI         string            `json:"uri,omitempty"`
+	Digest      map[string]string `json:"digest,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Provenance is the SLSA provenance predicate.
+type Provenance struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string                 `json:"buildType"`
+	ExternalParameters   map[string]interface{} `json:"externalParameters"`
+	InternalParameters   map[string]interface{} `json:"internalParameters,omitempty"`
+	ResolvedDependencies []ResourceDescriptor   `json:"resolvedDependencies,omitempty"`
+}
+
+type RunDetails struct {
+	Builder  Builder        `json:"builder"`
+	Metadata *BuildMetadata `json:"metadata,omitempty"`
+}
+
+type Builder struct {
+	ID string `json:"id"`
+}
+
+type BuildMetadata struct {
+	InvocationID string     `json:"invocationId,omitempty"`
+	StartedOn    *time.Time `json:"startedOn,omitempty"`
+	FinishedOn   *time.Time `json:"finishedOn,omitempty"`
+}
+
+// GetSubject provides the subject with the given name.
+func (s *Statement) GetSubject(name string) *ResourceDescriptor {
+	for i, d := range s.Subject {
+		if d.Name == name {
+			return &s.Subject[i]
+		}
+	}
+	return nil
+}
+
+// ParseStatement parses and validates a serialized in-toto statement.
+func ParseStatement(data []byte) (*Statement, error) {
+	var s Statement
+	err := json.Unmarshal(data, &s)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid in-toto statement")
+	}
+	if s.Type != StatementType {
+		return nil, errors.Newf("unexpected statement type %q", s.Type)
+	}
+	if s.PredicateType != PredicateTypeSLSAProvenance {
+		return nil, errors.Newf("unexpected predicate type %q", s.PredicateType)
+	}
+	return &s, nil
+}
+
+// DigestAlgorithm maps an OCM hash algorithm name to the
+// digest algorithm name used by in-toto.
+func DigestAlgorithm(algo string) string {
+	return strings.ToLower(strings.ReplaceAll(algo, "-", ""))
+}
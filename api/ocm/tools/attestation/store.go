@@ -0,0 +1,195 @@
+package attestation
+
+import (
+	"encoding/json"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/cpi/repocpi"
+	"ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/ocm/extensions/repositories/genericocireg"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+)
+
+const (
+	// MediaTypeEmptyConfig is the media type of the empty config blob
+	// used for attestation referrer manifests.
+	MediaTypeEmptyConfig = "application/vnd.oci.empty.v1+json"
+
+	// DefaultResourceName is the default resource name used to store
+	// an attestation as local blob.
+	DefaultResourceName = "attestation"
+)
+
+// AddResource adds the envelope as local blob resource of type attestation
+// to the component version. The component version must be updated
+// afterward by the caller.
+// Because the component descriptor is modified, existing signatures of the
+// component version are invalidated. For signed component versions the
+// attestation should be stored as OCI referrer.
+func AddResource(cv ocm.ComponentVersionAccess, env *Envelope, name string) error {
+	if name == "" {
+		name = DefaultResourceName
+	}
+	data, err := json.Marshal(env)
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal attestation")
+	}
+	meta := compdesc.NewResourceMeta(name, artifacttypes.ATTESTATION, metav1.LocalRelation)
+	blob := blobaccess.ForData(MediaTypeEnvelope, data)
+	defer blob.Close()
+	return cv.SetResourceBlob(meta, blob, "", nil)
+}
+
+// GetResource provides the attestation stored as resource with the given
+// name. If no name is given, the default resource name is used.
+func GetResource(cv ocm.ComponentVersionAccess, name string) (*Envelope, error) {
+	if name == "" {
+		name = DefaultResourceName
+	}
+	r, err := cv.GetResource(metav1.NewIdentity(name))
+	if err != nil {
+		return nil, err
+	}
+	if r.Meta().GetType() != artifacttypes.ATTESTATION {
+		return nil, errors.ErrInvalid(KIND_ATTESTATION, name)
+	}
+	m, err := r.AccessMethod()
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+	data, err := m.Get()
+	if err != nil {
+		return nil, err
+	}
+	return ParseEnvelope(data)
+}
+
+// AddReferrer stores the envelope as OCI artifact referring to the OCI
+// artifact used to store the component version. This requires an OCI based
+// OCM repository. The referrer manifest is not tagged, it is addressed
+// by the returned digest.
+func AddReferrer(cv ocm.ComponentVersionAccess, env *Envelope) (digest.Digest, error) {
+	impl, err := repocpi.GetRepositoryImplementation(cv.Repository())
+	if err != nil {
+		return "", err
+	}
+	gen, ok := impl.(*genericocireg.RepositoryImpl)
+	if !ok {
+		return "", errors.ErrNotSupported("non-oci based ocm repository")
+	}
+
+	nsname, err := gen.MapComponentNameToNamespace(cv.GetName())
+	if err != nil {
+		return "", err
+	}
+	tag, err := genericocireg.VersionToTag(cv.GetVersion())
+	if err != nil {
+		return "", err
+	}
+	ns, err := gen.OCIRepository().LookupNamespace(nsname)
+	if err != nil {
+		return "", err
+	}
+	defer ns.Close()
+
+	subject, err := ns.GetArtifact(tag)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot access component version artifact")
+	}
+	defer subject.Close()
+	sblob, err := subject.Blob()
+	if err != nil {
+		return "", err
+	}
+	defer sblob.Close()
+
+	data, err := json.Marshal(env)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot marshal attestation")
+	}
+
+	art, err := ns.NewArtifact()
+	if err != nil {
+		return "", err
+	}
+	defer art.Close()
+
+	m, err := art.Manifest()
+	if err != nil {
+		return "", err
+	}
+	config := blobaccess.ForString(MediaTypeEmptyConfig, "{}")
+	defer config.Close()
+	m.Config.MediaType = config.MimeType()
+	m.Config.Digest = config.Digest()
+	m.Config.Size = config.Size()
+	err = art.AddBlob(config)
+	if err != nil {
+		return "", err
+	}
+
+	blob := blobaccess.ForData(MediaTypeEnvelope, data)
+	defer blob.Close()
+	_, err = art.AddLayer(blob, nil)
+	if err != nil {
+		return "", err
+	}
+
+	m.ArtifactType = PayloadType
+	m.Subject = &artdesc.Descriptor{
+		MediaType: sblob.MimeType(),
+		Digest:    sblob.Digest(),
+		Size:      sblob.Size(),
+	}
+
+	b, err := ns.AddArtifact(art)
+	if err != nil {
+		return "", errors.Wrapf(err, "cannot store attestation referrer")
+	}
+	defer b.Close()
+	return b.Digest(), nil
+}
+
+// GetReferrer provides the attestation stored as OCI referrer with
+// the given digest.
+func GetReferrer(cv ocm.ComponentVersionAccess, dig digest.Digest) (*Envelope, error) {
+	impl, err := repocpi.GetRepositoryImplementation(cv.Repository())
+	if err != nil {
+		return nil, err
+	}
+	gen, ok := impl.(*genericocireg.RepositoryImpl)
+	if !ok {
+		return nil, errors.ErrNotSupported("non-oci based ocm repository")
+	}
+	nsname, err := gen.MapComponentNameToNamespace(cv.GetName())
+	if err != nil {
+		return nil, err
+	}
+	art, err := gen.OCIRepository().LookupArtifact(nsname, dig.String())
+	if err != nil {
+		return nil, err
+	}
+	defer art.Close()
+
+	m := art.ManifestAccess()
+	if m == nil || m.GetDescriptor().ArtifactType != PayloadType || len(m.GetDescriptor().Layers) != 1 {
+		return nil, errors.ErrInvalid(KIND_ATTESTATION, dig.String())
+	}
+	blob, err := m.GetBlob(m.GetDescriptor().Layers[0].Digest)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Close()
+	data, err := blob.Get()
+	if err != nil {
+		return nil, err
+	}
+	return ParseEnvelope(data)
+}
@@ -0,0 +1,151 @@
+package convert
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/utils/accessio"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+// ErrLossyConversion is reported if a conversion would lose information
+// and lossy conversions are not accepted.
+var ErrLossyConversion = errors.New("lossy conversion")
+
+// Result describes the conversion of a component version.
+type Result struct {
+	common.NameVersion
+	*compdesc.SchemeConversion
+	// Modified reports whether the stored descriptor has been updated.
+	// It is not set for dry runs and for temporary component versions,
+	// whose descriptor is only converted in memory.
+	Modified bool
+}
+
+// ComponentVersion converts the descriptor of a component version into
+// the given scheme version and updates the component version in its repository.
+// Lossy conversions are rejected with ErrLossyConversion, if not explicitly
+// accepted by option AllowLoss.
+func ComponentVersion(cv ocm.ComponentVersionAccess, scheme string, opts ...Option) (*Result, error) {
+	eff := EvalOptions(opts...)
+
+	r, err := compdesc.ConvertScheme(cv.GetDescriptor(), scheme)
+	if err != nil {
+		return nil, err
+	}
+	result := &Result{
+		NameVersion:      common.VersionedElementKey(cv),
+		SchemeConversion: r,
+	}
+	if r.Source == r.Target {
+		return result, nil
+	}
+	if r.IsLossy() && !eff.AllowLoss {
+		return result, fmt.Errorf("%w: %d element(s) cannot be kept", ErrLossyConversion, len(r.Losses))
+	}
+	if eff.DryRun {
+		return result, nil
+	}
+	if cv.IsReadOnly() {
+		return result, accessio.ErrReadOnly
+	}
+	cd := cv.GetDescriptor()
+	orig := cd.Metadata.ConfiguredVersion
+	cd.Metadata.ConfiguredVersion = scheme
+	err = cv.Update()
+	if err != nil {
+		if errors.Is(err, ocm.ErrTempVersion) {
+			// the descriptor of a temporary component version is only
+			// converted in memory, there is nothing stored, yet.
+			return result, nil
+		}
+		cd.Metadata.ConfiguredVersion = orig
+		return result, err
+	}
+	result.Modified = true
+	return result, nil
+}
+
+// Repository converts the descriptors of all component versions found in
+// a repository into the given scheme version. This can be used to rewrite
+// a whole CTF in place.
+func Repository(repo ocm.Repository, scheme string, opts ...Option) ([]*Result, error) {
+	eff := EvalOptions(opts...)
+
+	lister := repo.ComponentLister()
+	if lister == nil {
+		return nil, errors.ErrNotSupported("component listing", repo.GetSpecification().GetKind())
+	}
+	names, err := lister.GetComponents("", true)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var results []*Result
+	list := errors.ErrListf("converting component versions")
+	for _, n := range names {
+		r, err := component(repo, n, scheme, eff)
+		results = append(results, r...)
+		list.Add(err)
+	}
+	return results, list.Result()
+}
+
+func component(repo ocm.Repository, name, scheme string, opts *Options) ([]*Result, error) {
+	comp, err := repo.LookupComponent(name)
+	if err != nil {
+		return nil, err
+	}
+	defer comp.Close()
+
+	vers, err := comp.ListVersions()
+	if err != nil {
+		return nil, errors.Wrapf(err, "component %s", name)
+	}
+
+	var results []*Result
+	list := errors.ErrListf("component %s", name)
+	for _, v := range vers {
+		r, err := version(comp, v, scheme, opts)
+		if r != nil {
+			results = append(results, r)
+			Report(opts.Printer, r, err)
+		}
+		if err != nil {
+			list.Add(errors.Wrapf(err, "version %s", v))
+		}
+	}
+	return results, list.Result()
+}
+
+func version(comp ocm.ComponentAccess, vers, scheme string, opts *Options) (*Result, error) {
+	cv, err := comp.LookupVersion(vers)
+	if err != nil {
+		return nil, err
+	}
+	defer cv.Close()
+
+	return ComponentVersion(cv, scheme, opts)
+}
+
+// Report prints the result of a component version conversion.
+func Report(p common.Printer, r *Result, err error) {
+	switch {
+	case r.Source == r.Target:
+		p.Printf("%s: already uses scheme %s\n", r.NameVersion, r.Target)
+	case err != nil:
+		p.Printf("%s: conversion from %s to %s failed: %s\n", r.NameVersion, r.Source, r.Target, err)
+	case r.Modified:
+		p.Printf("%s: converted from %s to %s\n", r.NameVersion, r.Source, r.Target)
+	default:
+		p.Printf("%s: would convert from %s to %s\n", r.NameVersion, r.Source, r.Target)
+	}
+	for _, l := range r.Losses {
+		p.Printf("  lost %s\n", l)
+	}
+}
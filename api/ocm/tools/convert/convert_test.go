@@ -0,0 +1,184 @@
+package convert_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+	. "ocm.software/ocm/api/ocm/testhelper"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	compdescv3 "ocm.software/ocm/api/ocm/compdesc/versions/ocm.software/v3alpha1"
+	compdescv2 "ocm.software/ocm/api/ocm/compdesc/versions/v2"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/tools/convert"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	ARCH       = "/tmp/ctf"
+	PROVIDER   = "mandelsoft"
+	VERSION    = "v1"
+	COMPONENTA = "github.com/mandelsoft/test"
+	COMPONENTB = "github.com/mandelsoft/ref"
+)
+
+var _ = Describe("scheme conversion", func() {
+	var env *Builder
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMPONENTA, VERSION, func() {
+				env.Provider(PROVIDER)
+				TestDataResource(env)
+			})
+			env.ComponentVersion(COMPONENTB, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("ref", COMPONENTA, VERSION)
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	schemeOf := func(name string) string {
+		repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo, "repo")
+		cv := Must(repo.LookupComponentVersion(name, VERSION))
+		defer Close(cv, "cv")
+		return cv.GetDescriptor().SchemaVersion()
+	}
+
+	It("converts a component version", func() {
+		repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+		cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+
+		r := Must(convert.ComponentVersion(cv, compdescv3.SchemaVersion))
+		Expect(r.NameVersion).To(Equal(common.NewNameVersion(COMPONENTA, VERSION)))
+		Expect(r.Source).To(Equal(compdescv2.SchemaVersion))
+		Expect(r.Target).To(Equal(compdescv3.SchemaVersion))
+		Expect(r.Modified).To(BeTrue())
+		MustBeSuccessful(cv.Close())
+		MustBeSuccessful(repo.Close())
+
+		Expect(schemeOf(COMPONENTA)).To(Equal(compdescv3.SchemaVersion))
+		Expect(schemeOf(COMPONENTB)).To(Equal(compdescv2.SchemaVersion))
+	})
+
+	It("converts a repository", func() {
+		repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+
+		buf := bytes.NewBuffer(nil)
+		results := Must(convert.Repository(repo, compdescv3.SchemaVersion, convert.WithPrinter(common.NewPrinter(buf))))
+		Expect(len(results)).To(Equal(2))
+		MustBeSuccessful(repo.Close())
+
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+github.com/mandelsoft/ref:v1: converted from v2 to ocm.software/v3alpha1
+github.com/mandelsoft/test:v1: converted from v2 to ocm.software/v3alpha1
+`))
+		Expect(schemeOf(COMPONENTA)).To(Equal(compdescv3.SchemaVersion))
+		Expect(schemeOf(COMPONENTB)).To(Equal(compdescv3.SchemaVersion))
+	})
+
+	It("handles a dry run", func() {
+		repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+
+		buf := bytes.NewBuffer(nil)
+		Must(convert.Repository(repo, compdescv3.SchemaVersion, convert.DryRun(), convert.WithPrinter(common.NewPrinter(buf))))
+		MustBeSuccessful(repo.Close())
+
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+github.com/mandelsoft/ref:v1: would convert from v2 to ocm.software/v3alpha1
+github.com/mandelsoft/test:v1: would convert from v2 to ocm.software/v3alpha1
+`))
+		Expect(schemeOf(COMPONENTA)).To(Equal(compdescv2.SchemaVersion))
+	})
+
+	Context("lossy", func() {
+		BeforeEach(func() {
+			repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			defer Close(repo, "repo")
+			cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+			defer Close(cv, "cv")
+			cv.GetDescriptor().Signatures = append(cv.GetDescriptor().Signatures, compdesc.Signature{
+				Name: "acme",
+				Digest: compdesc.DigestSpec{
+					HashAlgorithm:          "SHA-256",
+					NormalisationAlgorithm: compdesc.JsonNormalisationV1,
+					Value:                  "0123456789abcdef",
+				},
+				Signature: compdesc.SignatureSpec{
+					Algorithm: "RSASSA-PKCS1-V1_5",
+					Value:     "0123456789abcdef",
+					MediaType: "application/vnd.ocm.signature.rsa",
+				},
+			})
+		})
+
+		It("rejects lossy conversions", func() {
+			repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+
+			buf := bytes.NewBuffer(nil)
+			_, err := convert.Repository(repo, compdescv3.SchemaVersion, convert.WithPrinter(common.NewPrinter(buf)))
+			Expect(err).To(MatchError(ContainSubstring(convert.ErrLossyConversion.Error())))
+			MustBeSuccessful(repo.Close())
+
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+github.com/mandelsoft/ref:v1: converted from v2 to ocm.software/v3alpha1
+github.com/mandelsoft/test:v1: conversion from v2 to ocm.software/v3alpha1 failed: lossy conversion: 1 element(s) cannot be kept
+  lost signature "acme": digest uses scheme specific normalization jsonNormalisation/v1
+`))
+			Expect(schemeOf(COMPONENTA)).To(Equal(compdescv2.SchemaVersion))
+		})
+
+		It("accepts lossy conversions", func() {
+			repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+			cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+
+			r := Must(convert.ComponentVersion(cv, compdescv3.SchemaVersion, convert.AllowLoss()))
+			Expect(r.IsLossy()).To(BeTrue())
+			Expect(r.Modified).To(BeTrue())
+			MustBeSuccessful(cv.Close())
+			MustBeSuccessful(repo.Close())
+
+			Expect(schemeOf(COMPONENTA)).To(Equal(compdescv3.SchemaVersion))
+		})
+	})
+
+	It("handles read-only repositories", func() {
+		repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo, "repo")
+		cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+		defer Close(cv, "cv")
+
+		_, err := convert.ComponentVersion(cv, compdescv3.SchemaVersion)
+		Expect(err).To(MatchError(accessio.ErrReadOnly))
+		Expect(Must(convert.ComponentVersion(cv, compdescv3.SchemaVersion, convert.DryRun())).Modified).To(BeFalse())
+	})
+
+	It("reports temporary component versions as not modified", func() {
+		repo := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+		defer Close(repo, "repo")
+		cv := Must(repo.NewComponentVersion(COMPONENTA, "v2"))
+		defer Close(cv, "cv")
+		cv.GetDescriptor().Provider.Name = PROVIDER
+
+		r := Must(convert.ComponentVersion(cv, compdescv3.SchemaVersion))
+		Expect(r.Target).To(Equal(compdescv3.SchemaVersion))
+		Expect(r.Modified).To(BeFalse())
+		Expect(cv.GetDescriptor().SchemaVersion()).To(Equal(compdescv3.SchemaVersion))
+		comp := Must(repo.LookupComponent(COMPONENTA))
+		defer Close(comp, "component")
+		Expect(Must(comp.ListVersions())).NotTo(ContainElement("v2"))
+	})
+
+})
@@ -0,0 +1,85 @@
+package convert
+
+import (
+	"github.com/mandelsoft/goutils/optionutils"
+
+	"ocm.software/ocm/api/utils"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+type Option = optionutils.Option[*Options]
+
+type Options struct {
+	// DryRun only determines the conversion result without
+	// updating the component versions.
+	DryRun bool
+	// AllowLoss accepts conversions losing information.
+	AllowLoss bool
+	// Printer is used to report the progress of a repository conversion.
+	Printer common.Printer
+}
+
+var _ Option = (*Options)(nil)
+
+func (o *Options) ApplyTo(opts *Options) {
+	if o.DryRun {
+		opts.DryRun = true
+	}
+	if o.AllowLoss {
+		opts.AllowLoss = true
+	}
+	if o.Printer != nil {
+		opts.Printer = o.Printer
+	}
+}
+
+func (o *Options) Apply(opts ...Option) {
+	optionutils.ApplyOptions(o, opts...)
+}
+
+// EvalOptions evaluates the option list and provides defaults.
+func EvalOptions(opts ...Option) *Options {
+	var eff Options
+	eff.Apply(opts...)
+	eff.Printer = common.AssurePrinter(eff.Printer)
+	return &eff
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// Local options
+
+type dryrun bool
+
+func (d dryrun) ApplyTo(opts *Options) {
+	opts.DryRun = bool(d)
+}
+
+// DryRun only determines the conversion result.
+func DryRun(b ...bool) Option {
+	return dryrun(utils.OptionalDefaultedBool(true, b...))
+}
+
+type allowloss bool
+
+func (a allowloss) ApplyTo(opts *Options) {
+	opts.AllowLoss = bool(a)
+}
+
+// AllowLoss accepts lossy conversions.
+func AllowLoss(b ...bool) Option {
+	return allowloss(utils.OptionalDefaultedBool(true, b...))
+}
+
+type printer struct {
+	common.Printer
+}
+
+func (p printer) ApplyTo(opts *Options) {
+	opts.Printer = p.Printer
+}
+
+// WithPrinter provides a printer used to report the conversion
+// of a repository.
+func WithPrinter(p common.Printer) Option {
+	return printer{p}
+}
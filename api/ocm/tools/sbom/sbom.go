@@ -0,0 +1,179 @@
+package sbom
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+	"golang.org/x/exp/slices"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/tech/sbom"
+)
+
+const (
+	// LABEL_SBOM is the label used at a resource to refer to the
+	// resource containing its SBOM. The value is the identity of the
+	// SBOM resource in the same component version.
+	LABEL_SBOM = "ocm.software/sbom"
+	// LABEL_SBOM_SUBJECT is the label used at an SBOM resource to refer to
+	// the resource described by the SBOM. The value is the identity of the
+	// described resource in the same component version.
+	LABEL_SBOM_SUBJECT = "ocm.software/sbom/subject"
+)
+
+const (
+	MIME_SPDX_JSON      = sbom.MIME_SPDX_JSON
+	MIME_CYCLONEDX_JSON = sbom.MIME_CYCLONEDX_JSON
+)
+
+type (
+	Document = sbom.Document
+	Package  = sbom.Package
+)
+
+// Types lists the resource types used for SBOMs.
+var Types = []string{artifacttypes.SBOM, artifacttypes.SPDX_SBOM, artifacttypes.CYCLONEDX_SBOM}
+
+// IsSBOM checks whether a resource is an SBOM.
+func IsSBOM(r *compdesc.Resource) bool {
+	return slices.Contains(Types, r.GetType())
+}
+
+// Related provides the SBOM resources of a component descriptor
+// describing the given resource. If the resource itself is an SBOM, it is
+// returned as only result.
+//
+// An SBOM describes a resource if
+//   - the resource refers to the SBOM with the label ocm.software/sbom, or
+//   - the SBOM refers to the resource with the label ocm.software/sbom/subject, or
+//   - none of both labels is used and both resources share a source reference.
+func Related(cd *compdesc.ComponentDescriptor, r *compdesc.Resource) ([]*compdesc.Resource, error) {
+	if IsSBOM(r) {
+		return []*compdesc.Resource{r}, nil
+	}
+	var result []*compdesc.Resource
+	for i := range cd.Resources {
+		s := &cd.Resources[i]
+		if !IsSBOM(s) {
+			continue
+		}
+		ok, err := describes(cd, s, r)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+// Subjects provides the resources of a component descriptor described by
+// the given SBOM resource.
+func Subjects(cd *compdesc.ComponentDescriptor, s *compdesc.Resource) ([]*compdesc.Resource, error) {
+	if !IsSBOM(s) {
+		return nil, errors.ErrInvalid(artifacttypes.KIND_RESOURCE_TYPE, s.GetType())
+	}
+	var result []*compdesc.Resource
+	for i := range cd.Resources {
+		r := &cd.Resources[i]
+		if IsSBOM(r) {
+			continue
+		}
+		ok, err := describes(cd, s, r)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+// Get reads and parses the SBOM provided by the given resource of
+// a component version. For the generic type sbom the format is derived
+// from the media type of the resource blob or from its content.
+func Get(cv ocm.ComponentVersionAccess, r *compdesc.Resource) (*Document, error) {
+	if !IsSBOM(r) {
+		return nil, errors.ErrInvalid(artifacttypes.KIND_RESOURCE_TYPE, r.GetType())
+	}
+	id := r.GetIdentity(cv.GetDescriptor().Resources)
+	ra, err := cv.GetResource(id)
+	if err != nil {
+		return nil, err
+	}
+	m, err := ra.AccessMethod()
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+	data, err := m.Get()
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read SBOM %s", id)
+	}
+
+	mime := m.MimeType()
+	switch r.GetType() {
+	case artifacttypes.SPDX_SBOM:
+		mime = sbom.MIME_SPDX_JSON
+	case artifacttypes.CYCLONEDX_SBOM:
+		mime = sbom.MIME_CYCLONEDX_JSON
+	}
+	doc, err := sbom.Parse(data, mime)
+	if err != nil {
+		return nil, errors.Wrapf(err, "SBOM %s", id)
+	}
+	return doc, nil
+}
+
+func describes(cd *compdesc.ComponentDescriptor, s, r *compdesc.Resource) (bool, error) {
+	explicit := false
+
+	sel, err := labelIdentity(r, LABEL_SBOM)
+	if err != nil {
+		return false, err
+	}
+	if sel != nil {
+		explicit = true
+		if ok, _ := sel.Match(s.GetIdentity(cd.Resources)); ok {
+			return true, nil
+		}
+	}
+
+	sel, err = labelIdentity(s, LABEL_SBOM_SUBJECT)
+	if err != nil {
+		return false, err
+	}
+	if sel != nil {
+		explicit = true
+		if ok, _ := sel.Match(r.GetIdentity(cd.Resources)); ok {
+			return true, nil
+		}
+	}
+
+	if explicit {
+		return false, nil
+	}
+	for _, a := range s.SourceRefs {
+		for _, b := range r.SourceRefs {
+			if len(a.IdentitySelector) > 0 && metav1.Identity(a.IdentitySelector).Equals(metav1.Identity(b.IdentitySelector)) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func labelIdentity(r *compdesc.Resource, name string) (metav1.Identity, error) {
+	var id metav1.Identity
+	ok, err := r.Labels.GetValue(name, &id)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid label %q of resource %s", name, r.GetName())
+	}
+	if !ok {
+		return nil, nil
+	}
+	return id, nil
+}
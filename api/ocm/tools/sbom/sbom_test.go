@@ -0,0 +1,128 @@
+package sbom_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/tools/sbom"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	ARCH      = "/tmp/ctf"
+	PROVIDER  = "mandelsoft"
+	VERSION   = "v1"
+	COMPONENT = "github.com/mandelsoft/test"
+)
+
+const SPDX = `{
+  "spdxVersion": "SPDX-2.3",
+  "name": "app",
+  "packages": [
+    { "name": "log4j-core", "versionInfo": "2.14.1", "licenseConcluded": "Apache-2.0" }
+  ]
+}`
+
+const CYCLONEDX = `{
+  "bomFormat": "CycloneDX",
+  "metadata": { "component": { "name": "lib" } },
+  "components": [
+    { "group": "org.yaml", "name": "snakeyaml", "version": "1.33" }
+  ]
+}`
+
+var _ = Describe("SBOM resources", func() {
+	var env *Builder
+	var repo ocm.Repository
+	var cv ocm.ComponentVersionAccess
+	var cd *compdesc.ComponentDescriptor
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMPONENT, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Resource("app", "", artifacttypes.EXECUTABLE, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_OCTET, "app")
+					env.Label(sbom.LABEL_SBOM, metav1.NewIdentity("app-sbom"))
+				})
+				env.Resource("app-sbom", "", artifacttypes.SPDX_SBOM, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_JSON, SPDX)
+				})
+				env.Resource("lib", "", artifacttypes.BLOB, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_OCTET, "lib")
+					env.SourceRef("name", "sources")
+				})
+				env.Resource("lib-sbom", "", artifacttypes.SBOM, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_JSON, CYCLONEDX)
+					env.SourceRef("name", "sources")
+				})
+				env.Resource("tool", "", artifacttypes.EXECUTABLE, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_OCTET, "tool")
+					env.SourceRef("name", "sources")
+				})
+				env.Resource("tool-sbom", "", artifacttypes.SPDX_SBOM, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_JSON, SPDX)
+					env.Label(sbom.LABEL_SBOM_SUBJECT, metav1.NewIdentity("tool"))
+				})
+			})
+		})
+
+		repo = Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		cv = Must(repo.LookupComponentVersion(COMPONENT, VERSION))
+		cd = cv.GetDescriptor()
+	})
+
+	AfterEach(func() {
+		MustBeSuccessful(cv.Close())
+		MustBeSuccessful(repo.Close())
+		env.Cleanup()
+	})
+
+	names := func(list []*compdesc.Resource) []string {
+		var result []string
+		for _, r := range list {
+			result = append(result, r.GetName())
+		}
+		return result
+	}
+
+	res := func(name string) *compdesc.Resource {
+		return &cd.Resources[cd.GetResourceIndexByIdentity(metav1.NewIdentity(name))]
+	}
+
+	It("finds related SBOMs", func() {
+		Expect(sbom.IsSBOM(res("app-sbom"))).To(BeTrue())
+		Expect(sbom.IsSBOM(res("app"))).To(BeFalse())
+
+		Expect(names(Must(sbom.Related(cd, res("app"))))).To(ConsistOf("app-sbom"))
+		Expect(names(Must(sbom.Related(cd, res("lib"))))).To(ConsistOf("lib-sbom"))
+		Expect(names(Must(sbom.Related(cd, res("tool"))))).To(ConsistOf("lib-sbom", "tool-sbom"))
+		Expect(names(Must(sbom.Related(cd, res("lib-sbom"))))).To(ConsistOf("lib-sbom"))
+	})
+
+	It("finds subjects", func() {
+		Expect(names(Must(sbom.Subjects(cd, res("app-sbom"))))).To(ConsistOf("app"))
+		Expect(names(Must(sbom.Subjects(cd, res("lib-sbom"))))).To(ConsistOf("lib", "tool"))
+		Expect(names(Must(sbom.Subjects(cd, res("tool-sbom"))))).To(ConsistOf("tool"))
+	})
+
+	It("reads SBOMs", func() {
+		doc := Must(sbom.Get(cv, res("app-sbom")))
+		Expect(doc.Packages).To(Equal([]sbom.Package{{Name: "log4j-core", Version: "2.14.1", Licenses: []string{"Apache-2.0"}}}))
+
+		doc = Must(sbom.Get(cv, res("lib-sbom")))
+		Expect(doc.Format).To(Equal("cyclonedx"))
+		Expect(doc.Find("snakeyaml", "1")).To(Equal([]sbom.Package{{Name: "org.yaml:snakeyaml", Version: "1.33"}}))
+	})
+})
@@ -6,6 +6,8 @@ import (
 	"ocm.software/ocm/api/ocm"
 	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
+	"ocm.software/ocm/api/ocm/extensions/attrs/verificationattr"
+	"ocm.software/ocm/api/ocm/tools/signing/policy"
 	"ocm.software/ocm/api/tech/signing/handlers/rsa"
 )
 
@@ -23,7 +25,7 @@ func SignComponentVersion(cv ocm.ComponentVersionAccess, name string, optlist ..
 	if opts.VerifySignature {
 		return nil, errors.Newf("impossible verification option set for signing")
 	}
-	if opts.Signer == nil {
+	if opts.Signer == nil && opts.SignAlgo == "" {
 		opts.Signer = signingattr.Get(cv.GetContext()).GetSigner(rsa.Algorithm)
 	}
 	err := opts.Complete(cv.GetContext())
@@ -55,3 +57,33 @@ func VerifyComponentVersion(cv ocm.ComponentVersionAccess, name string, optlist
 	}
 	return Apply(nil, nil, cv, &opts)
 }
+
+// VerifyComponentVersionByPolicy verifies a component version and the
+// component versions reachable by following the component references
+// according to a verification policy. If no policy is given, the policy
+// configured for the context of the component version is used.
+func VerifyComponentVersionByPolicy(cv ocm.ComponentVersionAccess, p *policy.Policy, optlist ...Option) (*metav1.DigestSpec, error) {
+	var opts Options
+
+	opts.Eval(
+		VerifyDigests(),
+		Recursive(),
+		VerificationPolicy(p),
+	)
+	opts.Eval(optlist...)
+
+	if opts.Policy == nil {
+		opts.Policy = verificationattr.Get(cv.GetContext())
+		if opts.Policy == nil {
+			return nil, errors.Newf("no verification policy found")
+		}
+	}
+	if opts.Signer != nil {
+		return nil, errors.Newf("impossible signer option set for verification")
+	}
+	err := opts.Complete(cv.GetContext())
+	if err != nil {
+		return nil, errors.Wrapf(err, "inconsistent options for verification")
+	}
+	return Apply(nil, nil, cv, &opts)
+}
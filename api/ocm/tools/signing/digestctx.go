@@ -181,6 +181,9 @@ func (dc *DigestContext) ValidFor(ctx *DigestContext) bool {
 }
 
 func (dc *DigestContext) determineSignatureInfo(state WalkingState, cv ocm.ComponentVersionAccess, opts *Options) (*Options, error) {
+	if opts.Policy != nil && !opts.DoVerify() {
+		return dc.determinePolicySignatureInfo(cv, opts)
+	}
 	if opts.SignatureName() != "" {
 		// determine digester type
 		var found bool
@@ -248,3 +251,20 @@ func (dc *DigestContext) determineSignatureInfo(state WalkingState, cv ocm.Compo
 	}
 	return opts, nil
 }
+
+// determinePolicySignatureInfo determines the digester type by the first
+// signature of a signer required by the verification policy.
+func (dc *DigestContext) determinePolicySignatureInfo(cv ocm.ComponentVersionAccess, opts *Options) (*Options, error) {
+	rule := opts.Policy.RuleFor(cv.GetName())
+	if rule == nil {
+		return nil, errors.Newf("no verification policy rule found for component %q", cv.GetName())
+	}
+	for _, n := range rule.AllSigners() {
+		i := dc.Descriptor.GetSignatureIndex(opts.Policy.SignatureName(n))
+		if i >= 0 {
+			dc.DigestType = DigesterType(&dc.Descriptor.Signatures[i].Digest)
+			return opts, nil
+		}
+	}
+	return nil, errors.Newf("no signature found for verification policy")
+}
@@ -18,6 +18,7 @@ import (
 	"ocm.software/ocm/api/ocm/compdesc"
 	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/none"
+	"ocm.software/ocm/api/ocm/tools/signing/policy"
 	"ocm.software/ocm/api/tech/signing"
 	"ocm.software/ocm/api/tech/signing/signutils"
 	"ocm.software/ocm/api/tech/signing/tsa"
@@ -181,7 +182,7 @@ func _apply(state WalkingState, nv common.NameVersion, cv ocm.ComponentVersionAc
 		// the first one creating hashes determines the digest mode to be used for all further signatures
 		mode := GetDigestMode(cv.GetDescriptor(), opts.DigestMode)
 		opts = opts.WithDigestMode(mode)
-		if opts.DoSign() || !opts.DoVerify() {
+		if opts.DoSign() || !(opts.DoVerify() || opts.Policy != nil) {
 			ctx.DigestType = ocm.DigesterType{
 				HashAlgorithm:          opts.Hasher.Algorithm(),
 				NormalizationAlgorithm: opts.NormalizationAlgo,
@@ -290,6 +291,13 @@ func _apply(state WalkingState, nv common.NameVersion, cv ocm.ComponentVersionAc
 
 		addVerified(state, cd, opts, signatureNames...)
 	}
+	if opts.Policy != nil && !opts.DoSign() {
+		sigs, err := doVerifyPolicy(digests, state, ctx.IsRoot(), opts)
+		if err != nil {
+			return nil, err
+		}
+		addVerified(state, cd, opts, sigs...)
+	}
 	err := ctx.Propagate(spec)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed propagating digest context")
@@ -302,11 +310,12 @@ func _apply(state WalkingState, nv common.NameVersion, cv ocm.ComponentVersionAc
 			return nil, err
 		}
 		sctx := &signing.DefaultSigningContext{
-			Hash:       opts.Hasher.Crypto(),
-			PrivateKey: priv,
-			PublicKey:  opts.PublicKey(opts.SignatureName()),
-			RootCerts:  opts.RootCerts,
-			Issuer:     opts.GetIssuer(),
+			Hash:             opts.Hasher.Crypto(),
+			PrivateKey:       priv,
+			PublicKey:        opts.PublicKey(opts.SignatureName()),
+			RootCerts:        opts.RootCerts,
+			RootCertificates: opts.rootCertificates,
+			Issuer:           opts.GetIssuer(),
 		}
 		sig, err := opts.Signer.Sign(cv.GetContext().CredentialsContext(), ctx.Digest.Value, sctx)
 		if err != nil {
@@ -344,7 +353,7 @@ func _apply(state WalkingState, nv common.NameVersion, cv ocm.ComponentVersionAc
 				return nil, err
 			}
 
-			ts, t, err := tsa.Request(url, mi)
+			ts, t, err := tsa.Request(url, mi, opts.EffectiveTSARootCerts())
 			if err != nil {
 				return nil, err
 			}
@@ -432,8 +441,9 @@ func doVerify(digests *compdesc.CompDescDigests, state WalkingState, signatureNa
 	var spec *metav1.DigestSpec
 
 	sctx := &signing.DefaultSigningContext{
-		Hash:      opts.Hasher.Crypto(),
-		RootCerts: opts.RootCerts,
+		Hash:             opts.Hasher.Crypto(),
+		RootCerts:        opts.RootCerts,
+		RootCertificates: opts.rootCertificates,
 	}
 
 	found := []string{}
@@ -444,14 +454,17 @@ func doVerify(digests *compdesc.CompDescDigests, state WalkingState, signatureNa
 		}
 		sig := &digests.Descriptor().Signatures[f]
 
+		timestamp, err := verifyTimestamp(sig, opts)
+		if err != nil {
+			return nil, errors.Wrapf(err, "signature %q", sig.Name)
+		}
+
 		sctx.Issuer = opts.IssuerFor(n)
 		if !opts.Keyless {
 			sctx.PublicKey = opts.PublicKey(n)
 			if sctx.PublicKey == nil {
-				var err error
-
 				opts.Printer.Printf("no public key found for signature %q -> extract key from signature\n", n)
-				sctx.PublicKey, err = GetPublicKeyFromSignature(sig, sctx, opts)
+				sctx.PublicKey, err = publicKeyFromSignature(sig, sctx, timestamp)
 				if err != nil {
 					return nil, errors.Wrapf(err, "public key from signature")
 				}
@@ -466,23 +479,9 @@ func doVerify(digests *compdesc.CompDescDigests, state WalkingState, signatureNa
 			continue
 		}
 
-		hasher := opts.Registry.GetHasher(sig.Digest.HashAlgorithm)
-		if hasher == nil {
-			return nil, errors.ErrUnknown(compdesc.KIND_HASH_ALGORITHM, sig.Digest.HashAlgorithm)
-		}
-
-		_, digest, err := digests.Get(sig.Digest.NormalisationAlgorithm, hasher)
-		if err != nil {
-			return nil, errors.Wrapf(err, "failed hashing component descriptor")
-		}
-		if sig.Digest.Value != digest {
-			return nil, errors.Newf("signature digest (%s) does not match found digest (%s)", sig.Digest.Value, digest)
-		}
-
-		sctx.Hash = hasher.Crypto()
-		err = verifier.Verify(sig.Digest.Value, sig.ConvertToSigning(), sctx)
+		err = verifySignature(digests, sig, verifier, sctx, timestamp, opts)
 		if err != nil {
-			return nil, errors.Wrapf(err, "signature %q", n)
+			return nil, err
 		}
 		found = append(found, n)
 		if opts.SignatureName() == sig.Name {
@@ -500,7 +499,181 @@ func doVerify(digests *compdesc.CompDescDigests, state WalkingState, signatureNa
 	return spec, nil
 }
 
+// verifySignature verifies a signature. If the signature provides a
+// timestamp, the already verified time of the timestamp must be given.
+func verifySignature(digests *compdesc.CompDescDigests, sig *compdesc.Signature, verifier signing.Verifier, sctx *signing.DefaultSigningContext, timestamp *time.Time, opts *Options) error {
+	hasher := opts.Registry.GetHasher(sig.Digest.HashAlgorithm)
+	if hasher == nil {
+		return errors.ErrUnknown(compdesc.KIND_HASH_ALGORITHM, sig.Digest.HashAlgorithm)
+	}
+
+	_, digest, err := digests.Get(sig.Digest.NormalisationAlgorithm, hasher)
+	if err != nil {
+		return errors.Wrapf(err, "failed hashing component descriptor")
+	}
+	if sig.Digest.Value != digest {
+		return errors.Newf("signature digest (%s) does not match found digest (%s)", sig.Digest.Value, digest)
+	}
+
+	if timestamp != nil {
+		if err := checkCertAt(sctx.PublicKey, sctx.RootCerts, *timestamp); err != nil {
+			return errors.Wrapf(err, "signature %q", sig.Name)
+		}
+	}
+
+	sctx.Hash = hasher.Crypto()
+	err = verifier.Verify(sig.Digest.Value, sig.ConvertToSigning(), sctx)
+	if err != nil {
+		return errors.Wrapf(err, "signature %q", sig.Name)
+	}
+	return nil
+}
+
+// verifyTimestamp verifies the timestamp of a signature against the
+// signature digest and the TSA root certificates. It returns the
+// time proven by the timestamp, or nil if the signature has no timestamp.
+func verifyTimestamp(sig *compdesc.Signature, opts *Options) (*time.Time, error) {
+	if sig.Timestamp == nil {
+		return nil, nil
+	}
+	ts, err := tsa.FromPem([]byte(sig.Timestamp.Value))
+	if err != nil {
+		return nil, errors.Wrapf(err, "signature timestamp")
+	}
+	h, d, err := DigestInfo(opts, &sig.Digest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signature digest")
+	}
+	mi, err := tsa.NewMessageImprint(h, d)
+	if err != nil {
+		return nil, errors.Wrapf(err, "signature digest")
+	}
+	t, err := tsa.Verify(mi, ts, false, opts.EffectiveTSARootCerts())
+	if err != nil {
+		return nil, errors.Wrapf(err, "signature timestamp verification")
+	}
+	if sig.Timestamp.Time != nil {
+		if diff := sig.Timestamp.Time.Time().Sub(*t); diff < -time.Second || diff > time.Second {
+			return nil, errors.Newf("signature timestamp time %s does not match timestamp token (%s)", sig.Timestamp.Time.Time().Format(time.RFC3339), t.Format(time.RFC3339))
+		}
+	}
+	return t, nil
+}
+
+// checkCertAt checks whether a public key given as certificate was valid
+// at the given time.
+func checkCertAt(data interface{}, rootCerts signutils.GenericCertificatePool, t time.Time) error {
+	if data == nil {
+		return nil
+	}
+	cert, pool, err := signutils.GetCertificate(data, false)
+	if err != nil {
+		return nil
+	}
+	err = signutils.VerifyCertificate(cert, pool, rootCerts, nil, &t)
+	if err != nil {
+		return errors.Wrapf(err, "public key certificate not valid at signature timestamp %s", t.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// doVerifyPolicy evaluates the rule of the verification policy
+// matching the component version. It returns the names
+// of the successfully verified signatures.
+func doVerifyPolicy(digests *compdesc.CompDescDigests, state WalkingState, root bool, opts *Options) ([]string, error) {
+	cd := digests.Descriptor()
+	rule := opts.Policy.RuleFor(cd.GetName())
+	if rule == nil {
+		if root && !opts.DoVerify() {
+			return nil, errors.Newf("no verification policy rule found for component %q", cd.GetName())
+		}
+		return nil, nil
+	}
+
+	var valid, signatures []string
+	for _, n := range rule.AllSigners() {
+		name := opts.Policy.SignatureName(n)
+		f := cd.GetSignatureIndex(name)
+		if f < 0 {
+			opts.Printer.Printf("  signer %q: signature %q not found\n", n, name)
+			continue
+		}
+		err := verifyPolicySigner(digests, &cd.Signatures[f], opts.Policy.Signers[n], opts)
+		if err != nil {
+			state.Logger.Debug("policy signer verification failed", "signer", n, "signature", name, "error", err.Error())
+			opts.Printer.Printf("  signer %q: signature %q invalid: %s\n", n, name, err)
+			continue
+		}
+		opts.Printer.Printf("  signer %q: signature %q verified\n", n, name)
+		valid = append(valid, n)
+		signatures = append(signatures, name)
+	}
+	if err := rule.Check(valid); err != nil {
+		return nil, errors.Wrapf(err, "verification policy")
+	}
+	return signatures, nil
+}
+
+func verifyPolicySigner(digests *compdesc.CompDescDigests, sig *compdesc.Signature, signer *policy.Signer, opts *Options) error {
+	sctx := &signing.DefaultSigningContext{
+		RootCerts:        opts.RootCerts,
+		RootCertificates: opts.rootCertificates,
+		Issuer:           signer.GetIssuer(),
+	}
+	rootCerts, err := signer.GetRootCertificates()
+	if err != nil {
+		return err
+	}
+	if rootCerts != nil {
+		sctx.RootCerts = rootCerts
+		sctx.RootCertificates = rootCerts
+	}
+	if sctx.Issuer == nil {
+		sctx.Issuer = opts.IssuerFor(sig.Name)
+	}
+
+	timestamp, err := verifyTimestamp(sig, opts)
+	if err != nil {
+		return err
+	}
+
+	pub, err := signer.GetPublicKey()
+	if err != nil {
+		return errors.Wrapf(err, "public key")
+	}
+	if pub == nil {
+		pub = opts.PublicKey(sig.Name)
+	}
+	if pub != nil {
+		if err := checkCert(pub, sctx.RootCerts, sctx.Issuer); err != nil {
+			return fmt.Errorf("public key not valid: %w", err)
+		}
+		sctx.PublicKey = pub
+	} else {
+		sctx.PublicKey, err = publicKeyFromSignature(sig, sctx, timestamp)
+		if err != nil {
+			return errors.Wrapf(err, "public key from signature")
+		}
+	}
+	verifier := opts.Registry.GetVerifier(sig.Signature.Algorithm)
+	if verifier == nil {
+		return errors.ErrUnknown(compdesc.KIND_VERIFY_ALGORITHM, sig.Signature.Algorithm)
+	}
+	return verifySignature(digests, sig, verifier, sctx, timestamp, opts)
+}
+
 func GetPublicKeyFromSignature(sig *compdesc.Signature, sctx signing.SigningContext, opts *Options) (signutils.GenericPublicKey, error) {
+	timestamp, err := verifyTimestamp(sig, opts)
+	if err != nil {
+		return nil, err
+	}
+	return publicKeyFromSignature(sig, sctx, timestamp)
+}
+
+// publicKeyFromSignature extracts the public key from a certificate
+// provided by the signature. The certificate is validated for the time
+// of the already verified timestamp of the signature, if given.
+func publicKeyFromSignature(sig *compdesc.Signature, sctx signing.SigningContext, timestamp *time.Time) (signutils.GenericPublicKey, error) {
 	if sig.Signature.MediaType != signutils.MediaTypePEM {
 		return nil, errors.ErrNotFound(compdesc.KIND_PUBLIC_KEY)
 	}
@@ -517,26 +690,6 @@ func GetPublicKeyFromSignature(sig *compdesc.Signature, sctx signing.SigningCont
 		return nil, err
 	}
 
-	var timestamp *time.Time
-	if sig.Timestamp != nil {
-		ts, err := tsa.FromPem([]byte(sig.Timestamp.Value))
-		if err != nil {
-			return nil, errors.Wrapf(err, "signature timestamp")
-		}
-		h, d, err := DigestInfo(opts, &sig.Digest)
-		if err != nil {
-			return nil, errors.Wrapf(err, "signature digest")
-		}
-		mi, err := tsa.NewMessageImprint(h, d)
-		if err != nil {
-			return nil, errors.Wrapf(err, "signature digest")
-		}
-		timestamp, err = tsa.Verify(mi, ts, false, sctx.GetRootCerts())
-		if err != nil {
-			return nil, errors.Wrapf(err, "signature timestamp verification")
-		}
-	}
-
 	err = signutils.VerifyCertificate(cert, pool, sctx.GetRootCerts(), sctx.GetIssuer(), timestamp)
 	if err != nil {
 		return nil, errors.Wrapf(err, "public key certificate")
@@ -34,12 +34,18 @@ var _ = Describe("Simple signing handlers", func() {
 			MustBeSuccessful(cv.SetResourceBlob(ocm.NewResourceMeta("blob", resourcetypes.PLAIN_TEXT, v1.LocalRelation), blobaccess.ForString(mime.MIME_TEXT, "test data"), "", nil))
 		})
 
-		DescribeTable("rsa handlers", func(kind string) {
+		DescribeTable("rsa handlers", func(kind, mediaType string) {
 			Must(signing.SignComponentVersion(cv, "signature", signing.PrivateKey("signature", priv), signing.SignerByAlgo(kind)))
+			sig := cv.GetDescriptor().Signatures[0].Signature
+			Expect(sig.Algorithm).To(Equal(kind))
+			Expect(sig.MediaType).To(Equal(mediaType))
 			Must(signing.VerifyComponentVersion(cv, "signature", signing.PublicKey("signature", pub)))
+
+			_, other := Must2(rsa.CreateKeyPair())
+			ExpectError(signing.VerifyComponentVersion(cv, "signature", signing.PublicKey("signature", other))).To(MatchError(ContainSubstring("signature verification failed")))
 		},
-			Entry("rsa", rsa.Algorithm),
-			Entry("rsapss", rsa_pss.Algorithm),
+			Entry("rsa", rsa.Algorithm, rsa.MediaType),
+			Entry("rsapss", rsa_pss.Algorithm, rsa_pss.MediaType),
 		)
 
 		It("uses verified store", func() {
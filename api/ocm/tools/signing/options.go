@@ -1,6 +1,7 @@
 package signing
 
 import (
+	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	"ocm.software/ocm/api/ocm/compdesc"
 	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
 	"ocm.software/ocm/api/ocm/resolvers"
+	"ocm.software/ocm/api/ocm/tools/signing/policy"
 	"ocm.software/ocm/api/tech/signing"
 	"ocm.software/ocm/api/tech/signing/hasher/sha256"
 	"ocm.software/ocm/api/tech/signing/signutils"
@@ -360,6 +362,24 @@ func (o *rootcerts) ApplySigningOption(opts *Options) {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+type tsarootcerts struct {
+	pool signutils.GenericCertificatePool
+}
+
+// TSARootCertificates provides an option requesting dedicated root certificates
+// for the validation of timestamp authorities used for signature timestamps.
+// By default, the TSA root certificates configured for the context are used,
+// or the general root certificates, if not configured.
+func TSARootCertificates(pool signutils.GenericCertificatePool) Option {
+	return &tsarootcerts{pool}
+}
+
+func (o *tsarootcerts) ApplySigningOption(opts *Options) {
+	opts.TSARootCerts = o.pool
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type privkey struct {
 	name string
 	key  interface{}
@@ -448,6 +468,24 @@ func (o *verifyedstore) ApplySigningOption(opts *Options) {
 
 ////////////////////////////////////////////////////////////////////////////////
 
+type verificationPolicy struct {
+	policy *policy.Policy
+}
+
+// VerificationPolicy sets a verification policy used to verify the
+// signatures of component versions. The policy is evaluated for all
+// component versions with a matching rule, including the component
+// versions found by following component references.
+func VerificationPolicy(p *policy.Policy) Option {
+	return &verificationPolicy{p}
+}
+
+func (o *verificationPolicy) ApplySigningOption(opts *Options) {
+	opts.Policy = o.policy
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
 type Options struct {
 	Printer           common.Printer
 	Update            bool
@@ -470,8 +508,11 @@ type Options struct {
 	Keyless           bool
 	TSAUrl            string
 	UseTSA            bool
+	TSARootCerts      signutils.GenericCertificatePool
+	Policy            *policy.Policy
 
 	effectiveRegistry signing.Registry
+	rootCertificates  []*x509.Certificate
 
 	VerifiedStore VerifiedStore
 }
@@ -541,6 +582,12 @@ func (o *Options) ApplySigningOption(opts *Options) {
 	if o.UseTSA {
 		opts.UseTSA = o.UseTSA
 	}
+	if o.TSARootCerts != nil {
+		opts.TSARootCerts = o.TSARootCerts
+	}
+	if o.Policy != nil {
+		opts.Policy = o.Policy
+	}
 }
 
 // Complete takes either nil, an ocm.ContextProvider or a signing.Registry.
@@ -580,9 +627,18 @@ func (o *Options) Complete(ctx interface{}) error {
 	certs := rootcertsattr.Get(ocmctx)
 	if o.RootCerts == nil && certs.HasRootCertificates() {
 		o.RootCerts = certs.GetRootCertPool(true)
+		o.rootCertificates = certs.GetRootCertificates()
 	}
 
 	if o.RootCerts != nil {
+		// keep the certificates, they cannot be extracted from the pool anymore
+		if _, ok := o.RootCerts.(*x509.CertPool); !ok {
+			chain, err := signutils.GetCertificateChain(o.RootCerts, false)
+			if err != nil {
+				return err
+			}
+			o.rootCertificates = chain
+		}
 		// check root certificates
 		pool, err := signutils.GetCertPool(o.RootCerts, false)
 		if err != nil {
@@ -591,6 +647,17 @@ func (o *Options) Complete(ctx interface{}) error {
 		o.RootCerts = pool
 	}
 
+	if o.TSARootCerts == nil && certs.HasTSARootCertificates() {
+		o.TSARootCerts = certs.GetTSARootCertPool()
+	}
+	if o.TSARootCerts != nil {
+		pool, err := signutils.GetCertPool(o.TSARootCerts, false)
+		if err != nil {
+			return errors.Wrapf(err, "TSA root certificates")
+		}
+		o.TSARootCerts = pool
+	}
+
 	if o.SkipAccessTypes == nil {
 		o.SkipAccessTypes = map[string]bool{}
 	}
@@ -613,6 +680,14 @@ func (o *Options) Complete(ctx interface{}) error {
 			return errors.ErrNotFound(compdesc.KIND_PRIVATE_KEY, o.SignatureNames[0])
 		}
 	}
+	if o.Policy != nil {
+		if o.Signer != nil {
+			return errors.Newf("verification policy not possible for signing")
+		}
+		if err := o.Policy.Validate(); err != nil {
+			return errors.Wrapf(err, "invalid verification policy")
+		}
+	}
 	if o.DigestMode == "" {
 		o.DigestMode = DIGESTMODE_LOCAL
 	}
@@ -655,11 +730,15 @@ func (o *Options) Complete(ctx interface{}) error {
 }
 
 func (o *Options) checkCert(data interface{}, name *pkix.Name) error {
+	return checkCert(data, o.RootCerts, name)
+}
+
+func checkCert(data interface{}, rootCerts signutils.GenericCertificatePool, name *pkix.Name) error {
 	cert, pool, err := signutils.GetCertificate(data, false)
 	if err != nil {
 		return nil
 	}
-	err = signing.VerifyCertDN(pool, o.RootCerts, name, cert)
+	err = signing.VerifyCertDN(pool, rootCerts, name, cert)
 	if err != nil {
 		if name != nil {
 			return errors.Wrapf(err, "issuer [%s]", name)
@@ -729,6 +808,16 @@ func (o *Options) PrivateKey() (signutils.GenericPrivateKey, error) {
 	return signing.ResolvePrivateKey(o.effectiveRegistry, o.SignatureName())
 }
 
+// EffectiveTSARootCerts returns the root certificates used to validate
+// the certificate chain of a timestamp authority. If no dedicated TSA
+// root certificates are configured, the general root certificates are used.
+func (o *Options) EffectiveTSARootCerts() signutils.GenericCertificatePool {
+	if o.TSARootCerts != nil {
+		return o.TSARootCerts
+	}
+	return o.RootCerts
+}
+
 func (o *Options) EffectiveTSAUrl() string {
 	if o.UseTSA {
 		if o.TSAUrl != "" {
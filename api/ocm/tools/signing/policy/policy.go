@@ -0,0 +1,263 @@
+package policy
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	cfgcpi "ocm.software/ocm/api/config/cpi"
+	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
+	"ocm.software/ocm/api/tech/signing/signutils"
+	"ocm.software/ocm/api/utils"
+)
+
+const KIND_SIGNER = "signer"
+
+type (
+	KeySpec = cfgcpi.ContentSpec
+	Issuer  = signingattr.Issuer
+)
+
+// Policy describes the signatures required for component versions.
+// Signers describe the accepted signatures by name, public key and
+// certificate constraints. Rules describe, which signers are required
+// for component versions with names matching a component name pattern.
+type Policy struct {
+	Signers map[string]*Signer `json:"signers,omitempty"`
+	Rules   []Rule             `json:"rules,omitempty"`
+}
+
+// Signer describes an accepted signature.
+type Signer struct {
+	// Signature is the name of the signature in the component descriptor.
+	// By default, the name of the signer is used.
+	Signature string `json:"signature,omitempty"`
+	// PublicKey is the public key or certificate used to verify the signature.
+	// If not given, the public key configured for the signature name is used,
+	// or the certificate provided together with the signature. In this case
+	// an Issuer is required.
+	PublicKey *KeySpec `json:"publicKey,omitempty"`
+	// Issuer is the required distinguished name of the key certificate.
+	// At least one of PublicKey or Issuer must be given.
+	Issuer *Issuer `json:"issuer,omitempty"`
+	// RootCertificates restrict the accepted root certificates for the
+	// key certificate. By default, the configured root certificates are used.
+	RootCertificates []KeySpec `json:"rootCertificates,omitempty"`
+}
+
+// Rule describes the required signers for component versions.
+type Rule struct {
+	// Components is a list of component name patterns the rule applies to.
+	// The wildcard * matches any sequence of characters, ? matches any
+	// single character. An empty list matches all components.
+	Components []string `json:"components,omitempty"`
+	// Required is a list of signers, which must have signed a component version.
+	Required []string `json:"required,omitempty"`
+	// Signers is a list of signers of which at least Threshold must have signed
+	// a component version.
+	Signers []string `json:"signers,omitempty"`
+	// Threshold is the minimal number of valid signatures of Signers
+	// (default 1).
+	Threshold int `json:"threshold,omitempty"`
+}
+
+// New creates an empty policy.
+func New() *Policy {
+	return &Policy{}
+}
+
+// AddSigner adds a signer to the policy.
+func (p *Policy) AddSigner(name string, s *Signer) *Policy {
+	if p.Signers == nil {
+		p.Signers = map[string]*Signer{}
+	}
+	p.Signers[name] = s
+	return p
+}
+
+// AddRule adds a rule to the policy.
+func (p *Policy) AddRule(r Rule) *Policy {
+	p.Rules = append(p.Rules, r)
+	return p
+}
+
+// IsEmpty checks whether the policy describes any rule.
+func (p *Policy) IsEmpty() bool {
+	return p == nil || len(p.Rules) == 0
+}
+
+// Validate checks the consistency of the policy.
+func (p *Policy) Validate() error {
+	for _, n := range utils.StringMapKeys(p.Signers) {
+		if err := p.Signers[n].Validate(); err != nil {
+			return errors.Wrapf(err, "signer %q", n)
+		}
+	}
+	for i, r := range p.Rules {
+		if err := p.validateRule(&r); err != nil {
+			return errors.Wrapf(err, "rule %d", i+1)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) validateRule(r *Rule) error {
+	if len(r.Required) == 0 && len(r.Signers) == 0 {
+		return errors.Newf("no signers specified")
+	}
+	for _, n := range append(slices.Clone(r.Required), r.Signers...) {
+		if p.Signers[n] == nil {
+			return errors.ErrUnknown(KIND_SIGNER, n)
+		}
+	}
+	if r.Threshold < 0 || r.Threshold > len(r.Signers) {
+		return errors.Newf("threshold %d out of range (%d signers)", r.Threshold, len(r.Signers))
+	}
+	for _, c := range r.Components {
+		if c == "" {
+			return errors.Newf("empty component pattern")
+		}
+	}
+	return nil
+}
+
+// RuleFor returns the first rule matching the given component name.
+// If no rule matches, nil is returned.
+func (p *Policy) RuleFor(component string) *Rule {
+	if p == nil {
+		return nil
+	}
+	for i, r := range p.Rules {
+		if r.Matches(component) {
+			return &p.Rules[i]
+		}
+	}
+	return nil
+}
+
+// SignatureName provides the name of the signature
+// used for the given signer.
+func (p *Policy) SignatureName(signer string) string {
+	if s := p.Signers[signer]; s != nil && s.Signature != "" {
+		return s.Signature
+	}
+	return signer
+}
+
+// Matches checks whether the rule applies to the given component name.
+func (r *Rule) Matches(component string) bool {
+	if len(r.Components) == 0 {
+		return true
+	}
+	for _, c := range r.Components {
+		if MatchComponent(c, component) {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectiveThreshold provides the number of required valid signatures
+// of the rule's signer list.
+func (r *Rule) EffectiveThreshold() int {
+	if len(r.Signers) == 0 {
+		return 0
+	}
+	if r.Threshold == 0 {
+		return 1
+	}
+	return r.Threshold
+}
+
+// AllSigners provides the names of all signers relevant for the rule.
+func (r *Rule) AllSigners() []string {
+	var result []string
+	for _, n := range append(slices.Clone(r.Required), r.Signers...) {
+		if !slices.Contains(result, n) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// Check evaluates the rule for the given list of signers
+// with a valid signature.
+func (r *Rule) Check(valid []string) error {
+	var missing []string
+	for _, n := range r.Required {
+		if !slices.Contains(valid, n) {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.Newf("required signer(s) %s missing", strings.Join(missing, ", "))
+	}
+	cnt := 0
+	for _, n := range r.Signers {
+		if slices.Contains(valid, n) {
+			cnt++
+		}
+	}
+	if t := r.EffectiveThreshold(); cnt < t {
+		return errors.Newf("%d of %d required signatures of [%s] found", cnt, t, strings.Join(r.Signers, ", "))
+	}
+	return nil
+}
+
+// MatchComponent matches a component name against a component name pattern.
+func MatchComponent(pattern, component string) bool {
+	expr := "^" + strings.ReplaceAll(strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, ".*"), `\?`, ".") + "$"
+	ok, _ := regexp.MatchString(expr, component)
+	return ok
+}
+
+// GetPublicKey provides the public key configured for the signer, if given.
+func (s *Signer) GetPublicKey() (signutils.GenericPublicKey, error) {
+	if s.PublicKey == nil {
+		return nil, nil
+	}
+	return s.PublicKey.Get()
+}
+
+// GetIssuer provides the required issuer of the signer, if given.
+func (s *Signer) GetIssuer() *pkix.Name {
+	if s.Issuer == nil {
+		return nil
+	}
+	return s.Issuer.Get()
+}
+
+// Validate checks the consistency of the signer.
+// A signer must restrict the accepted signatures by a
+// public key or an issuer.
+func (s *Signer) Validate() error {
+	if s == nil || (s.PublicKey == nil && s.Issuer == nil) {
+		return errors.Newf("public key or issuer required")
+	}
+	return nil
+}
+
+// GetRootCertificates provides the root certificates accepted for the signer.
+// If no dedicated root certificates are configured, nil is returned.
+func (s *Signer) GetRootCertificates() ([]*x509.Certificate, error) {
+	if len(s.RootCertificates) == 0 {
+		return nil, nil
+	}
+	var certs []*x509.Certificate
+	for i, k := range s.RootCertificates {
+		data, err := k.Get()
+		if err != nil {
+			return nil, errors.Wrapf(err, "root certificate %d", i+1)
+		}
+		chain, err := signutils.GetCertificateChain(data, false)
+		if err != nil {
+			return nil, errors.Wrapf(err, "root certificate %d", i+1)
+		}
+		certs = append(certs, chain...)
+	}
+	return certs, nil
+}
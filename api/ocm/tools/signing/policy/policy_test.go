@@ -0,0 +1,97 @@
+package policy_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/ocm/tools/signing/policy"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+var _ = Describe("verification policy", func() {
+	key := &policy.KeySpec{StringData: "key"}
+	p := policy.New().
+		AddSigner("alice", &policy.Signer{PublicKey: key}).
+		AddSigner("bob", &policy.Signer{Signature: "bob-sig", PublicKey: key}).
+		AddSigner("carol", &policy.Signer{Issuer: &policy.Issuer{CommonName: "carol"}}).
+		AddRule(policy.Rule{
+			Components: []string{"acme.org/special"},
+			Required:   []string{"alice"},
+			Signers:    []string{"bob", "carol"},
+			Threshold:  2,
+		}).
+		AddRule(policy.Rule{
+			Components: []string{"acme.org/*"},
+			Signers:    []string{"alice", "bob", "carol"},
+		})
+
+	It("validates", func() {
+		MustBeSuccessful(p.Validate())
+
+		Expect(policy.New().AddRule(policy.Rule{}).Validate()).To(MatchError("rule 1: no signers specified"))
+		Expect(policy.New().AddRule(policy.Rule{Signers: []string{"alice"}}).Validate()).To(MatchError("rule 1: signer \"alice\" is unknown"))
+		Expect(policy.New().AddSigner("alice", &policy.Signer{PublicKey: key}).AddRule(policy.Rule{Signers: []string{"alice"}, Threshold: 2}).Validate()).To(MatchError("rule 1: threshold 2 out of range (1 signers)"))
+	})
+
+	It("rejects signers without public key or issuer", func() {
+		Expect(policy.New().AddSigner("alice", &policy.Signer{}).AddRule(policy.Rule{Signers: []string{"alice"}}).Validate()).To(MatchError("signer \"alice\": public key or issuer required"))
+		Expect(policy.New().AddSigner("alice", &policy.Signer{Signature: "sig", RootCertificates: []policy.KeySpec{*key}}).Validate()).To(MatchError("signer \"alice\": public key or issuer required"))
+		Expect(policy.New().AddSigner("alice", nil).Validate()).To(MatchError("signer \"alice\": public key or issuer required"))
+	})
+
+	It("matches components", func() {
+		Expect(policy.MatchComponent("acme.org/*", "acme.org/a/b")).To(BeTrue())
+		Expect(policy.MatchComponent("acme.org/?", "acme.org/a")).To(BeTrue())
+		Expect(policy.MatchComponent("acme.org/?", "acme.org/ab")).To(BeFalse())
+		Expect(policy.MatchComponent("acme.org", "acme.org/a")).To(BeFalse())
+		Expect(policy.MatchComponent("acme.org/a.b", "acme.org/aXb")).To(BeFalse())
+
+		Expect(p.RuleFor("acme.org/special")).To(BeIdenticalTo(&p.Rules[0]))
+		Expect(p.RuleFor("acme.org/other")).To(BeIdenticalTo(&p.Rules[1]))
+		Expect(p.RuleFor("other.org/other")).To(BeNil())
+	})
+
+	It("provides signature names", func() {
+		Expect(p.SignatureName("alice")).To(Equal("alice"))
+		Expect(p.SignatureName("bob")).To(Equal("bob-sig"))
+		Expect(p.Rules[0].AllSigners()).To(Equal([]string{"alice", "bob", "carol"}))
+	})
+
+	It("checks rules", func() {
+		r := p.Rules[0]
+		MustBeSuccessful(r.Check([]string{"alice", "bob", "carol"}))
+		Expect(r.Check([]string{"bob", "carol"})).To(MatchError("required signer(s) alice missing"))
+		Expect(r.Check([]string{"alice", "carol"})).To(MatchError("1 of 2 required signatures of [bob, carol] found"))
+
+		r = p.Rules[1]
+		MustBeSuccessful(r.Check([]string{"carol"}))
+		Expect(r.Check(nil)).To(MatchError("0 of 1 required signatures of [alice, bob, carol] found"))
+	})
+
+	It("deserializes", func() {
+		data := `
+signers:
+  alice:
+    issuer:
+      commonName: alice
+  bob:
+    signature: bob-sig
+    publicKey:
+      stringdata: key
+rules:
+- components:
+  - acme.org/*
+  required:
+  - alice
+  signers:
+  - bob
+`
+		var r policy.Policy
+		MustBeSuccessful(runtime.DefaultYAMLEncoding.Unmarshal([]byte(data), &r))
+		MustBeSuccessful(r.Validate())
+		Expect(r.Signers["alice"].GetIssuer().CommonName).To(Equal("alice"))
+		Expect(Must(r.Signers["bob"].GetPublicKey())).To(Equal([]byte("key")))
+		Expect(r.RuleFor("acme.org/test").EffectiveThreshold()).To(Equal(1))
+	})
+})
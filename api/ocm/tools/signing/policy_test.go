@@ -0,0 +1,176 @@
+package signing_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+	. "ocm.software/ocm/api/ocm/testhelper"
+	. "ocm.software/ocm/api/ocm/tools/signing"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
+	"ocm.software/ocm/api/ocm/extensions/attrs/verificationattr"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/resolvers"
+	"ocm.software/ocm/api/ocm/tools/signing/policy"
+	"ocm.software/ocm/api/ocm/tools/signing/signingtest"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const SIGNATURE3 = "third"
+
+var _ = Describe("verification policy", func() {
+	var env *Builder
+	var src ocm.Repository
+	var resolver ocm.ComponentVersionResolver
+
+	BeforeEach(func() {
+		env = NewBuilder(signingtest.ModifiableTestData())
+		env.RSAKeyPair(SIGNATURE, SIGNATURE2)
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMPONENTA, VERSION, func() {
+				env.Provider(PROVIDER)
+				TestDataResource(env)
+			})
+			env.ComponentVersion(COMPONENTB, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("ref", COMPONENTA, VERSION)
+			})
+		})
+
+		src = Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env))
+		resolver = resolvers.NewCompoundResolver(src)
+
+		signer := signingattr.Get(env.OCMContext()).GetSigner(SIGN_ALGO)
+		cv := Must(resolver.LookupComponentVersion(COMPONENTA, VERSION))
+		for _, n := range []string{SIGNATURE, SIGNATURE2} {
+			opts := NewOptions(Sign(signer, n), Resolver(resolver), Update(), VerifyDigests())
+			MustBeSuccessful(opts.Complete(env))
+			Must(Apply(nil, nil, cv, opts))
+		}
+		MustBeSuccessful(cv.Close())
+
+		cv = Must(resolver.LookupComponentVersion(COMPONENTB, VERSION))
+		opts := NewOptions(Sign(signer, SIGNATURE), Resolver(resolver), Update(), VerifyDigests())
+		MustBeSuccessful(opts.Complete(env))
+		Must(Apply(nil, nil, cv, opts))
+		MustBeSuccessful(cv.Close())
+	})
+
+	AfterEach(func() {
+		MustBeSuccessful(src.Close())
+		env.Cleanup()
+	})
+
+	signer := func() *policy.Signer {
+		pub := signingattr.Get(env.OCMContext()).GetPublicKey(SIGNATURE)
+		return &policy.Signer{PublicKey: &policy.KeySpec{Parsed: pub}}
+	}
+
+	newPolicy := func(threshold int) *policy.Policy {
+		return policy.New().
+			AddSigner(SIGNATURE, signer()).
+			AddSigner(SIGNATURE2, signer()).
+			AddSigner(SIGNATURE3, signer()).
+			AddRule(policy.Rule{
+				Components: []string{COMPONENTB},
+				Required:   []string{SIGNATURE},
+			}).
+			AddRule(policy.Rule{
+				Components: []string{"github.com/mandelsoft/*"},
+				Signers:    []string{SIGNATURE, SIGNATURE2, SIGNATURE3},
+				Threshold:  threshold,
+			})
+	}
+
+	It("verifies with threshold", func() {
+		cv := Must(resolver.LookupComponentVersion(COMPONENTB, VERSION))
+		defer Close(cv)
+
+		opts := NewOptions(VerificationPolicy(newPolicy(2)), Resolver(resolver), VerifyDigests(), Recursive())
+		MustBeSuccessful(opts.Complete(env))
+
+		pr, buf := common.NewBufferedPrinter()
+		Must(Apply(pr, nil, cv, opts))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+applying to version "github.com/mandelsoft/ref:v1"[github.com/mandelsoft/ref:v1]...
+  no digest found for "github.com/mandelsoft/test:v1"
+  applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/ref:v1]...
+    resource 0:  "name"="testdata": digest SHA-256:${D_TESTDATA}[genericBlobDigest/v1]
+    signer "test": signature "test" verified
+    signer "second": signature "second" verified
+    signer "third": signature "third" not found
+  reference 0:  github.com/mandelsoft/test:v1: digest SHA-256:5923de2b3b68e904eecb58eca91727926b36623623555025dc5a8700edfa9daa[jsonNormalisation/v1]
+  signer "test": signature "test" verified
+`, Digests))
+	})
+
+	It("fails for unmatched threshold", func() {
+		cv := Must(resolver.LookupComponentVersion(COMPONENTB, VERSION))
+		defer Close(cv)
+
+		opts := NewOptions(VerificationPolicy(newPolicy(3)), Resolver(resolver), VerifyDigests(), Recursive())
+		MustBeSuccessful(opts.Complete(env))
+
+		ExpectError(Apply(nil, nil, cv, opts)).To(MatchError("github.com/mandelsoft/ref:v1: failed applying to component reference ref[github.com/mandelsoft/test:v1]: github.com/mandelsoft/ref:v1->github.com/mandelsoft/test:v1: verification policy: 2 of 3 required signatures of [test, second, third] found"))
+	})
+
+	It("fails for invalid signer key", func() {
+		_, pub := Must2(rsa.Handler{}.CreateKeyPair())
+		p := newPolicy(2)
+		p.Signers[SIGNATURE2].PublicKey = &policy.KeySpec{Parsed: pub}
+
+		cv := Must(resolver.LookupComponentVersion(COMPONENTB, VERSION))
+		defer Close(cv)
+
+		ExpectError(VerifyComponentVersionByPolicy(cv, p, Resolver(resolver))).To(MatchError(ContainSubstring("github.com/mandelsoft/test:v1: verification policy: 1 of 2 required signatures of [test, second, third] found")))
+	})
+
+	It("fails for missing required signer", func() {
+		p := policy.New().
+			AddSigner(SIGNATURE2, signer()).
+			AddRule(policy.Rule{Required: []string{SIGNATURE2}})
+
+		cv := Must(resolver.LookupComponentVersion(COMPONENTB, VERSION))
+		defer Close(cv)
+
+		ExpectError(VerifyComponentVersionByPolicy(cv, p, Resolver(resolver))).To(MatchError("github.com/mandelsoft/ref:v1: failed to determine signature info: no signature found for verification policy"))
+	})
+
+	It("fails for root component without rule", func() {
+		p := policy.New().
+			AddSigner(SIGNATURE, signer()).
+			AddRule(policy.Rule{Components: []string{COMPONENTA}, Required: []string{SIGNATURE}})
+
+		cv := Must(resolver.LookupComponentVersion(COMPONENTB, VERSION))
+		defer Close(cv)
+
+		ExpectError(VerifyComponentVersionByPolicy(cv, p, Resolver(resolver))).To(MatchError("github.com/mandelsoft/ref:v1: failed to determine signature info: no verification policy rule found for component \"github.com/mandelsoft/ref\""))
+	})
+
+	It("verifies with configured policy", func() {
+		cfg := verificationattr.New()
+		cfg.Policy = *newPolicy(2)
+		MustBeSuccessful(env.ConfigContext().ApplyConfig(cfg, "policy"))
+
+		cv := Must(resolver.LookupComponentVersion(COMPONENTB, VERSION))
+		defer Close(cv)
+
+		Must(VerifyComponentVersionByPolicy(cv, nil, Resolver(resolver)))
+	})
+
+	It("rejects invalid policy", func() {
+		p := policy.New().AddRule(policy.Rule{Required: []string{SIGNATURE}})
+		opts := NewOptions(VerificationPolicy(p), Resolver(resolver))
+		ExpectError(opts.Complete(env)).To(MatchError("invalid verification policy: rule 1: signer \"test\" is unknown"))
+
+		p = policy.New().AddSigner(SIGNATURE, &policy.Signer{}).AddRule(policy.Rule{Required: []string{SIGNATURE}})
+		opts = NewOptions(VerificationPolicy(p), Resolver(resolver))
+		ExpectError(opts.Complete(env)).To(MatchError("invalid verification policy: signer \"test\": public key or issuer required"))
+	})
+})
@@ -0,0 +1,139 @@
+package signing_test
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/ocm/tools/signing"
+
+	"ocm.software/ocm/api/datacontext/attrs/rootcertsattr"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/extensions/repositories/composition"
+	"ocm.software/ocm/api/ocm/resolvers"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	"ocm.software/ocm/api/tech/signing/signutils"
+	"ocm.software/ocm/api/tech/signing/tsa"
+	"ocm.software/ocm/api/tech/signing/tsa/tsatest"
+)
+
+var _ = Describe("timestamp authority", func() {
+	const digest = "9cf14695c864411cad03071a8766e6769bb00373bdd8c65887e4644cc285dc78"
+
+	now := time.Now()
+	caStart := now.Add(-5 * time.Hour)
+	certStart := now.Add(-3 * time.Hour)
+
+	capriv, _ := Must2(rsa.Handler{}.CreateKeyPair())
+	ca, _ := Must2(signutils.CreateCertificate(&signutils.Specification{
+		Subject:      *signutils.CommonName("ca-authority"),
+		IsCA:         true,
+		CAPrivateKey: capriv,
+		NotBefore:    &caStart,
+		Validity:     10 * time.Hour,
+		Usages:       signutils.Usages{x509.ExtKeyUsageCodeSigning, x509.KeyUsageDigitalSignature},
+	}))
+	// certificate already expired one hour after it has been issued.
+	priv, pub := Must2(rsa.Handler{}.CreateKeyPair())
+	_, pemBytes := Must2(signutils.CreateCertificate(&signutils.Specification{
+		Subject:      pkix.Name{CommonName: PROVIDER},
+		RootCAs:      ca,
+		CAChain:      ca,
+		CAPrivateKey: capriv,
+		PublicKey:    pub,
+		NotBefore:    &certStart,
+		Validity:     time.Hour,
+		Usages:       signutils.Usages{x509.ExtKeyUsageCodeSigning, x509.KeyUsageDigitalSignature},
+	}))
+
+	var authority *tsatest.Authority
+	var server *httptest.Server
+	var ctx ocm.Context
+	var cv ocm.ComponentVersionAccess
+	var res ocm.ComponentVersionResolver
+
+	BeforeEach(func() {
+		authority = Must(tsatest.New(caStart, 10*time.Hour))
+		server = httptest.NewServer(authority)
+		ctx = ocm.New()
+		cv = composition.NewComponentVersion(ctx, COMPONENTA, VERSION)
+		res = resolvers.NewDedicatedResolver(cv)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	sign := func(t time.Time) {
+		authority.SetTime(&t)
+		SignComponent(res, PROVIDER, COMPONENTA, digest, PrivateKey(PROVIDER, priv), PublicKey(PROVIDER, pemBytes), RootCertificates(ca),
+			UseTSA(), TSAUrl(server.URL), TSARootCertificates(authority.Root))
+		Expect(authority.Requests).To(Equal(1))
+		sig := cv.GetDescriptor().Signatures[0]
+		Expect(sig.Timestamp).NotTo(BeNil())
+		Expect(sig.Timestamp.Time.Time()).To(BeTemporally("~", t, time.Second))
+	}
+
+	It("accepts expired certificate valid at timestamp", func() {
+		sign(certStart.Add(30 * time.Minute))
+		VerifyComponent(res, PROVIDER, COMPONENTA, digest, RootCertificates(ca), TSARootCertificates(authority.Root))
+	})
+
+	It("rejects certificate not valid at timestamp", func() {
+		sign(now)
+		FailVerifyComponent(res, PROVIDER, COMPONENTA, digest,
+			"github.com/mandelsoft/test:v1: public key from signature: public key certificate: x509: certificate has expired or is not yet valid: current time "+now.UTC().Format(time.RFC3339)+" is after "+certStart.Add(time.Hour).UTC().Format(time.RFC3339),
+			RootCertificates(ca), TSARootCertificates(authority.Root))
+	})
+
+	It("rejects timestamp of untrusted authority", func() {
+		sign(certStart.Add(30 * time.Minute))
+
+		other := Must(tsatest.New(caStart, 10*time.Hour))
+		opts := NewOptions(VerifySignature(PROVIDER), Resolver(res), VerifyDigests(), RootCertificates(ca), TSARootCertificates(other.Root))
+		MustBeSuccessful(opts.Complete(ctx))
+		ExpectError(Apply(nil, nil, cv, opts)).To(MatchError(ContainSubstring(`github.com/mandelsoft/test:v1: signature "mandelsoft": signature timestamp verification: timestamp authority certificate "CN=test tsa": x509: certificate signed by unknown authority`)))
+	})
+
+	It("rejects timestamp for other digest", func() {
+		sign(certStart.Add(30 * time.Minute))
+
+		d := sha256.Sum256([]byte("other"))
+		ts := Must(authority.TimeStamp(Must(tsa.NewMessageImprint(crypto.SHA256, d[:]))))
+		sig := &cv.GetDescriptor().Signatures[0]
+		sig.Timestamp.Value = string(Must(tsa.ToPem(ts)))
+
+		opts := NewOptions(VerifySignature(PROVIDER), Resolver(res), VerifyDigests(), RootCertificates(ca), TSARootCertificates(authority.Root))
+		MustBeSuccessful(opts.Complete(ctx))
+		ExpectError(Apply(nil, nil, cv, opts)).To(MatchError(ContainSubstring(`github.com/mandelsoft/test:v1: signature "mandelsoft": signature timestamp verification: digest mismatch`)))
+	})
+
+	It("uses configured TSA root certificates", func() {
+		sign(certStart.Add(30 * time.Minute))
+
+		cfg := rootcertsattr.New()
+		MustBeSuccessful(cfg.AddTSARootCertificate(authority.Root))
+		MustBeSuccessful(ctx.ConfigContext().ApplyConfig(cfg, "tsa"))
+
+		VerifyComponent(res, PROVIDER, COMPONENTA, digest, RootCertificates(ca))
+	})
+
+	It("uses root certificates for timestamp authority without TSA root certificates", func() {
+		sign(certStart.Add(30 * time.Minute))
+
+		pool := x509.NewCertPool()
+		pool.AddCert(ca)
+		pool.AddCert(authority.Root)
+		VerifyComponent(res, PROVIDER, COMPONENTA, digest, RootCertificates(pool))
+
+		opts := NewOptions(VerifySignature(PROVIDER), Resolver(res), VerifyDigests(), RootCertificates(ca))
+		MustBeSuccessful(opts.Complete(ctx))
+		ExpectError(Apply(nil, nil, cv, opts)).To(MatchError(ContainSubstring(`signature timestamp verification: timestamp authority certificate "CN=test tsa": x509: certificate signed by unknown authority`)))
+	})
+})
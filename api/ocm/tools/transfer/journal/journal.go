@@ -0,0 +1,269 @@
+package journal
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	KIND_JOURNAL = "transfer journal"
+
+	// FORMAT_VERSION is the actual version of the journal format.
+	FORMAT_VERSION = "v1"
+)
+
+// Journal records the progress of a transfer process in a file.
+// It is used to resume an aborted transfer without
+// re-evaluating already completed component versions and
+// re-transferring already copied resource blobs.
+// Every update is persisted immediately.
+// A Journal can be used concurrently.
+type Journal struct {
+	lock sync.Mutex
+	fs   vfs.FileSystem
+	path string
+	data Descriptor
+}
+
+// Descriptor is the serialization format of a journal.
+type Descriptor struct {
+	Version           string                           `json:"version"`
+	Target            *runtime.UnstructuredTypedObject `json:"target,omitempty"`
+	ComponentVersions []*ComponentVersionEntry         `json:"componentVersions,omitempty"`
+	index             map[common.NameVersion]*ComponentVersionEntry
+}
+
+// ComponentVersionEntry describes the transfer state of a
+// component version.
+type ComponentVersionEntry struct {
+	Component string           `json:"component"`
+	Version   string           `json:"version"`
+	Completed bool             `json:"completed,omitempty"`
+	Resources []*ResourceEntry `json:"resources,omitempty"`
+}
+
+// ResourceEntry describes a resource blob already transferred
+// to the target repository for a component version.
+type ResourceEntry struct {
+	Identity metav1.Identity                           `json:"identity"`
+	Digest   *metav1.DigestSpec                        `json:"digest"`
+	Access   *runtime.UnstructuredVersionedTypedObject `json:"access"`
+}
+
+// Create creates a new empty journal for the given file.
+// An already existing file is only overwritten if force is set.
+// Otherwise, Open must be used to resume a transfer.
+func Create(fs vfs.FileSystem, path string, force bool) (*Journal, error) {
+	if !force {
+		if _, err := fs.Stat(path); err == nil {
+			return nil, errors.ErrAlreadyExists(KIND_JOURNAL, path)
+		} else if !vfs.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "cannot check %s %q", KIND_JOURNAL, path)
+		}
+	}
+	j := &Journal{
+		fs:   fs,
+		path: path,
+		data: Descriptor{Version: FORMAT_VERSION},
+	}
+	return j, j.persist()
+}
+
+// Open opens an existing journal file to resume a transfer.
+func Open(fs vfs.FileSystem, path string) (*Journal, error) {
+	data, err := vfs.ReadFile(fs, path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot read %s %q", KIND_JOURNAL, path)
+	}
+	j := &Journal{
+		fs:   fs,
+		path: path,
+	}
+	err = json.Unmarshal(data, &j.data)
+	if err != nil {
+		return nil, errors.ErrInvalidWrap(err, KIND_JOURNAL, path)
+	}
+	if j.data.Version != FORMAT_VERSION {
+		return nil, errors.ErrNotSupported("journal format version", j.data.Version)
+	}
+	return j, nil
+}
+
+// OpenOrCreate opens an existing journal or creates a new one,
+// if the file does not exist, yet.
+func OpenOrCreate(fs vfs.FileSystem, path string) (*Journal, error) {
+	if ok, err := vfs.FileExists(fs, path); ok || err != nil {
+		if err != nil {
+			return nil, err
+		}
+		return Open(fs, path)
+	}
+	return Create(fs, path, false)
+}
+
+func (j *Journal) GetPath() string {
+	return j.path
+}
+
+// GetDescriptor returns a copy of the actual journal state.
+func (j *Journal) GetDescriptor() *Descriptor {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	data, err := json.Marshal(&j.data)
+	if err != nil {
+		return nil
+	}
+	var d Descriptor
+	if json.Unmarshal(data, &d) != nil {
+		return nil
+	}
+	return &d
+}
+
+// SetTarget assures that the journal is used for the given target repository.
+// A journal recorded for a different target cannot be used to resume
+// a transfer.
+func (j *Journal) SetTarget(spec runtime.TypedObject) error {
+	u, err := runtime.ToUnstructuredTypedObject(spec)
+	if err != nil {
+		return err
+	}
+	if u != nil {
+		// the access mode is not relevant for the identity of a target.
+		delete(u.Object, "accessMode")
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	if j.data.Target == nil {
+		j.data.Target = u
+		return j.persist()
+	}
+	if u == nil || !reflect.DeepEqual(u.Object, j.data.Target.Object) {
+		return errors.Newf("%s %q recorded for a different target repository", KIND_JOURNAL, j.path)
+	}
+	return nil
+}
+
+// IsCompleted checks whether the transfer of a component version
+// has been completed.
+func (j *Journal) IsCompleted(nv common.NameVersion) bool {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	e := j.data.lookup(nv)
+	return e != nil && e.Completed
+}
+
+// SetCompleted records the completed transfer of a component version.
+func (j *Journal) SetCompleted(nv common.NameVersion) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	e := j.data.assure(nv)
+	e.Completed = true
+	e.Resources = nil
+	return j.persist()
+}
+
+// GetResourceAccess returns the access specification of an already transferred
+// resource blob. The blob is only considered to be transferred, if the recorded
+// digest matches the given one.
+func (j *Journal) GetResourceAccess(nv common.NameVersion, id metav1.Identity, digest *metav1.DigestSpec) compdesc.AccessSpec {
+	if digest == nil {
+		return nil
+	}
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	e := j.data.lookup(nv)
+	if e == nil {
+		return nil
+	}
+	for _, r := range e.Resources {
+		if r.Identity.Equals(id) && r.Digest.Equal(digest) && r.Access != nil {
+			return r.Access
+		}
+	}
+	return nil
+}
+
+// AddResource records a transferred resource blob together with its new
+// access specification in the target repository.
+// Local blob accesses refer to blobs already stored in the target
+// repository, which must be added again to the target component version
+// when resuming a transfer. Resources without digest are not recorded, because
+// they cannot be verified when resuming a transfer.
+func (j *Journal) AddResource(nv common.NameVersion, id metav1.Identity, digest *metav1.DigestSpec, acc compdesc.AccessSpec) error {
+	if digest == nil || acc == nil {
+		return nil
+	}
+	u, err := runtime.ToUnstructuredVersionedTypedObject(acc)
+	if err != nil {
+		return err
+	}
+
+	j.lock.Lock()
+	defer j.lock.Unlock()
+
+	e := j.data.assure(nv)
+	entry := &ResourceEntry{
+		Identity: id.Copy(),
+		Digest:   digest.Copy(),
+		Access:   u,
+	}
+	for i, r := range e.Resources {
+		if r.Identity.Equals(id) {
+			e.Resources[i] = entry
+			return j.persist()
+		}
+	}
+	e.Resources = append(e.Resources, entry)
+	return j.persist()
+}
+
+// persist writes the journal atomically, so that an abort during
+// the write operation never leaves a corrupted journal.
+func (j *Journal) persist() error {
+	data, err := json.MarshalIndent(&j.data, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := j.path + ".tmp"
+	err = vfs.WriteFile(j.fs, tmp, data, 0o600)
+	if err != nil {
+		return errors.Wrapf(err, "cannot write %s %q", KIND_JOURNAL, j.path)
+	}
+	return j.fs.Rename(tmp, j.path)
+}
+
+func (d *Descriptor) lookup(nv common.NameVersion) *ComponentVersionEntry {
+	if d.index == nil {
+		d.index = map[common.NameVersion]*ComponentVersionEntry{}
+		for _, e := range d.ComponentVersions {
+			d.index[common.NewNameVersion(e.Component, e.Version)] = e
+		}
+	}
+	return d.index[nv]
+}
+
+func (d *Descriptor) assure(nv common.NameVersion) *ComponentVersionEntry {
+	e := d.lookup(nv)
+	if e == nil {
+		e = &ComponentVersionEntry{Component: nv.GetName(), Version: nv.GetVersion()}
+		d.ComponentVersions = append(d.ComponentVersions, e)
+		d.index[nv] = e
+	}
+	return e
+}
@@ -0,0 +1,246 @@
+package journal_test
+
+import (
+	"fmt"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+	. "ocm.software/ocm/api/ocm/testhelper"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	ocictf "ocm.software/ocm/api/oci/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/localblob"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/wget"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/ocmutils"
+	"ocm.software/ocm/api/ocm/tools/transfer"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	ARCH      = "/tmp/ctf"
+	OUT       = "/tmp/res"
+	JOURNAL   = "/tmp/journal"
+	COMPONENT = "github.com/mandelsoft/test"
+	VERSION   = "v1"
+	URL       = "https://ocm.software/testdata"
+)
+
+// failingHandler simulates an uploader providing an external access
+// (or a standard local blob transfer, if local is set)
+// for the first resource and an aborted transfer by failing
+// for the second resource.
+type failingHandler struct {
+	transferhandler.TransferHandler
+	local bool
+}
+
+func (h *failingHandler) GetJournal() *journal.Journal {
+	return transferhandler.GetJournal(h.TransferHandler)
+}
+
+func (h *failingHandler) HandleTransferResource(r ocm.ResourceAccess, m cpi.AccessMethod, hint string, t ocm.ComponentVersionAccess) error {
+	if r.Meta().GetName() == "otherdata" {
+		return fmt.Errorf("aborted")
+	}
+	if h.local {
+		return h.TransferHandler.HandleTransferResource(r, m, hint, t)
+	}
+	return t.SetResource(r.Meta(), wget.New(URL), ocm.ModifyElement(), ocm.SkipVerify())
+}
+
+var _ = Describe("transfer journal", func() {
+	var env *Builder
+
+	nv := common.NewNameVersion(COMPONENT, VERSION)
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMPONENT, func() {
+				env.Version(VERSION, func() {
+					env.Provider("mandelsoft")
+					TestDataResource(env)
+					env.Resource("otherdata", "", "PlainText", metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, S_OTHERDATA)
+					})
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	transport := func(j *journal.Journal, opts ...interface{}) (string, error) {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		var handler transferhandler.TransferHandler
+		var topts []transferhandler.TransferOption
+		for _, o := range opts {
+			switch t := o.(type) {
+			case transferhandler.TransferOption:
+				topts = append(topts, t)
+			case *failingHandler:
+				handler = t
+			}
+		}
+		base := Must(standard.New(append(topts, standard.Journal(j))...))
+		if f, ok := handler.(*failingHandler); ok {
+			f.TransferHandler = base
+		} else {
+			handler = base
+		}
+
+		p, buf := common.NewBufferedPrinter()
+		err := transfer.TransferVersion(p, nil, cv, tgt, handler)
+		return buf.String(), err
+	}
+
+	It("persists and reloads a journal", func() {
+		j := Must(journal.Create(env, JOURNAL, false))
+		Expect(j.IsCompleted(nv)).To(BeFalse())
+		MustBeSuccessful(j.SetCompleted(nv))
+
+		j = Must(journal.Open(env, JOURNAL))
+		Expect(j.IsCompleted(nv)).To(BeTrue())
+		Expect(j.GetDescriptor().Version).To(Equal(journal.FORMAT_VERSION))
+	})
+
+	It("does not overwrite existing journals", func() {
+		j := Must(journal.Create(env, JOURNAL, false))
+		MustBeSuccessful(j.SetCompleted(nv))
+
+		ExpectError(journal.Create(env, JOURNAL, false)).To(MatchError(`transfer journal "/tmp/journal" already exists`))
+		Expect(Must(journal.Open(env, JOURNAL)).IsCompleted(nv)).To(BeTrue())
+
+		j = Must(journal.Create(env, JOURNAL, true))
+		Expect(j.IsCompleted(nv)).To(BeFalse())
+		Expect(Must(journal.Open(env, JOURNAL)).IsCompleted(nv)).To(BeFalse())
+	})
+
+	It("rejects unknown format versions", func() {
+		MustBeSuccessful(vfs.WriteFile(env, JOURNAL, []byte(`{"version":"v0"}`), 0o600))
+		ExpectError(journal.Open(env, JOURNAL)).To(MatchError(`journal format version "v0" not supported`))
+	})
+
+	It("skips completed component versions", func() {
+		j := Must(journal.Create(env, JOURNAL, false))
+		Expect(Must(transport(j))).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+...resource 0 testdata[PlainText]...
+...resource 1 otherdata[PlainText]...
+...adding component version...
+`))
+		j = Must(journal.Open(env, JOURNAL))
+		Expect(j.IsCompleted(nv)).To(BeTrue())
+		Expect(j.GetDescriptor().Target).NotTo(BeNil())
+
+		Expect(Must(transport(j, standard.EnforceTransport()))).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+  version "github.com/mandelsoft/test:v1" already transferred according to journal -> skip transport
+`))
+	})
+
+	It("skips already transferred resources", func() {
+		j := Must(journal.Create(env, JOURNAL, false))
+		ExpectError(transport(j, &failingHandler{})).To(MatchError(ContainSubstring("transferring resource 1: aborted")))
+
+		j = Must(journal.Open(env, JOURNAL))
+		Expect(j.IsCompleted(nv)).To(BeFalse())
+		Expect(j.GetDescriptor().ComponentVersions[0].Resources).To(HaveLen(1))
+
+		Expect(Must(transport(j))).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+...resource 0 testdata[PlainText] (already transferred according to journal)
+...resource 1 otherdata[PlainText]...
+...adding component version...
+`))
+		Expect(Must(journal.Open(env, JOURNAL)).IsCompleted(nv)).To(BeTrue())
+
+		tgt := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(tgt, "target")
+		tcv := Must(tgt.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(tcv, "target cv")
+		Expect(tcv.GetDescriptor().Resources[0].Access.GetType()).To(Equal(wget.Type))
+	})
+
+	It("skips already transferred local blobs", func() {
+		j := Must(journal.Create(env, JOURNAL, false))
+		ExpectError(transport(j, &failingHandler{local: true})).To(MatchError(ContainSubstring("transferring resource 1: aborted")))
+
+		j = Must(journal.Open(env, JOURNAL))
+		Expect(j.IsCompleted(nv)).To(BeFalse())
+		Expect(j.GetDescriptor().ComponentVersions[0].Resources).To(HaveLen(1))
+
+		Expect(Must(transport(j))).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+...resource 0 testdata[PlainText] (already transferred according to journal)
+...resource 1 otherdata[PlainText]...
+...adding component version...
+`))
+		Expect(Must(journal.Open(env, JOURNAL)).IsCompleted(nv)).To(BeTrue())
+
+		tgt := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(tgt, "target")
+		tcv := Must(tgt.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(tcv, "target cv")
+		Expect(tcv.GetDescriptor().Resources[0].Access.GetType()).To(Equal(localblob.Type))
+		res := Must(tcv.GetResourceByIndex(0))
+		data := Must(ocmutils.GetResourceData(res))
+		Expect(string(data)).To(Equal(S_TESTDATA))
+	})
+
+	It("transfers local blobs again, which are not available anymore", func() {
+		j := Must(journal.Create(env, JOURNAL, false))
+		ExpectError(transport(j, &failingHandler{local: true})).To(MatchError(ContainSubstring("transferring resource 1: aborted")))
+
+		blob := vfs.Join(env, OUT, ocictf.BlobsDirectoryName, common.DigestToFileName(digest.FromString(S_TESTDATA)))
+		Expect(vfs.FileExists(env, blob)).To(BeTrue())
+		MustBeSuccessful(env.Remove(blob))
+
+		j = Must(journal.Open(env, JOURNAL))
+		Expect(Must(transport(j))).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+...resource 0 testdata[PlainText]...
+...resource 1 otherdata[PlainText]...
+...adding component version...
+`))
+		Expect(vfs.FileExists(env, blob)).To(BeTrue())
+	})
+
+	It("rejects journals for other targets", func() {
+		j := Must(journal.Create(env, JOURNAL, false))
+		MustBeSuccessful(j.SetTarget(Must(ctf.NewRepositorySpec(accessobj.ACC_WRITABLE, "/other"))))
+
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(COMPONENT, VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, OUT, 0o700, accessio.FormatDirectory, env))
+		defer Close(tgt, "target")
+
+		handler := Must(standard.New(standard.Journal(j)))
+		ExpectError(transfer.TransferVersion(nil, nil, cv, tgt, handler)).To(MatchError(`transfer journal "/tmp/journal" recorded for a different target repository`))
+	})
+})
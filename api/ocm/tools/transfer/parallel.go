@@ -0,0 +1,379 @@
+package transfer
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+// workers limits the number of transfer operations executed concurrently
+// for a complete transfer run and keeps the state shared by all
+// those operations.
+// A task is only executed in a separate goroutine, if a free slot is
+// available. Otherwise, it is executed synchronously by the calling
+// goroutine. Therefore, a task waiting for its nested tasks never blocks
+// slots required by those tasks, and recursive transfers cannot deadlock.
+type workers struct {
+	lock     sync.Mutex
+	slots    chan struct{}
+	journal  *journal.Journal
+	plan     *Plan
+	versions map[common.NameVersion]*versionState
+}
+
+// versionState keeps track of a component version handled by a task.
+// Other tasks referring to the same component version must wait for its
+// completion before they can add their own component version to the
+// target repository.
+type versionState struct {
+	done   chan struct{}
+	err    error
+	parent *common.NameVersion
+	// deps counts the unfinished component versions the transfer
+	// of this version currently depends on (started references and
+	// references handled by other tasks).
+	deps map[common.NameVersion]int
+}
+
+// newWorkers provides a worker pool for the number of concurrent operations
+// configured for the given handler.
+// For a number <= 1 all tasks are executed sequentially without any
+// buffering of the printer output.
+func newWorkers(handler TransferHandler) *workers {
+	w := &workers{
+		journal:  transferhandler.GetJournal(handler),
+		versions: map[common.NameVersion]*versionState{},
+	}
+	if n := transferhandler.GetParallel(handler); n > 1 {
+		// the calling goroutine always executes tasks, too.
+		w.slots = make(chan struct{}, n-1)
+	}
+	return w
+}
+
+// newPlanningWorkers provides a worker pool for evaluating a transfer
+// without modifying the target repository. The planned operations are
+// recorded in the given plan. A transfer journal is not used.
+func newPlanningWorkers(handler TransferHandler, plan *Plan) *workers {
+	w := newWorkers(handler)
+	w.journal = nil
+	w.plan = plan
+	return w
+}
+
+func (w *workers) IsParallel() bool {
+	return w.slots != nil
+}
+
+// Add adds a component version to the walking state.
+// The closure is shared among all concurrently executed tasks,
+// therefore the access has to be synchronized.
+// If the component version is newly added, it is handled by the
+// calling task, which must report its completion with Done.
+func (w *workers) Add(state *WalkingState, kind string, nv common.NameVersion) (bool, error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	// the history slice may be shared with concurrently executed
+	// sibling tasks, so never append to a shared backing array.
+	state.History = state.History.Copy()
+	ok, err := state.Add(kind, nv)
+	if ok {
+		v := &versionState{done: make(chan struct{}), deps: map[common.NameVersion]int{}}
+		if p := parentOf(state.History); p != nil {
+			v.parent = p
+			if ps := w.versions[*p]; ps != nil {
+				ps.deps[nv]++
+			}
+		}
+		w.versions[nv] = v
+	}
+	return ok, err
+}
+
+// Done reports the completion of a component version added
+// by the calling task.
+func (w *workers) Done(nv common.NameVersion, err error) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	v := w.versions[nv]
+	if v == nil {
+		return
+	}
+	v.err = err
+	close(v.done)
+	if v.parent != nil {
+		w.releaseDep(*v.parent, nv)
+	}
+}
+
+// Wait waits for the completion of a component version already handled
+// by another task. The history must end with the component version.
+// A referencing component version must not be added to the target
+// before its references are completely transferred.
+func (w *workers) Wait(kind string, hist common.History, nv common.NameVersion) error {
+	w.lock.Lock()
+	v := w.versions[nv]
+	if v == nil {
+		// handled by a previous transfer sharing the closure.
+		w.lock.Unlock()
+		return nil
+	}
+	p := parentOf(hist)
+	if p != nil {
+		if w.dependsOn(nv, *p) {
+			w.lock.Unlock()
+			return errors.ErrRecusion(kind, nv, hist[:len(hist)-1])
+		}
+		if ps := w.versions[*p]; ps != nil {
+			ps.deps[nv]++
+		}
+	}
+	w.lock.Unlock()
+
+	<-v.done
+
+	if p != nil {
+		w.lock.Lock()
+		w.releaseDep(*p, nv)
+		w.lock.Unlock()
+	}
+	if v.err != nil {
+		return errors.Newf("referenced component version %s failed", nv)
+	}
+	return nil
+}
+
+func (w *workers) releaseDep(p, nv common.NameVersion) {
+	ps := w.versions[p]
+	if ps == nil {
+		return
+	}
+	if ps.deps[nv]--; ps.deps[nv] <= 0 {
+		delete(ps.deps, nv)
+	}
+}
+
+// dependsOn checks whether the completion of component version nv
+// (transitively) depends on the completion of component version o.
+func (w *workers) dependsOn(nv, o common.NameVersion) bool {
+	visited := map[common.NameVersion]bool{}
+	var check func(n common.NameVersion) bool
+	check = func(n common.NameVersion) bool {
+		if n == o {
+			return true
+		}
+		if visited[n] {
+			return false
+		}
+		visited[n] = true
+		if v := w.versions[n]; v != nil {
+			for d := range v.deps {
+				if check(d) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	return check(nv)
+}
+
+func parentOf(hist common.History) *common.NameVersion {
+	if len(hist) < 2 {
+		return nil
+	}
+	return &hist[len(hist)-2]
+}
+
+// IsCompleted checks whether the transfer of a component version has
+// already been completed according to the transfer journal.
+func (w *workers) IsCompleted(nv common.NameVersion) bool {
+	return w.journal != nil && w.journal.IsCompleted(nv)
+}
+
+// SetCompleted records a completed component version in the transfer journal.
+func (w *workers) SetCompleted(nv common.NameVersion) error {
+	if w.journal == nil {
+		return nil
+	}
+	return w.journal.SetCompleted(nv)
+}
+
+// GetResourceAccess provides the target access of a resource blob
+// already transferred according to the transfer journal.
+func (w *workers) GetResourceAccess(t ocm.ComponentVersionAccess, id metav1.Identity, digest *metav1.DigestSpec) compdesc.AccessSpec {
+	if w.journal == nil {
+		return nil
+	}
+	return w.journal.GetResourceAccess(common.VersionedElementKey(t), id, digest)
+}
+
+// AddResource records the target access of a transferred resource
+// blob in the transfer journal.
+// This includes local blobs, which are added again from the target
+// repository, if a transfer is resumed (see reuseResource).
+func (w *workers) AddResource(t ocm.ComponentVersionAccess, id metav1.Identity, digest *metav1.DigestSpec) error {
+	if w.journal == nil || digest == nil {
+		return nil
+	}
+	var acc compdesc.AccessSpec
+	// the descriptor may be modified concurrently by other tasks.
+	err := t.Execute(func() error {
+		r, err := t.GetDescriptor().GetResourceByIdentity(id)
+		if err == nil {
+			acc = r.Access
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return w.journal.AddResource(common.VersionedElementKey(t), id, digest, acc)
+}
+
+// IsPlanning reports whether the transfer is only planned.
+// In this case the target repository must not be modified.
+func (w *workers) IsPlanning() bool {
+	return w.plan != nil
+}
+
+// PlanVersion records the planned action for a component version.
+func (w *workers) PlanVersion(nv common.NameVersion, action string, msg string) {
+	if w.plan != nil {
+		w.plan.setVersion(nv, action, msg)
+	}
+}
+
+// PlanResource records the planned action for a resource.
+func (w *workers) PlanResource(nv common.NameVersion, a *ArtifactPlan) {
+	if w.plan != nil {
+		w.plan.addResource(nv, a)
+	}
+}
+
+// PlanSource records the planned action for a source.
+func (w *workers) PlanSource(nv common.NameVersion, a *ArtifactPlan) {
+	if w.plan != nil {
+		w.plan.addSource(nv, a)
+	}
+}
+
+func (w *workers) tryAcquire() bool {
+	if w.slots == nil {
+		return false
+	}
+	select {
+	case w.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *workers) release() {
+	<-w.slots
+}
+
+// NewGroup provides a new task group. The output of the tasks
+// of a group is forwarded to the given printer in the order
+// the tasks have been started, regardless of the order of their
+// completion.
+func (w *workers) NewGroup(printer common.Printer) *taskGroup {
+	return &taskGroup{workers: w, printer: printer}
+}
+
+type task struct {
+	buffer *bytes.Buffer
+	done   bool
+	err    error
+}
+
+type taskGroup struct {
+	workers *workers
+	printer common.Printer
+	wg      sync.WaitGroup
+
+	lock    sync.Mutex
+	tasks   []*task
+	flushed int
+	failed  bool
+}
+
+// Run executes a task, either concurrently, if a free slot is available,
+// or synchronously. The task gets a printer, which must be used for
+// all its output.
+func (g *taskGroup) Run(f func(printer common.Printer) error) {
+	if !g.workers.IsParallel() {
+		t := &task{err: f(g.printer), done: true}
+		g.lock.Lock()
+		g.tasks = append(g.tasks, t)
+		g.failed = g.failed || t.err != nil
+		g.flushed++
+		g.lock.Unlock()
+		return
+	}
+
+	p, buf := common.NewBufferedPrinter()
+	t := &task{buffer: buf}
+	g.lock.Lock()
+	g.tasks = append(g.tasks, t)
+	g.lock.Unlock()
+
+	if g.workers.tryAcquire() {
+		g.wg.Add(1)
+		go func() {
+			defer g.wg.Done()
+			defer g.workers.release()
+			g.finish(t, f(p))
+		}()
+	} else {
+		g.finish(t, f(p))
+	}
+}
+
+func (g *taskGroup) finish(t *task, err error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	t.err = err
+	t.done = true
+	g.failed = g.failed || err != nil
+	for g.flushed < len(g.tasks) && g.tasks[g.flushed].done {
+		if b := g.tasks[g.flushed].buffer; b != nil && b.Len() > 0 {
+			g.printer.Printf("%s", b.String())
+			b.Reset()
+		}
+		g.flushed++
+	}
+}
+
+// Failed reports whether a task of the group already failed.
+func (g *taskGroup) Failed() bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return g.failed
+}
+
+// Wait waits for all tasks of the group and returns the
+// errors of the failed tasks in the order the tasks have been started.
+func (g *taskGroup) Wait() []error {
+	g.wg.Wait()
+
+	var errs []error
+	for _, t := range g.tasks {
+		if t.err != nil {
+			errs = append(errs, t.err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,98 @@
+package transfer
+
+import (
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/ocm"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("parallel workers", func() {
+	var (
+		w     *workers
+		state WalkingState
+
+		A = common.NewNameVersion("acme.org/a", "v1")
+		B = common.NewNameVersion("acme.org/b", "v1")
+		C = common.NewNameVersion("acme.org/c", "v1")
+		D = common.NewNameVersion("acme.org/d", "v1")
+	)
+
+	add := func(nv common.NameVersion, hist ...common.NameVersion) (WalkingState, bool, error) {
+		s := WalkingState{Closure: state.Closure, History: hist}
+		ok, err := w.Add(&s, ocm.KIND_COMPONENTVERSION, nv)
+		return s, ok, err
+	}
+
+	wait := func(s WalkingState, nv common.NameVersion) chan error {
+		result := make(chan error, 1)
+		go func() {
+			result <- w.Wait(ocm.KIND_COMPONENTVERSION, s.History, nv)
+		}()
+		return result
+	}
+
+	BeforeEach(func() {
+		w = &workers{versions: map[common.NameVersion]*versionState{}}
+		state = WalkingState{Closure: TransportClosure{}}
+	})
+
+	It("waits for a component version handled by another task", func() {
+		_, ok, _ := add(A)
+		Expect(ok).To(BeTrue())
+		_, ok, _ = add(B)
+		Expect(ok).To(BeTrue())
+		_, ok, _ = add(C, A)
+		Expect(ok).To(BeTrue())
+
+		s, ok, err := add(C, B)
+		Expect(ok).To(BeFalse())
+		Expect(err).To(Succeed())
+
+		result := wait(s, C)
+		Consistently(result, 100*time.Millisecond).ShouldNot(Receive())
+		w.Done(C, nil)
+		Eventually(result).Should(Receive(BeNil()))
+	})
+
+	It("reports failed references", func() {
+		add(A)
+		add(B)
+		add(C, A)
+		s, _, _ := add(C, B)
+
+		result := wait(s, C)
+		w.Done(C, fmt.Errorf("failed"))
+		Eventually(result).Should(Receive(MatchError("referenced component version acme.org/c:v1 failed")))
+	})
+
+	It("does not wait for completed component versions", func() {
+		add(A)
+		add(C, A)
+		w.Done(C, nil)
+		s, ok, _ := add(C, B)
+		Expect(ok).To(BeFalse())
+		Expect(w.Wait(ocm.KIND_COMPONENTVERSION, s.History, C)).To(Succeed())
+	})
+
+	It("detects cycles across tasks", func() {
+		add(A)
+		add(B)
+		add(C, A)
+		add(D, B)
+
+		s, _, _ := add(D, A, C)
+		result := wait(s, D)
+		Consistently(result, 100*time.Millisecond).ShouldNot(Receive())
+
+		s, _, _ = add(C, B, D)
+		Expect(w.Wait(ocm.KIND_COMPONENTVERSION, s.History, C)).To(MatchError(ContainSubstring("recursion")))
+
+		w.Done(D, nil)
+		Eventually(result).Should(Receive(BeNil()))
+	})
+})
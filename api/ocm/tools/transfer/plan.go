@@ -0,0 +1,194 @@
+package transfer
+
+import (
+	"sort"
+	"sync"
+
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	ocmcpi "ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+// Actions planned for a component version.
+const (
+	// PLAN_CREATE describes a component version not yet present in the target.
+	PLAN_CREATE = "create"
+	// PLAN_TRANSPORT describes a component version present in the target,
+	// which is transported again (enforced transport or missing resource blobs).
+	PLAN_TRANSPORT = "transport"
+	// PLAN_UPDATE describes the update of volatile properties of an
+	// existing component version.
+	PLAN_UPDATE = "update"
+	// PLAN_OVERWRITE describes a component version replacing a different
+	// version in the target.
+	PLAN_OVERWRITE = "overwrite"
+	// PLAN_SKIP describes a component version which is not touched.
+	PLAN_SKIP = "skip"
+	// PLAN_REJECT describes a component version, which differs from the
+	// version in the target, but must not be overwritten.
+	PLAN_REJECT = "reject"
+)
+
+// Actions planned for a resource or source.
+const (
+	// PLAN_COPY describes an artifact blob copied by value.
+	PLAN_COPY = "copy"
+	// PLAN_OVERWRITE_BLOB describes an artifact blob copied by value
+	// replacing a different blob of the current target version.
+	PLAN_OVERWRITE_BLOB = "overwrite"
+	// PLAN_KEEP describes an artifact keeping the access of the current target version.
+	PLAN_KEEP = "keep"
+	// PLAN_REFERENCE describes an artifact transported by reference,
+	// keeping its access specification.
+	PLAN_REFERENCE = "reference"
+)
+
+// Plan describes the operations a transfer would execute
+// without modifying the target repository.
+// It is filled by PlanVersion and can be serialized as JSON or YAML.
+// A Plan can be used concurrently.
+type Plan struct {
+	lock              sync.Mutex
+	ComponentVersions []*VersionPlan `json:"componentVersions,omitempty"`
+}
+
+// VersionPlan describes the planned operation for a component version.
+type VersionPlan struct {
+	Component string          `json:"component"`
+	Version   string          `json:"version"`
+	Action    string          `json:"action"`
+	Message   string          `json:"message,omitempty"`
+	Resources []*ArtifactPlan `json:"resources,omitempty"`
+	Sources   []*ArtifactPlan `json:"sources,omitempty"`
+}
+
+// ArtifactPlan describes the planned operation for a resource or source.
+// Access is the access specification in the source repository. If the
+// resulting access in the target repository is already known, it is
+// given by TargetAccess. For blobs copied by value, the access
+// is rewritten by the target repository or an upload handler,
+// potentially using the artifact name Hint.
+type ArtifactPlan struct {
+	Index         int                                       `json:"index"`
+	Name          string                                    `json:"name"`
+	Version       string                                    `json:"version,omitempty"`
+	ExtraIdentity metav1.Identity                           `json:"extraIdentity,omitempty"`
+	Type          string                                    `json:"type"`
+	Action        string                                    `json:"action"`
+	Message       string                                    `json:"message,omitempty"`
+	Hint          string                                    `json:"hint,omitempty"`
+	Size          int64                                     `json:"size,omitempty"`
+	Digest        *metav1.DigestSpec                        `json:"digest,omitempty"`
+	Access        *runtime.UnstructuredVersionedTypedObject `json:"access,omitempty"`
+	TargetAccess  *runtime.UnstructuredVersionedTypedObject `json:"targetAccess,omitempty"`
+}
+
+// NewPlan creates a new empty transfer plan.
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+// GetVersion returns the plan for a component version, or nil.
+func (p *Plan) GetVersion(nv common.NameVersion) *VersionPlan {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.lookup(nv)
+}
+
+func (p *Plan) lookup(nv common.NameVersion) *VersionPlan {
+	for _, v := range p.ComponentVersions {
+		if v.Component == nv.GetName() && v.Version == nv.GetVersion() {
+			return v
+		}
+	}
+	return nil
+}
+
+func (p *Plan) assure(nv common.NameVersion) *VersionPlan {
+	v := p.lookup(nv)
+	if v == nil {
+		v = &VersionPlan{Component: nv.GetName(), Version: nv.GetVersion()}
+		p.ComponentVersions = append(p.ComponentVersions, v)
+		sort.SliceStable(p.ComponentVersions, func(i, j int) bool {
+			return common.CompareNameVersion(
+				common.NewNameVersion(p.ComponentVersions[i].Component, p.ComponentVersions[i].Version),
+				common.NewNameVersion(p.ComponentVersions[j].Component, p.ComponentVersions[j].Version)) < 0
+		})
+	}
+	return v
+}
+
+func (p *Plan) setVersion(nv common.NameVersion, action string, msg string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	v := p.assure(nv)
+	v.Action = action
+	v.Message = msg
+}
+
+func (p *Plan) addResource(nv common.NameVersion, a *ArtifactPlan) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	v := p.assure(nv)
+	v.Resources = addArtifact(v.Resources, a)
+}
+
+func (p *Plan) addSource(nv common.NameVersion, a *ArtifactPlan) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	v := p.assure(nv)
+	v.Sources = addArtifact(v.Sources, a)
+}
+
+// addArtifact keeps the artifacts ordered by their index, because they
+// may be planned concurrently.
+func addArtifact(list []*ArtifactPlan, a *ArtifactPlan) []*ArtifactPlan {
+	i := sort.Search(len(list), func(i int) bool { return list[i].Index >= a.Index })
+	list = append(list, nil)
+	copy(list[i+1:], list[i:])
+	list[i] = a
+	return list
+}
+
+// newArtifactPlan describes the planned operation for an element of a component version.
+// If the blob is copied by value, its size is determined, if the access method
+// is able to provide it without reading the content.
+func newArtifactPlan(i int, meta *compdesc.ElementMeta, typ string, digest *metav1.DigestSpec, a ocm.AccessSpec, m ocmcpi.AccessMethod, action string, msg string) *ArtifactPlan {
+	e := &ArtifactPlan{
+		Index:         i,
+		Name:          meta.GetName(),
+		Version:       meta.GetVersion(),
+		ExtraIdentity: meta.ExtraIdentity.Copy(),
+		Type:          typ,
+		Action:        action,
+		Message:       msg,
+		Digest:        digest,
+		Access:        toUnstructured(a),
+	}
+	if m != nil && (action == PLAN_COPY || action == PLAN_OVERWRITE_BLOB) {
+		if p, ok := accspeccpi.GetAccessMethodImplementation(m).(accspeccpi.BlobSizeProvider); ok {
+			if size, err := p.GetBlobSize(); err == nil && size > 0 {
+				e.Size = size
+			}
+		}
+	}
+	return e
+}
+
+func toUnstructured(spec compdesc.AccessSpec) *runtime.UnstructuredVersionedTypedObject {
+	if spec == nil {
+		return nil
+	}
+	u, err := runtime.ToUnstructuredVersionedTypedObject(spec)
+	if err != nil {
+		return nil
+	}
+	return u
+}
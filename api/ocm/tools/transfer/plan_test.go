@@ -0,0 +1,143 @@
+package transfer_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/helper/builder"
+	. "ocm.software/ocm/api/ocm/testhelper"
+
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/repositories/comparch"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/ocmutils"
+	"ocm.software/ocm/api/ocm/tools/transfer"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+const (
+	PLAN_ARCH    = "/tmp/ctf"
+	PLAN_OUT     = "/tmp/res"
+	PLAN_COMP    = "github.com/mandelsoft/test"
+	PLAN_REFCOMP = "github.com/mandelsoft/ref"
+	PLAN_VERSION = "v1"
+	PLAN_CA      = "/tmp/ca"
+)
+
+var _ = Describe("transfer plan", func() {
+	var env *Builder
+
+	nv := common.NewNameVersion(PLAN_COMP, PLAN_VERSION)
+	refnv := common.NewNameVersion(PLAN_REFCOMP, PLAN_VERSION)
+
+	BeforeEach(func() {
+		env = NewBuilder()
+
+		env.OCMCommonTransport(PLAN_ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(PLAN_REFCOMP, PLAN_VERSION, func() {
+				env.Provider("mandelsoft")
+				TestDataResource(env)
+			})
+			env.ComponentVersion(PLAN_COMP, PLAN_VERSION, func() {
+				env.Provider("mandelsoft")
+				TestDataResource(env)
+				env.Reference("ref", PLAN_REFCOMP, PLAN_VERSION)
+			})
+		})
+		env.OCMCommonTransport(PLAN_OUT, accessio.FormatDirectory, func() {
+			env.ComponentVersion(PLAN_COMP, PLAN_VERSION, func() {
+				env.Provider("mandelsoft")
+				env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, S_OTHERDATA)
+				})
+				env.Reference("ref", PLAN_REFCOMP, PLAN_VERSION)
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	plan := func(opts ...transferhandler.TransferOption) (*transfer.Plan, error) {
+		src := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, PLAN_ARCH, 0, env))
+		defer Close(src, "source")
+		cv := Must(src.LookupComponentVersion(PLAN_COMP, PLAN_VERSION))
+		defer Close(cv, "source cv")
+		tgt := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, PLAN_OUT, 0, env))
+		defer Close(tgt, "target")
+
+		p := transfer.NewPlan()
+		handler := Must(standard.New(append(opts, standard.Recursive(), standard.ResourcesByValue())...))
+		return p, transfer.PlanVersion(nil, nil, p, cv, tgt, handler)
+	}
+
+	checkTarget := func() {
+		tgt := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, PLAN_OUT, 0, env))
+		defer Close(tgt, "target")
+		Expect(tgt.ComponentLister().GetComponents("", true)).To(ConsistOf(PLAN_COMP))
+
+		cv := Must(tgt.LookupComponentVersion(PLAN_COMP, PLAN_VERSION))
+		defer Close(cv, "target cv")
+		r := Must(cv.GetResourceByIndex(0))
+		Expect(ocmutils.GetResourceData(r)).To(Equal([]byte(S_OTHERDATA)))
+	}
+
+	It("plans rejected version", func() {
+		p, err := plan()
+		MustFailWithMessage(err, "component version \"github.com/mandelsoft/test:v1\" already exists")
+
+		// references are not evaluated for a rejected version.
+		Expect(p.ComponentVersions).To(HaveLen(1))
+		Expect(p.GetVersion(nv)).NotTo(BeNil())
+		Expect(p.GetVersion(nv).Action).To(Equal(transfer.PLAN_REJECT))
+		Expect(p.GetVersion(nv).Resources).To(BeEmpty())
+		checkTarget()
+	})
+
+	It("plans overwritten version", func() {
+		p := Must(plan(standard.Overwrite()))
+
+		Expect(p.ComponentVersions).To(HaveLen(2))
+		Expect(p.ComponentVersions[0].Component).To(Equal(PLAN_REFCOMP))
+		Expect(p.GetVersion(refnv).Action).To(Equal(transfer.PLAN_CREATE))
+		Expect(p.GetVersion(refnv).Resources).To(HaveLen(1))
+		Expect(p.GetVersion(refnv).Resources[0].Action).To(Equal(transfer.PLAN_COPY))
+
+		Expect(p.GetVersion(nv).Action).To(Equal(transfer.PLAN_OVERWRITE))
+		Expect(p.GetVersion(nv).Resources).To(HaveLen(1))
+		r := p.GetVersion(nv).Resources[0]
+		Expect(r.Name).To(Equal("testdata"))
+		Expect(r.Action).To(Equal(transfer.PLAN_OVERWRITE_BLOB))
+		Expect(r.Size).To(Equal(int64(len(S_TESTDATA))))
+		Expect(r.Access.GetType()).To(Equal("localBlob"))
+		Expect(r.TargetAccess).To(BeNil())
+		checkTarget()
+	})
+
+	It("plans blob sizes for component archives", func() {
+		env.ComponentArchive(PLAN_CA, accessio.FormatDirectory, PLAN_REFCOMP, PLAN_VERSION, func() {
+			env.Provider("mandelsoft")
+			TestDataResource(env)
+		})
+
+		ca := Must(comparch.Open(env.OCMContext(), accessobj.ACC_READONLY, PLAN_CA, 0, env))
+		defer Close(ca, "component archive")
+		tgt := Must(ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, PLAN_OUT, 0, env))
+		defer Close(tgt, "target")
+
+		p := transfer.NewPlan()
+		MustBeSuccessful(transfer.PlanVersion(nil, nil, p, ca, tgt, Must(standard.New())))
+
+		Expect(p.GetVersion(refnv).Action).To(Equal(transfer.PLAN_CREATE))
+		Expect(p.GetVersion(refnv).Resources).To(HaveLen(1))
+		r := p.GetVersion(refnv).Resources[0]
+		Expect(r.Action).To(Equal(transfer.PLAN_COPY))
+		Expect(r.Size).To(Equal(int64(len(S_TESTDATA))))
+	})
+})
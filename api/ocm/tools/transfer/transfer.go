@@ -2,17 +2,23 @@ package transfer
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/mandelsoft/goutils/finalizer"
 	"github.com/mandelsoft/logging"
+	"github.com/opencontainers/go-digest"
 
 	"ocm.software/ocm/api/ocm"
 	"ocm.software/ocm/api/ocm/compdesc"
 	ocmcpi "ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/localblob"
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/none"
 	"ocm.software/ocm/api/ocm/tools/transfer/internal"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
+	"ocm.software/ocm/api/utils/blobaccess"
 	"ocm.software/ocm/api/utils/errkind"
 	common "ocm.software/ocm/api/utils/misc"
 	"ocm.software/ocm/api/utils/runtime"
@@ -27,15 +33,38 @@ func TransferVersion(printer common.Printer, closure TransportClosure, src ocmcp
 		closure = TransportClosure{}
 	}
 	state := WalkingState{Closure: closure}
-	return transferVersion(common.AssurePrinter(printer), Logger(src), state, src, tgt, handler)
+	if j := transferhandler.GetJournal(handler); j != nil {
+		if err := j.SetTarget(tgt.GetSpecification()); err != nil {
+			return err
+		}
+	}
+	return transferVersion(common.AssurePrinter(printer), Logger(src), newWorkers(handler), state, src, tgt, handler)
 }
 
-func transferVersion(printer common.Printer, log logging.Logger, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler) (rerr error) {
+// PlanVersion evaluates the transfer of a component version using the same
+// decision logic as TransferVersion, but without modifying the target
+// repository. The operations a transfer would execute are recorded in the
+// given plan.
+func PlanVersion(printer common.Printer, closure TransportClosure, plan *Plan, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler) error {
+	if closure == nil {
+		closure = TransportClosure{}
+	}
+	state := WalkingState{Closure: closure}
+	return transferVersion(common.AssurePrinter(printer), Logger(src), newPlanningWorkers(handler, plan), state, src, tgt, handler)
+}
+
+func transferVersion(printer common.Printer, log logging.Logger, w *workers, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler) (rerr error) {
 	nv := common.VersionedElementKey(src)
 	log = log.WithValues("history", state.History.String(), "version", nv)
-	if ok, err := state.Add(ocm.KIND_COMPONENTVERSION, nv); !ok {
-		return err
+	if ok, err := w.Add(&state, ocm.KIND_COMPONENTVERSION, nv); !ok {
+		if err != nil {
+			return err
+		}
+		// the component version might still be transferred by a
+		// concurrently executed task.
+		return w.Wait(ocm.KIND_COMPONENTVERSION, state.History, nv)
 	}
+	defer func() { w.Done(nv, rerr) }()
 	log.Info("transferring version")
 	printer.Printf("transferring version %q...\n", nv)
 	if handler == nil {
@@ -51,6 +80,14 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 
 	d := src.GetDescriptor()
 
+	if w.IsCompleted(nv) {
+		// references are still followed, because they might not have
+		// been completed if they are shared with other component versions.
+		printer.Printf("  version %q already transferred according to journal -> skip transport\n", nv)
+		list := errors.ErrListf("component references for %s", nv)
+		return errors.Join(transferReferences(printer, log, w, state, src, tgt, handler, list), list.Result())
+	}
+
 	comp, err := tgt.LookupComponent(src.GetName())
 	if err != nil {
 		return errors.Wrapf(err, "%s: lookup target component", state.History)
@@ -78,8 +115,13 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 
 	if err != nil {
 		if errors.IsErrNotFound(err) {
-			t, err = comp.NewVersion(src.GetVersion())
-			finalize.Close(t, "new target version")
+			if w.IsPlanning() {
+				w.PlanVersion(nv, PLAN_CREATE, "")
+				t, err = nil, nil
+			} else {
+				t, err = comp.NewVersion(src.GetVersion())
+				finalize.Close(t, "new target version")
+			}
 		}
 	} else {
 		ok, err = handler.EnforceTransport(src, t)
@@ -89,6 +131,7 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 		if ok {
 			//  execute transport as if the component version were not present
 			// 	on the target side.
+			w.PlanVersion(nv, PLAN_TRANSPORT, "transport enforced")
 		} else {
 			// determine transport mode for component version present
 			// on the target side.
@@ -96,9 +139,11 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 				if eq.IsEquivalent() {
 					if !needsResourceTransport(src, d, t.GetDescriptor(), handler) {
 						printer.Printf("  version %q already present -> skip transport\n", nv)
+						w.PlanVersion(nv, PLAN_SKIP, "already present")
 						doTransport = false
 					} else {
 						printer.Printf("  version %q already present -> but requires resource transport\n", nv)
+						w.PlanVersion(nv, PLAN_TRANSPORT, "already present, but requires resource transport")
 					}
 				} else {
 					ok, err = handler.UpdateVersion(src, t)
@@ -107,15 +152,18 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 					}
 					if !ok {
 						printer.Printf("  version %q requires update of volatile data, but skipped\n", nv)
+						w.PlanVersion(nv, PLAN_SKIP, "requires update of volatile data, but skipped")
 						return nil
 					}
 					ok, err = handler.OverwriteVersion(src, t)
 					if ok {
 						printer.Printf("  warning: version %q already present, but transport enforced by overwrite option)\n", nv)
+						w.PlanVersion(nv, PLAN_OVERWRITE, "transport enforced by overwrite option")
 						doMerge = false
 						doCopy = true
 					} else {
 						printer.Printf("  updating volatile properties of %q\n", nv)
+						w.PlanVersion(nv, PLAN_UPDATE, "updating volatile properties")
 						doMerge = true
 						doCopy = false
 					}
@@ -144,8 +192,10 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 				if ok {
 					doMerge = false
 					printer.Printf("warning: "+msg+" (transport enforced by overwrite option)\n", nv)
+					w.PlanVersion(nv, PLAN_OVERWRITE, fmt.Sprintf(strings.TrimSpace(msg)+" (transport enforced by overwrite option)", nv))
 				} else {
 					printer.Printf(msg+" -> transport aborted (use option overwrite option to enforce transport)\n", nv)
+					w.PlanVersion(nv, PLAN_REJECT, fmt.Sprintf(strings.TrimSpace(msg), nv))
 					return errors.ErrAlreadyExists(ocm.KIND_COMPONENTVERSION, nv.String())
 				}
 			}
@@ -155,18 +205,10 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 		return errors.Wrapf(err, "%s: creating target version", state.History)
 	}
 
-	subp := printer.AddGap("  ")
 	list := errors.ErrListf("component references for %s", nv)
-	log.Info("  transferring references")
-	for _, r := range d.References {
-		cv, shdlr, err := handler.TransferVersion(src.Repository(), src, &r, tgt)
-		if err != nil {
-			return errors.Wrapf(err, "%s: nested component %s[%s:%s]", state.History, r.GetName(), r.ComponentName, r.GetVersion())
-		}
-		if cv != nil {
-			list.Add(transferVersion(subp, log.WithValues("ref", r.Name), state, cv, tgt, shdlr))
-			list.Addf(nil, cv.Close(), "closing reference %s", r.Name)
-		}
+	err = transferReferences(printer, log, w, state, src, tgt, handler, list)
+	if err != nil {
+		return err
 	}
 
 	if doTransport {
@@ -196,133 +238,280 @@ func transferVersion(printer common.Printer, log logging.Logger, state WalkingSt
 		// corrupted content in target.
 		// If no copy is done, merge must keep the access methods in target!!!
 		if !doMerge || doCopy {
-			err = copyVersion(printer, log, state.History, src, t, n, handler)
+			err = copyVersion(printer, log, w, state.History, src, t, n, handler)
 			if err != nil {
 				return err
 			}
-		} else {
+		} else if !w.IsPlanning() {
 			*t.GetDescriptor() = *n
 		}
 
-		printer.Printf("...adding component version...\n")
-		log.Info("  adding component version")
-		list.Add(comp.AddVersion(t))
+		if !w.IsPlanning() {
+			printer.Printf("...adding component version...\n")
+			log.Info("  adding component version")
+			list.Add(comp.AddVersion(t))
+		}
+	}
+	if list.Result() == nil {
+		list.Add(w.SetCompleted(nv))
 	}
 	return list.Result()
 }
 
+// transferReferences transfers the references of a component version
+// using the given worker pool. Errors of the nested transfers are added
+// to the given error list, an error is only returned if a reference
+// cannot be resolved.
+func transferReferences(printer common.Printer, log logging.Logger, w *workers, state WalkingState, src ocmcpi.ComponentVersionAccess, tgt ocmcpi.Repository, handler TransferHandler, list *errors.ErrorList) error {
+	log.Info("  transferring references")
+	refs := w.NewGroup(printer.AddGap("  "))
+	for _, r := range src.GetDescriptor().References {
+		cv, shdlr, err := handler.TransferVersion(src.Repository(), src, &r, tgt)
+		if err != nil {
+			refs.Wait()
+			return errors.Wrapf(err, "%s: nested component %s[%s:%s]", state.History, r.GetName(), r.ComponentName, r.GetVersion())
+		}
+		if cv != nil {
+			name := r.Name
+			refs.Run(func(subp common.Printer) error {
+				err := transferVersion(subp, log.WithValues("ref", name), w, state, cv, tgt, shdlr)
+				if cerr := cv.Close(); cerr != nil {
+					err = errors.Join(err, errors.Wrapf(cerr, "closing reference %s", name))
+				}
+				return err
+			})
+		}
+	}
+	list.Add(refs.Wait()...)
+	return nil
+}
+
 func CopyVersion(printer common.Printer, log logging.Logger, hist common.History, src ocm.ComponentVersionAccess, t ocm.ComponentVersionAccess, handler TransferHandler) (rerr error) {
-	return copyVersion(common.AssurePrinter(printer), log, hist, src, t, src.GetDescriptor().Copy(), handler)
+	return copyVersion(common.AssurePrinter(printer), log, newWorkers(handler), hist, src, t, src.GetDescriptor().Copy(), handler)
 }
 
 // copyVersion (purely internal) expects an already prepared target comp desc for t given as prep.
-func copyVersion(printer common.Printer, log logging.Logger, hist common.History, src ocm.ComponentVersionAccess, t ocm.ComponentVersionAccess, prep *compdesc.ComponentDescriptor, handler TransferHandler) (rerr error) {
-	var finalize finalizer.Finalizer
-
-	defer errors.PropagateError(&rerr, finalize.Finalize)
-
+// Resources and sources are transferred using the given worker pool, but the
+// target descriptor keeps the element order of the prepared descriptor.
+// If the transfer is only planned, t is nil for a new component version
+// and is never modified.
+func copyVersion(printer common.Printer, log logging.Logger, w *workers, hist common.History, src ocm.ComponentVersionAccess, t ocm.ComponentVersionAccess, prep *compdesc.ComponentDescriptor, handler TransferHandler) error {
 	if handler == nil {
 		handler = standard.NewDefaultHandler(nil)
 	}
 
-	srccd := src.GetDescriptor()
-	cur := *t.GetDescriptor()
-	*t.GetDescriptor() = *prep
+	var cur compdesc.ComponentDescriptor
+	if t != nil {
+		cur = *t.GetDescriptor()
+	}
+	if !w.IsPlanning() {
+		*t.GetDescriptor() = *prep
+	}
 	log.Info("  transferring resources")
+	resources := w.NewGroup(printer)
 	for i, r := range src.GetResources() {
-		var m ocmcpi.AccessMethod
-
-		nested := finalize.Nested()
+		if resources.Failed() {
+			break
+		}
+		resources.Run(func(printer common.Printer) error {
+			return copyResource(printer, log, w, hist, src, t, &cur, i, r, handler)
+		})
+	}
+	if errs := resources.Wait(); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
 
-		a, err := r.Access()
-		if err == nil {
-			m, err = a.AccessMethod(src)
-			nested.Close(m, fmt.Sprintf("%s: transferring resource %d: closing access method", hist, i))
+	log.Info("  transferring sources")
+	sources := w.NewGroup(printer)
+	for i, r := range src.GetSources() {
+		if sources.Failed() {
+			break
 		}
-		if err == nil {
-			ok := a.IsLocal(src.GetContext())
-			if !ok {
-				if !none.IsNone(a.GetKind()) {
-					ok, err = handler.TransferResource(src, a, r)
-					if err == nil && !ok {
-						log.Info("transport omitted", "resource", r.Meta().Name, "index", i, "access", a.GetType())
-					}
+		sources.Run(func(printer common.Printer) error {
+			return copySource(printer, log, w, hist, src, t, i, r, handler)
+		})
+	}
+	if errs := sources.Wait(); len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+func copyResource(printer common.Printer, log logging.Logger, w *workers, hist common.History, src ocm.ComponentVersionAccess, t ocm.ComponentVersionAccess, cur *compdesc.ComponentDescriptor, i int, r ocm.ResourceAccess, handler TransferHandler) (rerr error) {
+	var m ocmcpi.AccessMethod
+	var finalize finalizer.Finalizer
+
+	defer errors.PropagateError(&rerr, finalize.Finalize)
+
+	a, err := r.Access()
+	if err == nil {
+		m, err = a.AccessMethod(src)
+		finalize.Close(m, fmt.Sprintf("%s: transferring resource %d: closing access method", hist, i))
+	}
+	if err == nil {
+		ok := a.IsLocal(src.GetContext())
+		if !ok {
+			if !none.IsNone(a.GetKind()) {
+				ok, err = handler.TransferResource(src, a, r)
+				if err == nil && !ok {
+					log.Info("transport omitted", "resource", r.Meta().Name, "index", i, "access", a.GetType())
 				}
 			}
-			if ok {
-				var old compdesc.Resource
-
-				hint := ocmcpi.ArtifactNameHint(a, src)
-				old, err = cur.GetResourceByIdentity(r.Meta().GetIdentity(srccd.Resources))
-
-				changed := err != nil || old.Digest == nil || !old.Digest.Equal(r.Meta().Digest)
-				valueNeeded := err == nil && needsTransport(src.GetContext(), r, &old)
-				if changed || valueNeeded {
-					var msgs []interface{}
-					if !errors.IsErrNotFound(err) {
-						if err != nil {
-							return err
-						}
-						if !changed && valueNeeded {
-							msgs = []interface{}{"copy"}
-						} else {
-							msgs = []interface{}{"overwrite"}
-						}
+		}
+		if ok {
+			var old compdesc.Resource
+
+			hint := ocmcpi.ArtifactNameHint(a, src)
+			id := r.Meta().GetIdentity(src.GetDescriptor().Resources)
+			old, err = cur.GetResourceByIdentity(id)
+
+			changed := err != nil || old.Digest == nil || !old.Digest.Equal(r.Meta().Digest)
+			valueNeeded := err == nil && needsTransport(src.GetContext(), r, &old)
+			if changed || valueNeeded {
+				var msgs []interface{}
+				action := PLAN_COPY
+				if !errors.IsErrNotFound(err) {
+					if err != nil {
+						return err
 					}
+					if !changed && valueNeeded {
+						msgs = []interface{}{"copy"}
+					} else {
+						msgs = []interface{}{"overwrite"}
+						action = PLAN_OVERWRITE_BLOB
+					}
+				}
+				err = nil
+				reused := false
+				if acc := w.GetResourceAccess(t, id, r.Meta().Digest); acc != nil {
+					reused, err = reuseResource(t, r.Meta(), acc, hint)
+				}
+				switch {
+				case err != nil:
+				case reused:
+					notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, "already transferred according to journal")
+				case w.IsPlanning():
+					notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, msgs...)
+					e := newArtifactPlan(i, &r.Meta().ElementMeta, r.Meta().GetType(), r.Meta().Digest, a, m, action, "")
+					e.Hint = hint
+					w.PlanResource(common.VersionedElementKey(src), e)
+				default:
 					notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, msgs...)
 					err = handler.HandleTransferResource(r, m, hint, t)
-				} else {
-					if err == nil { // old resource found -> keep current access method
+					if err == nil {
+						err = w.AddResource(t, id, r.Meta().Digest)
+					}
+				}
+			} else {
+				if err == nil { // old resource found -> keep current access method
+					if w.IsPlanning() {
+						e := newArtifactPlan(i, &r.Meta().ElementMeta, r.Meta().GetType(), r.Meta().Digest, a, m, PLAN_KEEP, "already present")
+						e.TargetAccess = toUnstructured(old.Access)
+						w.PlanResource(common.VersionedElementKey(src), e)
+					} else {
 						t.SetResource(r.Meta(), old.Access, ocm.ModifyElement(), ocm.SkipVerify())
 					}
-					notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, "already present")
 				}
+				notifyArtifactInfo(printer, log, "resource", i, r.Meta(), hint, "already present")
 			}
+		} else if err == nil && w.IsPlanning() {
+			e := newArtifactPlan(i, &r.Meta().ElementMeta, r.Meta().GetType(), r.Meta().Digest, a, m, PLAN_REFERENCE, "")
+			e.TargetAccess = e.Access
+			w.PlanResource(common.VersionedElementKey(src), e)
 		}
-		if err != nil {
-			if !errors.IsErrUnknownKind(err, errkind.KIND_ACCESSMETHOD) {
-				return errors.Wrapf(err, "%s: transferring resource %d", hist, i)
-			}
-			printer.Printf("WARN: %s: transferring resource %d: %s (enforce transport by reference)\n", hist, i, err)
-		}
-		err = nested.Finalize()
-		if err != nil {
-			return err
+	}
+	if err != nil {
+		if !errors.IsErrUnknownKind(err, errkind.KIND_ACCESSMETHOD) {
+			return errors.Wrapf(err, "%s: transferring resource %d", hist, i)
 		}
+		printer.Printf("WARN: %s: transferring resource %d: %s (enforce transport by reference)\n", hist, i, err)
 	}
+	return nil
+}
 
-	log.Info("  transferring sources")
-	for i, r := range src.GetSources() {
-		var m ocmcpi.AccessMethod
+// reuseResource sets the access of a resource to a blob already transferred
+// according to the transfer journal.
+// Local blobs are bound to the technical representation of the target
+// component version, which is recreated by a resumed transfer. Therefore,
+// they are bound again to the blob already stored in the target repository.
+// The existence of this blob is checked without reading its content, and
+// because it is already present, its content is not transferred again.
+// If such a blob is not available anymore, or it cannot be checked this way,
+// false is returned and the resource has to be transferred again.
+func reuseResource(t ocm.ComponentVersionAccess, meta *ocm.ResourceMeta, acc compdesc.AccessSpec, hint string) (bool, error) {
+	spec, err := t.GetContext().AccessSpecForSpec(acc)
+	if err != nil {
+		return false, err
+	}
+	if !spec.IsLocal(t.GetContext()) {
+		return true, t.SetResource(meta, acc, ocm.ModifyElement(), ocm.SkipVerify())
+	}
 
-		a, err := r.Access()
-		if err == nil {
-			m, err = a.AccessMethod(src)
-		}
-		if err == nil {
-			ok := a.IsLocal(src.GetContext())
-			if !ok {
-				if !none.IsNone(a.GetKind()) {
-					ok, err = handler.TransferSource(src, a, r)
-					if err == nil && !ok {
-						log.Info("transport omitted", "source", r.Meta().Name, "index", i, "access", a.GetType())
-					}
+	local, ok := spec.(*localblob.AccessSpec)
+	if !ok {
+		return false, nil
+	}
+	dig, err := digest.Parse(local.LocalReference)
+	if err != nil {
+		return false, nil
+	}
+	m, err := spec.AccessMethod(t)
+	if err != nil {
+		return false, nil
+	}
+	defer m.Close()
+	p, ok := accspeccpi.GetAccessMethodImplementation(m).(accspeccpi.BlobSizeProvider)
+	if !ok {
+		return false, nil
+	}
+	size, err := p.GetBlobSize()
+	if err != nil || size == blobaccess.BLOB_UNKNOWN_SIZE {
+		return false, nil
+	}
+	blob := blobaccess.ForDataAccess(dig, size, local.MediaType, m)
+	return true, t.SetResourceBlob(meta, blob, hint, nil, ocm.SkipVerify())
+}
+
+func copySource(printer common.Printer, log logging.Logger, w *workers, hist common.History, src ocm.ComponentVersionAccess, t ocm.ComponentVersionAccess, i int, r ocm.SourceAccess, handler TransferHandler) error {
+	var m ocmcpi.AccessMethod
+
+	a, err := r.Access()
+	if err == nil {
+		m, err = a.AccessMethod(src)
+	}
+	if err == nil {
+		ok := a.IsLocal(src.GetContext())
+		if !ok {
+			if !none.IsNone(a.GetKind()) {
+				ok, err = handler.TransferSource(src, a, r)
+				if err == nil && !ok {
+					log.Info("transport omitted", "source", r.Meta().Name, "index", i, "access", a.GetType())
 				}
 			}
-			if ok {
-				// sources do not have digests fo far, so they have to copied, always.
-				hint := ocmcpi.ArtifactNameHint(a, src)
-				notifyArtifactInfo(printer, log, "source", i, r.Meta(), hint)
+		}
+		if ok {
+			// sources do not have digests fo far, so they have to copied, always.
+			hint := ocmcpi.ArtifactNameHint(a, src)
+			notifyArtifactInfo(printer, log, "source", i, r.Meta(), hint)
+			if w.IsPlanning() {
+				e := newArtifactPlan(i, &r.Meta().ElementMeta, r.Meta().GetType(), nil, a, m, PLAN_COPY, "")
+				e.Hint = hint
+				w.PlanSource(common.VersionedElementKey(src), e)
+			} else {
 				err = errors.Join(err, handler.HandleTransferSource(r, m, hint, t))
 			}
-			err = errors.Join(err, m.Close())
+		} else if err == nil && w.IsPlanning() {
+			e := newArtifactPlan(i, &r.Meta().ElementMeta, r.Meta().GetType(), nil, a, m, PLAN_REFERENCE, "")
+			e.TargetAccess = e.Access
+			w.PlanSource(common.VersionedElementKey(src), e)
 		}
-		if err != nil {
-			if !errors.IsErrUnknownKind(err, errkind.KIND_ACCESSMETHOD) {
-				return errors.Wrapf(err, "%s: transferring source %d", hist, i)
-			}
-			printer.Printf("WARN: %s: transferring source %d: %s (enforce transport by reference)\n", hist, i, err)
+		err = errors.Join(err, m.Close())
+	}
+	if err != nil {
+		if !errors.IsErrUnknownKind(err, errkind.KIND_ACCESSMETHOD) {
+			return errors.Wrapf(err, "%s: transferring source %d", hist, i)
 		}
+		printer.Printf("WARN: %s: transferring source %d: %s (enforce transport by reference)\n", hist, i, err)
 	}
 	return nil
 }
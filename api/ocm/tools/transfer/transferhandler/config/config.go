@@ -27,6 +27,7 @@ type Config struct {
 	StopOnExisting              *bool    `json:"stopOnExistingVersion,omitempty"`
 	Overwrite                   *bool    `json:"overwrite,omitempty"`
 	OmitAccessTypes             []string `json:"omitAccessTypes,omitempty"`
+	Parallel                    *int     `json:"parallel,omitempty"`
 }
 
 // NewConfig creates a new memory ConfigSpec.
@@ -81,6 +82,11 @@ func (c *Config) ApplyTo(ctx cfgcpi.Context, target interface{}) error {
 			opts.SetOmittedAccessTypes(c.OmitAccessTypes...)
 		}
 	}
+	if c.Parallel != nil {
+		if opts, ok := target.(standard.ParallelOption); ok {
+			opts.SetParallel(*c.Parallel)
+		}
+	}
 	return nil
 }
 
@@ -98,5 +104,10 @@ The config type <code>` + ConfigType + `</code> can be used to define transfer s
     stopOnExistingVersion: false
     omitAccessTypes:
     - s3
+    parallel: 4
 </pre>
+
+The field <code>parallel</code> limits the number of nested component versions
+and resource/source blobs transferred concurrently. By default, the transfer
+is executed sequentially.
 `
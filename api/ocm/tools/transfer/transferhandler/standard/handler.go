@@ -11,6 +11,7 @@ import (
 	"ocm.software/ocm/api/ocm/cpi"
 	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
 	"ocm.software/ocm/api/ocm/resolvers"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
 	"ocm.software/ocm/api/utils/accessio"
 )
@@ -35,6 +36,17 @@ func New(opts ...transferhandler.TransferOption) (transferhandler.TransferHandle
 	return NewDefaultHandler(defaultOpts), nil
 }
 
+// GetParallel provides the maximum number of concurrent
+// transfer operations configured for this handler.
+func (h *Handler) GetParallel() int {
+	return h.opts.GetParallel()
+}
+
+// GetJournal provides the transfer journal configured for this handler.
+func (h *Handler) GetJournal() *journal.Journal {
+	return h.opts.GetJournal()
+}
+
 func (h *Handler) UpdateVersion(src ocm.ComponentVersionAccess, tgt ocm.ComponentVersionAccess) (bool, error) {
 	return !h.opts.IsSkipUpdate(), nil
 }
@@ -17,6 +17,7 @@ import (
 	"ocm.software/ocm/api/oci/artdesc"
 	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
 	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
 	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 	"ocm.software/ocm/api/ocm/cpi/accspeccpi"
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/localblob"
@@ -483,6 +484,42 @@ warning:   version "github.com/mandelsoft/test:v1" already present, but differs
 		Expect(err).To(Succeed())
 	})
 
+	It("it should transfer in parallel with deterministic output", func() {
+		parentSrc := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH2, 0, env))
+		defer Close(parentSrc, "parent source")
+		childSrc := Must(ctf.Open(env.OCMContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(childSrc, "child source")
+		cv := Must(parentSrc.LookupComponentVersion(COMPONENT2, VERSION))
+		defer Close(cv, "source cv")
+
+		transport := func(path string, opts ...transferhandler.TransferOption) (string, *compdesc.ComponentDescriptor) {
+			tgt := Must(ctf.Create(env.OCMContext(), accessobj.ACC_WRITABLE|accessobj.ACC_CREATE, path, 0o700, accessio.FormatDirectory, env))
+			defer Close(tgt, "target")
+
+			p, buf := common.NewBufferedPrinter()
+			handler := Must(standard.New(append(opts, standard.Recursive(), standard.ResourcesByValue(), standard.Resolver(childSrc))...))
+			MustBeSuccessful(transfer.TransferVersion(p, nil, cv, tgt, handler))
+
+			tcv := Must(tgt.LookupComponentVersion(COMPONENT, VERSION))
+			defer Close(tcv, "target cv")
+			return buf.String(), tcv.GetDescriptor().Copy()
+		}
+
+		seqOut, seqDesc := transport(OUT)
+		parOut, parDesc := transport(OUT+"2", standard.Parallel(4))
+
+		Expect(parOut).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test2:v1"...
+  transferring version "github.com/mandelsoft/test:v1"...
+  ...resource 0 testdata[PlainText]...
+  ...resource 1 artifact[ociImage](ocm/value:v2.0)...
+  ...adding component version...
+...adding component version...
+`))
+		Expect(parOut).To(Equal(seqOut))
+		Expect(parDesc.Resources).To(Equal(seqDesc.Resources))
+	})
+
 	It("it should copy signatures", func() {
 		src, err := ctf.Open(env.OCMContext(), accessobj.ACC_WRITABLE, ARCH, 0, env)
 		Expect(err).To(Succeed())
@@ -9,6 +9,7 @@ import (
 	"golang.org/x/exp/slices"
 
 	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
 	"ocm.software/ocm/api/utils/runtime"
 )
@@ -19,6 +20,8 @@ func init() {
 
 type Options struct {
 	retries           *int
+	parallel          *int
+	journal           *journal.Journal
 	recursive         *bool
 	resourcesByValue  *bool
 	localByValue      *bool
@@ -37,6 +40,8 @@ var (
 	_ transferhandler.TransferOption = (*Options)(nil)
 
 	_ RetryOption                 = (*Options)(nil)
+	_ ParallelOption              = (*Options)(nil)
+	_ JournalOption               = (*Options)(nil)
 	_ ResourcesByValueOption      = (*Options)(nil)
 	_ LocalResourcesByValueOption = (*Options)(nil)
 	_ EnforceTransportOption      = (*Options)(nil)
@@ -66,6 +71,16 @@ func (o *Options) ApplyTransferOption(target transferhandler.TransferOptions) er
 			opts.SetRetries(*o.retries)
 		}
 	}
+	if o.parallel != nil {
+		if opts, ok := target.(ParallelOption); ok {
+			opts.SetParallel(*o.parallel)
+		}
+	}
+	if o.journal != nil {
+		if opts, ok := target.(JournalOption); ok {
+			opts.SetJournal(o.journal)
+		}
+	}
 	if o.recursive != nil {
 		if opts, ok := target.(RecursiveOption); ok {
 			opts.SetRecursive(*o.recursive)
@@ -208,6 +223,25 @@ func (o *Options) GetRetries() int {
 	return *o.retries
 }
 
+func (o *Options) SetParallel(n int) {
+	o.parallel = &n
+}
+
+func (o *Options) GetParallel() int {
+	if o.parallel == nil {
+		return 0
+	}
+	return *o.parallel
+}
+
+func (o *Options) SetJournal(j *journal.Journal) {
+	o.journal = j
+}
+
+func (o *Options) GetJournal() *journal.Journal {
+	return o.journal
+}
+
 func (o *Options) SetResolver(resolver ocm.ComponentVersionResolver) {
 	o.resolver = resolver
 }
@@ -400,6 +434,64 @@ func Retries(retries int) transferhandler.TransferOption {
 
 ///////////////////////////////////////////////////////////////////////////////
 
+type ParallelOption interface {
+	SetParallel(n int)
+	GetParallel() int
+}
+
+type parallelOption struct {
+	TransferOptionsCreator
+	parallel int
+}
+
+func (o *parallelOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(ParallelOption); ok {
+		eff.SetParallel(o.parallel)
+		return nil
+	} else {
+		return errors.ErrNotSupported(transferhandler.KIND_TRANSFEROPTION, "parallel")
+	}
+}
+
+// Parallel sets the maximum number of transfer operations
+// (nested component versions and resource/source blobs)
+// executed concurrently. A value less than or equal to 1
+// results in a sequential transfer.
+func Parallel(n int) transferhandler.TransferOption {
+	return &parallelOption{parallel: n}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type JournalOption interface {
+	SetJournal(j *journal.Journal)
+	GetJournal() *journal.Journal
+}
+
+type journalOption struct {
+	TransferOptionsCreator
+	journal *journal.Journal
+}
+
+func (o *journalOption) ApplyTransferOption(to transferhandler.TransferOptions) error {
+	if eff, ok := to.(JournalOption); ok {
+		eff.SetJournal(o.journal)
+		return nil
+	} else {
+		return errors.ErrNotSupported(transferhandler.KIND_TRANSFEROPTION, "journal")
+	}
+}
+
+// Journal sets a journal used to record the progress of a transfer.
+// If the journal already contains entries of a previous transfer
+// to the same target, completed component versions and already
+// transferred resource blobs are skipped.
+func Journal(j *journal.Journal) transferhandler.TransferOption {
+	return &journalOption{journal: j}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
 type RecursiveOption interface {
 	SetRecursive(bool)
 	IsRecursive() bool
@@ -7,6 +7,7 @@ import (
 	"ocm.software/ocm/api/ocm"
 	"ocm.software/ocm/api/ocm/compdesc"
 	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
 )
 
 const KIND_TRANSFEROPTION = "transfer option"
@@ -105,6 +106,41 @@ type TransferHandler interface {
 	HandleTransferSource(r ocm.SourceAccess, m cpi.AccessMethod, hint string, t ocm.ComponentVersionAccess) error
 }
 
+// ParallelProvider is an optional interface for a TransferHandler
+// providing the maximum number of transfer operations
+// (nested component versions and artifact blobs) executed concurrently.
+// Handlers providing a value greater than one must be usable concurrently.
+type ParallelProvider interface {
+	GetParallel() int
+}
+
+// GetParallel determines the maximum number of concurrent transfer operations
+// for a given handler. If the handler does not provide this information,
+// a sequential transfer (1) is assumed.
+func GetParallel(h TransferHandler) int {
+	if p, ok := h.(ParallelProvider); ok {
+		if n := p.GetParallel(); n > 1 {
+			return n
+		}
+	}
+	return 1
+}
+
+// JournalProvider is an optional interface for a TransferHandler
+// providing a journal used to record the progress of a transfer.
+type JournalProvider interface {
+	GetJournal() *journal.Journal
+}
+
+// GetJournal determines the transfer journal for a given handler.
+// If the handler does not provide a journal, nil is returned.
+func GetJournal(h TransferHandler) *journal.Journal {
+	if p, ok := h.(JournalProvider); ok {
+		return p.GetJournal()
+	}
+	return nil
+}
+
 func ApplyOptions(set TransferOptions, opts ...TransferOption) error {
 	list := errors.ErrListf("transfer options")
 	for _, o := range opts {
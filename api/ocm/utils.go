@@ -6,9 +6,11 @@ import (
 
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
 
 	"ocm.software/ocm/api/ocm/compdesc"
 	"ocm.software/ocm/api/ocm/cpi"
+	"ocm.software/ocm/api/ocm/cpi/repocpi"
 	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
 	"ocm.software/ocm/api/ocm/internal"
 	"ocm.software/ocm/api/utils"
@@ -113,3 +115,12 @@ func GlobalAccess(spec AccessSpec, ctx Context) AccessSpec {
 	}
 	return nil
 }
+
+// CleanupBlobs removes the blobs of a repository not used anymore
+// by any component version, for example after component versions
+// have been deleted. With dryrun=true, the orphaned blobs are only reported.
+// If the repository does not support a garbage collection,
+// an errors.ErrNotSupported error is returned.
+func CleanupBlobs(repo Repository, dryrun bool) ([]digest.Digest, error) {
+	return repocpi.CleanupBlobs(repo, dryrun)
+}
@@ -0,0 +1,88 @@
+package docker
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/log"
+	"github.com/pkg/errors"
+
+	"ocm.software/ocm/api/tech/docker/resolve"
+)
+
+type dockerDeleter struct {
+	dockerBase *dockerBase
+}
+
+func (r *dockerResolver) Deleter(ctx context.Context, ref string) (resolve.Deleter, error) {
+	base, err := r.resolveDockerBase(ref)
+	if err != nil {
+		return nil, err
+	}
+	if base.refspec.Object != "" {
+		return nil, ErrObjectNotRequired
+	}
+
+	return &dockerDeleter{
+		dockerBase: base,
+	}, nil
+}
+
+func (r *dockerDeleter) Delete(ctx context.Context, ref string) error {
+	base := r.dockerBase
+
+	hosts := base.filterHosts(HostCapabilityPush)
+	if len(hosts) == 0 {
+		return errors.Wrap(errdefs.ErrNotFound, "no delete hosts")
+	}
+
+	ctx, err := ContextWithRepositoryScope(ctx, base.refspec, true)
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, host := range hosts {
+		ctxWithLogger := log.WithLogger(ctx, log.G(ctx).WithField("host", host.Host))
+
+		req := base.request(host, http.MethodDelete, "manifests", ref)
+		if err := req.addNamespace(base.refspec.Hostname()); err != nil {
+			return err
+		}
+
+		log.G(ctxWithLogger).Debug("deleting")
+		resp, err := req.doWithRetries(ctxWithLogger, nil)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			log.G(ctxWithLogger).WithError(err).Info("trying next host")
+			continue // try another host
+		}
+		resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusOK, http.StatusAccepted, http.StatusNoContent:
+			return nil
+		case http.StatusNotFound:
+			if firstErr == nil {
+				firstErr = errors.Wrapf(errdefs.ErrNotFound, "%s@%s", base.refspec.Locator, ref)
+			}
+			continue
+		case http.StatusMethodNotAllowed, http.StatusBadRequest:
+			// the registry does not support the deletion of manifests
+			// or the deletion by tag.
+			return errors.Wrapf(errdefs.ErrNotImplemented, "delete %s from host %s: %s", ref, host.Host, resp.Status)
+		default:
+			if firstErr == nil {
+				firstErr = errors.Errorf("delete %s from host %s failed with status code %v", ref, host.Host, resp.Status)
+			}
+		}
+	}
+
+	if firstErr == nil {
+		firstErr = errors.Wrap(errdefs.ErrNotFound, base.refspec.Locator)
+	}
+	return firstErr
+}
@@ -0,0 +1,106 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/containerd/log"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+
+	"ocm.software/ocm/api/tech/docker/resolve"
+)
+
+type dockerReferrers struct {
+	dockerBase *dockerBase
+}
+
+func (r *dockerResolver) Referrers(ctx context.Context, ref string) (resolve.Referrers, error) {
+	base, err := r.resolveDockerBase(ref)
+	if err != nil {
+		return nil, err
+	}
+	if base.refspec.Object != "" {
+		return nil, ErrObjectNotRequired
+	}
+
+	return &dockerReferrers{
+		dockerBase: base,
+	}, nil
+}
+
+func (r *dockerReferrers) ListReferrers(ctx context.Context, dig digest.Digest) ([]ocispec.Descriptor, error) {
+	base := r.dockerBase
+
+	hosts := base.filterHosts(HostCapabilityPull | HostCapabilityResolve)
+	if len(hosts) == 0 {
+		return nil, errors.Wrap(errdefs.ErrNotFound, "no referrers hosts")
+	}
+
+	ctx, err := ContextWithRepositoryScope(ctx, base.refspec, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var firstErr error
+	for _, host := range hosts {
+		ctxWithLogger := log.WithLogger(ctx, log.G(ctx).WithField("host", host.Host))
+
+		req := base.request(host, http.MethodGet, "referrers", dig.String())
+		if err := req.addNamespace(base.refspec.Hostname()); err != nil {
+			return nil, err
+		}
+		req.header["Accept"] = []string{ocispec.MediaTypeImageIndex}
+
+		log.G(ctxWithLogger).Debug("listing referrers")
+		resp, err := req.doWithRetries(ctxWithLogger, nil)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			log.G(ctxWithLogger).WithError(err).Info("trying next host")
+			continue // try another host
+		}
+
+		switch resp.StatusCode {
+		case http.StatusOK:
+		case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusBadRequest:
+			// according to the distribution spec a registry supporting the
+			// referrers API must not answer with 404 for an existing
+			// namespace, so the API is not supported by this host.
+			resp.Body.Close()
+			if firstErr == nil {
+				firstErr = errors.Wrapf(errdefs.ErrNotImplemented, "referrers API on host %s: %s", host.Host, resp.Status)
+			}
+			continue
+		default:
+			resp.Body.Close()
+			if firstErr == nil {
+				firstErr = errors.Errorf("listing referrers from host %s failed with status code %v", host.Host, resp.Status)
+			}
+			continue
+		}
+
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		var index ocispec.Index
+		err = json.Unmarshal(data, &index)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid referrers index")
+		}
+		return index.Manifests, nil
+	}
+
+	if firstErr == nil {
+		firstErr = errors.Wrap(errdefs.ErrNotFound, base.refspec.Locator)
+	}
+	return nil, firstErr
+}
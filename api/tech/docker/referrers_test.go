@@ -0,0 +1,92 @@
+package docker_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/containerd/containerd/errdefs"
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/go-digest"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"ocm.software/ocm/api/tech/docker"
+)
+
+const SIGNATURE = "application/vnd.test.signature"
+
+var _ = Describe("referrers", func() {
+	var server *httptest.Server
+	var status int
+	var path string
+
+	subject := digest.FromString("subject")
+	referrer := ocispec.Descriptor{
+		MediaType:    ocispec.MediaTypeImageManifest,
+		ArtifactType: SIGNATURE,
+		Digest:       digest.FromString("referrer"),
+		Size:         8,
+	}
+
+	BeforeEach(func() {
+		status = http.StatusOK
+		path = ""
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || !strings.HasPrefix(r.URL.Path, "/v2/test/repo/referrers/") {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			path = r.URL.Path
+			if status != http.StatusOK {
+				w.WriteHeader(status)
+				return
+			}
+			w.Header().Set("Content-Type", ocispec.MediaTypeImageIndex)
+			json.NewEncoder(w).Encode(ocispec.Index{
+				MediaType: ocispec.MediaTypeImageIndex,
+				Manifests: []ocispec.Descriptor{referrer},
+			})
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	list := func() ([]ocispec.Descriptor, error) {
+		resolver := docker.NewResolver(docker.ResolverOptions{PlainHTTP: true})
+		ref := strings.TrimPrefix(server.URL, "http://") + "/test/repo"
+		r := Must(resolver.Referrers(context.Background(), ref))
+		return r.ListReferrers(context.Background(), subject)
+	}
+
+	It("lists referrers", func() {
+		Expect(Must(list())).To(Equal([]ocispec.Descriptor{referrer}))
+		Expect(path).To(Equal("/v2/test/repo/referrers/" + subject.String()))
+	})
+
+	It("reports an unsupported referrers API", func() {
+		status = http.StatusNotFound
+		_, err := list()
+		Expect(err).To(HaveOccurred())
+		Expect(errdefs.IsNotImplemented(err)).To(BeTrue())
+	})
+
+	It("reports other errors", func() {
+		status = http.StatusForbidden
+		_, err := list()
+		Expect(err).To(HaveOccurred())
+		Expect(errdefs.IsNotImplemented(err)).To(BeFalse())
+	})
+
+	It("rejects a reference with object", func() {
+		resolver := docker.NewResolver(docker.ResolverOptions{PlainHTTP: true})
+		ref := strings.TrimPrefix(server.URL, "http://") + "/test/repo:v1"
+		_, err := resolver.Referrers(context.Background(), ref)
+		Expect(err).To(Equal(docker.ErrObjectNotRequired))
+	})
+})
@@ -41,6 +41,14 @@ type Resolver interface {
 	Pusher(ctx context.Context, ref string) (Pusher, error)
 
 	Lister(ctx context.Context, ref string) (Lister, error)
+
+	// Deleter returns a new deleter for the provided reference.
+	// All content deleted by the returned deleter will be
+	// from the namespace referred to by ref.
+	Deleter(ctx context.Context, ref string) (Deleter, error)
+
+	// Referrers returns a new referrers lister for the provided reference.
+	Referrers(ctx context.Context, ref string) (Referrers, error)
 }
 
 // Fetcher fetches content.
@@ -61,6 +69,24 @@ type Lister interface {
 	List(context.Context) ([]string, error)
 }
 
+// Deleter deletes manifests.
+type Deleter interface {
+	// Delete deletes the manifest identified by a digest or tag.
+	// Deleting a manifest by tag might not be supported by
+	// all registries.
+	Delete(ctx context.Context, ref string) error
+}
+
+// Referrers lists the artifacts referring to a manifest
+// via their subject field.
+type Referrers interface {
+	// ListReferrers uses the referrers API of the OCI distribution spec
+	// to list the descriptors of artifacts referring to the manifest with
+	// the given digest. If the registry does not support this API an
+	// errdefs.ErrNotImplemented error is returned.
+	ListReferrers(ctx context.Context, dig digest.Digest) ([]ocispec.Descriptor, error)
+}
+
 // PushRequest handles the result of a push request
 // replaces containerd content.Writer.
 type PushRequest interface {
@@ -0,0 +1,78 @@
+package sbom
+
+import (
+	"encoding/json"
+
+	"github.com/mandelsoft/goutils/errors"
+)
+
+type cdxDocument struct {
+	BOMFormat  string         `json:"bomFormat"`
+	Metadata   *cdxMetadata   `json:"metadata"`
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxMetadata struct {
+	Component *cdxComponent `json:"component"`
+}
+
+type cdxComponent struct {
+	Group      string         `json:"group"`
+	Name       string         `json:"name"`
+	Version    string         `json:"version"`
+	PURL       string         `json:"purl"`
+	Licenses   []cdxLicense   `json:"licenses"`
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxLicense struct {
+	License *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"license"`
+	Expression string `json:"expression"`
+}
+
+func parseCycloneDX(data []byte) (*Document, error) {
+	var doc cdxDocument
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid CycloneDX document")
+	}
+	result := &Document{
+		Format: FORMAT_CYCLONEDX,
+	}
+	if doc.Metadata != nil && doc.Metadata.Component != nil {
+		result.Name = doc.Metadata.Component.Name
+	}
+	result.Packages = cdxPackages(nil, doc.Components)
+	return result, nil
+}
+
+// cdxPackages flattens the (nested) component list.
+func cdxPackages(result []Package, list []cdxComponent) []Package {
+	for _, c := range list {
+		name := c.Name
+		if c.Group != "" {
+			name = c.Group + ":" + c.Name
+		}
+		pkg := Package{
+			Name:    name,
+			Version: c.Version,
+			PURL:    c.PURL,
+		}
+		for _, l := range c.Licenses {
+			switch {
+			case l.Expression != "":
+				pkg.Licenses = append(pkg.Licenses, l.Expression)
+			case l.License != nil && l.License.ID != "":
+				pkg.Licenses = append(pkg.Licenses, l.License.ID)
+			case l.License != nil && l.License.Name != "":
+				pkg.Licenses = append(pkg.Licenses, l.License.Name)
+			}
+		}
+		result = append(result, pkg)
+		result = cdxPackages(result, c.Components)
+	}
+	return result
+}
@@ -0,0 +1,209 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
+	"golang.org/x/mod/modfile"
+)
+
+const (
+	SPDX_VERSION      = "SPDX-2.3"
+	CYCLONEDX_VERSION = "1.5"
+
+	// TOOL is the tool name used to describe the creator of generated documents.
+	TOOL = "ocm"
+
+	// CREATED is the creation timestamp used for generated SPDX documents.
+	// A fixed value is used to keep the generation reproducible.
+	CREATED = "1970-01-01T00:00:00Z"
+
+	// SPDX_NAMESPACE is the prefix used for the document namespace of
+	// generated SPDX documents.
+	SPDX_NAMESPACE = "https://ocm.software/spdx/"
+)
+
+// MimeTypeForFormat provides the media type used for an SBOM format.
+// For unknown formats an empty string is returned.
+func MimeTypeForFormat(format string) string {
+	switch format {
+	case FORMAT_SPDX:
+		return MIME_SPDX_JSON
+	case FORMAT_CYCLONEDX:
+		return MIME_CYCLONEDX_JSON
+	}
+	return ""
+}
+
+// Generate creates a JSON SBOM document in the given format for
+// the format independent document description.
+func Generate(doc *Document, format string) ([]byte, error) {
+	switch format {
+	case FORMAT_SPDX:
+		return generateSPDX(doc)
+	case FORMAT_CYCLONEDX:
+		return generateCycloneDX(doc)
+	default:
+		return nil, errors.ErrNotSupported(KIND_SBOM_FORMAT, format)
+	}
+}
+
+// FromGoModule creates a document for the requirements of a Go module
+// described by the content of a go.mod file. The package URLs use
+// the package type golang.
+func FromGoModule(data []byte) (*Document, error) {
+	f, err := modfile.ParseLax("go.mod", data, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid go module file")
+	}
+	doc := &Document{}
+	if f.Module != nil {
+		doc.Name = f.Module.Mod.Path
+	}
+	for _, r := range f.Require {
+		doc.Packages = append(doc.Packages, Package{
+			Name:    r.Mod.Path,
+			Version: r.Mod.Version,
+			PURL:    "pkg:golang/" + r.Mod.Path + "@" + r.Mod.Version,
+		})
+	}
+	return doc, nil
+}
+
+type spdxOutDocument struct {
+	SPDXVersion       string           `json:"spdxVersion"`
+	DataLicense       string           `json:"dataLicense"`
+	SPDXID            string           `json:"SPDXID"`
+	Name              string           `json:"name"`
+	DocumentNamespace string           `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo `json:"creationInfo"`
+	Packages          []spdxOutPackage `json:"packages"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxOutPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+func generateSPDX(doc *Document) ([]byte, error) {
+	ns, err := spdxNamespace(doc)
+	if err != nil {
+		return nil, err
+	}
+	out := &spdxOutDocument{
+		SPDXVersion:       SPDX_VERSION,
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              doc.Name,
+		DocumentNamespace: ns,
+		CreationInfo: spdxCreationInfo{
+			Created:  CREATED,
+			Creators: []string{"Tool: " + TOOL},
+		},
+		Packages: []spdxOutPackage{},
+	}
+	for i, p := range doc.Packages {
+		license := "NOASSERTION"
+		if len(p.Licenses) > 0 {
+			license = p.Licenses[0]
+		}
+		pkg := spdxOutPackage{
+			SPDXID:           spdxID(i),
+			Name:             p.Name,
+			VersionInfo:      p.Version,
+			DownloadLocation: "NOASSERTION",
+			LicenseConcluded: license,
+			LicenseDeclared:  license,
+		}
+		if p.PURL != "" {
+			pkg.ExternalRefs = []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  p.PURL,
+			}}
+		}
+		out.Packages = append(out.Packages, pkg)
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
+
+// spdxNamespace provides a unique document namespace derived from
+// the digest of the described content.
+func spdxNamespace(doc *Document) (string, error) {
+	content := *doc
+	content.Format = ""
+	data, err := json.Marshal(&content)
+	if err != nil {
+		return "", err
+	}
+	ns := SPDX_NAMESPACE
+	if doc.Name != "" {
+		ns += doc.Name + "-"
+	}
+	return ns + digest.FromBytes(data).Encoded(), nil
+}
+
+func spdxID(i int) string {
+	return fmt.Sprintf("SPDXRef-Package-%d", i+1)
+}
+
+type cdxOutDocument struct {
+	BOMFormat   string            `json:"bomFormat"`
+	SpecVersion string            `json:"specVersion"`
+	Version     int               `json:"version"`
+	Metadata    cdxOutMetadata    `json:"metadata"`
+	Components  []cdxOutComponent `json:"components"`
+}
+
+type cdxOutMetadata struct {
+	Component *cdxOutComponent `json:"component,omitempty"`
+}
+
+type cdxOutComponent struct {
+	Type     string          `json:"type"`
+	Name     string          `json:"name"`
+	Version  string          `json:"version,omitempty"`
+	PURL     string          `json:"purl,omitempty"`
+	Licenses []cdxOutLicense `json:"licenses,omitempty"`
+}
+
+type cdxOutLicense struct {
+	Expression string `json:"expression"`
+}
+
+func generateCycloneDX(doc *Document) ([]byte, error) {
+	out := &cdxOutDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: CYCLONEDX_VERSION,
+		Version:     1,
+		Components:  []cdxOutComponent{},
+	}
+	if doc.Name != "" {
+		out.Metadata.Component = &cdxOutComponent{Type: "application", Name: doc.Name}
+	}
+	for _, p := range doc.Packages {
+		c := cdxOutComponent{
+			Type:    "library",
+			Name:    p.Name,
+			Version: p.Version,
+			PURL:    p.PURL,
+		}
+		for _, l := range p.Licenses {
+			c.Licenses = append(c.Licenses, cdxOutLicense{Expression: l})
+		}
+		out.Components = append(out.Components, c)
+	}
+	return json.MarshalIndent(out, "", "  ")
+}
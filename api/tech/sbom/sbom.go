@@ -0,0 +1,146 @@
+package sbom
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+)
+
+const (
+	FORMAT_SPDX      = "spdx"
+	FORMAT_CYCLONEDX = "cyclonedx"
+
+	MIME_SPDX_JSON      = "application/spdx+json"
+	MIME_CYCLONEDX_JSON = "application/vnd.cyclonedx+json"
+
+	KIND_SBOM_FORMAT = "SBOM format"
+)
+
+// Document is the format independent representation of a software
+// bill of materials.
+type Document struct {
+	Format   string    `json:"format"`
+	Name     string    `json:"name,omitempty"`
+	Packages []Package `json:"packages,omitempty"`
+}
+
+// Package describes a software package listed in an SBOM.
+type Package struct {
+	Name     string   `json:"name"`
+	Version  string   `json:"version,omitempty"`
+	PURL     string   `json:"purl,omitempty"`
+	Licenses []string `json:"licenses,omitempty"`
+}
+
+// Matches checks whether the package matches a name pattern and a version
+// prefix. The name pattern may contain shell wildcards and is matched
+// against the package name (with and without a group prefix separated by
+// a colon) and the name part of the package URL. A version
+// prefix matches complete version segments, only (2.14 matches 2.14 and 2.14.1,
+// but not 2.141). Empty values always match.
+func (p *Package) Matches(name, version string) bool {
+	if name != "" && !p.matchName(name) {
+		return false
+	}
+	if version != "" && p.Version != version && !strings.HasPrefix(p.Version, version+".") {
+		return false
+	}
+	return true
+}
+
+// Find provides all packages matching the given name pattern and version
+// prefix.
+func (d *Document) Find(name, version string) []Package {
+	var result []Package
+	for _, p := range d.Packages {
+		if p.Matches(name, version) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// FormatForMimeType provides the SBOM format for a media type.
+// If the media type does not indicate a known format, an empty string is
+// returned.
+func FormatForMimeType(mime string) string {
+	mime, _, _ = strings.Cut(mime, ";")
+	switch strings.TrimSpace(mime) {
+	case MIME_SPDX_JSON:
+		return FORMAT_SPDX
+	case MIME_CYCLONEDX_JSON:
+		return FORMAT_CYCLONEDX
+	}
+	return ""
+}
+
+// DetectFormat determines the format of a JSON SBOM document.
+func DetectFormat(data []byte) string {
+	var probe struct {
+		SPDXVersion string `json:"spdxVersion"`
+		BOMFormat   string `json:"bomFormat"`
+	}
+	if json.Unmarshal(data, &probe) != nil {
+		return ""
+	}
+	if probe.SPDXVersion != "" {
+		return FORMAT_SPDX
+	}
+	if probe.BOMFormat == "CycloneDX" {
+		return FORMAT_CYCLONEDX
+	}
+	return ""
+}
+
+// Parse parses a JSON SBOM document. The format is derived from
+// the given media type. If it does not indicate a known format, the format
+// is detected from the document content.
+func Parse(data []byte, mime string) (*Document, error) {
+	format := FormatForMimeType(mime)
+	if format == "" {
+		format = DetectFormat(data)
+	}
+	switch format {
+	case FORMAT_SPDX:
+		return parseSPDX(data)
+	case FORMAT_CYCLONEDX:
+		return parseCycloneDX(data)
+	case "":
+		return nil, errors.Newf("unknown SBOM format")
+	default:
+		return nil, errors.ErrNotSupported(KIND_SBOM_FORMAT, format)
+	}
+}
+
+func (p *Package) matchName(pattern string) bool {
+	names := []string{p.Name, purlName(p.PURL)}
+	if idx := strings.LastIndex(p.Name, ":"); idx >= 0 {
+		names = append(names, p.Name[idx+1:])
+	}
+	for _, n := range names {
+		if n == "" {
+			continue
+		}
+		if ok, err := path.Match(pattern, n); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// purlName extracts the name part of a package URL
+// (pkg:type/namespace/name@version?qualifiers#subpath).
+func purlName(purl string) string {
+	if !strings.HasPrefix(purl, "pkg:") {
+		return ""
+	}
+	purl, _, _ = strings.Cut(purl, "#")
+	purl, _, _ = strings.Cut(purl, "?")
+	purl, _, _ = strings.Cut(purl, "@")
+	if idx := strings.LastIndex(purl, "/"); idx >= 0 {
+		return purl[idx+1:]
+	}
+	return ""
+}
@@ -0,0 +1,124 @@
+package sbom_test
+
+import (
+	"encoding/json"
+	"os"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/tech/sbom"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+var _ = Describe("SBOM parsing", func() {
+	It("parses SPDX", func() {
+		data := Must(os.ReadFile("testdata/spdx.json"))
+		Expect(sbom.DetectFormat(data)).To(Equal(sbom.FORMAT_SPDX))
+
+		doc := Must(sbom.Parse(data, sbom.MIME_SPDX_JSON))
+		Expect(doc).To(Equal(&sbom.Document{
+			Format: sbom.FORMAT_SPDX,
+			Name:   "acme-app",
+			Packages: []sbom.Package{
+				{Name: "log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", Licenses: []string{"Apache-2.0"}},
+				{Name: "commons-lang3", Version: "3.12.0", Licenses: []string{"Apache-2.0"}},
+			},
+		}))
+	})
+
+	It("parses CycloneDX", func() {
+		data := Must(os.ReadFile("testdata/cyclonedx.json"))
+		Expect(sbom.DetectFormat(data)).To(Equal(sbom.FORMAT_CYCLONEDX))
+
+		doc := Must(sbom.Parse(data, mime.MIME_JSON))
+		Expect(doc).To(Equal(&sbom.Document{
+			Format: sbom.FORMAT_CYCLONEDX,
+			Name:   "acme-service",
+			Packages: []sbom.Package{
+				{Name: "org.apache.logging.log4j:log4j-api", Version: "2.141.0", Licenses: []string{"Apache-2.0"}},
+				{Name: "nested", Version: "1.0.0", PURL: "pkg:generic/nested@1.0.0"},
+				{Name: "golang.org/x/net", Version: "0.23.0", PURL: "pkg:golang/golang.org/x/net@0.23.0", Licenses: []string{"BSD-3-Clause"}},
+			},
+		}))
+	})
+
+	It("rejects unknown format", func() {
+		ExpectError(sbom.Parse([]byte(`{"some": "json"}`), mime.MIME_JSON)).To(MatchError("unknown SBOM format"))
+	})
+
+	It("matches packages", func() {
+		spdx := Must(sbom.Parse(Must(os.ReadFile("testdata/spdx.json")), ""))
+		cdx := Must(sbom.Parse(Must(os.ReadFile("testdata/cyclonedx.json")), ""))
+
+		Expect(spdx.Find("log4j*", "2.14")).To(HaveLen(1))
+		Expect(cdx.Find("log4j*", "2.14")).To(HaveLen(0))
+		Expect(cdx.Find("log4j-api", "2.141")).To(HaveLen(1))
+		Expect(cdx.Find("net", "")).To(HaveLen(1))
+		Expect(cdx.Find("", "")).To(HaveLen(3))
+	})
+
+	Context("generation", func() {
+		doc := &sbom.Document{
+			Name: "acme-app",
+			Packages: []sbom.Package{
+				{Name: "log4j-core", Version: "2.14.1", PURL: "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1", Licenses: []string{"Apache-2.0"}},
+				{Name: "commons-lang3", Version: "3.12.0"},
+			},
+		}
+
+		DescribeTable("generates parsable documents", func(format string) {
+			data := Must(sbom.Generate(doc, format))
+			Expect(sbom.DetectFormat(data)).To(Equal(format))
+
+			exp := *doc
+			exp.Format = format
+			Expect(sbom.Parse(data, sbom.MimeTypeForFormat(format))).To(Equal(&exp))
+		},
+			Entry("spdx", sbom.FORMAT_SPDX),
+			Entry("cyclonedx", sbom.FORMAT_CYCLONEDX),
+		)
+
+		It("generates reproducible spdx documents", func() {
+			data := Must(sbom.Generate(doc, sbom.FORMAT_SPDX))
+			Expect(sbom.Generate(doc, sbom.FORMAT_SPDX)).To(Equal(data))
+
+			var out map[string]interface{}
+			MustBeSuccessful(json.Unmarshal(data, &out))
+			Expect(out["creationInfo"]).To(HaveKeyWithValue("created", sbom.CREATED))
+			ns := out["documentNamespace"]
+			Expect(ns).To(HavePrefix(sbom.SPDX_NAMESPACE + "acme-app-"))
+
+			other := *doc
+			other.Packages = doc.Packages[:1]
+			data = Must(sbom.Generate(&other, sbom.FORMAT_SPDX))
+			MustBeSuccessful(json.Unmarshal(data, &out))
+			Expect(out["documentNamespace"]).NotTo(Equal(ns))
+		})
+
+		It("rejects unknown format", func() {
+			ExpectError(sbom.Generate(doc, "other")).To(MatchError(`SBOM format "other" not supported`))
+		})
+
+		It("describes go modules", func() {
+			doc := Must(sbom.FromGoModule([]byte(`
+module acme.org/app
+
+go 1.23
+
+require (
+	golang.org/x/net v0.23.0
+	github.com/mandelsoft/goutils v0.0.1 // indirect
+)
+`)))
+			Expect(doc).To(Equal(&sbom.Document{
+				Name: "acme.org/app",
+				Packages: []sbom.Package{
+					{Name: "golang.org/x/net", Version: "v0.23.0", PURL: "pkg:golang/golang.org/x/net@v0.23.0"},
+					{Name: "github.com/mandelsoft/goutils", Version: "v0.0.1", PURL: "pkg:golang/github.com/mandelsoft/goutils@v0.0.1"},
+				},
+			}))
+		})
+	})
+})
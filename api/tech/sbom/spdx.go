@@ -0,0 +1,59 @@
+package sbom
+
+import (
+	"encoding/json"
+
+	"github.com/mandelsoft/goutils/errors"
+)
+
+type spdxDocument struct {
+	SPDXVersion string        `json:"spdxVersion"`
+	Name        string        `json:"name"`
+	Packages    []spdxPackage `json:"packages"`
+}
+
+type spdxPackage struct {
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+func parseSPDX(data []byte) (*Document, error) {
+	var doc spdxDocument
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid SPDX document")
+	}
+	result := &Document{
+		Format: FORMAT_SPDX,
+		Name:   doc.Name,
+	}
+	for _, p := range doc.Packages {
+		pkg := Package{
+			Name:    p.Name,
+			Version: p.VersionInfo,
+		}
+		for _, r := range p.ExternalRefs {
+			if r.ReferenceType == "purl" {
+				pkg.PURL = r.ReferenceLocator
+				break
+			}
+		}
+		for _, l := range []string{p.LicenseConcluded, p.LicenseDeclared} {
+			if l != "" && l != "NOASSERTION" && l != "NONE" {
+				pkg.Licenses = append(pkg.Licenses, l)
+				break
+			}
+		}
+		result.Packages = append(result.Packages, pkg)
+	}
+	return result, nil
+}
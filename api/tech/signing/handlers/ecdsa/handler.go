@@ -0,0 +1,79 @@
+package ecdsa
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/internal/keyhandler"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+// Algorithm defines the type for the ECDSA signature algorithm using the NIST P-256 curve.
+const Algorithm = "ECDSA-P256"
+
+// AlgorithmP384 defines the type for the ECDSA signature algorithm using the NIST P-384 curve.
+const AlgorithmP384 = "ECDSA-P384"
+
+// MediaType defines the media type for a plain ASN.1 encoded ECDSA signature.
+const MediaType = "application/vnd.ocm.signature.ecdsa"
+
+// MediaTypePEM is used if the signature contains the public key certificate chain.
+const MediaTypePEM = signutils.MediaTypePEM
+
+func init() {
+	signing.DefaultHandlerRegistry().RegisterSigner(Algorithm, NewHandler())
+	signing.DefaultHandlerRegistry().RegisterSigner(AlgorithmP384, NewHandlerFor(elliptic.P384()))
+}
+
+type (
+	PrivateKey = ecdsa.PrivateKey
+	PublicKey  = ecdsa.PublicKey
+)
+
+// Handler is a signatures.Signer compatible struct to sign with ECDSA
+// and a signatures.Verifier compatible struct to verify ECDSA signatures.
+type Handler = keyhandler.Handler[*PrivateKey, *PublicKey]
+
+// NewHandler creates a handler for ECDSA signatures based on the P-256 curve.
+func NewHandler() *Handler {
+	return NewHandlerFor(elliptic.P256())
+}
+
+// NewHandlerFor creates a handler for ECDSA signatures based on the given
+// curve. Supported curves are P-256 and P-384.
+func NewHandlerFor(curve elliptic.Curve) *Handler {
+	algo := Algorithm
+	if curve == elliptic.P384() {
+		algo = AlgorithmP384
+	}
+	return keyhandler.New(&keyhandler.Method[*PrivateKey, *PublicKey]{
+		Algorithm: algo,
+		MediaType: MediaType,
+		CheckKey: func(pub *PublicKey) error {
+			if pub.Curve != curve {
+				return fmt.Errorf("ecdsa key with curve %s does not match algorithm %s", pub.Curve.Params().Name, algo)
+			}
+			return nil
+		},
+		Sign: func(priv *PrivateKey, hash crypto.Hash, digest []byte) ([]byte, error) {
+			return ecdsa.SignASN1(rand.Reader, priv, digest)
+		},
+		Verify: func(pub *PublicKey, hash crypto.Hash, digest []byte, sig []byte) error {
+			if !ecdsa.VerifyASN1(pub, digest, sig) {
+				return fmt.Errorf("invalid ecdsa signature")
+			}
+			return nil
+		},
+		CreateKeyPair: func() (*PrivateKey, *PublicKey, error) {
+			key, err := ecdsa.GenerateKey(curve, rand.Reader)
+			if err != nil {
+				return nil, nil, err
+			}
+			return key, &key.PublicKey, nil
+		},
+	})
+}
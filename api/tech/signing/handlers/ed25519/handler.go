@@ -0,0 +1,55 @@
+package ed25519
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/internal/keyhandler"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+// Algorithm defines the type for the Ed25519 signature algorithm.
+const Algorithm = "Ed25519"
+
+// MediaType defines the media type for a plain Ed25519 signature.
+const MediaType = "application/vnd.ocm.signature.ed25519"
+
+// MediaTypePEM is used if the signature contains the public key certificate chain.
+const MediaTypePEM = signutils.MediaTypePEM
+
+func init() {
+	signing.DefaultHandlerRegistry().RegisterSigner(Algorithm, NewHandler())
+}
+
+type (
+	PrivateKey = ed25519.PrivateKey
+	PublicKey  = ed25519.PublicKey
+)
+
+// Handler is a signatures.Signer compatible struct to sign with Ed25519
+// and a signatures.Verifier compatible struct to verify Ed25519 signatures.
+// The digest of the signed content is signed as message (pure Ed25519).
+type Handler = keyhandler.Handler[PrivateKey, PublicKey]
+
+func NewHandler() *Handler {
+	return keyhandler.New(&keyhandler.Method[PrivateKey, PublicKey]{
+		Algorithm: Algorithm,
+		MediaType: MediaType,
+		Sign: func(priv PrivateKey, hash crypto.Hash, digest []byte) ([]byte, error) {
+			return ed25519.Sign(priv, digest), nil
+		},
+		Verify: func(pub PublicKey, hash crypto.Hash, digest []byte, sig []byte) error {
+			if !ed25519.Verify(pub, digest, sig) {
+				return fmt.Errorf("invalid ed25519 signature")
+			}
+			return nil
+		},
+		CreateKeyPair: func() (PrivateKey, PublicKey, error) {
+			pub, priv, err := ed25519.GenerateKey(rand.Reader)
+			return priv, pub, err
+		},
+	})
+}
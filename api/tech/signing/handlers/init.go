@@ -2,9 +2,12 @@ package handlers
 
 import (
 	_ "github.com/sigstore/cosign/v2/pkg/providers/all"
+	_ "ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	_ "ocm.software/ocm/api/tech/signing/handlers/ed25519"
 	_ "ocm.software/ocm/api/tech/signing/handlers/rsa"
 	_ "ocm.software/ocm/api/tech/signing/handlers/rsa-pss"
 	_ "ocm.software/ocm/api/tech/signing/handlers/rsa-pss-signingservice"
 	_ "ocm.software/ocm/api/tech/signing/handlers/rsa-signingservice"
+	_ "ocm.software/ocm/api/tech/signing/handlers/signingagent"
 	_ "ocm.software/ocm/api/tech/signing/handlers/sigstore"
 )
@@ -0,0 +1,216 @@
+package keyhandler
+
+import (
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+// PublicKey is the common interface of all public key
+// implementations of the crypto packages.
+type PublicKey interface {
+	Equal(x crypto.PublicKey) bool
+}
+
+// Method describes a signature algorithm for a dedicated type of
+// private and public keys.
+type Method[PRIV crypto.Signer, PUB PublicKey] struct {
+	Algorithm string
+	MediaType string
+	// CheckKey optionally validates the parameters of a public key.
+	CheckKey func(pub PUB) error
+	Sign     func(priv PRIV, hash crypto.Hash, digest []byte) ([]byte, error)
+	Verify   func(pub PUB, hash crypto.Hash, digest []byte, sig []byte) error
+	// CreateKeyPair creates a new key pair suitable for the method.
+	CreateKeyPair func() (PRIV, PUB, error)
+}
+
+// Handler is a signing.SignatureHandler for a signature Method.
+// Signatures are either provided as plain hex encoded signature
+// or as PEM document including the certificate chain of the public
+// key, if the public key is given by a certificate.
+type Handler[PRIV crypto.Signer, PUB PublicKey] struct {
+	method *Method[PRIV, PUB]
+}
+
+var (
+	_ signing.SignatureHandler = (*Handler[crypto.Signer, PublicKey])(nil)
+	_ signing.KeyPairCreator   = (*Handler[crypto.Signer, PublicKey])(nil)
+)
+
+func New[PRIV crypto.Signer, PUB PublicKey](m *Method[PRIV, PUB]) *Handler[PRIV, PUB] {
+	return &Handler[PRIV, PUB]{method: m}
+}
+
+func (h *Handler[PRIV, PUB]) Algorithm() string {
+	return h.method.Algorithm
+}
+
+func (h *Handler[PRIV, PUB]) Sign(cctx credentials.Context, digest string, sctx signing.SigningContext) (signature *signing.Signature, err error) {
+	privateKey, err := h.GetPrivateKey(sctx.GetPrivateKey())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s private key", h.method.Algorithm)
+	}
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed decoding hash to bytes")
+	}
+	sig, err := h.method.Sign(privateKey, sctx.GetHash(), decodedHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed signing hash, %w", err)
+	}
+
+	media := h.method.MediaType
+	value := hex.EncodeToString(sig)
+
+	var iss string
+	pub := sctx.GetPublicKey()
+	if pub != nil {
+		var pubKey PUB
+		certs, err := signutils.GetCertificateChain(pub, false)
+		if err == nil && len(certs) > 0 {
+			pubKey, _, err = h.GetPublicKey(certs[0].PublicKey)
+			if err != nil {
+				return nil, errors.ErrInvalidWrap(err, "public key")
+			}
+			err = signutils.VerifyCertificate(certs[0], certs[1:], sctx.GetRootCerts(), sctx.GetIssuer())
+			if err != nil {
+				return nil, errors.Wrapf(err, "public key certificate")
+			}
+			media = signutils.MediaTypePEM
+			value = string(signutils.SignatureBytesToPem(h.Algorithm(), sig, certs...))
+			iss = certs[0].Subject.String()
+		} else {
+			pubKey, _, err = h.GetPublicKey(pub)
+			if err != nil {
+				return nil, errors.ErrInvalidWrap(err, "public key")
+			}
+		}
+		if !pubKey.Equal(privateKey.Public()) {
+			return nil, fmt.Errorf("invalid public key for private key")
+		}
+	}
+
+	return &signing.Signature{
+		Value:     value,
+		MediaType: media,
+		Algorithm: h.Algorithm(),
+		Issuer:    iss,
+	}, nil
+}
+
+// Verify checks the signature, returns an error on verification failure.
+func (h *Handler[PRIV, PUB]) Verify(digest string, signature *signing.Signature, sctx signing.SigningContext) (err error) {
+	var signatureBytes []byte
+
+	publicKey, name, err := h.GetPublicKey(sctx.GetPublicKey())
+	if err != nil {
+		return fmt.Errorf("failed to get public key: %w", err)
+	}
+
+	switch signature.MediaType {
+	case h.method.MediaType:
+		signatureBytes, err = hex.DecodeString(signature.Value)
+		if err != nil {
+			return fmt.Errorf("unable to get signature value: failed decoding hash %s: %w", digest, err)
+		}
+	case signutils.MediaTypePEM:
+		sig, algo, _, err := signutils.GetSignatureFromPem([]byte(signature.Value))
+		if err != nil {
+			return fmt.Errorf("unable to get signature from pem: %w", err)
+		}
+		if algo != "" && algo != h.Algorithm() {
+			return errors.ErrInvalid(signutils.KIND_SIGN_ALGORITHM, algo)
+		}
+		signatureBytes = sig
+	default:
+		return fmt.Errorf("invalid signature mediaType %s", signature.MediaType)
+	}
+
+	decodedHash, err := hex.DecodeString(digest)
+	if err != nil {
+		return fmt.Errorf("failed decoding hash %s: %w", digest, err)
+	}
+
+	if name != nil {
+		if signature.Issuer != "" {
+			iss, err := signutils.ParseDN(signature.Issuer)
+			if err != nil {
+				return errors.Wrapf(err, "signature issuer")
+			}
+			if signutils.MatchDN(*iss, *name) != nil {
+				return fmt.Errorf("issuer %s does not match %s", signature.Issuer, name)
+			}
+		}
+	}
+	if err := h.method.Verify(publicKey, sctx.GetHash(), decodedHash, signatureBytes); err != nil {
+		return fmt.Errorf("signature verification failed, %w", err)
+	}
+
+	return nil
+}
+
+// GetPrivateKey maps a generic private key to the key type of the method.
+func (h *Handler[PRIV, PUB]) GetPrivateKey(key signutils.GenericPrivateKey) (PRIV, error) {
+	var _nil PRIV
+
+	k, err := signutils.GetPrivateKey(key)
+	if err != nil {
+		return _nil, err
+	}
+	priv, ok := k.(PRIV)
+	if !ok {
+		return _nil, fmt.Errorf("unexpected private key type %T for %s", k, h.method.Algorithm)
+	}
+	if h.method.CheckKey != nil {
+		if pub, ok := priv.Public().(PUB); ok {
+			err = h.method.CheckKey(pub)
+		}
+	}
+	return priv, err
+}
+
+// GetPublicKey maps a generic public key, private key or certificate to the
+// public key type of the method. For certificates, additionally the subject
+// is returned.
+func (h *Handler[PRIV, PUB]) GetPublicKey(key signutils.GenericPublicKey) (PUB, *pkix.Name, error) {
+	var _nil PUB
+	var name *pkix.Name
+
+	if data, ok := key.([]byte); ok {
+		if cert, err := signutils.ParseCertificate(data); err == nil {
+			key = cert
+		}
+	}
+	switch k := key.(type) {
+	case *x509.Certificate:
+		name = &k.Subject
+		key = k.PublicKey
+	case PRIV:
+		key = k.Public()
+	}
+	k, err := signutils.GetPublicKey(key)
+	if err != nil {
+		return _nil, nil, err
+	}
+	pub, ok := k.(PUB)
+	if !ok {
+		return _nil, nil, fmt.Errorf("unexpected public key type %T for %s", k, h.method.Algorithm)
+	}
+	if h.method.CheckKey != nil {
+		err = h.method.CheckKey(pub)
+	}
+	return pub, name, err
+}
+
+func (h *Handler[PRIV, PUB]) CreateKeyPair() (priv signutils.GenericPrivateKey, pub signutils.GenericPublicKey, err error) {
+	return h.method.CreateKeyPair()
+}
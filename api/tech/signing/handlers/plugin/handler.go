@@ -0,0 +1,199 @@
+package plugin
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/ocm/plugin"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/signutils"
+	"ocm.software/ocm/api/utils/blobaccess"
+)
+
+// ID_ALGORITHM is the consumer id attribute used to pass the
+// signing algorithm for a credential request.
+const ID_ALGORITHM = "algorithm"
+
+// Handler is a signing.SignatureHandler delegating
+// the signing and verification to a plugin.
+type Handler struct {
+	plugin plugin.Plugin
+	name   string
+}
+
+var _ signing.SignatureHandler = (*Handler)(nil)
+
+func New(p plugin.Plugin, name string) (signing.SignatureHandler, error) {
+	if p.GetSigningHandlerDescriptor(name) == nil {
+		return nil, errors.ErrUnknown(plugin.KIND_SIGNINGHANDLER, name, plugin.KIND_PLUGIN, p.Name())
+	}
+	return &Handler{
+		plugin: p,
+		name:   name,
+	}, nil
+}
+
+func (h *Handler) Algorithm() string {
+	return h.name
+}
+
+func (h *Handler) Sign(cctx credentials.Context, digest string, sctx signing.SigningContext) (*signing.Signature, error) {
+	var err error
+
+	req := &plugin.SigningRequest{
+		Digest: digest,
+	}
+	if sctx.GetHash() != 0 {
+		req.Hash = sctx.GetHash().String()
+	}
+	if sctx.GetIssuer() != nil {
+		req.Issuer = sctx.GetIssuer().String()
+	}
+	req.PrivateKey, err = privateKeyData(sctx.GetPrivateKey())
+	if err != nil {
+		return nil, err
+	}
+	req.PublicKey, err = publicKeyData(sctx.GetPublicKey())
+	if err != nil {
+		return nil, err
+	}
+	req.RootCerts, err = certPoolData(sctx)
+	if err != nil {
+		return nil, err
+	}
+
+	desc := h.plugin.GetSigningHandlerDescriptor(h.name)
+	if desc.ConsumerType != "" && cctx != nil {
+		id := credentials.NewConsumerIdentity(desc.ConsumerType, ID_ALGORITHM, h.name)
+		creds, err := credentials.CredentialsForConsumer(cctx, id, credentials.PartialMatch)
+		if err != nil {
+			return nil, err
+		}
+		if creds != nil {
+			req.Credentials = credentials.DirectCredentials(creds.Properties())
+		}
+	}
+
+	sig, err := h.plugin.Sign(h.name, req)
+	if err != nil {
+		return nil, err
+	}
+	if sig.Algorithm == "" {
+		sig.Algorithm = h.name
+	}
+	return &signing.Signature{
+		Value:     sig.Value,
+		MediaType: sig.MediaType,
+		Algorithm: sig.Algorithm,
+		Issuer:    sig.Issuer,
+	}, nil
+}
+
+func (h *Handler) Verify(digest string, sig *signing.Signature, sctx signing.SigningContext) error {
+	var err error
+
+	req := &plugin.VerificationRequest{
+		Digest: digest,
+		Signature: plugin.Signature{
+			Value:     sig.Value,
+			MediaType: sig.MediaType,
+			Algorithm: sig.Algorithm,
+			Issuer:    sig.Issuer,
+		},
+	}
+	if sctx.GetHash() != 0 {
+		req.Hash = sctx.GetHash().String()
+	}
+	if sctx.GetIssuer() != nil {
+		req.Issuer = sctx.GetIssuer().String()
+	}
+	req.PublicKey, err = publicKeyData(sctx.GetPublicKey())
+	if err != nil {
+		return err
+	}
+	req.RootCerts, err = certPoolData(sctx)
+	if err != nil {
+		return err
+	}
+	return h.plugin.Verify(h.name, req)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// genericData provides the data for keys given as data source.
+// Other key types are reported with found=false.
+func genericData(in interface{}) (data []byte, found bool, err error) {
+	if in == nil {
+		return nil, true, nil
+	}
+	data, err = blobaccess.GetData(in)
+	if err != nil {
+		if errors.IsErrInvalidKind(err, blobaccess.KIND_DATASOURCE) {
+			return nil, false, nil
+		}
+		return nil, true, err
+	}
+	return data, true, nil
+}
+
+func privateKeyData(key signutils.GenericPrivateKey) ([]byte, error) {
+	data, ok, err := genericData(key)
+	if ok || err != nil {
+		return data, err
+	}
+	priv, err := signutils.GetPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	block := signutils.PemBlockForPrivateKey(priv)
+	if block == nil {
+		return nil, errors.ErrInvalidType(signutils.KIND_PRIVATE_KEY, priv)
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+func publicKeyData(key signutils.GenericPublicKey) ([]byte, error) {
+	data, ok, err := genericData(key)
+	if ok || err != nil {
+		return data, err
+	}
+	switch k := key.(type) {
+	case *x509.Certificate:
+		return signutils.CertificateToPem(k), nil
+	case []*x509.Certificate:
+		return signutils.CertificateChainToPem(k), nil
+	}
+	pub, err := signutils.GetPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	block := signutils.PemBlockForPublicKey(pub)
+	if block == nil {
+		return nil, errors.ErrInvalidType(signutils.KIND_PUBLIC_KEY, pub)
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+func certPoolData(sctx signing.SigningContext) ([]byte, error) {
+	if p, ok := sctx.(signing.RootCertificatesProvider); ok {
+		if certs := p.GetRootCertificates(); len(certs) > 0 {
+			return signutils.CertificateChainToPem(certs), nil
+		}
+	}
+	pool := sctx.GetRootCerts()
+	data, ok, err := genericData(pool)
+	if ok || err != nil {
+		return data, err
+	}
+	if _, ok := pool.(*x509.CertPool); ok {
+		return nil, errors.Newf("root certificates cannot be extracted from a certificate pool")
+	}
+	certs, err := signutils.GetCertificateChain(pool, false)
+	if err != nil {
+		return nil, err
+	}
+	return signutils.CertificateChainToPem(certs), nil
+}
@@ -0,0 +1,165 @@
+//go:build unix
+
+package plugin_test
+
+import (
+	"crypto"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"time"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/ocm/plugin/testutils"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/datacontext/attrs/rootcertsattr"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/extensions/attrs/plugincacheattr"
+	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
+	"ocm.software/ocm/api/ocm/extensions/repositories/composition"
+	"ocm.software/ocm/api/ocm/plugin/registration"
+	signingtool "ocm.software/ocm/api/ocm/tools/signing"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/plugin"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+)
+
+const ALGORITHM = "test-kms"
+
+var _ = Describe("plugin signing handler", func() {
+	var ctx ocm.Context
+	var plugins TempPluginDir
+
+	BeforeEach(func() {
+		ctx = ocm.New()
+		plugins = Must(ConfigureTestPlugins(ctx, "testdata"))
+		MustBeSuccessful(registration.RegisterExtensions(ctx))
+	})
+
+	AfterEach(func() {
+		plugins.Cleanup()
+	})
+
+	It("registers signing handler for context", func() {
+		Expect(signingattr.Get(ctx).GetSigner(ALGORITHM)).NotTo(BeNil())
+		Expect(signingattr.Get(ctx).GetVerifier(ALGORITHM)).NotTo(BeNil())
+		Expect(signingattr.Get(ctx).SignerNames()).To(ContainElement(ALGORITHM))
+
+		Expect(signing.DefaultRegistry().GetSigner(ALGORITHM)).To(BeNil())
+	})
+
+	It("signs and verifies", func() {
+		sctx := &signing.DefaultSigningContext{
+			Hash:       crypto.SHA256,
+			PrivateKey: []byte("key"),
+			PublicKey:  []byte("key"),
+		}
+		h := signingattr.Get(ctx).GetSigner(ALGORITHM)
+		sig := Must(h.Sign(ctx.CredentialsContext(), "0815", sctx))
+		Expect(sig).To(Equal(&signing.Signature{
+			Value:     "key:0815",
+			MediaType: "application/vnd.test.signature",
+			Algorithm: ALGORITHM,
+		}))
+
+		v := signingattr.Get(ctx).GetVerifier(sig.Algorithm)
+		MustBeSuccessful(v.Verify("0815", sig, sctx))
+		ExpectError(v.Verify("4711", sig, sctx)).To(MatchError(ContainSubstring("signature invalid")))
+	})
+
+	It("passes credentials", func() {
+		ctx.CredentialsContext().SetCredentialsForConsumer(
+			credentials.NewConsumerIdentity("TestKMS"),
+			credentials.DirectCredentials{"token": "secret"},
+		)
+		sctx := &signing.DefaultSigningContext{
+			PrivateKey: "key",
+		}
+		h := signingattr.Get(ctx).GetSigner(ALGORITHM)
+		sig := Must(h.Sign(ctx.CredentialsContext(), "0815", sctx))
+		Expect(sig.Value).To(Equal("key:0815:auth"))
+	})
+
+	It("signs component versions", func() {
+		cv := composition.NewComponentVersion(ctx, "acme.org/test", "v1")
+		defer Close(cv)
+
+		digest := Must(signingtool.SignComponentVersion(cv, "kms",
+			signingtool.SignerByAlgo(ALGORITHM),
+			signingtool.PrivateKey("kms", []byte("key")),
+		))
+		sigs := cv.GetDescriptor().Signatures
+		Expect(len(sigs)).To(Equal(1))
+		Expect(sigs[0].Signature.Algorithm).To(Equal(ALGORITHM))
+		Expect(sigs[0].Signature.Value).To(Equal("key:" + digest.Value))
+
+		Must(signingtool.VerifyComponentVersion(cv, "kms",
+			signingtool.PublicKey("kms", []byte("key")),
+		))
+		ExpectError(signingtool.VerifyComponentVersion(cv, "kms",
+			signingtool.PublicKey("kms", []byte("other")),
+		)).To(MatchError(ContainSubstring("signature invalid")))
+	})
+
+	Context("root certificates", func() {
+		var ca *x509.Certificate
+
+		BeforeEach(func() {
+			ca, _ = Must2(rsa.CreateRootCertificate(&pkix.Name{CommonName: "ca-authority"}, 10*time.Hour))
+		})
+
+		It("passes root certificates", func() {
+			pool := x509.NewCertPool()
+			pool.AddCert(ca)
+			sctx := &signing.DefaultSigningContext{
+				PrivateKey:       "key",
+				RootCerts:        pool,
+				RootCertificates: []*x509.Certificate{ca},
+			}
+			h := signingattr.Get(ctx).GetSigner(ALGORITHM)
+			sig := Must(h.Sign(ctx.CredentialsContext(), "0815", sctx))
+			Expect(sig.Value).To(Equal("key:0815:roots"))
+		})
+
+		It("rejects a certificate pool without root certificates", func() {
+			sctx := &signing.DefaultSigningContext{
+				PrivateKey: "key",
+				RootCerts:  x509.NewCertPool(),
+			}
+			h := signingattr.Get(ctx).GetSigner(ALGORITHM)
+			ExpectError(h.Sign(ctx.CredentialsContext(), "0815", sctx)).To(MatchError(ContainSubstring("root certificates cannot be extracted from a certificate pool")))
+		})
+
+		It("passes root certificate option for component versions", func() {
+			cv := composition.NewComponentVersion(ctx, "acme.org/test", "v1")
+			defer Close(cv)
+
+			digest := Must(signingtool.SignComponentVersion(cv, "kms",
+				signingtool.SignerByAlgo(ALGORITHM),
+				signingtool.PrivateKey("kms", []byte("key")),
+				signingtool.RootCertificates(ca),
+			))
+			Expect(cv.GetDescriptor().Signatures[0].Signature.Value).To(Equal("key:" + digest.Value + ":roots"))
+		})
+
+		It("passes configured root certificates for component versions", func() {
+			MustBeSuccessful(rootcertsattr.Get(ctx).RegisterRootCertificates(ca))
+			cv := composition.NewComponentVersion(ctx, "acme.org/test", "v1")
+			defer Close(cv)
+
+			digest := Must(signingtool.SignComponentVersion(cv, "kms",
+				signingtool.SignerByAlgo(ALGORITHM),
+				signingtool.PrivateKey("kms", []byte("key")),
+			))
+			Expect(cv.GetDescriptor().Signatures[0].Signature.Value).To(Equal("key:" + digest.Value + ":roots"))
+		})
+	})
+
+	It("rejects unknown algorithms", func() {
+		p := plugincacheattr.Get(ctx).Get("test")
+		Expect(p).NotTo(BeNil())
+		ExpectError(plugin.New(p, "other")).To(MatchError(ContainSubstring("unknown")))
+	})
+})
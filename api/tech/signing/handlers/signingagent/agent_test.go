@@ -0,0 +1,172 @@
+package signingagent_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/ed25519"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	"ocm.software/ocm/api/tech/signing/handlers/signingagent"
+	"ocm.software/ocm/api/tech/signing/hasher/sha256"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+var _ = Describe("signing agent", func() {
+	var defaultContext credentials.Context
+	var server *signingagent.Server
+	var listener net.Listener
+	var socket string
+
+	registry := signing.DefaultRegistry()
+	hasher := registry.GetHasher(sha256.Algorithm)
+	hash, _ := signing.Hash(hasher.Create(), []byte("test"))
+
+	rsapriv, rsapub := Must2(rsa.Handler{}.CreateKeyPair())
+	edpriv, edpub := Must2(ed25519.NewHandler().CreateKeyPair())
+
+	ISSUER := &pkix.Name{CommonName: "mandelsoft"}
+
+	keySpec := func(key, algo string) []byte {
+		return []byte(fmt.Sprintf("socket: %s\nkey: %s\nalgorithm: %s\n", socket, key, algo))
+	}
+
+	BeforeEach(func() {
+		defaultContext = credentials.New()
+		dir := Must(os.MkdirTemp("", "agent"))
+		DeferCleanup(os.RemoveAll, dir)
+
+		socket = filepath.Join(dir, "agent.sock")
+		server = signingagent.NewServer(nil, nil)
+		MustBeSuccessful(server.AddKey("rsa", rsapriv, nil))
+		MustBeSuccessful(server.AddKey("ed", edpriv, nil))
+
+		listener = Must(signingagent.Listen(socket))
+		go server.Serve(listener)
+	})
+
+	AfterEach(func() {
+		listener.Close()
+	})
+
+	It("lists keys", func() {
+		keys := Must(signingagent.NewClient(socket).Keys())
+		Expect(keys).To(Equal([]signingagent.KeyInfo{
+			{Name: "ed", Algorithms: []string{ed25519.Algorithm}},
+			{Name: "rsa", Algorithms: []string{rsa.Algorithm, "RSASSA-PSS"}},
+		}))
+	})
+
+	It("restricts socket access", func() {
+		fi := Must(os.Stat(socket))
+		Expect(fi.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+		ExpectError(signingagent.Listen(socket)).To(MatchError(ContainSubstring("already in use")))
+	})
+
+	It("removes the socket on close", func() {
+		dir := filepath.Dir(socket)
+		Expect(Must(os.ReadDir(dir))).To(HaveLen(1))
+		MustBeSuccessful(listener.Close())
+		Expect(Must(os.ReadDir(dir))).To(BeEmpty())
+
+		listener = Must(signingagent.Listen(socket))
+		go server.Serve(listener)
+		Expect(Must(signingagent.NewClient(socket).Keys())).To(HaveLen(2))
+	})
+
+	It("refuses to replace other files", func() {
+		file := filepath.Join(filepath.Dir(socket), "file")
+		MustBeSuccessful(os.WriteFile(file, []byte("data"), 0o600))
+		ExpectError(signingagent.Listen(file)).To(MatchError(ContainSubstring("exists and is no socket")))
+		Expect(Must(os.ReadFile(file))).To(Equal([]byte("data")))
+
+		link := filepath.Join(filepath.Dir(socket), "link")
+		MustBeSuccessful(os.Symlink(socket, link))
+		ExpectError(signingagent.Listen(link)).To(MatchError(ContainSubstring("exists and is no socket")))
+	})
+
+	DescribeTable("signs with agent", func(key, algo string, pub interface{}) {
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: keySpec(key, algo),
+		}
+		sig := Must(registry.GetSigner(signingagent.Name).Sign(defaultContext, hash, sctx))
+		Expect(sig.Algorithm).To(Equal(algo))
+
+		sctx.PublicKey = pub
+		MustBeSuccessful(registry.GetVerifier(sig.Algorithm).Verify(hash, sig, sctx))
+		Expect(registry.GetVerifier(sig.Algorithm).Verify("A"+hash[1:], sig, sctx)).To(HaveOccurred())
+	},
+		Entry("rsa", "rsa", rsa.Algorithm, rsapub),
+		Entry("rsa-pss", "rsa", "RSASSA-PSS", rsapub),
+		Entry("ed25519", "ed", ed25519.Algorithm, edpub),
+	)
+
+	It("signs with certificate chain", func() {
+		ca, capriv := Must2(rsa.CreateRootCertificate(&pkix.Name{CommonName: "ca-authority"}, 10*time.Hour))
+		_, chain, priv := Must3(rsa.CreateSigningCertificate(ISSUER, ca, ca, capriv, 10*time.Hour))
+		pool := x509.NewCertPool()
+		pool.AddCert(ca)
+
+		agent := signingagent.NewServer(nil, pool)
+		MustBeSuccessful(agent.AddKey("cert", priv, chain))
+		listener.Close()
+		listener = Must(signingagent.Listen(socket))
+		go agent.Serve(listener)
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: keySpec("", rsa.Algorithm),
+			RootCerts:  pool,
+			Issuer:     ISSUER,
+		}
+		sig := Must(registry.GetSigner(signingagent.Name).Sign(defaultContext, hash, sctx))
+		Expect(sig.MediaType).To(Equal(signutils.MediaTypePEM))
+		Expect(sig.Issuer).To(Equal("CN=mandelsoft"))
+
+		_, _, certs := Must3(signutils.GetSignatureFromPem([]byte(sig.Value)))
+		sctx.PublicKey = certs[0]
+		MustBeSuccessful(registry.GetVerifier(sig.Algorithm).Verify(hash, sig, sctx))
+
+		sctx.RootCerts = x509.NewCertPool()
+		_, err := registry.GetSigner(signingagent.Name).Sign(defaultContext, hash, sctx)
+		Expect(err).To(MatchError(ContainSubstring("public key certificate from signing agent")))
+	})
+
+	It("uses socket from environment", func() {
+		GinkgoT().Setenv(signingagent.ENV_SOCKET, socket)
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: []byte("key: ed\nalgorithm: Ed25519\n"),
+		}
+		Must(registry.GetSigner(signingagent.Name).Sign(defaultContext, hash, sctx))
+	})
+
+	It("reports agent errors", func() {
+		sctx := &signing.DefaultSigningContext{
+			Hash: hasher.Crypto(),
+		}
+
+		sctx.PrivateKey = keySpec("unknown", rsa.Algorithm)
+		_, err := registry.GetSigner(signingagent.Name).Sign(defaultContext, hash, sctx)
+		MustFailWithMessage(err, fmt.Sprintf("signing agent %q: private key \"unknown\" is unknown", socket))
+
+		sctx.PrivateKey = keySpec("ed", rsa.Algorithm)
+		_, err = registry.GetSigner(signingagent.Name).Sign(defaultContext, hash, sctx)
+		MustFailWithMessage(err, fmt.Sprintf("signing agent %q: algorithm \"RSASSA-PKCS1-V1_5\" not supported for key \"ed\"", socket))
+
+		sctx.PrivateKey = keySpec("", rsa.Algorithm)
+		_, err = registry.GetSigner(signingagent.Name).Sign(defaultContext, hash, sctx)
+		MustFailWithMessage(err, fmt.Sprintf("signing agent %q: key name required", socket))
+	})
+})
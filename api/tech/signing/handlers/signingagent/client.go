@@ -0,0 +1,82 @@
+package signingagent
+
+import (
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+)
+
+// DefaultTimeout is the default timeout for a request to a signing agent.
+const DefaultTimeout = 30 * time.Second
+
+// Client is a client for a signing agent listening on a Unix domain socket.
+type Client struct {
+	socket  string
+	timeout time.Duration
+}
+
+func NewClient(socket string, timeout ...time.Duration) *Client {
+	t := DefaultTimeout
+	if len(timeout) > 0 && timeout[0] > 0 {
+		t = timeout[0]
+	}
+	return &Client{socket: socket, timeout: t}
+}
+
+// Do sends a request to the agent and returns its response.
+// An error reported by the agent is returned as error.
+func (c *Client) Do(req *Request) (*Response, error) {
+	conn, err := net.DialTimeout("unix", c.socket, c.timeout)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot connect to signing agent %q", c.socket)
+	}
+	defer conn.Close()
+
+	err = conn.SetDeadline(time.Now().Add(c.timeout))
+	if err != nil {
+		return nil, err
+	}
+	err = json.NewEncoder(conn).Encode(req)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot send request to signing agent %q", c.socket)
+	}
+	var res Response
+	err = json.NewDecoder(conn).Decode(&res)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid response from signing agent %q", c.socket)
+	}
+	if res.Error != "" {
+		return nil, errors.Newf("signing agent %q: %s", c.socket, res.Error)
+	}
+	return &res, nil
+}
+
+// Sign requests the signature for a hex encoded digest.
+func (c *Client) Sign(key, algo, hashAlgo, digest, issuer string) (*Signature, error) {
+	res, err := c.Do(&Request{
+		Operation:     OP_SIGN,
+		Key:           key,
+		Algorithm:     algo,
+		HashAlgorithm: hashAlgo,
+		Digest:        digest,
+		Issuer:        issuer,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.Signature == nil || res.Signature.Value == "" {
+		return nil, errors.Newf("invalid response from signing agent %q: signature missing", c.socket)
+	}
+	return res.Signature, nil
+}
+
+// Keys requests the list of keys provided by the agent.
+func (c *Client) Keys() ([]KeyInfo, error) {
+	res, err := c.Do(&Request{Operation: OP_KEYS})
+	if err != nil {
+		return nil, err
+	}
+	return res.Keys, nil
+}
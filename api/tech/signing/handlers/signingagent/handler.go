@@ -0,0 +1,119 @@
+package signingagent
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	"ocm.software/ocm/api/tech/signing/signutils"
+	"ocm.software/ocm/api/utils/runtime"
+)
+
+const (
+	Name = "signing-agent"
+
+	// ENV_SOCKET is the environment variable used to determine the socket
+	// of the signing agent, if not configured by the key specification.
+	ENV_SOCKET = "OCM_SIGNING_AGENT_SOCKET"
+)
+
+// Key is the specification passed instead of a private key.
+// It describes the signing agent and the key used by the agent.
+type Key struct {
+	// Socket is the path of the Unix domain socket of the agent.
+	Socket string `json:"socket,omitempty"`
+	// Key is the name of the key in the agent.
+	Key string `json:"key,omitempty"`
+	// Algorithm is the signature algorithm (default RSASSA-PKCS1-V1_5).
+	Algorithm string `json:"algorithm,omitempty"`
+}
+
+func init() {
+	signing.DefaultHandlerRegistry().RegisterSigner(Name, NewHandler())
+}
+
+// Handler is a signatures.Signer compatible struct delegating the
+// signing to a local signing agent.
+// The resulting signatures can be verified with the verifier
+// registered for the signature algorithm used by the agent.
+type Handler struct{}
+
+func NewHandler() signing.Signer {
+	return &Handler{}
+}
+
+func (h *Handler) Algorithm() string {
+	return Name
+}
+
+func (h *Handler) Sign(cctx credentials.Context, digest string, sctx signing.SigningContext) (signature *signing.Signature, err error) {
+	key, err := PrivateKey(sctx.GetPrivateKey())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid signing agent configuration")
+	}
+
+	var hash, issuer string
+	if sctx.GetHash().Available() {
+		hash = sctx.GetHash().String()
+	}
+	if sctx.GetIssuer() != nil {
+		issuer = sctx.GetIssuer().String()
+	}
+
+	sig, err := NewClient(key.Socket).Sign(key.Key, key.Algorithm, hash, digest, issuer)
+	if err != nil {
+		return nil, err
+	}
+	if sig.Algorithm != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signature algorithm %q from signing agent (expected %q)", sig.Algorithm, key.Algorithm)
+	}
+	if sig.MediaType == signutils.MediaTypePEM {
+		_, _, certs, err := signutils.GetSignatureFromPem([]byte(sig.Value))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid signature from signing agent")
+		}
+		if len(certs) > 0 {
+			err = signutils.VerifyCertificate(certs[0], certs[1:], sctx.GetRootCerts(), sctx.GetIssuer())
+			if err != nil {
+				return nil, errors.Wrapf(err, "public key certificate from signing agent")
+			}
+			sig.Issuer = certs[0].Subject.String()
+		} else if sctx.GetIssuer() != nil {
+			return nil, errors.Newf("certificates missing in signing agent response")
+		}
+	}
+	return sig.Convert(), nil
+}
+
+// PrivateKey determines the agent Key specification from the
+// private key passed to the handler. It is either given by a
+// Key object or its YAML/JSON serialization. Missing
+// fields are defaulted.
+func PrivateKey(k interface{}) (*Key, error) {
+	var key Key
+	switch t := k.(type) {
+	case *Key:
+		key = *t
+	case []byte:
+		err := runtime.DefaultYAMLEncoding.Unmarshal(t, &key)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown key specification %T", k)
+	}
+	if key.Socket == "" {
+		key.Socket = os.Getenv(ENV_SOCKET)
+		if key.Socket == "" {
+			return nil, errors.Newf("signing agent socket missing (field socket or environment variable %s)", ENV_SOCKET)
+		}
+	}
+	if key.Algorithm == "" {
+		key.Algorithm = rsa.Algorithm
+	}
+	return &key, nil
+}
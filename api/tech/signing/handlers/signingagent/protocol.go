@@ -0,0 +1,79 @@
+package signingagent
+
+import (
+	"ocm.software/ocm/api/tech/signing"
+)
+
+// The signing agent protocol is a simple request/response protocol
+// on a Unix domain socket. For every request a new connection is opened.
+// The client sends a single JSON encoded Request and the agent answers with
+// a single JSON encoded Response before closing the connection.
+
+const (
+	// OP_SIGN requests the signature of a digest with a key of the agent.
+	OP_SIGN = "sign"
+	// OP_KEYS requests the names and algorithms of the keys provided by the agent.
+	OP_KEYS = "keys"
+)
+
+// Request is the request sent to a signing agent.
+type Request struct {
+	// Operation is the requested operation (OP_SIGN or OP_KEYS).
+	Operation string `json:"operation"`
+	// Key is the name of the key used for signing.
+	// It may be omitted if the agent provides only a single key.
+	Key string `json:"key,omitempty"`
+	// Algorithm is the signature algorithm used for signing.
+	// The agent must support this algorithm for the selected key.
+	Algorithm string `json:"algorithm,omitempty"`
+	// HashAlgorithm is the name of the hash algorithm used to
+	// calculate the digest (for example SHA-256).
+	HashAlgorithm string `json:"hashAlgorithm,omitempty"`
+	// Digest is the hex encoded digest to sign.
+	Digest string `json:"digest,omitempty"`
+	// Issuer is the optional expected distinguished name of the
+	// issuer of the key certificate.
+	Issuer string `json:"issuer,omitempty"`
+}
+
+// Response is the response of a signing agent.
+// If the request cannot be processed, only the field Error is set.
+type Response struct {
+	Error     string     `json:"error,omitempty"`
+	Signature *Signature `json:"signature,omitempty"`
+	Keys      []KeyInfo  `json:"keys,omitempty"`
+}
+
+// Signature is the signature created by the agent. The fields
+// have the same meaning as for a signature created by the local
+// signing handler for the signature algorithm.
+type Signature struct {
+	Value     string `json:"value"`
+	MediaType string `json:"mediaType"`
+	Algorithm string `json:"algorithm"`
+	Issuer    string `json:"issuer,omitempty"`
+}
+
+// KeyInfo describes a key provided by the agent.
+type KeyInfo struct {
+	Name       string   `json:"name"`
+	Algorithms []string `json:"algorithms,omitempty"`
+}
+
+func (s *Signature) Convert() *signing.Signature {
+	return &signing.Signature{
+		Value:     s.Value,
+		MediaType: s.MediaType,
+		Algorithm: s.Algorithm,
+		Issuer:    s.Issuer,
+	}
+}
+
+func NewSignature(sig *signing.Signature) *Signature {
+	return &Signature{
+		Value:     sig.Value,
+		MediaType: sig.MediaType,
+		Algorithm: sig.Algorithm,
+		Issuer:    sig.Issuer,
+	}
+}
@@ -0,0 +1,289 @@
+package signingagent
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	ocmecdsa "ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	ocmed25519 "ocm.software/ocm/api/tech/signing/handlers/ed25519"
+	ocmrsa "ocm.software/ocm/api/tech/signing/handlers/rsa"
+	rsa_pss "ocm.software/ocm/api/tech/signing/handlers/rsa-pss"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+type serverKey struct {
+	name       string
+	privateKey interface{}
+	publicKey  signutils.GenericPublicKey
+	algorithms []string
+}
+
+// Server implements the agent side of the signing agent protocol.
+// The signatures are created with the signing handlers of the
+// given registry for the keys added to the server.
+type Server struct {
+	lock      sync.RWMutex
+	handlers  signing.SignerRegistryFuncs
+	rootCerts signutils.GenericCertificatePool
+	keys      map[string]*serverKey
+	timeout   time.Duration
+}
+
+// NewServer creates a new agent server using the handlers of the given
+// registry. If no registry is given the default registry is used.
+// The root certificates are used to validate the certificate
+// chains of the keys.
+func NewServer(handlers signing.SignerRegistryFuncs, rootCerts signutils.GenericCertificatePool) *Server {
+	if handlers == nil {
+		handlers = signing.DefaultHandlerRegistry()
+	}
+	return &Server{
+		handlers:  handlers,
+		rootCerts: rootCerts,
+		keys:      map[string]*serverKey{},
+		timeout:   DefaultTimeout,
+	}
+}
+
+// AddKey adds a private key to the agent. The optional public key
+// may be given by a certificate chain.
+func (s *Server) AddKey(name string, priv signutils.GenericPrivateKey, pub signutils.GenericPublicKey) error {
+	key, err := signutils.GetPrivateKey(priv)
+	if err != nil {
+		return errors.Wrapf(err, "key %q", name)
+	}
+	algos := AlgorithmsForKey(key)
+	if len(algos) == 0 {
+		return errors.Newf("key %q: unsupported key type %T", name, key)
+	}
+	if pub != nil {
+		if _, err := signutils.GetCertificateChain(pub, false); err != nil {
+			if _, err := signutils.GetPublicKey(pub); err != nil {
+				return errors.Wrapf(err, "key %q: invalid public key", name)
+			}
+		}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.keys[name] = &serverKey{name: name, privateKey: key, publicKey: pub, algorithms: algos}
+	return nil
+}
+
+// AlgorithmsForKey returns the signature algorithms usable with a private key.
+func AlgorithmsForKey(key interface{}) []string {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return []string{ocmrsa.Algorithm, rsa_pss.Algorithm}
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return []string{ocmecdsa.Algorithm}
+		case elliptic.P384():
+			return []string{ocmecdsa.AlgorithmP384}
+		}
+	case ed25519.PrivateKey:
+		return []string{ocmed25519.Algorithm}
+	}
+	return nil
+}
+
+// Listen creates a listener for the given Unix domain socket.
+// A stale socket file is removed and the socket is accessible
+// for the owner, only. An existing file, which is no socket,
+// is never replaced.
+// To avoid a time window with a socket accessible by other users,
+// the socket is created in a temporary directory only accessible
+// by the owner and linked to its final location after restricting
+// its permissions. The socket file is removed when the listener is closed.
+func Listen(socket string) (net.Listener, error) {
+	if fi, err := os.Lstat(socket); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, errors.Newf("signing agent socket path %q exists and is no socket", socket)
+		}
+		if conn, err := net.Dial("unix", socket); err == nil {
+			conn.Close()
+			return nil, errors.Newf("signing agent socket %q already in use", socket)
+		}
+		os.Remove(socket)
+	}
+
+	dir, err := os.MkdirTemp(filepath.Dir(socket), ".agent-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	tmp := filepath.Join(dir, "sock")
+	l, err := net.ListenUnix("unix", &net.UnixAddr{Name: tmp, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	l.SetUnlinkOnClose(false)
+	err = os.Chmod(tmp, 0o600)
+	if err == nil {
+		// in contrast to a rename, a link never replaces a file
+		// created in the meantime.
+		err = os.Link(tmp, socket)
+	}
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	return &listener{l, socket}, nil
+}
+
+// listener removes the socket file moved to its final location
+// when closed.
+type listener struct {
+	*net.UnixListener
+	socket string
+}
+
+func (l *listener) Close() error {
+	err := l.UnixListener.Close()
+	if err == nil {
+		os.Remove(l.socket)
+	}
+	return err
+}
+
+// Serve handles the connections of the given listener until the listener
+// is closed.
+func (s *Server) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go s.handleConnection(conn)
+	}
+}
+
+func (s *Server) handleConnection(conn net.Conn) {
+	defer conn.Close()
+
+	var req Request
+	var res *Response
+
+	conn.SetDeadline(time.Now().Add(s.timeout))
+	err := json.NewDecoder(conn).Decode(&req)
+	if err != nil {
+		res = &Response{Error: "invalid request: " + err.Error()}
+	} else {
+		res = s.Handle(&req)
+	}
+	json.NewEncoder(conn).Encode(res)
+}
+
+// Handle processes a single request.
+func (s *Server) Handle(req *Request) *Response {
+	switch req.Operation {
+	case OP_KEYS:
+		return &Response{Keys: s.Keys()}
+	case OP_SIGN:
+		sig, err := s.Sign(req)
+		if err != nil {
+			return &Response{Error: err.Error()}
+		}
+		return &Response{Signature: NewSignature(sig)}
+	default:
+		return &Response{Error: errors.ErrNotSupported("operation", req.Operation).Error()}
+	}
+}
+
+// Keys provides the keys of the agent.
+func (s *Server) Keys() []KeyInfo {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var list []KeyInfo
+	for _, k := range s.keys {
+		list = append(list, KeyInfo{Name: k.name, Algorithms: slices.Clone(k.algorithms)})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// Sign creates the signature for a signing request.
+func (s *Server) Sign(req *Request) (*signing.Signature, error) {
+	key, err := s.getKey(req.Key)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(key.algorithms, req.Algorithm) {
+		return nil, errors.Newf("algorithm %q not supported for key %q", req.Algorithm, key.name)
+	}
+	handler := s.handlers.GetSigner(req.Algorithm)
+	if handler == nil {
+		return nil, errors.ErrUnknown(signutils.KIND_SIGN_ALGORITHM, req.Algorithm)
+	}
+
+	var hash crypto.Hash
+	if req.HashAlgorithm != "" {
+		hash = hashForName(req.HashAlgorithm)
+		if hash == 0 {
+			return nil, errors.ErrUnknown(signutils.KIND_HASH_ALGORITHM, req.HashAlgorithm)
+		}
+	}
+
+	sctx := &signing.DefaultSigningContext{
+		Hash:       hash,
+		PrivateKey: key.privateKey,
+		PublicKey:  key.publicKey,
+		RootCerts:  s.rootCerts,
+	}
+	if req.Issuer != "" {
+		sctx.Issuer, err = signutils.ParseDN(req.Issuer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "issuer")
+		}
+	}
+	return handler.Sign(credentials.New(), req.Digest, sctx)
+}
+
+func (s *Server) getKey(name string) (*serverKey, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if name == "" {
+		if len(s.keys) == 1 {
+			for _, k := range s.keys {
+				return k, nil
+			}
+		}
+		return nil, errors.Newf("key name required")
+	}
+	key := s.keys[name]
+	if key == nil {
+		return nil, errors.ErrUnknown(signutils.KIND_PRIVATE_KEY, name)
+	}
+	return key, nil
+}
+
+func hashForName(name string) crypto.Hash {
+	for h := crypto.MD4; h <= crypto.BLAKE2b_512; h++ {
+		if h.String() == name {
+			return h
+		}
+	}
+	return 0
+}
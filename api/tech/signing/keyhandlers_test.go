@@ -0,0 +1,132 @@
+package signing_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"time"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"golang.org/x/crypto/ssh"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	"ocm.software/ocm/api/tech/signing/handlers/ed25519"
+	"ocm.software/ocm/api/tech/signing/hasher/sha256"
+	"ocm.software/ocm/api/tech/signing/signutils"
+)
+
+var _ = Describe("key based signing handlers", func() {
+	var defaultContext credentials.Context
+
+	hasher := registry.GetHasher(sha256.Algorithm)
+	hash, _ := signing.Hash(hasher.Create(), []byte("test"))
+
+	BeforeEach(func() {
+		defaultContext = credentials.New()
+	})
+
+	DescribeTable("signs and verifies", func(algo string, media string) {
+		priv, pub := Must2(registry.GetSigner(algo).(signing.KeyPairCreator).CreateKeyPair())
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: priv,
+			PublicKey:  pub,
+			Issuer:     ISSUER,
+		}
+		sig := Must(registry.GetSigner(algo).Sign(defaultContext, hash, sctx))
+		Expect(sig.MediaType).To(Equal(media))
+		Expect(sig.Algorithm).To(Equal(algo))
+
+		MustBeSuccessful(registry.GetVerifier(algo).Verify(hash, sig, sctx))
+		Expect(registry.GetVerifier(algo).Verify("A"+hash[1:], sig, sctx)).To(HaveOccurred())
+	},
+		Entry("ecdsa p256", ecdsa.Algorithm, ecdsa.MediaType),
+		Entry("ecdsa p384", ecdsa.AlgorithmP384, ecdsa.MediaType),
+		Entry("ed25519", ed25519.Algorithm, ed25519.MediaType),
+	)
+
+	DescribeTable("signs with certificate chain", func(algo string) {
+		creator := registry.GetSigner(algo).(signing.KeyPairCreator)
+		capriv, capub := Must2(creator.CreateKeyPair())
+		caData := Must(CreateCertificate(pkix.Name{CommonName: "ca-authority"}, nil, 10*time.Hour, capub, nil, capriv, true))
+		ca := Must(signutils.ParseCertificate(caData))
+
+		priv, pub := Must2(creator.CreateKeyPair())
+		certData := Must(CreateCertificate(*ISSUER, nil, 10*time.Hour, pub, ca, capriv, false))
+		chain := Must(signutils.GetCertificateChain(certData, false))
+
+		pool := x509.NewCertPool()
+		pool.AddCert(ca)
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: priv,
+			PublicKey:  chain,
+			RootCerts:  pool,
+			Issuer:     ISSUER,
+		}
+		sig := Must(registry.GetSigner(algo).Sign(defaultContext, hash, sctx))
+		Expect(sig.MediaType).To(Equal(signutils.MediaTypePEM))
+		Expect(sig.Issuer).To(Equal("CN=mandelsoft"))
+
+		_, a, certs := Must3(signutils.GetSignatureFromPem([]byte(sig.Value)))
+		Expect(a).To(Equal(algo))
+		Expect(len(certs)).To(Equal(2))
+
+		sctx.PublicKey = certs[0]
+		MustBeSuccessful(registry.GetVerifier(algo).Verify(hash, sig, sctx))
+
+		sig.Issuer = "CN=other"
+		Expect(registry.GetVerifier(algo).Verify(hash, sig, sctx)).To(MatchError(ContainSubstring("issuer CN=other does not match")))
+	},
+		Entry("ecdsa p256", ecdsa.Algorithm),
+		Entry("ed25519", ed25519.Algorithm),
+	)
+
+	It("rejects keys for other curves", func() {
+		priv, _ := Must2(ecdsa.NewHandler().CreateKeyPair())
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: priv,
+		}
+		_, err := registry.GetSigner(ecdsa.AlgorithmP384).Sign(defaultContext, hash, sctx)
+		MustFailWithMessage(err, "invalid ECDSA-P384 private key: ecdsa key with curve P-256 does not match algorithm ECDSA-P384")
+	})
+
+	It("rejects keys for other algorithms", func() {
+		priv, _ := Must2(ed25519.NewHandler().CreateKeyPair())
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: priv,
+		}
+		_, err := registry.GetSigner(ecdsa.Algorithm).Sign(defaultContext, hash, sctx)
+		MustFailWithMessage(err, "invalid ECDSA-P256 private key: unexpected private key type ed25519.PrivateKey for ECDSA-P256")
+	})
+
+	It("uses PEM and OpenSSH encoded keys", func() {
+		priv, pub := Must2(ed25519.NewHandler().CreateKeyPair())
+
+		block := Must(ssh.MarshalPrivateKey(priv, "test"))
+		sshpub := Must(ssh.NewPublicKey(pub))
+
+		sctx := &signing.DefaultSigningContext{
+			Hash:       hasher.Crypto(),
+			PrivateKey: pem.EncodeToMemory(block),
+			PublicKey:  ssh.MarshalAuthorizedKey(sshpub),
+		}
+		sig := Must(registry.GetSigner(ed25519.Algorithm).Sign(defaultContext, hash, sctx))
+
+		sctx.PublicKey = pem.EncodeToMemory(signutils.PemBlockForPublicKey(pub))
+		MustBeSuccessful(registry.GetVerifier(ed25519.Algorithm).Verify(hash, sig, sctx))
+
+		sctx.PrivateKey = pem.EncodeToMemory(signutils.PemBlockForPrivateKey(priv))
+		sig = Must(registry.GetSigner(ed25519.Algorithm).Sign(defaultContext, hash, sctx))
+		MustBeSuccessful(registry.GetVerifier(ed25519.Algorithm).Verify(hash, sig, sctx))
+	})
+})
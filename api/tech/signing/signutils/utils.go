@@ -5,6 +5,7 @@ import (
 	"crypto"
 	"crypto/dsa" //nolint: staticcheck // yes
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -17,6 +18,7 @@ import (
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/mandelsoft/vfs/pkg/vfs"
 	"github.com/modern-go/reflect2"
+	"golang.org/x/crypto/ssh"
 	"golang.org/x/exp/slices"
 
 	"ocm.software/ocm/api/utils"
@@ -30,11 +32,28 @@ func privateKey(block *pem.Block) (interface{}, error) {
 		return x509.ParsePKCS1PrivateKey(x509Encoded)
 	case "EC PRIVATE KEY":
 		return x509.ParseECPrivateKey(x509Encoded)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(x509Encoded)
+	case "OPENSSH PRIVATE KEY":
+		key, err := ssh.ParseRawPrivateKey(pem.EncodeToMemory(block))
+		if err != nil {
+			return nil, err
+		}
+		return normalizePrivateKey(key), nil
 	default:
 		return nil, fmt.Errorf("invalid pem block type %q", block.Type)
 	}
 }
 
+// normalizePrivateKey maps pointers to ed25519 keys (as provided
+// by the ssh package) to the key type used by the crypto packages.
+func normalizePrivateKey(key interface{}) interface{} {
+	if k, ok := key.(*ed25519.PrivateKey); ok {
+		return *k
+	}
+	return key
+}
+
 func PemBlockForPrivateKey(priv interface{}) *pem.Block {
 	switch k := priv.(type) {
 	case *rsa.PrivateKey:
@@ -46,6 +65,12 @@ func PemBlockForPrivateKey(priv interface{}) *pem.Block {
 			os.Exit(2)
 		}
 		return &pem.Block{Type: "EC PRIVATE KEY", Bytes: b}
+	case ed25519.PrivateKey:
+		b, err := x509.MarshalPKCS8PrivateKey(k)
+		if err != nil {
+			return nil
+		}
+		return &pem.Block{Type: "PRIVATE KEY", Bytes: b}
 	default:
 		return nil
 	}
@@ -68,14 +93,28 @@ func PemBlockForPublicKey(priv interface{}, gen ...bool) *pem.Block {
 			return nil
 		}
 		return &pem.Block{Type: "ECDSA PUBLIC KEY", Bytes: b}
+	case ed25519.PublicKey:
+		b, err := x509.MarshalPKIXPublicKey(k)
+		if err != nil {
+			return nil
+		}
+		return &pem.Block{Type: "PUBLIC KEY", Bytes: b}
 	default:
 		return nil
 	}
 }
 
+// ParsePublicKey parses a PEM encoded public key or certificate.
+// Additionally, public keys in the OpenSSH authorized keys format are
+// accepted.
 func ParsePublicKey(data []byte) (interface{}, error) {
 	block, _ := pem.Decode(data)
 	if block == nil {
+		if pub, _, _, _, err := ssh.ParseAuthorizedKey(data); err == nil {
+			if c, ok := pub.(ssh.CryptoPublicKey); ok {
+				return c.CryptoPublicKey(), nil
+			}
+		}
 		return nil, fmt.Errorf("invalid public key format (expected pem block)")
 	}
 	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
@@ -98,6 +137,8 @@ func ParsePublicKey(data []byte) (interface{}, error) {
 		return pub, nil
 	case *ecdsa.PublicKey:
 		return pub, nil
+	case ed25519.PublicKey:
+		return pub, nil
 	default:
 		return nil, fmt.Errorf("unknown type of public key")
 	}
@@ -188,6 +229,10 @@ func GetPrivateKey(key GenericPrivateKey) (interface{}, error) {
 		return k, nil
 	case *ecdsa.PrivateKey:
 		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	case *ed25519.PrivateKey:
+		return *k, nil
 	default:
 		return nil, errors.ErrInvalidType(KIND_PRIVATE_KEY, k)
 	}
@@ -207,6 +252,8 @@ func GetPublicKey(key GenericPublicKey) (interface{}, error) {
 		return k, nil
 	case *ecdsa.PublicKey:
 		return k, nil
+	case ed25519.PublicKey:
+		return k, nil
 	case *x509.Certificate:
 		return k.PublicKey, nil
 	case PublicKeySource:
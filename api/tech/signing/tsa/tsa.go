@@ -2,6 +2,7 @@ package tsa
 
 import (
 	"crypto"
+	"crypto/x509"
 	"crypto/x509/pkix"
 	"fmt"
 	"time"
@@ -11,6 +12,7 @@ import (
 	tsa "github.com/InfiniteLoopSpace/go_S-MIME/timestamp"
 	"github.com/go-test/deep"
 	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/goutils/maputils"
 
 	"ocm.software/ocm/api/tech/signing/signutils"
 	"ocm.software/ocm/api/utils"
@@ -45,7 +47,10 @@ func NewMessageImprintForData(data []byte, hash crypto.Hash) (*MessageImprint, e
 	return &mi, nil
 }
 
-func Request(url string, mi *tsa.MessageImprint) (*TimeStamp, time.Time, error) {
+// Request requests a timestamp for the given message imprint from the TSA
+// with the given url. The response is verified against the given root
+// certificates (by default the system root certificates).
+func Request(url string, mi *tsa.MessageImprint, rootpool ...signutils.GenericCertificatePool) (*TimeStamp, time.Time, error) {
 	if mi == nil {
 		return nil, time.Time{}, fmt.Errorf("message imprint required")
 	}
@@ -60,19 +65,27 @@ func Request(url string, mi *tsa.MessageImprint) (*TimeStamp, time.Time, error)
 	if err != nil {
 		return nil, time.Time{}, errors.Wrapf(err, "requesting timestamp from %s", url)
 	}
+	if err := resp.Status.GetError(); err != nil {
+		return nil, time.Time{}, errors.Wrapf(err, "timestamp request rejected by %s", url)
+	}
 
 	sd, err := resp.TimeStampToken.SignedDataContent()
 	if err != nil {
 		return nil, time.Time{}, errors.Wrapf(err, "unexpected answer timestamp response from %s", url)
 	}
 
-	t, err := Verify(mi, sd, true)
+	t, err := Verify(mi, sd, true, rootpool...)
 	if err != nil {
 		return nil, time.Time{}, errors.Wrapf(err, "cannot verify timestamp response")
 	}
 	return sd, *t, nil
 }
 
+// Verify verifies a timestamp token for the given message imprint.
+// The certificate chain of the TSA is validated against the given root
+// certificates (by default the system root certificates) at the
+// generation time of the timestamp, or the actual time if now is set.
+// It returns the generation time of the timestamp.
 func Verify(mi *tsa.MessageImprint, sd *TimeStamp, now bool, rootpool ...signutils.GenericCertificatePool) (*time.Time, error) {
 	info, err := tsa.ParseInfo(sd.EncapContentInfo)
 	if err != nil {
@@ -84,21 +97,56 @@ func Verify(mi *tsa.MessageImprint, sd *TimeStamp, now bool, rootpool ...signuti
 	if diff := deep.Equal(info.MessageImprint.HashedMessage, mi.HashedMessage); diff != nil {
 		return nil, fmt.Errorf("digest mismatch: %s", diff)
 	}
-	opts := tsa.Opts
-	if !now {
-		opts.CurrentTime = info.GenTime
+	opts := x509.VerifyOptions{
+		Intermediates: x509.NewCertPool(),
+		CurrentTime:   info.GenTime,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageTimeStamping},
+	}
+	if now {
+		opts.CurrentTime = time.Now()
 	}
 	opts.Roots, err = signutils.GetCertPool(utils.Optional(rootpool...), false)
 	if err != nil {
 		return nil, errors.Wrapf(err, "root cert pool")
 	}
+	// the signed data verification checks the signatures, only,
+	// the certificate chain of the signers is validated separately.
 	_, err = sd.Verify(opts, nil)
 	if err != nil {
 		return nil, err
 	}
+	err = verifyChain(sd, opts)
+	if err != nil {
+		return nil, err
+	}
 	return &info.GenTime, nil
 }
 
+func verifyChain(sd *TimeStamp, opts x509.VerifyOptions) error {
+	if len(sd.SignerInfos) == 0 {
+		return errors.Newf("no signer found in timestamp")
+	}
+	certs, err := sd.X509Certificates()
+	if err != nil {
+		return errors.Wrapf(err, "timestamp certificates")
+	}
+	list := maputils.Values(certs)
+	for _, c := range list {
+		opts.Intermediates.AddCert(c)
+	}
+	for _, s := range sd.SignerInfos {
+		cert, err := s.FindCertificate(list)
+		if err != nil {
+			return errors.Wrapf(err, "timestamp authority certificate")
+		}
+		_, err = cert.Verify(opts)
+		if err != nil {
+			return errors.Wrapf(err, "timestamp authority certificate %q", cert.Subject)
+		}
+	}
+	return nil
+}
+
 func GetTimestamp(ts *TimeStamp) (time.Time, error) {
 	info, err := tsa.ParseInfo(ts.EncapContentInfo)
 	if err != nil {
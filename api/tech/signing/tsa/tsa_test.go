@@ -0,0 +1,66 @@
+package tsa_test
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/tech/signing/tsa"
+	"ocm.software/ocm/api/tech/signing/tsa/tsatest"
+)
+
+var _ = Describe("timestamps", func() {
+	var authority *tsatest.Authority
+	var server *httptest.Server
+	var mi *tsa.MessageImprint
+
+	BeforeEach(func() {
+		authority = Must(tsatest.New(time.Now().Add(-time.Hour), 24*time.Hour))
+		server = httptest.NewServer(authority)
+		d := sha256.Sum256([]byte("test"))
+		mi = Must(tsa.NewMessageImprint(crypto.SHA256, d[:]))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("requests and verifies a timestamp", func() {
+		ts, t := Must2(tsa.Request(server.URL, mi, authority.Root))
+		Expect(t).To(BeTemporally("~", time.Now(), time.Minute))
+
+		data := Must(tsa.ToPem(ts))
+		ts = Must(tsa.FromPem(data))
+		Expect(*Must(tsa.Verify(mi, ts, false, authority.Root))).To(Equal(t))
+		Expect(Must(tsa.GetTimestamp(ts))).To(Equal(t))
+	})
+
+	It("rejects timestamp of untrusted authority", func() {
+		other := Must(tsatest.New(time.Now().Add(-time.Hour), 24*time.Hour))
+		ts := Must(authority.TimeStamp(mi))
+
+		ExpectError(tsa.Verify(mi, ts, false, other.Root)).To(MatchError(ContainSubstring("timestamp authority certificate \"CN=test tsa\": x509: certificate signed by unknown authority")))
+		ExpectError(tsa.Request(server.URL, mi, other.Root)).To(MatchError(ContainSubstring("cannot verify timestamp response")))
+	})
+
+	It("rejects timestamp for other message", func() {
+		ts := Must(authority.TimeStamp(mi))
+		d := sha256.Sum256([]byte("other"))
+		other := Must(tsa.NewMessageImprint(crypto.SHA256, d[:]))
+
+		ExpectError(tsa.Verify(other, ts, false, authority.Root)).To(MatchError(ContainSubstring("digest mismatch")))
+	})
+
+	It("validates the authority at generation time", func() {
+		t := time.Now().Add(-2 * time.Hour)
+		authority.SetTime(&t)
+		ts := Must(authority.TimeStamp(mi))
+
+		ExpectError(tsa.Verify(mi, ts, false, authority.Root)).To(MatchError(ContainSubstring("certificate has expired or is not yet valid")))
+	})
+})
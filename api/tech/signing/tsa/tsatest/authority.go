@@ -0,0 +1,174 @@
+// Package tsatest provides a simple RFC 3161 timestamp authority
+// usable for tests.
+package tsatest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	stdasn1 "encoding/asn1"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/InfiniteLoopSpace/go_S-MIME/asn1"
+	cms "github.com/InfiniteLoopSpace/go_S-MIME/cms/protocol"
+	"github.com/InfiniteLoopSpace/go_S-MIME/oid"
+	tsa "github.com/InfiniteLoopSpace/go_S-MIME/timestamp"
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/tech/signing/signutils"
+	ocmtsa "ocm.software/ocm/api/tech/signing/tsa"
+)
+
+const (
+	ContentTypeQuery = "application/timestamp-query"
+	ContentTypeReply = "application/timestamp-reply"
+)
+
+// Authority is a timestamp authority based on a dedicated
+// self-signed root certificate.
+type Authority struct {
+	lock   sync.Mutex
+	serial int64
+	time   *time.Time
+
+	Root     *x509.Certificate
+	Cert     *x509.Certificate
+	Key      *rsa.PrivateKey
+	Requests int
+}
+
+// New creates a new timestamp authority with a fresh root certificate
+// and timestamping certificate valid from notBefore for the given
+// duration.
+func New(notBefore time.Time, validity time.Duration) (*Authority, error) {
+	rootKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	root, _, err := signutils.CreateCertificate(&signutils.Specification{
+		IsCA:         true,
+		CAPrivateKey: rootKey,
+		Subject:      pkix.Name{CommonName: "test tsa root"},
+		NotBefore:    &notBefore,
+		Validity:     validity,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "root certificate")
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	cert, _, err := signutils.CreateCertificate(&signutils.Specification{
+		RootCAs:      root,
+		CAChain:      root,
+		CAPrivateKey: rootKey,
+		PublicKey:    &key.PublicKey,
+		Subject:      pkix.Name{CommonName: "test tsa"},
+		Usages:       signutils.Usages{x509.ExtKeyUsageTimeStamping, x509.KeyUsageDigitalSignature},
+		NotBefore:    &notBefore,
+		Validity:     validity,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "timestamping certificate")
+	}
+	return &Authority{Root: root, Cert: cert, Key: key}, nil
+}
+
+// SetTime fixes the generation time used for timestamps.
+// If nil, the actual time is used.
+func (a *Authority) SetTime(t *time.Time) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.time = t
+}
+
+// TimeStamp creates a timestamp token for the given message imprint.
+func (a *Authority) TimeStamp(mi *ocmtsa.MessageImprint, nonce ...*big.Int) (*ocmtsa.TimeStamp, error) {
+	a.lock.Lock()
+	a.serial++
+	a.Requests++
+	info := tsa.TSTInfo{
+		Version:        1,
+		Policy:         stdasn1.ObjectIdentifier{1, 2, 3, 4, 1},
+		MessageImprint: *mi,
+		SerialNumber:   big.NewInt(a.serial),
+		GenTime:        time.Now().UTC(),
+	}
+	if a.time != nil {
+		info.GenTime = a.time.UTC()
+	}
+	a.lock.Unlock()
+
+	if len(nonce) > 0 {
+		info.Nonce = nonce[0]
+	}
+
+	der, err := asn1.Marshal(info)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot marshal timestamp info")
+	}
+	eci, err := cms.NewEncapsulatedContentInfo(oid.TSTInfo, der)
+	if err != nil {
+		return nil, err
+	}
+	sd, err := cms.NewSignedData(eci)
+	if err != nil {
+		return nil, err
+	}
+	err = sd.AddSignerInfo(tls.Certificate{
+		Certificate: [][]byte{a.Cert.Raw},
+		PrivateKey:  a.Key,
+		Leaf:        a.Cert,
+	}, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot sign timestamp info")
+	}
+	return sd, nil
+}
+
+// ServeHTTP implements a http.Handler answering RFC 3161 timestamp
+// requests.
+func (a *Authority) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != ContentTypeQuery {
+		http.Error(w, "invalid timestamp request", http.StatusBadRequest)
+		return
+	}
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var req tsa.TimeStampReq
+	if _, err := asn1.Unmarshal(data, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sd, err := a.TimeStamp(&req.MessageImprint, req.Nonce)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	token, err := sd.ContentInfo()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	resp, err := asn1.Marshal(tsa.TimeStampResp{
+		Status:         tsa.PKIStatusInfo{Status: 0},
+		TimeStampToken: token,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", ContentTypeReply)
+	w.Write(resp)
+}
@@ -2,6 +2,7 @@ package signing
 
 import (
 	"crypto"
+	"crypto/x509"
 	"crypto/x509/pkix"
 	"encoding/json"
 	"hash"
@@ -20,15 +21,28 @@ type SigningContext interface {
 	GetIssuer() *pkix.Name
 }
 
+// RootCertificatesProvider is an optional interface for a SigningContext
+// providing the root certificates its certificate pool is based on.
+// It is used by handlers, which have to pass the root certificates
+// to an external process, because certificates cannot be extracted
+// from a x509.CertPool.
+type RootCertificatesProvider interface {
+	GetRootCertificates() []*x509.Certificate
+}
+
 type DefaultSigningContext struct {
-	Hash       crypto.Hash
-	PrivateKey signutils.GenericPrivateKey
-	PublicKey  signutils.GenericPublicKey
-	RootCerts  signutils.GenericCertificatePool
-	Issuer     *pkix.Name
+	Hash             crypto.Hash
+	PrivateKey       signutils.GenericPrivateKey
+	PublicKey        signutils.GenericPublicKey
+	RootCerts        signutils.GenericCertificatePool
+	RootCertificates []*x509.Certificate
+	Issuer           *pkix.Name
 }
 
-var _ SigningContext = (*DefaultSigningContext)(nil)
+var (
+	_ SigningContext           = (*DefaultSigningContext)(nil)
+	_ RootCertificatesProvider = (*DefaultSigningContext)(nil)
+)
 
 func (d *DefaultSigningContext) GetHash() crypto.Hash {
 	return d.Hash
@@ -46,6 +60,10 @@ func (d *DefaultSigningContext) GetRootCerts() signutils.GenericCertificatePool
 	return d.RootCerts
 }
 
+func (d *DefaultSigningContext) GetRootCertificates() []*x509.Certificate {
+	return d.RootCertificates
+}
+
 func (d *DefaultSigningContext) GetIssuer() *pkix.Name {
 	return d.Issuer
 }
@@ -98,6 +116,12 @@ type SignatureHandler interface {
 	Verifier
 }
 
+// KeyPairCreator is an optional interface of a SignatureHandler
+// able to create key pairs suitable for its signature algorithm.
+type KeyPairCreator interface {
+	CreateKeyPair() (priv signutils.GenericPrivateKey, pub signutils.GenericPublicKey, err error)
+}
+
 // Hasher creates a new hash.Hash interface.
 type Hasher interface {
 	Algorithm() string
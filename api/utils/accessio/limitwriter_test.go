@@ -0,0 +1,31 @@
+package accessio_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/utils/accessio"
+)
+
+var _ = Describe("limited buffer", func() {
+	It("accepts empty content", func() {
+		buf := accessio.LimitBuffer(10)
+		Expect(buf.Exceeded()).To(BeFalse())
+		Expect(buf.Bytes()).To(BeEmpty())
+	})
+
+	It("accepts content up to limit", func() {
+		buf := accessio.LimitBuffer(10)
+		buf.Write([]byte(strings.Repeat("x", 10)))
+		Expect(buf.Exceeded()).To(BeFalse())
+		Expect(string(buf.Bytes())).To(Equal(strings.Repeat("x", 10)))
+	})
+
+	It("detects exceeded limit", func() {
+		buf := accessio.LimitBuffer(10)
+		buf.Write([]byte(strings.Repeat("x", 11)))
+		Expect(buf.Exceeded()).To(BeTrue())
+	})
+})
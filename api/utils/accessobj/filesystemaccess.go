@@ -76,7 +76,11 @@ func (a *FileSystemBlobAccess) GetBlobData(digest digest.Digest) (int64, blobacc
 	}
 	path := a.DigestPath(digest)
 	if ok, err := vfs.FileExists(a.base.GetFileSystem(), path); ok {
-		return blobaccess.BLOB_UNKNOWN_SIZE, file.DataAccess(a.base.GetFileSystem(), path), nil
+		size := blobaccess.BLOB_UNKNOWN_SIZE
+		if fi, err := a.base.GetFileSystem().Stat(path); err == nil {
+			size = fi.Size()
+		}
+		return size, file.DataAccess(a.base.GetFileSystem(), path), nil
 	} else {
 		if err != nil {
 			return blobaccess.BLOB_UNKNOWN_SIZE, nil, err
@@ -153,3 +157,49 @@ func (a *FileSystemBlobAccess) AddBlob(blob blobaccess.BlobAccess) error {
 	}
 	return w.Close()
 }
+
+// ListBlobs returns the digests of all blobs stored in the
+// blob directory.
+func (a *FileSystemBlobAccess) ListBlobs() ([]digest.Digest, error) {
+	if a.IsClosed() {
+		return nil, accessio.ErrClosed
+	}
+	dir := a.base.GetInfo().GetElementDirectoryName()
+	if ok, err := vfs.DirExists(a.base.GetFileSystem(), dir); !ok || err != nil {
+		return nil, err
+	}
+	entries, err := vfs.ReadDir(a.base.GetFileSystem(), dir)
+	if err != nil {
+		return nil, err
+	}
+	var result []digest.Digest
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		d := common.PathToDigest(e.Name())
+		if d.Validate() == nil {
+			result = append(result, d)
+		}
+	}
+	return result, nil
+}
+
+// RemoveBlob removes the blob with the given digest from
+// the blob directory.
+func (a *FileSystemBlobAccess) RemoveBlob(digest digest.Digest) error {
+	if a.base.IsClosed() {
+		return accessio.ErrClosed
+	}
+	if a.base.IsReadOnly() {
+		return accessio.ErrReadOnly
+	}
+	path := a.DigestPath(digest)
+	if ok, err := vfs.FileExists(a.base.GetFileSystem(), path); !ok {
+		if err != nil {
+			return err
+		}
+		return blobaccess.ErrBlobNotFound(digest)
+	}
+	return a.base.GetFileSystem().Remove(path)
+}
@@ -28,8 +28,9 @@ type fileDataAccess struct {
 }
 
 var (
-	_ bpi.DataSource  = (*fileDataAccess)(nil)
-	_ bpi.Validatable = (*fileDataAccess)(nil)
+	_ bpi.DataSource   = (*fileDataAccess)(nil)
+	_ bpi.Validatable  = (*fileDataAccess)(nil)
+	_ bpi.FileLocation = (*fileDataAccess)(nil)
 )
 
 func DataAccess(fs vfs.FileSystem, path string) bpi.DataAccess {
@@ -70,6 +71,14 @@ func (a *fileDataAccess) Origin() string {
 	return a.path
 }
 
+func (a *fileDataAccess) FileSystem() vfs.FileSystem {
+	return a.fs
+}
+
+func (a *fileDataAccess) Path() string {
+	return a.path
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 type fileBlobAccess struct {
@@ -82,14 +91,6 @@ var (
 	_ bpi.FileLocation = (*fileBlobAccess)(nil)
 )
 
-func (f *fileBlobAccess) FileSystem() vfs.FileSystem {
-	return f.fs
-}
-
-func (f *fileBlobAccess) Path() string {
-	return f.path
-}
-
 func (f *fileBlobAccess) Dup() (bpi.BlobAccess, error) {
 	return f, nil
 }
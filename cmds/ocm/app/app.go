@@ -17,6 +17,7 @@ import (
 	clictx "ocm.software/ocm/api/cli"
 	"ocm.software/ocm/api/datacontext/attrs/clicfgattr"
 	"ocm.software/ocm/api/ocm/extensions/attrs/plugincacheattr"
+	"ocm.software/ocm/api/ocm/extensions/attrs/plugindirattr"
 	"ocm.software/ocm/api/ocm/ocmutils/defaultconfigregistry"
 	"ocm.software/ocm/api/ocm/plugin/registration"
 	"ocm.software/ocm/api/utils/cobrautils"
@@ -30,6 +31,7 @@ import (
 	creds "ocm.software/ocm/cmds/ocm/commands/misccmds/credentials"
 	"ocm.software/ocm/cmds/ocm/commands/ocicmds"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds"
+	plugininputs "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/plugin"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/componentarchive"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/names"
@@ -47,10 +49,13 @@ import (
 	"ocm.software/ocm/cmds/ocm/commands/verbs/check"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/clean"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/controller"
+	"ocm.software/ocm/cmds/ocm/commands/verbs/convert"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/create"
+	del "ocm.software/ocm/cmds/ocm/commands/verbs/delete"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/describe"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/download"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/execute"
+	"ocm.software/ocm/cmds/ocm/commands/verbs/explain"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/get"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/hash"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/install"
@@ -58,6 +63,7 @@ import (
 	"ocm.software/ocm/cmds/ocm/commands/verbs/set"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/show"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/sign"
+	"ocm.software/ocm/cmds/ocm/commands/verbs/tag"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/transfer"
 	"ocm.software/ocm/cmds/ocm/commands/verbs/verify"
 	cmdutils "ocm.software/ocm/cmds/ocm/common/utils"
@@ -227,6 +233,8 @@ func newCliCommand(opts *CLIOptions, mod ...func(clictx.Context, *cobra.Command)
 	cmd.AddCommand(check.NewCommand(opts.Context))
 	cmd.AddCommand(get.NewCommand(opts.Context))
 	cmd.AddCommand(set.NewCommand(opts.Context))
+	cmd.AddCommand(del.NewCommand(opts.Context))
+	cmd.AddCommand(explain.NewCommand(opts.Context))
 	cmd.AddCommand(list.NewCommand(opts.Context))
 	cmd.AddCommand(create.NewCommand(opts.Context))
 	cmd.AddCommand(add.NewCommand(opts.Context))
@@ -234,7 +242,9 @@ func newCliCommand(opts *CLIOptions, mod ...func(clictx.Context, *cobra.Command)
 	cmd.AddCommand(hash.NewCommand(opts.Context))
 	cmd.AddCommand(verify.NewCommand(opts.Context))
 	cmd.AddCommand(show.NewCommand(opts.Context))
+	cmd.AddCommand(tag.NewCommand(opts.Context))
 	cmd.AddCommand(transfer.NewCommand(opts.Context))
+	cmd.AddCommand(convert.NewCommand(opts.Context))
 	cmd.AddCommand(describe.NewCommand(opts.Context))
 	cmd.AddCommand(download.NewCommand(opts.Context))
 	cmd.AddCommand(bootstrap.NewCommand(opts.Context))
@@ -385,17 +395,36 @@ func (o *CLIOptions) Complete() error {
 	old := o.Context.ConfigContext().SkipUnknownConfig(true)
 	defer o.Context.ConfigContext().SkipUnknownConfig(old)
 
-	o.EvaluatedOptions, err = o.Config.Evaluate(o.Context.OCMContext(), true)
+	// the plugin extensions must be registered before the configuration
+	// is evaluated, because it may use plugin based types, for example
+	// credential repositories.
+	dir := plugindirattr.Get(o.Context.OCMContext())
+	err = o.registerExtensions()
 	if err != nil {
 		return err
 	}
-	err = registration.RegisterExtensions(o.Context)
+	o.EvaluatedOptions, err = o.Config.Evaluate(o.Context.OCMContext(), true)
 	if err != nil {
 		return err
 	}
+	if plugindirattr.Get(o.Context.OCMContext()) != dir {
+		// the configuration selects another plugin directory
+		err = o.registerExtensions()
+		if err != nil {
+			return err
+		}
+	}
 	return o.Context.ConfigContext().Validate()
 }
 
+func (o *CLIOptions) registerExtensions() error {
+	err := registration.RegisterExtensions(o.Context)
+	if err != nil {
+		return err
+	}
+	return plugininputs.RegisterExtensions(o.Context)
+}
+
 func prepare(s string) string {
 	idx := 0
 	for {
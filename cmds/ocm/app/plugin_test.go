@@ -0,0 +1,38 @@
+//go:build unix
+
+package app_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/ocm/plugin/testutils"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+)
+
+var _ = Describe("Plugin Extensions", func() {
+	var env *TestEnv
+	var plugins TempPluginDir
+
+	BeforeEach(func() {
+		env = NewTestEnv(TestData())
+		plugins = Must(ConfigureTestPlugins(env, "testdata/plugins"))
+	})
+
+	AfterEach(func() {
+		plugins.Cleanup()
+		env.Cleanup()
+	})
+
+	It("uses plugin based credential repositories in config files", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("--config", "testdata/credcfg.yaml", "get", "credentials", "type=OCIRegistry", "hostname=ghcr.io")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+ATTRIBUTE VALUE
+password  ghcr.io
+username  consumer
+`))
+	})
+})
@@ -4,7 +4,11 @@ import (
 	"github.com/spf13/cobra"
 
 	clictx "ocm.software/ocm/api/cli"
+	del "ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/delete"
+	"ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/explain"
 	credentials "ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/get"
+	"ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/list"
+	"ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/set"
 	"ocm.software/ocm/cmds/ocm/commands/misccmds/names"
 	"ocm.software/ocm/cmds/ocm/common/utils"
 )
@@ -17,5 +21,9 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 		Short: "Commands acting on credentials",
 	}, Names...)
 	cmd.AddCommand(credentials.NewCommand(ctx, credentials.Verb))
+	cmd.AddCommand(set.NewCommand(ctx, set.Verb))
+	cmd.AddCommand(del.NewCommand(ctx, del.Verb))
+	cmd.AddCommand(list.NewCommand(ctx, list.Verb))
+	cmd.AddCommand(explain.NewCommand(ctx, explain.Verb))
 	return cmd
 }
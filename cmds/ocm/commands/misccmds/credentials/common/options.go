@@ -0,0 +1,113 @@
+package common
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/spf13/pflag"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/credentials/extensions/repositories/encrypted"
+	"ocm.software/ocm/api/utils"
+	"ocm.software/ocm/cmds/ocm/common/options"
+)
+
+func From(o options.OptionSetProvider) *StoreOption {
+	var opt *StoreOption
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+// NewStoreOption creates an option to select the encrypted credential store.
+// If create is set, an option to create a missing key file is offered.
+func NewStoreOption(create bool) *StoreOption {
+	return &StoreOption{create: create}
+}
+
+type StoreOption struct {
+	create bool
+
+	Store     string
+	KeyFile   string
+	CreateKey bool
+}
+
+var _ options.Options = (*StoreOption)(nil)
+
+func (o *StoreOption) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Store, "store", "", "", "encrypted credential store file (default ~/.ocm/"+encrypted.DefaultStoreFileName+")")
+	fs.StringVarP(&o.KeyFile, "key-file", "", "", "key file used to encrypt the store")
+	if o.create {
+		fs.BoolVarP(&o.CreateKey, "create-key", "", false, "create key file, if it does not exist")
+	}
+}
+
+func (o *StoreOption) Usage() string {
+	s := `
+The credentials are stored in an encrypted file (option <code>--store</code>,
+default is <code>~/.ocm/` + encrypted.DefaultStoreFileName + `</code>). The content is encrypted with a
+master key read from a key file (option <code>--key-file</code>) or derived
+from the passphrase given by the environment variable
+<code>` + encrypted.ENV_PASSPHRASE + `</code>. If none of both is given, the key file
+<code>~/.ocm/` + encrypted.DefaultKeyFileName + `</code> is used.
+
+The store can be used as credential repository of type <code>` + encrypted.Type + `</code>
+in the ocm configuration.
+`
+	if o.create {
+		s += `
+With option <code>--create-key</code> a new key file is created, if the
+selected key file does not exist.
+`
+	}
+	return s
+}
+
+// StorePath provides the resolved path of the selected store file.
+func (o *StoreOption) StorePath() (string, error) {
+	if o.Store == "" {
+		return encrypted.DefaultStoreFile(), nil
+	}
+	return utils.ResolvePath(o.Store)
+}
+
+// Open opens the selected store.
+func (o *StoreOption) Open(ctx clictx.Context) (*encrypted.Store, error) {
+	fs := ctx.FileSystem()
+	path, err := o.StorePath()
+	if err != nil {
+		return nil, err
+	}
+
+	var key *encrypted.MasterKey
+	if o.CreateKey {
+		keyFile := o.KeyFile
+		if keyFile == "" {
+			keyFile = encrypted.DefaultKeyFile()
+		}
+		keyFile, err = utils.ResolvePath(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		_, err = fs.Stat(keyFile)
+		if err != nil {
+			if !vfs.IsNotExist(err) {
+				return nil, errors.Wrapf(err, "cannot check key file %q", keyFile)
+			}
+			key, err = encrypted.CreateKeyFile(keyFile, fs)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if key == nil {
+		key, err = encrypted.GetMasterKey(o.KeyFile, "", fs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	store, err := encrypted.OpenStore(path, key, fs)
+	if err != nil {
+		return nil, errors.Wrapf(err, "cannot open credential store")
+	}
+	return store, nil
+}
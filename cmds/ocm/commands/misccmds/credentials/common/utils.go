@@ -0,0 +1,28 @@
+package common
+
+import (
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+
+	"ocm.software/ocm/api/credentials"
+)
+
+// ParseConsumerIdentity parses a consumer identity given by
+// arguments of the form <name>=<value>.
+func ParseConsumerIdentity(args ...string) (credentials.ConsumerIdentity, error) {
+	id := credentials.ConsumerIdentity{}
+	for _, s := range args {
+		i := strings.Index(s, "=")
+		if i < 0 {
+			return nil, errors.ErrInvalid("consumer setting", s)
+		}
+		name := s[:i]
+		value := s[i+1:]
+		if len(name) == 0 {
+			return nil, errors.ErrInvalid("consumer setting", s)
+		}
+		id[name] = value
+	}
+	return id, nil
+}
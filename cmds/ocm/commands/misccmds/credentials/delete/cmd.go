@@ -0,0 +1,74 @@
+package delete
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/utils/out"
+	credcommon "ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/common"
+	"ocm.software/ocm/cmds/ocm/commands/misccmds/names"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var (
+	Names = names.Credentials
+	Verb  = verbs.Delete
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Consumer credentials.ConsumerIdentity
+}
+
+var _ utils.OCMCommand = (*Command)(nil)
+
+// NewCommand creates a new delete credentials command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, credcommon.NewStoreOption(false))}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] {<consumer property>=<value>}",
+		Short: "delete credentials for a consumer from the encrypted credential store",
+		Long: `
+Delete the credentials stored for a consumer identity from the encrypted
+credential store. The consumer identity must be given completely.
+`,
+		Example: `
+$ ocm delete credentials type=OCIRegistry hostname=ghcr.io
+`,
+	}
+}
+
+func (o *Command) Complete(args []string) error {
+	var err error
+	o.Consumer, err = credcommon.ParseConsumerIdentity(args...)
+	if err != nil {
+		return err
+	}
+	if len(o.Consumer) == 0 {
+		return errors.New("consumer identity required")
+	}
+	return nil
+}
+
+func (o *Command) Run() error {
+	store, err := credcommon.From(o).Open(o.Context)
+	if err != nil {
+		return err
+	}
+	if !store.Delete(o.Consumer) {
+		return errors.ErrNotFound("credentials for consumer", o.Consumer.String())
+	}
+	err = store.Save()
+	if err != nil {
+		return err
+	}
+	out.Outf(o, "credentials for consumer %s deleted\n", o.Consumer)
+	return nil
+}
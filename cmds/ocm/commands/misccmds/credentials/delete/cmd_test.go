@@ -0,0 +1,46 @@
+package delete_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/extensions/repositories/encrypted"
+	"ocm.software/ocm/api/tech/oci/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		store := Must(encrypted.OpenStore("/store", Must(encrypted.CreateKeyFile("/key", env)), env))
+		store.Set(cpi.NewConsumerIdentity(identity.CONSUMER_TYPE, identity.ID_HOSTNAME, "ghcr.io"), common.Properties{"token": "abc"})
+		MustBeSuccessful(store.Save())
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("deletes credentials", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("delete", "credentials", "--store", "/store", "--key-file", "/key",
+			cpi.ID_TYPE+"="+identity.CONSUMER_TYPE, identity.ID_HOSTNAME+"=ghcr.io")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+credentials for consumer {"hostname":"ghcr.io","type":"OCIRegistry"} deleted
+`))
+		store := Must(encrypted.OpenStore("/store", Must(encrypted.KeyFromFile("/key", env)), env))
+		Expect(store.Entries()).To(BeEmpty())
+	})
+
+	It("fails for unknown consumer", func() {
+		Expect(env.Execute("delete", "credentials", "--store", "/store", "--key-file", "/key",
+			cpi.ID_TYPE+"="+identity.CONSUMER_TYPE, identity.ID_HOSTNAME+"=gcr.io")).To(MatchError(`credentials for consumer "{"hostname":"gcr.io","type":"OCIRegistry"}" not found`))
+	})
+})
@@ -0,0 +1,198 @@
+package explain
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/utils/out"
+	"ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/common"
+	"ocm.software/ocm/cmds/ocm/commands/misccmds/names"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var (
+	Names = names.Credentials
+	Verb  = verbs.Explain
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	cmd *cobra.Command
+
+	Consumer credentials.ConsumerIdentity
+	Matcher  credentials.IdentityMatcher
+	Type     string
+
+	Operation []string
+}
+
+var _ utils.OCMCommand = (*Command)(nil)
+
+// NewCommand creates a new credentials explain command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx)}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "{<consumer property>=<value>} | -- <ocm command> {<argument>}",
+		Short: "Explain the credential resolution for a consumer or an OCM operation",
+		Long: `
+Trace the resolution of credentials and show the decisions taken by the
+credential context. The trace shows every requested consumer identity together
+with the used identity matcher, the evaluated consumer providers and the
+configured consumer identities accepted or rejected by the matcher. Additionally,
+resolved repository aliases and credential repository lookups are shown.
+Secret credential values are always redacted.
+
+If consumer properties are given, the credentials for this consumer
+identity are resolved like with <CMD>ocm get credentials</CMD>.
+The used matcher is derived from the consumer attribute <code>type</code>.
+The usage of a dedicated matcher can be enforced by the option <code>--matcher</code>.
+
+If an OCM command is given after the argument <code>--</code>, this command
+is executed and the trace of all credential requests executed by this command
+is shown after the command output. Global options must be given before
+the <code>explain</code> command.
+`,
+		Example: `
+$ ocm explain credentials type=OCIRegistry hostname=ghcr.io
+$ ocm explain credentials -- transfer componentversions ghcr.io/acme//acme.org/app:1.0.0 ./ctf
+`,
+	}
+}
+
+func (o *Command) TweakCommand(cmd *cobra.Command) {
+	o.cmd = cmd
+}
+
+func (o *Command) AddFlags(set *pflag.FlagSet) {
+	o.BaseCommand.AddFlags(set)
+	set.StringVarP(&o.Type, "matcher", "m", "", "matcher type override")
+}
+
+func (o *Command) Complete(args []string) error {
+	var err error
+
+	if n := o.cmd.ArgsLenAtDash(); n >= 0 {
+		o.Operation = args[n:]
+		args = args[:n]
+		if len(o.Operation) == 0 {
+			return errors.Newf("ocm command required after --")
+		}
+		if len(args) > 0 {
+			return errors.Newf("either consumer properties or an ocm command can be given")
+		}
+		return nil
+	}
+
+	if o.Type != "" {
+		o.Matcher = o.CredentialsContext().ConsumerIdentityMatchers().Get(o.Type)
+		if o.Matcher == nil {
+			return errors.ErrUnknown("identity matcher", o.Type)
+		}
+	}
+	o.Consumer, err = common.ParseConsumerIdentity(args...)
+	if err != nil {
+		return err
+	}
+	if len(o.Consumer) == 0 {
+		return errors.Newf("consumer properties or an ocm command required")
+	}
+	return nil
+}
+
+func (o *Command) Run() error {
+	trace := credentials.NewTraceRecorder()
+	old := credentials.GetTracer(o)
+	credentials.SetTracer(o, trace)
+	defer credentials.SetTracer(o, old)
+
+	var err error
+	if len(o.Operation) > 0 {
+		err = o.execute()
+		out.Outf(o, "\nCredential resolution trace:\n")
+	} else {
+		var creds credentials.Credentials
+		creds, err = credentials.CredentialsForConsumer(o.CredentialsContext(), o.Consumer, o.Matcher)
+		if err == nil && creds == nil {
+			defer out.Outf(o, "no credentials found for consumer %s\n", o.Consumer)
+		}
+	}
+	events := trace.Events()
+	if len(events) == 0 {
+		out.Outf(o, "no credentials requested\n")
+	}
+	for _, e := range events {
+		out.Outf(o, "%*s%s\n", 2*e.Depth, "", e)
+	}
+	return err
+}
+
+// execute runs the given ocm command in the actual cli context.
+// The run hooks of the command are called like by cobra. The persistent
+// hooks of the root command are omitted, they have already been executed
+// for the explain command.
+func (o *Command) execute() error {
+	root := o.cmd.Root()
+	cmd, args, err := root.Find(o.Operation)
+	if err != nil {
+		return err
+	}
+	if cmd == o.cmd {
+		return errors.Newf("credential explanation cannot be nested")
+	}
+	if cmd.RunE == nil && cmd.Run == nil {
+		return errors.Newf("%q is no executable ocm command", cmd.CommandPath())
+	}
+	err = cmd.ParseFlags(args)
+	if err != nil {
+		return err
+	}
+	args = cmd.Flags().Args()
+	err = cmd.ValidateArgs(args)
+	if err != nil {
+		return err
+	}
+
+	for p := cmd; p != nil && p != root; p = p.Parent() {
+		if p.PersistentPreRunE != nil || p.PersistentPreRun != nil {
+			if err := runHook(cmd, args, p.PersistentPreRunE, p.PersistentPreRun); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	if err := runHook(cmd, args, cmd.PreRunE, cmd.PreRun); err != nil {
+		return err
+	}
+	if err := runHook(cmd, args, cmd.RunE, cmd.Run); err != nil {
+		return err
+	}
+	if err := runHook(cmd, args, cmd.PostRunE, cmd.PostRun); err != nil {
+		return err
+	}
+	for p := cmd; p != nil && p != root; p = p.Parent() {
+		if p.PersistentPostRunE != nil || p.PersistentPostRun != nil {
+			return runHook(cmd, args, p.PersistentPostRunE, p.PersistentPostRun)
+		}
+	}
+	return nil
+}
+
+// runHook calls the error returning variant of a cobra hook, if given,
+// or the plain one.
+func runHook(cmd *cobra.Command, args []string, e func(*cobra.Command, []string) error, f func(*cobra.Command, []string)) error {
+	if e != nil {
+		return e(cmd, args)
+	}
+	if f != nil {
+		f(cmd, args)
+	}
+	return nil
+}
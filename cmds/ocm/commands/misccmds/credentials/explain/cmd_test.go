@@ -0,0 +1,121 @@
+package explain_test
+
+import (
+	"bytes"
+	"fmt"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/tech/oci/identity"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		cctx := env.CLI.CredentialsContext()
+
+		ids := credentials.NewConsumerIdentity("test", identity.ID_HOSTNAME, "ghcr.io")
+		creds := credentials.DirectCredentials{
+			"username": "testuser",
+			"password": "testpass",
+		}
+		cctx.SetCredentialsForConsumer(ids, creds)
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("explains consumer identity", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("explain", "credentials", cpi.ID_TYPE+"=test", identity.ID_HOSTNAME+"=ghcr.io", "path=repo")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+request consumer {"hostname":"ghcr.io","path":"repo","type":"test"} (matcher partial)
+  provider explicit
+    candidate {"hostname":"ghcr.io","type":"test"}: accepted
+result: credentials found for {"hostname":"ghcr.io","type":"test"}
+credentials: password=***, username=testuser
+`))
+	})
+
+	It("explains unknown consumer identity", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("explain", "credentials", cpi.ID_TYPE+"=test", identity.ID_HOSTNAME+"=gcr.io")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+request consumer {"hostname":"gcr.io","type":"test"} (matcher partial)
+  provider explicit
+    candidate {"hostname":"ghcr.io","type":"test"}: rejected
+result: no credentials found for {"hostname":"gcr.io","type":"test"}
+no credentials found for consumer {"hostname":"gcr.io","type":"test"}
+`))
+	})
+
+	It("explains ocm command", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("explain", "credentials", "--", "get", "credentials", cpi.ID_TYPE+"=test", identity.ID_HOSTNAME+"=ghcr.io")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+ATTRIBUTE VALUE
+password  testpass
+username  testuser
+
+Credential resolution trace:
+request consumer {"hostname":"ghcr.io","type":"test"} (matcher partial)
+  provider explicit
+    candidate {"hostname":"ghcr.io","type":"test"}: accepted
+result: credentials found for {"hostname":"ghcr.io","type":"test"}
+credentials: password=***, username=testuser
+`))
+	})
+
+	It("executes the run hooks of the ocm command", func() {
+		var hooks []string
+		mod := func(_ clictx.Context, cmd *cobra.Command) {
+			if cmd == nil {
+				return
+			}
+			get, _, err := cmd.Find([]string{"get", "credentials"})
+			Expect(err).To(Succeed())
+			get.PreRunE = func(*cobra.Command, []string) error {
+				hooks = append(hooks, "pre")
+				return nil
+			}
+			get.PostRun = func(*cobra.Command, []string) {
+				hooks = append(hooks, "post")
+			}
+		}
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).ExecuteModified(mod, "explain", "credentials", "--", "get", "credentials", cpi.ID_TYPE+"=test", identity.ID_HOSTNAME+"=ghcr.io")).To(Succeed())
+		Expect(hooks).To(Equal([]string{"pre", "post"}))
+	})
+
+	It("stops on failing pre run hooks", func() {
+		mod := func(_ clictx.Context, cmd *cobra.Command) {
+			if cmd == nil {
+				return
+			}
+			get, _, err := cmd.Find([]string{"get", "credentials"})
+			Expect(err).To(Succeed())
+			get.PreRunE = func(*cobra.Command, []string) error {
+				return fmt.Errorf("pre run failed")
+			}
+		}
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).ExecuteModified(mod, "explain", "credentials", "--", "get", "credentials", cpi.ID_TYPE+"=test", identity.ID_HOSTNAME+"=ghcr.io")).To(MatchError("pre run failed"))
+		Expect(buf.String()).NotTo(ContainSubstring("testpass"))
+	})
+
+	It("rejects nested explanations", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("explain", "credentials", "--", "explain", "credentials", "type=test")).To(MatchError("credential explanation cannot be nested"))
+	})
+})
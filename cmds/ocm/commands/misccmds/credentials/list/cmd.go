@@ -0,0 +1,68 @@
+package list
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/utils"
+	credcommon "ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/common"
+	"ocm.software/ocm/cmds/ocm/commands/misccmds/names"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/output"
+	cmdutils "ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var (
+	Names = names.Credentials
+	Verb  = verbs.List
+)
+
+type Command struct {
+	cmdutils.BaseCommand
+}
+
+var _ cmdutils.OCMCommand = (*Command)(nil)
+
+// NewCommand creates a new list credentials command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return cmdutils.SetupCommand(&Command{BaseCommand: cmdutils.NewBaseCommand(ctx, credcommon.NewStoreOption(false))}, cmdutils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>]",
+		Args:  cobra.NoArgs,
+		Short: "list credentials of the encrypted credential store",
+		Long: `
+List the entries of the encrypted credential store. Only the names
+of the stored credential properties are shown, the values are not
+revealed.
+`,
+		Example: `
+$ ocm list credentials
+`,
+	}
+}
+
+func (o *Command) Complete(args []string) error {
+	return nil
+}
+
+func (o *Command) Run() error {
+	store, err := credcommon.From(o).Open(o.Context)
+	if err != nil {
+		return err
+	}
+	list := [][]string{{"NAME", "CONSUMER", "PROPERTIES"}}
+	for _, e := range store.Entries() {
+		consumer := ""
+		if len(e.ConsumerId) > 0 {
+			consumer = e.ConsumerId.String()
+		}
+		list = append(list, []string{e.Name, consumer, strings.Join(utils.StringMapKeys(e.Properties), ",")})
+	}
+	output.FormatTable(o, "", list)
+	return nil
+}
@@ -0,0 +1,54 @@
+package list_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"ocm.software/ocm/api/credentials"
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/extensions/repositories/encrypted"
+	"ocm.software/ocm/api/tech/oci/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		store := Must(encrypted.OpenStore("/store", Must(encrypted.CreateKeyFile("/key", env)), env))
+		store.Set(cpi.NewConsumerIdentity(identity.CONSUMER_TYPE, identity.ID_HOSTNAME, "ghcr.io"), common.Properties{"username": "acme", "password": "secret"})
+		store.SetNamed("acme", common.Properties{"token": "abc"})
+		MustBeSuccessful(store.Save())
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("lists credentials", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("list", "credentials", "--store", "/store", "--key-file", "/key")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+NAME CONSUMER                                    PROPERTIES
+     {"hostname":"ghcr.io","type":"OCIRegistry"} password,username
+acme                                             token
+`))
+	})
+
+	It("uses the same filesystem as the store repository", func() {
+		cctx := env.CLI.CredentialsContext()
+		Must(cctx.RepositoryForSpec(encrypted.NewRepositorySpec("/store", "/key")))
+
+		creds := Must(credentials.CredentialsForConsumer(cctx, cpi.NewConsumerIdentity(identity.CONSUMER_TYPE, identity.ID_HOSTNAME, "ghcr.io")))
+		Expect(creds.Properties()).To(Equal(common.Properties{"username": "acme", "password": "secret"}))
+	})
+
+	It("fails without master key", func() {
+		Expect(env.Execute("list", "credentials", "--store", "/store", "--key-file", "/other")).To(MatchError(ContainSubstring(`cannot read key file "/other"`)))
+	})
+})
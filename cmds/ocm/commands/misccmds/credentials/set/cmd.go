@@ -0,0 +1,115 @@
+package set
+
+import (
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/credentials"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/out"
+	credcommon "ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/common"
+	"ocm.software/ocm/cmds/ocm/commands/misccmds/names"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var (
+	Names = names.Credentials
+	Verb  = verbs.Set
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Consumer credentials.ConsumerIdentity
+
+	Properties     []string
+	PropertyFiles  []string
+	CredentialProp common.Properties
+}
+
+var _ utils.OCMCommand = (*Command)(nil)
+
+// NewCommand creates a new set credentials command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, credcommon.NewStoreOption(true))}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] {<consumer property>=<value>}",
+		Short: "set credentials for a consumer in the encrypted credential store",
+		Long: `
+Store credentials for a consumer identity in the encrypted credential store.
+The consumer identity is given by the arguments. The credential properties are
+given with the option <code>--property</code>. Values can be read from files
+with the option <code>--property-file</code>. Credentials already stored for
+the identical consumer identity are replaced.
+`,
+		Example: `
+$ OCM_CREDENTIAL_STORE_PASSPHRASE=... ocm set credentials type=OCIRegistry hostname=ghcr.io --property username=acme --property-file password=token.txt
+$ ocm set credentials --create-key type=HelmChartRepository hostname=charts.acme.org --property username=acme --property password=secret
+`,
+	}
+}
+
+func (o *Command) AddFlags(set *pflag.FlagSet) {
+	o.BaseCommand.AddFlags(set)
+	set.StringArrayVarP(&o.Properties, "property", "p", nil, "credential property (<name>=<value>)")
+	set.StringArrayVarP(&o.PropertyFiles, "property-file", "", nil, "credential property read from file (<name>=<path>)")
+}
+
+func (o *Command) Complete(args []string) error {
+	var err error
+	o.Consumer, err = credcommon.ParseConsumerIdentity(args...)
+	if err != nil {
+		return err
+	}
+	if len(o.Consumer) == 0 {
+		return errors.New("consumer identity required")
+	}
+
+	o.CredentialProp = common.Properties{}
+	for _, p := range o.Properties {
+		name, value, ok := strings.Cut(p, "=")
+		if !ok || name == "" {
+			return errors.ErrInvalid("credential property", p)
+		}
+		o.CredentialProp[name] = value
+	}
+	for _, p := range o.PropertyFiles {
+		name, path, ok := strings.Cut(p, "=")
+		if !ok || name == "" {
+			return errors.ErrInvalid("credential property file", p)
+		}
+		data, err := vfs.ReadFile(o.FileSystem(), path)
+		if err != nil {
+			return errors.Wrapf(err, "cannot read file for credential property %q", name)
+		}
+		o.CredentialProp[name] = strings.TrimSuffix(string(data), "\n")
+	}
+	if len(o.CredentialProp) == 0 {
+		return errors.New("at least one credential property required")
+	}
+	return nil
+}
+
+func (o *Command) Run() error {
+	opt := credcommon.From(o)
+	store, err := opt.Open(o.Context)
+	if err != nil {
+		return err
+	}
+	store.Set(o.Consumer, o.CredentialProp)
+	err = store.Save()
+	if err != nil {
+		return err
+	}
+	out.Outf(o, "credentials for consumer %s stored\n", o.Consumer)
+	return nil
+}
@@ -0,0 +1,87 @@
+package set_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/credentials/cpi"
+	"ocm.software/ocm/api/credentials/extensions/repositories/encrypted"
+	"ocm.software/ocm/api/tech/oci/identity"
+	common "ocm.software/ocm/api/utils/misc"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("sets and lists credentials", func() {
+		MustBeSuccessful(vfs.WriteFile(env, "/token", []byte("secret\n"), 0o600))
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("set", "credentials", "--store", "/store", "--key-file", "/key", "--create-key",
+			cpi.ID_TYPE+"="+identity.CONSUMER_TYPE, identity.ID_HOSTNAME+"=ghcr.io",
+			"--property", "username=acme", "--property-file", "password=/token")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+credentials for consumer {"hostname":"ghcr.io","type":"OCIRegistry"} stored
+`))
+
+		store := Must(encrypted.OpenStore("/store", Must(encrypted.KeyFromFile("/key", env)), env))
+		Expect(store.Get(cpi.NewConsumerIdentity(identity.CONSUMER_TYPE, identity.ID_HOSTNAME, "ghcr.io"))).To(Equal(common.Properties{
+			"username": "acme",
+			"password": "secret",
+		}))
+
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("set", "credentials", "--store", "/store", "--key-file", "/key",
+			cpi.ID_TYPE+"=wget", identity.ID_HOSTNAME+"=acme.org", "-p", "token=abc")).To(Succeed())
+
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("list", "credentials", "--store", "/store", "--key-file", "/key")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+NAME CONSUMER                                    PROPERTIES
+     {"hostname":"acme.org","type":"wget"}       token
+     {"hostname":"ghcr.io","type":"OCIRegistry"} password,username
+`))
+	})
+
+	It("requires credential properties", func() {
+		Expect(env.Execute("set", "credentials", "--store", "/store", "--key-file", "/key", "--create-key",
+			cpi.ID_TYPE+"=wget")).To(MatchError("at least one credential property required"))
+	})
+
+	It("does not replace an existing key file", func() {
+		key := Must(encrypted.CreateKeyFile("/key", env))
+		data := Must(vfs.ReadFile(env, "/key"))
+		Expect(env.Execute("set", "credentials", "--store", "/store", "--key-file", "/key", "--create-key",
+			cpi.ID_TYPE+"=wget", "-p", "token=abc")).To(Succeed())
+		Expect(vfs.ReadFile(env, "/key")).To(Equal(data))
+
+		store := Must(encrypted.OpenStore("/store", key, env))
+		Expect(store.Get(cpi.NewConsumerIdentity("wget"))).To(Equal(common.Properties{"token": "abc"}))
+	})
+
+	It("fails for an unchecked key file", func() {
+		MustBeSuccessful(vfs.WriteFile(env, "/file", []byte("data"), 0o600))
+		Expect(env.Execute("set", "credentials", "--store", "/store", "--key-file", "/file/key", "--create-key",
+			cpi.ID_TYPE+"=wget", "-p", "token=abc")).To(MatchError(ContainSubstring(`cannot check key file "/file/key"`)))
+		Expect(vfs.FileExists(env, "/file")).To(BeTrue())
+	})
+
+	It("requires a master key", func() {
+		Expect(env.Execute("set", "credentials", "--store", "/store", "--key-file", "/key",
+			cpi.ID_TYPE+"=wget", "-p", "token=abc")).To(MatchError(ContainSubstring(`cannot read key file "/key"`)))
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"crypto/x509/pkix"
 	"encoding/pem"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 
 	clictx "ocm.software/ocm/api/cli"
 	"ocm.software/ocm/api/datacontext/attrs/rootcertsattr"
+	"ocm.software/ocm/api/ocm/compdesc"
 	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
 	"ocm.software/ocm/api/tech/signing"
 	"ocm.software/ocm/api/tech/signing/handlers/rsa"
@@ -23,6 +25,7 @@ import (
 	utils2 "ocm.software/ocm/api/utils"
 	"ocm.software/ocm/api/utils/cobrautils/flag"
 	"ocm.software/ocm/api/utils/encrypt"
+	"ocm.software/ocm/api/utils/listformat"
 	"ocm.software/ocm/api/utils/out"
 	"ocm.software/ocm/cmds/ocm/commands/misccmds/names"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common"
@@ -43,6 +46,8 @@ type Command struct {
 	priv        string
 	pub         string
 	ekey        string
+	algorithm   string
+	creator     signing.KeyPairCreator
 
 	attrs     map[string]string
 	ca        bool
@@ -74,7 +79,13 @@ func (o *Command) ForName(name string) *cobra.Command {
 		Long: `
 Create an RSA public key pair and save to files.
 
-The default for the filename to store the private key is <code>rsa.priv</code>.
+With the option <code>--algorithm</code> key pairs for other signature
+algorithms can be created. The following algorithms are supported:
+` + listformat.FormatList(rsa.Algorithm, keyPairAlgorithms()...) + `
+
+The default for the filename to store the private key is <code>rsa.priv</code>
+for RSA keys and the lower-cased algorithm name with the suffix <code>.priv</code>
+for other algorithms.
 If no public key file is specified, its name will be derived from the filename for
 the private key (suffix <code>.pub</code> for public key or <code>.cert</code>
 for certificate). If a certificate authority is given (<code>--ca-cert</code>)
@@ -101,12 +112,14 @@ For signing the public key the following subject attributes are supported:
 	`,
 		Example: `
 $ ocm create rsakeypair mandelsoft.priv mandelsoft.cert issuer=mandelsoft
+$ ocm create rsakeypair --algorithm Ed25519 mandelsoft.priv
 `,
 		Annotations: map[string]string{"ExampleCodeStyle": "bash"},
 	}
 }
 
 func (o *Command) AddFlags(set *pflag.FlagSet) {
+	set.StringVarP(&o.algorithm, "algorithm", "S", rsa.Algorithm, "signature algorithm of the key pair")
 	set.BoolVarP(&o.ca, "ca", "", false, "create certificate for a signing authority")
 	set.StringVarP(&o.rootcerts, "root-certs", "", "", "root certificates used to validate used certificate authority")
 	set.StringVarP(&o.cacert, "ca-cert", "", "", "certificate authority to sign public key")
@@ -134,6 +147,16 @@ func (o *Command) Complete(args []string) error {
 		return errors.Newf("only one of --encrypt or --encryptionKey is possible")
 	}
 
+	s := signingattr.Get(o.Context.OCMContext()).GetSigner(o.algorithm)
+	if s == nil {
+		return errors.ErrUnknown(compdesc.KIND_SIGN_ALGORITHM, o.algorithm)
+	}
+	if c, ok := s.(signing.KeyPairCreator); ok {
+		o.creator = c
+	} else {
+		return errors.ErrNotSupported("key pair creation", o.algorithm)
+	}
+
 	if o.rootcerts != "" {
 		pool, err := signutils.GetCertPool(o.rootcerts, false)
 		if err != nil {
@@ -256,6 +279,9 @@ func (o *Command) Complete(args []string) error {
 		o.priv = args[0]
 	} else {
 		o.priv = "rsa.priv"
+		if o.algorithm != rsa.Algorithm {
+			o.priv = strings.ToLower(o.algorithm) + ".priv"
+		}
 	}
 	if len(args) > 1 {
 		o.pub = args[1]
@@ -281,7 +307,7 @@ func (o *Command) Complete(args []string) error {
 func (o *Command) Run() error {
 	raw := false
 
-	priv, pub, err := rsa.Handler{}.CreateKeyPair()
+	priv, pub, err := o.creator.CreateKeyPair()
 	if err != nil {
 		return err
 	}
@@ -333,7 +359,7 @@ func (o *Command) Run() error {
 		}
 	}
 	if key != nil {
-		data, err := rsa.KeyData(priv)
+		data, err := KeyData(priv)
 		if err != nil {
 			return err
 		}
@@ -366,7 +392,11 @@ func (o *Command) Run() error {
 			add = "[" + o.ekey + "]"
 		}
 	}
-	out.Outf(o.Context, "created%s rsa key pair %s[%s]%s\n", msg, o.priv, o.pub, add)
+	kind := "rsa"
+	if o.algorithm != rsa.Algorithm {
+		kind = o.algorithm
+	}
+	out.Outf(o.Context, "created%s %s key pair %s[%s]%s\n", msg, kind, o.priv, o.pub, add)
 	return nil
 }
 
@@ -384,7 +414,7 @@ func (o *Command) WriteKey(key interface{}, path string, raw bool) error {
 			err = pem.Encode(fd, block)
 		}
 	} else {
-		err = rsa.WriteKeyData(key, fd)
+		err = WriteKeyData(key, fd)
 	}
 	if err != nil {
 		fd.Close()
@@ -397,3 +427,48 @@ func (o *Command) WriteKey(key interface{}, path string, raw bool) error {
 	}
 	return o.FileSystem().Chmod(path, 0o400)
 }
+
+// keyPairAlgorithms provides the signature algorithms of the default
+// registry able to create key pairs.
+func keyPairAlgorithms() []string {
+	var list []string
+	reg := signing.DefaultHandlerRegistry()
+	for _, n := range reg.SignerNames() {
+		if _, ok := reg.GetSigner(n).(signing.KeyPairCreator); ok {
+			list = append(list, n)
+		}
+	}
+	return list
+}
+
+// pemBlockForKey provides the PEM block for RSA keys in the
+// format used by the rsa handler and for other keys in the
+// generic format.
+func pemBlockForKey(key interface{}) (*pem.Block, error) {
+	if block, err := rsa.PemBlockForKey(key); err == nil {
+		return block, nil
+	}
+	if block := signutils.PemBlockForPrivateKey(key); block != nil {
+		return block, nil
+	}
+	if block := signutils.PemBlockForPublicKey(key); block != nil {
+		return block, nil
+	}
+	return nil, errors.ErrInvalid("key")
+}
+
+func KeyData(key interface{}) ([]byte, error) {
+	block, err := pemBlockForKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(block), nil
+}
+
+func WriteKeyData(key interface{}, w io.Writer) error {
+	block, err := pemBlockForKey(key)
+	if err != nil {
+		return err
+	}
+	return pem.Encode(w, block)
+}
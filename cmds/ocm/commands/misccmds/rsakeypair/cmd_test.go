@@ -2,6 +2,7 @@ package rsakeypair_test
 
 import (
 	"bytes"
+	"crypto/elliptic"
 	"crypto/x509/pkix"
 	"encoding/pem"
 
@@ -15,6 +16,8 @@ import (
 	"ocm.software/ocm/api/credentials"
 	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
 	"ocm.software/ocm/api/tech/signing"
+	"ocm.software/ocm/api/tech/signing/handlers/ecdsa"
+	"ocm.software/ocm/api/tech/signing/handlers/ed25519"
 	"ocm.software/ocm/api/tech/signing/handlers/rsa"
 	"ocm.software/ocm/api/tech/signing/signutils"
 	"ocm.software/ocm/api/utils/encrypt"
@@ -189,4 +192,56 @@ created rsa key pair key.priv[key.cert]
 			ExpectError(signing.VerifyCertDN(chain[1:], root, &pkix.Name{CommonName: "mandelsoft", Country: []string{"US"}}, chain[0])).To(MatchError(`country "US" not found`))
 		})
 	})
+
+	Context("other algorithms", func() {
+		It("creates ed25519 key pair", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("create", "rsakeypair", "--algorithm", ed25519.Algorithm)).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+created Ed25519 key pair ed25519.priv[ed25519.pub]
+`))
+			priv := Must(env.ReadFile("ed25519.priv"))
+			pub := Must(env.ReadFile("ed25519.pub"))
+
+			sctx := &signing.DefaultSigningContext{
+				PrivateKey: priv,
+				Issuer:     ISSUER,
+			}
+			d := digest.FromBytes([]byte("digest"))
+			sig := Must(ed25519.NewHandler().Sign(defaultContext, d.Hex(), sctx))
+			Expect(sig.Algorithm).To(Equal(ed25519.Algorithm))
+			Expect(sig.MediaType).To(Equal(ed25519.MediaType))
+
+			MustBeSuccessful(ed25519.NewHandler().Verify(d.Hex(), sig, &signing.DefaultSigningContext{PublicKey: pub}))
+		})
+
+		It("creates self-signed ecdsa key pair", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("create", "rsakeypair", "-S", ecdsa.AlgorithmP384, "key.priv", "CN=mandelsoft")).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+created ECDSA-P384 key pair key.priv[key.cert]
+`))
+			priv := Must(env.ReadFile("key.priv"))
+			pub := Must(env.ReadFile("key.cert"))
+
+			sctx := &signing.DefaultSigningContext{
+				PrivateKey: priv,
+				PublicKey:  pub,
+				RootCerts:  pub,
+				Issuer:     ISSUER,
+			}
+			d := digest.FromBytes([]byte("digest"))
+			h := ecdsa.NewHandlerFor(elliptic.P384())
+			sig := Must(h.Sign(defaultContext, d.Hex(), sctx))
+			Expect(sig.Algorithm).To(Equal(ecdsa.AlgorithmP384))
+			Expect(sig.MediaType).To(Equal(ecdsa.MediaTypePEM))
+			Expect(sig.Issuer).To(Equal("CN=mandelsoft"))
+
+			MustBeSuccessful(h.Verify(d.Hex(), sig, &signing.DefaultSigningContext{PublicKey: pub}))
+		})
+
+		It("rejects unknown algorithm", func() {
+			ExpectError(env.Execute("create", "rsakeypair", "-S", "unknown")).To(MatchError(`signing algorithm "unknown" is unknown`))
+		})
+	})
 })
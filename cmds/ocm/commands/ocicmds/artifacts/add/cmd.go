@@ -0,0 +1,333 @@
+package add
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/goutils/finalizer"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/oci"
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
+	"ocm.software/ocm/api/oci/extensions/repositories/ctf"
+	"ocm.software/ocm/api/oci/extensions/repositories/ocilayout"
+	"ocm.software/ocm/api/oci/tools/transfer"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/blobaccess/blobaccess"
+	"ocm.software/ocm/api/utils/blobaccess/dirtree"
+	"ocm.software/ocm/api/utils/blobaccess/file"
+	"ocm.software/ocm/api/utils/mime"
+	"ocm.software/ocm/api/utils/out"
+	"ocm.software/ocm/cmds/ocm/commands/ocicmds/names"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var (
+	Names = names.Artifacts
+	Verb  = verbs.Add
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	MediaType       string
+	Config          string
+	ConfigMediaType string
+	ArtifactType    string
+	Annotations     []string
+
+	Target string
+	Paths  []string
+
+	annotations map[string]string
+}
+
+// NewCommand creates a new add command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx)}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] <target artifact reference> {<path>}",
+		Args:  cobra.MinimumNArgs(2),
+		Short: "add an OCI artifact composed from local files",
+		Long: `
+Add an OCI artifact composed from local files to an OCI repository.
+The target is given by an artifact reference, which may describe any
+supported OCI repository, for example an OCI registry or a CTF. If the
+target is a file system path not yet existing, a CTF is created.
+
+Every given path is added as dedicated layer of a new OCI image manifest.
+A regular file is taken as it is, while a directory is added as a gzipped
+tar archive. The layer media type can be set with option <code>--mediatype</code>.
+It defaults to <code>application/octet-stream</code> for files and
+<code>` + artdesc.MediaTypeImageLayerGzip + `</code> for directories.
+The file name is kept in the layer annotation
+<code>` + ociv1.AnnotationTitle + `</code>.
+
+The config blob can be taken from a file with option <code>--config</code>.
+Otherwise, the empty JSON config is used. The artifact type can be set with
+option <code>--artifact-type</code>, annotations can be added with option
+<code>--annotation</code>.
+
+If a single path is given, describing an OCI image layout (a directory containing
+an <code>oci-layout</code> file), the artifact of the layout is added
+instead of composing a new one. The layout must describe a single artifact.
+Besides regular OCI image layouts, artifact sets in OCI format are accepted,
+here the main artifact is used.
+`,
+		Example: `
+$ ocm add artifact ghcr.io/MY_USER/data:1.0 data.json docs
+$ ocm add artifact --mediatype application/json --artifact-type application/vnd.acme.config ./ctf//acme/config:1.0 config.json
+$ ocm add artifact ghcr.io/MY_USER/image:1.0 ./layout
+`,
+		Annotations: map[string]string{"ExampleCodeStyle": "bash"},
+	}
+}
+
+func (o *Command) AddFlags(flags *pflag.FlagSet) {
+	o.BaseCommand.AddFlags(flags)
+	flags.StringVarP(&o.MediaType, "mediatype", "m", "", "media type used for layers")
+	flags.StringVarP(&o.Config, "config", "c", "", "file used as config blob")
+	flags.StringVarP(&o.ConfigMediaType, "config-mediatype", "", "", "media type of config blob")
+	flags.StringVarP(&o.ArtifactType, "artifact-type", "", "", "artifact type of the manifest")
+	flags.StringArrayVarP(&o.Annotations, "annotation", "a", nil, "manifest annotation (<name>=<value>)")
+}
+
+func (o *Command) Complete(args []string) error {
+	o.Target = args[0]
+	o.Paths = args[1:]
+
+	o.annotations = map[string]string{}
+	for _, a := range o.Annotations {
+		i := strings.Index(a, "=")
+		if i <= 0 {
+			return errors.ErrInvalid("annotation", a)
+		}
+		o.annotations[a[:i]] = a[i+1:]
+	}
+	return nil
+}
+
+func (o *Command) Run() (err error) {
+	var finalize finalizer.Finalizer
+	defer finalize.FinalizeWithErrorPropagation(&err)
+
+	session := oci.NewSession(nil)
+	finalize.Close(session, "session")
+
+	ref, err := oci.ParseRef(o.Target)
+	if err != nil {
+		return err
+	}
+	if ref.Repository == "" {
+		return errors.Newf("target repository required")
+	}
+	if ref.Digest != nil {
+		return fmt.Errorf("add to target digest not supported")
+	}
+	ref.CreateIfMissing = true
+	ref.TypeHint = ctf.Type
+	repo, err := session.DetermineRepositoryBySpec(o.Context.OCIContext(), &ref.UniformRepositorySpec)
+	if err != nil {
+		return err
+	}
+	ns, err := session.LookupNamespace(repo, ref.Repository)
+	if err != nil {
+		return err
+	}
+	tag := ""
+	if ref.Tag != nil {
+		tag = *ref.Tag
+	}
+
+	var blob blobaccess.BlobAccess
+	if len(o.Paths) == 1 && isLayout(o.FileSystem(), o.Paths[0]) {
+		blob, err = o.addLayout(&finalize, ns, tag)
+	} else {
+		blob, err = o.addArtifact(&finalize, ns, tag)
+	}
+	if err != nil {
+		return err
+	}
+	out.Outf(o, "added artifact %s (%s)\n", &ref, blob.Digest())
+	return nil
+}
+
+func (o *Command) addArtifact(finalize *finalizer.Finalizer, ns oci.NamespaceAccess, tag string) (blobaccess.BlobAccess, error) {
+	fs := o.FileSystem()
+
+	art, err := ns.NewArtifact()
+	if err != nil {
+		return nil, err
+	}
+	finalize.Close(art, "artifact")
+	m := art.ManifestAccess()
+
+	var config blobaccess.BlobAccess
+	if o.Config != "" {
+		mt := o.ConfigMediaType
+		if mt == "" {
+			mt = artdesc.MediaTypeImageConfig
+		}
+		config = file.BlobAccess(mt, o.Config, fs)
+	} else {
+		mt := o.ConfigMediaType
+		if mt == "" {
+			mt = artdesc.MediaTypeEmptyJSON
+		}
+		config = blobaccess.ForString(mt, "{}")
+	}
+	finalize.Close(config, "config")
+	err = m.SetConfigBlob(config, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "config")
+	}
+
+	for _, p := range o.Paths {
+		blob, err := o.layerBlob(p)
+		if err != nil {
+			return nil, errors.Wrapf(err, "layer %q", p)
+		}
+		finalize.Close(blob, p)
+		_, err = m.AddLayer(blob, &artdesc.Descriptor{
+			MediaType:   blob.MimeType(),
+			Annotations: map[string]string{ociv1.AnnotationTitle: filepath.Base(p)},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "layer %q", p)
+		}
+	}
+
+	d := art.GetDescriptor()
+	if o.ArtifactType != "" {
+		err = d.SetArtifactType(o.ArtifactType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for n, v := range o.annotations {
+		err = d.SetAnnotation(n, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ns.AddArtifact(art, oci.AsTags(tag)...)
+}
+
+func (o *Command) layerBlob(path string) (blobaccess.BlobAccess, error) {
+	fs := o.FileSystem()
+
+	ok, err := vfs.IsDir(fs, path)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		mt := o.MediaType
+		if mt == "" {
+			mt = artdesc.MediaTypeImageLayerGzip
+		}
+		return dirtree.BlobAccess(path, dirtree.WithFileSystem(fs), dirtree.WithMimeType(mt), dirtree.WithCompressWithGzip(true))
+	}
+	mt := o.MediaType
+	if mt == "" {
+		mt = mime.MIME_OCTET
+	}
+	return file.BlobAccess(mt, path, fs), nil
+}
+
+func (o *Command) addLayout(finalize *finalizer.Finalizer, ns oci.NamespaceAccess, tag string) (blobaccess.BlobAccess, error) {
+	path := o.Paths[0]
+
+	if isImageLayout(o.FileSystem(), path) {
+		blob, err := o.addImageLayout(finalize, ns, tag)
+		if err != nil {
+			return nil, errors.Wrapf(err, "oci layout %q", path)
+		}
+		return blob, nil
+	}
+
+	set, err := artifactset.Open(accessobj.ACC_READONLY, path, 0, accessio.PathFileSystem(o.FileSystem()))
+	if err != nil {
+		return nil, errors.Wrapf(err, "oci layout %q", path)
+	}
+	finalize.Close(set, "oci layout")
+
+	main := artifactset.RetrieveMainArtifactFromIndex(set.GetIndex())
+	if main == "" {
+		return nil, errors.Newf("oci layout %q contains no unique main artifact", path)
+	}
+	art, err := set.GetArtifact(main)
+	if err != nil {
+		return nil, errors.Wrapf(err, "oci layout %q", path)
+	}
+	finalize.Close(art, "layout artifact")
+
+	err = transfer.TransferArtifact(art, ns, oci.AsTags(tag)...)
+	if err != nil {
+		return nil, err
+	}
+	return art.Blob()
+}
+
+// addImageLayout adds the artifact described by an OCI image layout.
+// The layout must describe a single artifact.
+func (o *Command) addImageLayout(finalize *finalizer.Finalizer, ns oci.NamespaceAccess, tag string) (blobaccess.BlobAccess, error) {
+	layout, err := ocilayout.Open(o.Context.OCIContext(), accessobj.ACC_READONLY, o.Paths[0], 0, accessio.PathFileSystem(o.FileSystem()))
+	if err != nil {
+		return nil, err
+	}
+	finalize.Close(layout, "oci layout")
+
+	var main *artdesc.Descriptor
+	index := layout.GetIndex()
+	for i, d := range index.Manifests {
+		if main != nil && main.Digest != d.Digest {
+			return nil, errors.Newf("contains no unique main artifact")
+		}
+		main = &index.Manifests[i]
+	}
+	if main == nil {
+		return nil, errors.Newf("contains no artifact")
+	}
+
+	src, err := layout.LookupNamespace("")
+	if err != nil {
+		return nil, err
+	}
+	finalize.Close(src, "oci layout namespace")
+	art, err := src.GetArtifact(main.Digest.String())
+	if err != nil {
+		return nil, err
+	}
+	finalize.Close(art, "layout artifact")
+
+	err = transfer.TransferArtifact(art, ns, oci.AsTags(tag)...)
+	if err != nil {
+		return nil, err
+	}
+	return art.Blob()
+}
+
+func isLayout(fs vfs.FileSystem, path string) bool {
+	ok, err := vfs.FileExists(fs, filepath.Join(path, artifactset.OCILayouFileName))
+	return ok && err == nil
+}
+
+// isImageLayout checks for the blob structure used by an OCI image layout.
+// Artifact sets use an oci-layout file, also, but a flat blob folder.
+func isImageLayout(fs vfs.FileSystem, path string) bool {
+	ok, err := vfs.DirExists(fs, filepath.Join(path, ocilayout.BlobsDirectoryName, digest.SHA256.String()))
+	return ok && err == nil
+}
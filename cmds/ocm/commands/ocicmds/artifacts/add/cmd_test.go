@@ -0,0 +1,176 @@
+package add_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+	ociv1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/extensions/repositories/artifactset"
+	"ocm.software/ocm/api/oci/extensions/repositories/ctf"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	OUT     = "/tmp/res"
+	LAYOUT  = "/tmp/layout"
+	NS      = "mandelsoft/test"
+	VERSION = "v1"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		MustBeSuccessful(env.MkdirAll("/tmp/dir/sub", 0o755))
+		MustBeSuccessful(vfs.WriteFile(env, "/tmp/data.txt", []byte("testdata"), 0o644))
+		MustBeSuccessful(vfs.WriteFile(env, "/tmp/dir/sub/file", []byte("nested"), 0o644))
+		MustBeSuccessful(vfs.WriteFile(env, "/tmp/config.json", []byte(`{"name":"test"}`), 0o644))
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("adds an artifact composed from files", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("add", "artifact", "--artifact-type", "application/vnd.test",
+			"-a", "test=value", OUT+"//"+NS+":"+VERSION, "/tmp/data.txt", "/tmp/dir"))
+
+		repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(repo, "repo")
+		art := Must(repo.LookupArtifact(NS, VERSION))
+		defer Close(art, "artifact")
+
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+added artifact /tmp/res//mandelsoft/test:v1 (` + art.Digest().String() + `)
+`))
+
+		Expect(art.IsManifest()).To(BeTrue())
+		m := art.ManifestAccess().GetDescriptor()
+		Expect(m.ArtifactType).To(Equal("application/vnd.test"))
+		Expect(m.Annotations).To(Equal(map[string]string{"test": "value"}))
+		Expect(m.Config.MediaType).To(Equal(artdesc.MediaTypeEmptyJSON))
+		Expect(len(m.Layers)).To(Equal(2))
+		Expect(m.Layers[0].MediaType).To(Equal(mime.MIME_OCTET))
+		Expect(m.Layers[0].Annotations[ociv1.AnnotationTitle]).To(Equal("data.txt"))
+		Expect(m.Layers[1].MediaType).To(Equal(artdesc.MediaTypeImageLayerGzip))
+		Expect(m.Layers[1].Annotations[ociv1.AnnotationTitle]).To(Equal("dir"))
+
+		blob := Must(art.GetBlob(m.Layers[0].Digest))
+		defer Close(blob, "layer")
+		Expect(string(Must(blob.Get()))).To(Equal("testdata"))
+	})
+
+	It("adds an artifact with config and media type", func() {
+		MustBeSuccessful(env.Execute("add", "artifact", "--config", "/tmp/config.json", "-m", mime.MIME_TEXT,
+			OUT+"//"+NS+":"+VERSION, "/tmp/data.txt"))
+
+		repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(repo, "repo")
+		art := Must(repo.LookupArtifact(NS, VERSION))
+		defer Close(art, "artifact")
+
+		m := art.ManifestAccess().GetDescriptor()
+		Expect(m.Config.MediaType).To(Equal(artdesc.MediaTypeImageConfig))
+		blob := Must(art.GetBlob(m.Config.Digest))
+		defer Close(blob, "config")
+		Expect(string(Must(blob.Get()))).To(Equal(`{"name":"test"}`))
+		Expect(m.Layers[0].MediaType).To(Equal(mime.MIME_TEXT))
+	})
+
+	It("adds an artifact from an oci layout", func() {
+		env.ArtifactSet(LAYOUT, accessio.FormatDirectory, func() {
+			env.Manifest(VERSION, func() {
+				env.Config(func() {
+					env.BlobStringData(mime.MIME_JSON, "{}")
+				})
+				env.Layer(func() {
+					env.BlobStringData(mime.MIME_TEXT, "layout")
+				})
+			})
+		})
+		Expect(vfs.FileExists(env, LAYOUT+"/"+artifactset.OCILayouFileName)).To(BeTrue())
+
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("add", "artifact", OUT+"//"+NS+":"+VERSION, LAYOUT))
+
+		repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(repo, "repo")
+		art := Must(repo.LookupArtifact(NS, VERSION))
+		defer Close(art, "artifact")
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+added artifact /tmp/res//mandelsoft/test:v1 (` + art.Digest().String() + `)
+`))
+		m := art.ManifestAccess().GetDescriptor()
+		blob := Must(art.GetBlob(m.Layers[0].Digest))
+		defer Close(blob, "layer")
+		Expect(string(Must(blob.Get()))).To(Equal("layout"))
+	})
+
+	Context("oci image layout", func() {
+		var manifest string
+
+		blob := func(data string) artdesc.Descriptor {
+			d := digest.FromString(data)
+			MustBeSuccessful(env.MkdirAll(LAYOUT+"/blobs/sha256", 0o755))
+			MustBeSuccessful(vfs.WriteFile(env, LAYOUT+"/blobs/sha256/"+d.Encoded(), []byte(data), 0o644))
+			return artdesc.Descriptor{Digest: d, Size: int64(len(data))}
+		}
+
+		BeforeEach(func() {
+			config := blob("{}")
+			config.MediaType = artdesc.MediaTypeImageConfig
+			layer := blob("layout")
+			layer.MediaType = mime.MIME_TEXT
+			m := artdesc.NewManifest()
+			m.Config = config
+			m.Layers = append(m.Layers, layer)
+			data := Must(json.Marshal(m))
+			manifest = string(data)
+			desc := blob(manifest)
+			desc.MediaType = artdesc.MediaTypeImageManifest
+
+			index := artdesc.NewIndex()
+			index.Manifests = append(index.Manifests, desc)
+			MustBeSuccessful(vfs.WriteFile(env, LAYOUT+"/index.json", Must(json.Marshal(index)), 0o644))
+			MustBeSuccessful(vfs.WriteFile(env, LAYOUT+"/"+artifactset.OCILayouFileName, []byte(`{"imageLayoutVersion":"1.0.0"}`), 0o644))
+		})
+
+		It("adds the artifact", func() {
+			buf := bytes.NewBuffer(nil)
+			MustBeSuccessful(env.CatchOutput(buf).Execute("add", "artifact", OUT+"//"+NS+":"+VERSION, LAYOUT))
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+added artifact /tmp/res//mandelsoft/test:v1 (` + digest.FromString(manifest).String() + `)
+`))
+
+			repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OUT, 0, env))
+			defer Close(repo, "repo")
+			art := Must(repo.LookupArtifact(NS, VERSION))
+			defer Close(art, "artifact")
+			Expect(art.Digest()).To(Equal(digest.FromString(manifest)))
+			m := art.ManifestAccess().GetDescriptor()
+			Expect(m.Layers[0].MediaType).To(Equal(mime.MIME_TEXT))
+			b := Must(art.GetBlob(m.Layers[0].Digest))
+			defer Close(b, "layer")
+			Expect(string(Must(b.Get()))).To(Equal("layout"))
+		})
+
+		It("rejects corrupted blobs", func() {
+			MustBeSuccessful(vfs.WriteFile(env, LAYOUT+"/blobs/sha256/"+digest.FromString("layout").Encoded(), []byte("corrupted"), 0o644))
+			Expect(env.Execute("add", "artifact", OUT+"//"+NS+":"+VERSION, LAYOUT)).To(
+				MatchError(`oci layout "/tmp/layout": getting layer blob ` + digest.FromString("layout").String() + `: blob size mismatch 9 != 6`))
+		})
+	})
+})
@@ -4,9 +4,12 @@ import (
 	"github.com/spf13/cobra"
 
 	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/cmds/ocm/commands/ocicmds/artifacts/add"
+	"ocm.software/ocm/cmds/ocm/commands/ocicmds/artifacts/delete"
 	"ocm.software/ocm/cmds/ocm/commands/ocicmds/artifacts/describe"
 	"ocm.software/ocm/cmds/ocm/commands/ocicmds/artifacts/download"
 	"ocm.software/ocm/cmds/ocm/commands/ocicmds/artifacts/get"
+	"ocm.software/ocm/cmds/ocm/commands/ocicmds/artifacts/tag"
 	"ocm.software/ocm/cmds/ocm/commands/ocicmds/artifacts/transfer"
 	"ocm.software/ocm/cmds/ocm/commands/ocicmds/names"
 	"ocm.software/ocm/cmds/ocm/common/utils"
@@ -24,5 +27,8 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 	cmd.AddCommand(describe.NewCommand(ctx, describe.Verb))
 	cmd.AddCommand(transfer.NewCommand(ctx, transfer.Verb))
 	cmd.AddCommand(download.NewCommand(ctx, download.Verb))
+	cmd.AddCommand(add.NewCommand(ctx, add.Verb))
+	cmd.AddCommand(tag.NewCommand(ctx, tag.Verb))
+	cmd.AddCommand(delete.NewCommand(ctx, delete.Verb))
 	return cmd
 }
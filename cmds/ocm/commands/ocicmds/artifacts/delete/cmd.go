@@ -0,0 +1,193 @@
+package delete
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/opencontainers/go-digest"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/oci"
+	"ocm.software/ocm/api/utils/out"
+	"ocm.software/ocm/cmds/ocm/commands/ocicmds/common"
+	"ocm.software/ocm/cmds/ocm/commands/ocicmds/common/handlers/artifacthdlr"
+	"ocm.software/ocm/cmds/ocm/commands/ocicmds/common/options/repooption"
+	"ocm.software/ocm/cmds/ocm/commands/ocicmds/names"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/dryrunoption"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var (
+	Names = names.Artifacts
+	Verb  = verbs.Delete
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	TagsOnly bool
+
+	Refs []string
+}
+
+// NewCommand creates a new delete command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, repooption.New(), dryrunoption.New("only list the artifacts to delete", false))}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] {<artifact-reference>}",
+		Short: "delete OCI artifacts",
+		Long: `
+Delete OCI artifacts from their OCI repository. The artifacts are
+deleted together with all tags referring to them. If only a repository
+is specified, all tagged artifacts of this repository are deleted.
+
+With option <code>--tags-only</code> only the tags given by the
+artifact references are removed, the artifacts itself are kept and are
+still accessible by their digests. The option <code>--dry-run</code>
+just lists the artifacts, which would be deleted.
+
+After the deletion, blobs of the repository which are not used anymore by
+any artifact are removed, if supported by the repository type.
+`,
+		Example: `
+$ ocm delete artifact ghcr.io/MY_USER/ocmcli:0.17.0
+$ ocm delete artifact --tags-only --repo ./ctf ocm.software/ocmcli:latest
+`,
+		Annotations: map[string]string{"ExampleCodeStyle": "bash"},
+	}
+}
+
+func (o *Command) AddFlags(flags *pflag.FlagSet) {
+	o.BaseCommand.AddFlags(flags)
+	flags.BoolVarP(&o.TagsOnly, "tags-only", "t", false, "delete only the tags of tagged artifact references")
+}
+
+func (o *Command) Complete(args []string) error {
+	if len(args) == 0 && repooption.From(o).Spec == "" {
+		return fmt.Errorf("a repository or at least one argument that defines the reference is needed")
+	}
+	o.Refs = args
+	return nil
+}
+
+func (o *Command) Run() error {
+	session := oci.NewSession(nil)
+	defer session.Close()
+	err := o.ProcessOnOptions(common.CompleteOptionsWithContext(o.Context, session))
+	if err != nil {
+		return err
+	}
+	handler := artifacthdlr.NewTypeHandler(o.Context.OCI(), session, repooption.From(o).Repository)
+	return utils.HandleOutput(&action{cmd: o, session: session}, handler, utils.StringElemSpecs(o.Refs...)...)
+}
+
+/////////////////////////////////////////////////////////////////////////////
+
+type action struct {
+	cmd     *Command
+	session oci.Session
+
+	srcs []*artifacthdlr.Object
+}
+
+func (a *action) Add(e interface{}) error {
+	src, ok := e.(*artifacthdlr.Object)
+	if !ok {
+		return fmt.Errorf("failed type assertion for type %T to artifacthdlr.Object", e)
+	}
+	if a.cmd.TagsOnly && !src.Spec.IsTagged() {
+		return errors.Newf("tag required for artifact %s", &src.Spec)
+	}
+	a.srcs = append(a.srcs, src)
+	return nil
+}
+
+func (a *action) Close() error {
+	return nil
+}
+
+func (a *action) Out() error {
+	list := errors.ErrListf("deleting artifacts")
+
+	var repos []oci.Repository
+	used := map[oci.Repository]bool{}
+	done := map[string]bool{}
+
+	// tags of a namespace are not ordered, sort the selected references
+	// to get a stable output and a stable tag reported for artifacts
+	// with multiple tags.
+	sort.SliceStable(a.srcs, func(i, j int) bool {
+		return a.srcs[i].Spec.String() < a.srcs[j].Spec.String()
+	})
+	for _, src := range a.srcs {
+		dig := src.Artifact.Digest()
+		key := src.Spec.UniformRepositorySpec.String() + "//" + src.Spec.Repository
+		if a.cmd.TagsOnly {
+			key += ":" + *src.Spec.Tag
+		} else {
+			key += "@" + dig.String()
+		}
+		if done[key] {
+			continue
+		}
+		done[key] = true
+
+		if dryrunoption.From(a.cmd).DryRun {
+			if a.cmd.TagsOnly {
+				out.Outf(a.cmd.Context, "would delete tag %s\n", &src.Spec)
+			} else {
+				out.Outf(a.cmd.Context, "would delete artifact %s (%s)\n", &src.Spec, dig)
+			}
+			continue
+		}
+		err := a.delete(src, dig)
+		if err != nil {
+			list.Add(errors.Wrapf(err, "%s", &src.Spec))
+			out.Outf(a.cmd.Context, "deleting %s failed: %s\n", &src.Spec, err)
+			continue
+		}
+		if a.cmd.TagsOnly {
+			out.Outf(a.cmd.Context, "deleted tag %s\n", &src.Spec)
+			continue
+		}
+		out.Outf(a.cmd.Context, "deleted artifact %s (%s)\n", &src.Spec, dig)
+
+		repo, err := a.session.DetermineRepositoryBySpec(a.cmd.Context.OCIContext(), &src.Spec.UniformRepositorySpec)
+		if err == nil && !used[repo] {
+			used[repo] = true
+			repos = append(repos, repo)
+		}
+	}
+
+	for _, r := range repos {
+		blobs, err := oci.CleanupBlobs(r, false)
+		if err != nil {
+			if !errors.IsErrNotSupported(err) {
+				list.Add(errors.Wrapf(err, "cleanup blobs"))
+			}
+			continue
+		}
+		if len(blobs) > 0 {
+			out.Outf(a.cmd.Context, "removed %d orphaned blob(s)\n", len(blobs))
+		}
+	}
+	return list.Result()
+}
+
+func (a *action) delete(src *artifacthdlr.Object, dig digest.Digest) error {
+	deleter := src.Namespace.ArtifactDeleter()
+	if deleter == nil {
+		return errors.ErrNotSupported("artifact deletion")
+	}
+	if a.cmd.TagsOnly {
+		return deleter.DeleteTag(*src.Spec.Tag)
+	}
+	return deleter.DeleteArtifact(dig)
+}
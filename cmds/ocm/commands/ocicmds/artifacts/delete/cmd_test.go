@@ -0,0 +1,94 @@
+package delete_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/extensions/repositories/ctf"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	ARCH = "/tmp/ctf"
+	NS   = "mandelsoft/test"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+	var desc1, desc2 *artdesc.Descriptor
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		env.OCICommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Namespace(NS, func() {
+				desc1 = env.Manifest("v1", func() {
+					env.Tags("latest")
+					env.Config(func() {
+						env.BlobStringData(mime.MIME_JSON, "{}")
+					})
+					env.Layer(func() {
+						env.BlobStringData(mime.MIME_TEXT, "v1")
+					})
+				})
+				desc2 = env.Manifest("v2", func() {
+					env.Config(func() {
+						env.BlobStringData(mime.MIME_JSON, "{}")
+					})
+					env.Layer(func() {
+						env.BlobStringData(mime.MIME_TEXT, "v2")
+					})
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("deletes an artifact", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("delete", "artifact", ARCH+"//"+NS+":v1"))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+deleted artifact /tmp/ctf//mandelsoft/test:v1 (` + desc1.Digest.String() + `)
+removed 2 orphaned blob(s)
+`))
+
+		repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo, "repo")
+		ns := Must(repo.LookupNamespace(NS))
+		defer Close(ns, "namespace")
+		Expect(Must(ns.ListTags())).To(ConsistOf("v2"))
+		Expect(ns.HasArtifact(desc1.Digest.String())).To(BeFalse())
+	})
+
+	It("deletes a tag", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("delete", "artifact", "--tags-only", "--repo", ARCH, NS+":latest"))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+deleted tag CommonTransportFormat::/tmp/ctf//mandelsoft/test:latest
+`))
+
+		repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo, "repo")
+		ns := Must(repo.LookupNamespace(NS))
+		defer Close(ns, "namespace")
+		Expect(Must(ns.ListTags())).To(ConsistOf("v1", "v2"))
+	})
+
+	It("lists artifacts in dry-run mode", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("delete", "artifact", "--dry-run", ARCH+"//"+NS))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+would delete artifact /tmp/ctf//mandelsoft/test:latest (` + desc1.Digest.String() + `)
+would delete artifact /tmp/ctf//mandelsoft/test:v2 (` + desc2.Digest.String() + `)
+`))
+	})
+})
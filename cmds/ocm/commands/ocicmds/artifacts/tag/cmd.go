@@ -0,0 +1,119 @@
+package tag
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/oci"
+	"ocm.software/ocm/api/oci/grammar"
+	"ocm.software/ocm/api/utils/out"
+	"ocm.software/ocm/cmds/ocm/commands/ocicmds/common"
+	"ocm.software/ocm/cmds/ocm/commands/ocicmds/common/options/repooption"
+	"ocm.software/ocm/cmds/ocm/commands/ocicmds/names"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var (
+	Names = names.Artifacts
+	Verb  = verbs.Tag
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Ref  string
+	Tags []string
+}
+
+// NewCommand creates a new tag command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, repooption.New())}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] <artifact-reference> {<tag>}",
+		Args:  cobra.MinimumNArgs(2),
+		Short: "tag OCI artifacts",
+		Long: `
+Add tags to an OCI artifact. The artifact is specified by a tag or digest
+of an artifact in an OCI repository. All given tags are added to the
+repository the artifact is stored in. If a tag is already used for
+another artifact of the repository, it is moved to the given artifact.
+`,
+		Example: `
+$ ocm tag artifact ghcr.io/MY_USER/ocmcli:0.17.0 latest stable
+$ ocm tag artifact --repo ./ctf ocm.software/ocmcli@sha256:... 0.17.0
+`,
+		Annotations: map[string]string{"ExampleCodeStyle": "bash"},
+	}
+}
+
+func (o *Command) Complete(args []string) error {
+	o.Ref = args[0]
+	o.Tags = args[1:]
+	for _, t := range o.Tags {
+		if !grammar.AnchoredTagRegexp.MatchString(t) {
+			return errors.Newf("invalid tag %q", t)
+		}
+	}
+	return nil
+}
+
+func (o *Command) Run() error {
+	session := oci.NewSession(nil)
+	defer session.Close()
+
+	err := o.ProcessOnOptions(common.CompleteOptionsWithContext(o.Context, session))
+	if err != nil {
+		return err
+	}
+
+	var art oci.ArtifactAccess
+	var ns oci.NamespaceAccess
+	var spec oci.RefSpec
+
+	repo := repooption.From(o)
+	if repo.Repository != nil {
+		cr, err := oci.ParseArt(o.Ref)
+		if err != nil {
+			return err
+		}
+		if !cr.IsVersion() {
+			return errors.Newf("artifact version required for %q", o.Ref)
+		}
+		ns, err = session.LookupNamespace(repo.Repository, cr.Repository)
+		if err != nil {
+			return err
+		}
+		art, err = session.GetArtifact(ns, cr.Version())
+		if err != nil {
+			return err
+		}
+		spec.UniformRepositorySpec = *repo.Repository.GetSpecification().UniformRepositorySpec()
+		spec.ArtSpec = *cr
+	} else {
+		r, err := session.EvaluateRef(o.Context.OCIContext(), o.Ref)
+		if err != nil {
+			return err
+		}
+		if r.Artifact == nil {
+			return errors.Newf("artifact version required for %q", o.Ref)
+		}
+		ns = r.Namespace
+		art = r.Artifact
+		spec = r.Ref
+	}
+
+	dig := art.Digest()
+	err = ns.AddTags(dig, o.Tags...)
+	if err != nil {
+		return errors.Wrapf(err, "tagging %s", &spec)
+	}
+	for _, t := range o.Tags {
+		out.Outf(o, "tagged %s (%s) with %s\n", &spec, dig, t)
+	}
+	return nil
+}
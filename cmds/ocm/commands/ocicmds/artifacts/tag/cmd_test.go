@@ -0,0 +1,86 @@
+package tag_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"ocm.software/ocm/api/oci/artdesc"
+	"ocm.software/ocm/api/oci/extensions/repositories/ctf"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	ARCH = "/tmp/ctf"
+	NS   = "mandelsoft/test"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+	var desc1, desc2 *artdesc.Descriptor
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		env.OCICommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Namespace(NS, func() {
+				desc1 = env.Manifest("v1", func() {
+					env.Config(func() {
+						env.BlobStringData(mime.MIME_JSON, "{}")
+					})
+					env.Layer(func() {
+						env.BlobStringData(mime.MIME_TEXT, "v1")
+					})
+				})
+				desc2 = env.Manifest("v2", func() {
+					env.Config(func() {
+						env.BlobStringData(mime.MIME_JSON, "{}")
+					})
+					env.Layer(func() {
+						env.BlobStringData(mime.MIME_TEXT, "v2")
+					})
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("adds and moves tags", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("tag", "artifact", ARCH+"//"+NS+":v1", "latest", "stable"))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+tagged /tmp/ctf//mandelsoft/test:v1 (` + desc1.Digest.String() + `) with latest
+tagged /tmp/ctf//mandelsoft/test:v1 (` + desc1.Digest.String() + `) with stable
+`))
+
+		buf.Reset()
+		MustBeSuccessful(env.CatchOutput(buf).Execute("tag", "artifact", "--repo", ARCH, NS+"@"+desc2.Digest.String(), "latest"))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+tagged CommonTransportFormat::/tmp/ctf//mandelsoft/test@` + desc2.Digest.String() + ` (` + desc2.Digest.String() + `) with latest
+`))
+
+		repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo, "repo")
+		ns := Must(repo.LookupNamespace(NS))
+		defer Close(ns, "namespace")
+		Expect(Must(ns.ListTags())).To(ConsistOf("v1", "v2", "latest", "stable"))
+
+		art := Must(ns.GetArtifact("latest"))
+		defer Close(art, "latest")
+		Expect(art.Digest()).To(Equal(desc2.Digest))
+		art2 := Must(ns.GetArtifact("stable"))
+		defer Close(art2, "stable")
+		Expect(art2.Digest()).To(Equal(desc1.Digest))
+	})
+
+	It("rejects invalid tags", func() {
+		ExpectError(env.Execute("tag", "artifact", ARCH+"//"+NS+":v1", "a:b")).To(MatchError(`invalid tag "a:b"`))
+	})
+})
@@ -31,6 +31,7 @@ type Command struct {
 	utils.BaseCommand
 
 	TransferRepo bool
+	Referrers    bool
 
 	Refs   []string
 	Target string
@@ -64,6 +65,11 @@ Sources may be specified as
 - registry, if the specified registry implementation supports a namespace/repository lister,
   which is not the case for registries conforming to the OCI distribution specification.
 
+With option <code>--referrers</code> the artifacts referring to the transferred
+artifacts by their <code>subject</code> field (for example signatures, SBOMs or
+attestations) are transferred, also. For registries not supporting the
+OCI referrers API, the referrers tag schema is used.
+
 Note that there is an indirection of "ocm oci artifact" to "ocm transfer artifact" out of convenience.`,
 		Example: `
 # Simple:
@@ -91,6 +97,7 @@ $ ocm transfer artifact  CommonTransportFormat::$PATH_TO_CTF//component-descript
 func (o *Command) AddFlags(flags *pflag.FlagSet) {
 	o.BaseCommand.AddFlags(flags)
 	flags.BoolVarP(&o.TransferRepo, "repo-name", "R", false, "transfer repository name")
+	flags.BoolVarP(&o.Referrers, "referrers", "", false, "transfer referrers (signatures, SBOMs, attestations)")
 }
 
 func (o *Command) Complete(args []string) error {
@@ -109,7 +116,7 @@ func (o *Command) Run() error {
 	if err != nil {
 		return err
 	}
-	a, err := NewAction(o.Context, session, o.Target, o.TransferRepo)
+	a, err := NewAction(o.Context, session, o.Target, o.TransferRepo, o.Referrers)
 	if err != nil {
 		return err
 	}
@@ -126,6 +133,7 @@ type action struct {
 	Registry     oci.Repository
 	Ref          oci.RefSpec
 	TransferRepo bool
+	Referrers    bool
 
 	srcs         []*artifacthdlr.Object
 	repositories map[string]map[string]digest.Digest
@@ -133,7 +141,7 @@ type action struct {
 	copied int
 }
 
-func NewAction(ctx clictx.Context, session oci.Session, target string, transferRepo bool) (*action, error) {
+func NewAction(ctx clictx.Context, session oci.Session, target string, transferRepo, referrers bool) (*action, error) {
 	ref, err := oci.ParseRef(target)
 	if err != nil {
 		return nil, err
@@ -159,6 +167,7 @@ func NewAction(ctx clictx.Context, session oci.Session, target string, transferR
 		Ref:          ref,
 		Registry:     repo,
 		TransferRepo: transferRepo,
+		Referrers:    referrers,
 		repositories: map[string]map[string]digest.Digest{},
 	}, nil
 }
@@ -232,10 +241,17 @@ func (a *action) handleArtifact(src *artifacthdlr.Object) error {
 	}
 	out.Outf(a.Context, "copying %s to %s...\n", &src.Spec, &tgt)
 	err = transfer.TransferArtifact(src.Artifact, ns, tag)
-	if err == nil {
-		a.copied++
+	if err != nil {
+		return err
+	}
+	if a.Referrers {
+		err = transfer.TransferReferrers(src.Namespace, src.Artifact.Digest(), ns)
+		if err != nil {
+			return err
+		}
 	}
-	return err
+	a.copied++
+	return nil
 }
 
 func (a *action) Target(obj *artifacthdlr.Object) (string, string) {
@@ -8,8 +8,14 @@ import (
 	. "github.com/onsi/gomega"
 	. "ocm.software/ocm/cmds/ocm/testhelper"
 
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	"ocm.software/ocm/api/oci/artdesc"
 	"ocm.software/ocm/api/oci/extensions/repositories/ctf"
+	"ocm.software/ocm/api/oci/extensions/repositories/ocilayout"
 	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
 	"ocm.software/ocm/api/utils/mime"
 )
 
@@ -18,6 +24,7 @@ const (
 	VERSION = "v1"
 	NS      = "mandelsoft/test"
 	OUT     = "/tmp/res"
+	LAYOUT  = "/tmp/layout"
 )
 
 var _ = Describe("Test Environment", func() {
@@ -56,6 +63,32 @@ copied 1 from 1 artifact(s) and 1 repositories
 		Expect(env.ReadFile(OUT + "/" + ctf.ArtifactIndexFileName)).To(Equal([]byte("{\"schemaVersion\":1,\"artifacts\":[{\"repository\":\"mandelsoft/test\",\"tag\":\"v1\",\"digest\":\"sha256:2c3e2c59e0ac9c99864bf0a9f9727c09f21a66080f9f9b03b36a2dad3cce6ff9\"}]}")))
 	})
 
+	It("transfers a named artifact to an oci image layout", func() {
+		env.OCICommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Namespace(NS, func() {
+				env.Manifest(VERSION, func() {
+					env.Config(func() {
+						env.BlobStringData(mime.MIME_JSON, "{}")
+					})
+					env.Layer(func() {
+						env.BlobStringData(mime.MIME_TEXT, "testdata")
+					})
+				})
+			})
+		})
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "artifact", ARCH+"//"+NS+":"+VERSION, "+oci-layout::"+LAYOUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+copying /tmp/ctf//mandelsoft/test:v1 to oci-layout::` + LAYOUT + `//mandelsoft/test:v1...
+copied 1 from 1 artifact(s) and 1 repositories
+`))
+		Expect(env.ReadFile(LAYOUT + "/" + ocilayout.LayoutFileName)).To(MatchJSON(`{"imageLayoutVersion":"1.0.0"}`))
+		Expect(env.ReadFile(LAYOUT + "/" + ocilayout.IndexFileName)).To(MatchJSON(`{"schemaVersion":2,"mediaType":"application/vnd.oci.image.index.v1+json","manifests":[{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"sha256:2c3e2c59e0ac9c99864bf0a9f9727c09f21a66080f9f9b03b36a2dad3cce6ff9","size":342,"annotations":{"org.opencontainers.image.ref.name":"mandelsoft/test:v1"}}]}`))
+		Expect(vfs.FileExists(env, LAYOUT+"/blobs/sha256/2c3e2c59e0ac9c99864bf0a9f9727c09f21a66080f9f9b03b36a2dad3cce6ff9")).To(BeTrue())
+	})
+
 	It("transfers a named artifact to changed repository", func() {
 		env.OCICommonTransport(ARCH, accessio.FormatDirectory, func() {
 			env.Namespace(NS, func() {
@@ -125,4 +158,45 @@ copied 1 from 1 artifact(s) and 1 repositories
 `))
 		Expect(env.ReadFile(OUT + "/" + ctf.ArtifactIndexFileName)).To(Equal([]byte("{\"schemaVersion\":1,\"artifacts\":[{\"repository\":\"mandelsoft/test\",\"tag\":\"v1\",\"digest\":\"sha256:2c3e2c59e0ac9c99864bf0a9f9727c09f21a66080f9f9b03b36a2dad3cce6ff9\"}]}")))
 	})
+
+	It("transfers a named artifact with referrers", func() {
+		env.OCICommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Namespace(NS, func() {
+				mdesc := env.Manifest(VERSION, func() {
+					env.Config(func() {
+						env.BlobStringData(mime.MIME_JSON, "{}")
+					})
+					env.Layer(func() {
+						env.BlobStringData(mime.MIME_TEXT, "testdata")
+					})
+				})
+				env.Manifest("", func() {
+					env.Subject(mdesc)
+					env.ArtifactType("application/vnd.test.signature")
+					env.Config(func() {
+						env.BlobStringData(artdesc.MediaTypeEmptyJSON, "{}")
+					})
+					env.Layer(func() {
+						env.BlobStringData(mime.MIME_TEXT, "signature")
+					})
+				})
+			})
+		})
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("transfer", "artifact", "--referrers", ARCH+"//"+NS+":"+VERSION, "directory::"+OUT)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+copying /tmp/ctf//mandelsoft/test:v1 to directory::` + OUT + `//mandelsoft/test:v1...
+copied 1 from 1 artifact(s) and 1 repositories
+`))
+
+		repo := Must(ctf.Open(env.OCIContext(), accessobj.ACC_READONLY, OUT, 0, env))
+		defer Close(repo, "target")
+		ns := Must(repo.LookupNamespace(NS))
+		defer Close(ns, "target namespace")
+		list := Must(ns.ReferrersLister().ListReferrers(digest.Digest("sha256:2c3e2c59e0ac9c99864bf0a9f9727c09f21a66080f9f9b03b36a2dad3cce6ff9"), ""))
+		Expect(len(list)).To(Equal(1))
+		Expect(list[0].ArtifactType).To(Equal("application/vnd.test.signature"))
+	})
 })
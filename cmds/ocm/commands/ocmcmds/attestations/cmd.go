@@ -0,0 +1,25 @@
+package attestations
+
+import (
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/attestations/create"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/names"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var Names = names.Attestations
+
+// NewCommand creates a new command.
+func NewCommand(ctx clictx.Context) *cobra.Command {
+	cmd := utils.MassageCommand(&cobra.Command{
+		Short: "Commands working on component version attestations",
+	}, Names...)
+	AddCommands(ctx, cmd)
+	return cmd
+}
+
+func AddCommands(ctx clictx.Context, cmd *cobra.Command) {
+	cmd.AddCommand(create.NewCommand(ctx, create.Verb))
+}
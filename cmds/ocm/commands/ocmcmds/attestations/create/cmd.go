@@ -0,0 +1,219 @@
+package create
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/resolvers"
+	"ocm.software/ocm/api/ocm/tools/attestation"
+	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	"ocm.software/ocm/api/utils/out"
+	"ocm.software/ocm/cmds/ocm/commands/common/options/keyoption"
+	ocmcommon "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/names"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/output"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+const (
+	STORE_RESOURCE = "resource"
+	STORE_REFERRER = "referrer"
+	STORE_NONE     = "none"
+)
+
+var (
+	Names = names.Attestations
+	Verb  = verbs.Create
+)
+
+type Command struct {
+	utils.BaseCommand
+	CompSpec string
+
+	Signature    string
+	Algorithm    string
+	BuilderID    string
+	InvocationID string
+	Store        string
+	Resource     string
+	Output       string
+
+	Keys keyoption.Option
+}
+
+// NewCommand creates a new attestation create command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, repooption.New(), lookupoption.New())}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] <component-version>",
+		Args:  cobra.ExactArgs(1),
+		Short: "create a signed provenance attestation for a component version",
+		Long: `
+Create an in-toto statement with a SLSA provenance predicate for the
+given component version and sign it. The subjects of the statement are the
+digest of the normalized component descriptor and the digests of all
+resources of the component version. Referenced component versions are
+described as resolved dependencies.
+
+The statement is signed with the signing handler selected by option
+<code>--algorithm</code> using the private key provided for the
+signature name given by option <code>--signature</code>.
+The signed statement is stored as DSSE envelope according to option
+<code>--store</code>:
+
+- <code>resource</code>: (default) add it as local blob resource of type
+  <code>attestation</code> to the component version. Adding a resource
+  changes the component descriptor, therefore existing signatures of the
+  component version are invalidated. Resources of this type are ignored for
+  the component version digest used as attestation subject.
+- <code>referrer</code>: store it as OCI artifact referring to the artifact
+  used to store the component version. This requires an OCI based
+  OCM repository and keeps the component descriptor untouched.
+- <code>none</code>: don't store the attestation.
+
+With option <code>--output</code> the DSSE envelope is additionally written
+to a file.
+` + keyoption.Usage(),
+		Example: `
+$ ocm create attestation --signature acme.org --private-key acme.org=acme.priv ghcr.io/acme//acme.org/test:1.0.0
+$ ocm create attestation -s acme.org -K acme.org=acme.priv --store referrer -O att.json ghcr.io/acme//acme.org/test:1.0.0
+`,
+		Annotations: map[string]string{"ExampleCodeStyle": "bash"},
+	}
+}
+
+func (o *Command) AddFlags(fs *pflag.FlagSet) {
+	o.BaseCommand.AddFlags(fs)
+	o.Keys.AddFlags(fs)
+	fs.StringVarP(&o.Signature, "signature", "s", "", "signature (key) name")
+	fs.StringVarP(&o.Algorithm, "algorithm", "S", rsa.Algorithm, "signature handler")
+	fs.StringVarP(&o.BuilderID, "builder", "", attestation.DefaultBuilderID, "builder id used for the provenance predicate")
+	fs.StringVarP(&o.InvocationID, "invocation", "", "", "invocation id used for the provenance predicate")
+	fs.StringVarP(&o.Store, "store", "", STORE_RESOURCE, "storage mode (resource, referrer or none)")
+	fs.StringVarP(&o.Resource, "resource", "", attestation.DefaultResourceName, "resource name used to store the attestation")
+	fs.StringVarP(&o.Output, "output", "O", "", "output file for DSSE envelope")
+}
+
+func (o *Command) Complete(args []string) error {
+	o.CompSpec = args[0]
+	if o.Signature == "" {
+		return fmt.Errorf("signature name required")
+	}
+	switch o.Store {
+	case STORE_RESOURCE, STORE_REFERRER, STORE_NONE:
+	default:
+		return errors.ErrInvalid("storage mode", o.Store)
+	}
+	if o.Algorithm == "" {
+		o.Algorithm = rsa.Algorithm
+	}
+	o.Keys.DefaultName = o.Signature
+	return o.Keys.Configure(o.OCMContext())
+}
+
+func (o *Command) Run() error {
+	session := ocm.NewSession(nil)
+	defer session.Close()
+
+	err := o.ProcessOnOptions(ocmcommon.CompleteOptionsWithSession(o, session))
+	if err != nil {
+		return err
+	}
+	handler := comphdlr.NewTypeHandler(o.Context.OCM(), session, repooption.From(o).Repository)
+	return utils.HandleOutput(&action{cmd: o}, handler, utils.StringElemSpecs(o.CompSpec)...)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type action struct {
+	data comphdlr.Objects
+	cmd  *Command
+}
+
+var _ output.Output = (*action)(nil)
+
+func (a *action) Add(e interface{}) error {
+	if len(a.data) > 0 {
+		return errors.New("found multiple component versions")
+	}
+	o, ok := e.(*comphdlr.Object)
+	if !ok {
+		return fmt.Errorf("object of type %T is not a valid comphdlr.Object", e)
+	}
+	a.data = append(a.data, o)
+	return nil
+}
+
+func (a *action) Close() error {
+	return nil
+}
+
+func (a *action) Out() error {
+	if len(a.data) == 0 {
+		return fmt.Errorf("no component version selected")
+	}
+	o := a.data[0]
+	cv := o.ComponentVersion
+
+	opts := []attestation.Option{
+		attestation.WithResolver(resolvers.NewCompoundResolver(o.Repository, lookupoption.From(a.cmd).Resolver)),
+		attestation.WithBuilderID(a.cmd.BuilderID),
+		attestation.WithInvocationID(a.cmd.InvocationID),
+		attestation.WithSignAlgorithm(a.cmd.Algorithm),
+		attestation.WithKeys(a.cmd.Keys.Keys),
+	}
+
+	stmt, err := attestation.Create(cv, opts...)
+	if err != nil {
+		return err
+	}
+	env, err := attestation.Sign(a.cmd.Context, stmt, a.cmd.Signature, opts...)
+	if err != nil {
+		return err
+	}
+
+	if a.cmd.Output != "" {
+		data, err := json.MarshalIndent(env, "", "  ")
+		if err != nil {
+			return err
+		}
+		err = vfs.WriteFile(a.cmd.Context.FileSystem(), a.cmd.Output, data, 0o644)
+		if err != nil {
+			return errors.Wrapf(err, "cannot write attestation file %q", a.cmd.Output)
+		}
+		out.Outf(a.cmd.Context, "attestation written to %s\n", a.cmd.Output)
+	}
+
+	switch a.cmd.Store {
+	case STORE_RESOURCE:
+		err = attestation.AddResource(cv, env, a.cmd.Resource)
+		if err == nil {
+			err = cv.Update()
+		}
+		if err != nil {
+			return errors.Wrapf(err, "cannot store attestation resource")
+		}
+		out.Outf(a.cmd.Context, "attestation added as resource %q to %s\n", a.cmd.Resource, cv.GetName()+":"+cv.GetVersion())
+	case STORE_REFERRER:
+		dig, err := attestation.AddReferrer(cv, env)
+		if err != nil {
+			return err
+		}
+		out.Outf(a.cmd.Context, "attestation stored as referrer %s for %s\n", dig, cv.GetName()+":"+cv.GetVersion())
+	}
+	return nil
+}
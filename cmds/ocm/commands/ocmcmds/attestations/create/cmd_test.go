@@ -0,0 +1,91 @@
+package create_test
+
+import (
+	"bytes"
+	"regexp"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+	"github.com/opencontainers/go-digest"
+
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/ocm/tools/attestation"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	ARCH     = "/tmp/ctf"
+	VERSION  = "v1"
+	COMP     = "test.de/x"
+	PROVIDER = "acme.org"
+	OUT      = "/tmp/att.json"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMP, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, "testdata")
+					})
+				})
+			})
+		})
+		env.RSAKeyPair(PROVIDER)
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("creates attestation resource", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("create", "attestation", "-s", PROVIDER, ARCH)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+attestation added as resource "attestation" to test.de/x:v1
+`))
+		repo := Must(ctf.Open(env, accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo, "repo")
+		cv := Must(repo.LookupComponentVersion(COMP, VERSION))
+		defer Close(cv, "cv")
+
+		envelope := Must(attestation.GetResource(cv, ""))
+		stmt := Must(attestation.Verify(env, envelope, PROVIDER))
+		Expect(len(stmt.Subject)).To(Equal(2))
+		MustBeSuccessful(attestation.Validate(cv, stmt))
+	})
+
+	It("creates attestation referrer", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("create", "attestation", "-s", PROVIDER, "--store", "referrer", "-O", OUT, ARCH)).To(Succeed())
+
+		Expect(buf.String()).To(MatchRegexp(`^attestation written to /tmp/att.json\nattestation stored as referrer sha256:[0-9a-f]{64} for test.de/x:v1\n$`))
+		dig := digest.Digest(regexp.MustCompile(`sha256:[0-9a-f]{64}`).FindString(buf.String()))
+
+		envelope := Must(attestation.ParseEnvelope(Must(vfs.ReadFile(env.FileSystem(), OUT))))
+
+		repo := Must(ctf.Open(env, accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo, "repo")
+		cv := Must(repo.LookupComponentVersion(COMP, VERSION))
+		defer Close(cv, "cv")
+		Expect(len(cv.GetDescriptor().Resources)).To(Equal(1))
+		Expect(Must(attestation.GetReferrer(cv, dig))).To(Equal(envelope))
+	})
+
+	It("rejects unknown key", func() {
+		buf := bytes.NewBuffer(nil)
+		ExpectError(env.CatchOutput(buf).Execute("create", "attestation", "-s", "other", ARCH)).To(MatchError("private key \"other\" not found"))
+	})
+})
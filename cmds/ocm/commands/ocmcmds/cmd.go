@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/attestations"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/componentarchive"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/ctf"
@@ -13,6 +14,7 @@ import (
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/resourceconfig"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/resources"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/routingslips"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/sboms"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/sourceconfig"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/sources"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/verified"
@@ -42,6 +44,8 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 	cmd.AddCommand(routingslips.NewCommand(ctx))
 	cmd.AddCommand(pubsub.NewCommand(ctx))
 	cmd.AddCommand(verified.NewCommand(ctx))
+	cmd.AddCommand(attestations.NewCommand(ctx))
+	cmd.AddCommand(sboms.NewCommand(ctx))
 
 	cmd.AddCommand(utils.DocuCommandPath(topicocmrefs.New(ctx), "ocm"))
 	cmd.AddCommand(utils.DocuCommandPath(topicocmaccessmethods.New(ctx), "ocm"))
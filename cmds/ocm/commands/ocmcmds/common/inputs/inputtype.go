@@ -173,10 +173,22 @@ type inputTypeScheme struct {
 	optionTypes flagsets.ConfigTypeOptionSetConfigProvider
 }
 
-func NewInputTypeScheme(defaultRepoDecoder runtime.TypedObjectDecoder[InputSpec]) InputTypeScheme {
-	scheme := runtime.MustNewDefaultScheme[InputSpec, InputType](&UnknownInputSpec{}, false, defaultRepoDecoder)
+// NewInputTypeScheme creates a new input type scheme. If a base scheme is
+// given, the new scheme extends it. The types of the base scheme known
+// at creation time are also offered as CLI options.
+func NewInputTypeScheme(defaultRepoDecoder runtime.TypedObjectDecoder[InputSpec], base ...InputTypeScheme) InputTypeScheme {
+	var rbase []runtime.Scheme[InputSpec, InputType]
+	if b := utils.Optional(base...); b != nil {
+		rbase = append(rbase, b)
+	}
+	scheme := runtime.MustNewDefaultScheme[InputSpec, InputType](&UnknownInputSpec{}, false, defaultRepoDecoder, rbase...)
 	prov := flagsets.NewTypedConfigProvider("input", "blob input specification", "inputType")
 	prov.AddGroups("Input Specification Options")
+	for _, b := range rbase {
+		for _, n := range b.KnownTypeNames() {
+			prov.AddTypeSet(b.GetDecoder(n).ConfigOptionTypeSetHandler())
+		}
+	}
 	return &inputTypeScheme{scheme, prov}
 }
 
@@ -32,6 +32,7 @@ var (
 	VersionOption        = flagsets.NewStringOptionType("inputVersion", "version info for inputs")
 	TextOption           = flagsets.NewStringOptionType("inputText", "utf8 text")
 	HelmRepositoryOption = flagsets.NewStringOptionType("inputHelmRepository", "helm repository base URL")
+	SBOMFormatOption     = flagsets.NewStringOptionType("inputSbomFormat", "SBOM format (spdx or cyclonedx)")
 )
 
 var (
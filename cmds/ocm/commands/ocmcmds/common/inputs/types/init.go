@@ -11,6 +11,7 @@ import (
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/npm"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/ociartifact"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/ocm"
+	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/sbom"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/spiff"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/utf8"
 	_ "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/wget"
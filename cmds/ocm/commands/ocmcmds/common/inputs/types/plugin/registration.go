@@ -0,0 +1,47 @@
+package plugin
+
+import (
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/ocm/extensions/attrs/plugincacheattr"
+	"ocm.software/ocm/api/ocm/plugin"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+)
+
+// RegisterExtensions registers the input types provided by the found
+// plugins. They are registered in a context specific input type scheme
+// to avoid modifications of the default scheme.
+func RegisterExtensions(ctx clictx.Context) error {
+	pi := plugincacheattr.Get(ctx.OCMContext())
+
+	var scheme inputs.InputTypeScheme
+	for _, n := range pi.PluginNames() {
+		p := pi.Get(n)
+		if !p.IsValid() {
+			continue
+		}
+		for _, t := range p.GetDescriptor().InputTypes {
+			cur := scheme
+			if cur == nil {
+				cur = inputs.For(ctx)
+			}
+			if old := cur.GetInputType(t.Name); old != nil {
+				// extensions might be registered multiple times for the same context.
+				if o, ok := old.(*inputType); !ok || o.plug.Name() != p.Name() {
+					p.Context().Logger(plugin.TAG).Error("input type {{type}} already registered", "type", t.Name, "plugin", p.Name())
+				}
+				continue
+			}
+			if scheme == nil {
+				scheme = inputs.NewInputTypeScheme(nil, cur)
+			}
+			p.Context().Logger(plugin.TAG).Info("registering input type",
+				"plugin", p.Name(),
+				"type", t.Name)
+			scheme.Register(NewType(t.Name, p, &t))
+		}
+	}
+	if scheme != nil {
+		inputs.SetFor(ctx, scheme)
+	}
+	return nil
+}
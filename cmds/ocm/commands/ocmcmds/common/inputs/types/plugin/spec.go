@@ -0,0 +1,62 @@
+package plugin
+
+import (
+	"encoding/json"
+
+	"github.com/mandelsoft/goutils/errors"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"ocm.software/ocm/api/ocm/plugin"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/blobaccess"
+	"ocm.software/ocm/api/utils/runtime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+)
+
+// Spec is the input specification for plugin based input types.
+// The content is completely handled by the plugin.
+type Spec struct {
+	runtime.UnstructuredVersionedTypedObject `json:",inline"`
+	plug                                     plugin.Plugin
+}
+
+var _ inputs.InputSpec = (*Spec)(nil)
+
+func (s *Spec) Validate(fldPath *field.Path, ctx inputs.Context, inputFilePath string) field.ErrorList {
+	_, _, _, err := s.validate(ctx, inputFilePath)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, s.GetType(), err.Error())}
+	}
+	return nil
+}
+
+func (s *Spec) GetBlob(ctx inputs.Context, info inputs.InputResourceInfo) (blobaccess.BlobAccess, string, error) {
+	dir, data, pinfo, err := s.validate(ctx, info.InputFilePath)
+	if err != nil {
+		return nil, "", err
+	}
+	return accessobj.CachedBlobAccessForWriter(ctx, pinfo.MediaType, plugin.NewInputDataWriter(s.plug, dir, data)), pinfo.Hint, nil
+}
+
+func (s *Spec) GetInputVersion(ctx inputs.Context) string {
+	return ""
+}
+
+func (s *Spec) validate(ctx inputs.Context, inputFilePath string) (string, []byte, *plugin.InputSpecInfo, error) {
+	if s.plug == nil {
+		return "", nil, nil, errors.ErrUnknown(plugin.KIND_INPUTTYPE, s.GetType())
+	}
+	dir, err := inputs.GetBaseDir(ctx.FileSystem(), inputFilePath)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", nil, nil, errors.Wrapf(err, "cannot marshal input specification")
+	}
+	info, err := s.plug.ValidateInput(dir, data)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	return dir, data, info, nil
+}
@@ -0,0 +1,73 @@
+package plugin
+
+import (
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/options"
+	"ocm.software/ocm/api/ocm/plugin"
+	"ocm.software/ocm/api/utils/cobrautils/flagsets"
+	"ocm.software/ocm/api/utils/runtime"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+)
+
+type inputType struct {
+	inputs.InputType
+	plug    plugin.Plugin
+	cliopts flagsets.ConfigOptionTypeSet
+}
+
+var _ inputs.InputType = (*inputType)(nil)
+
+// NewType creates an input type delegating the handling of
+// input specifications to the given plugin.
+func NewType(name string, p plugin.Plugin, desc *plugin.InputTypeDescriptor) inputs.InputType {
+	t := &inputType{
+		plug: p,
+	}
+
+	cfghdlr := flagsets.NewConfigOptionTypeSetHandler(name, t.AddConfig)
+	for _, o := range desc.CLIOptions {
+		var opt flagsets.ConfigOptionType
+		if o.Type == "" {
+			opt = options.DefaultRegistry.GetOptionType(o.Name)
+			if opt == nil {
+				p.Context().Logger(plugin.TAG).Warn("unknown option", "plugin", p.Name(), "inputtype", name, "option", o.Name)
+			}
+		} else {
+			var err error
+			opt, err = options.DefaultRegistry.CreateOptionType(o.Type, o.Name, o.Description)
+			if err != nil {
+				p.Context().Logger(plugin.TAG).Warn("invalid option", "plugin", p.Name(), "inputtype", name, "option", o.Name, "error", err.Error())
+			}
+		}
+		if opt != nil {
+			cfghdlr.AddOptionType(opt)
+		}
+	}
+
+	usage := desc.Description
+	if desc.Format != "" {
+		usage += "\n\n" + desc.Format
+	}
+	usage += "\n\nThis input type is provided by plugin <code>" + p.Name() + "</code>."
+
+	var hdlr flagsets.ConfigOptionTypeSetHandler
+	if cfghdlr.Size() > 0 {
+		hdlr = cfghdlr
+		t.cliopts = cfghdlr
+	}
+	t.InputType = inputs.NewInputType(name, &Spec{}, usage, hdlr)
+	return t
+}
+
+func (t *inputType) Decode(data []byte, unmarshaler runtime.Unmarshaler) (inputs.InputSpec, error) {
+	spec, err := t.InputType.Decode(data, unmarshaler)
+	if err != nil {
+		return nil, err
+	}
+	spec.(*Spec).plug = t.plug
+	return spec, nil
+}
+
+func (t *inputType) AddConfig(opts flagsets.ConfigOptions, cfg flagsets.Config) error {
+	opts = opts.FilterBy(t.cliopts.HasOptionType)
+	return t.plug.ComposeInput(t.GetType(), opts, cfg)
+}
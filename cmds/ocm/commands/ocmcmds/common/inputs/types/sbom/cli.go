@@ -0,0 +1,20 @@
+package sbom
+
+import (
+	"ocm.software/ocm/api/utils/cobrautils/flagsets"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/cpi"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/options"
+)
+
+func ConfigHandler() flagsets.ConfigOptionTypeSetHandler {
+	return cpi.NewMediaFileSpecOptionType(TYPE, AddConfig,
+		options.SBOMFormatOption)
+}
+
+func AddConfig(opts flagsets.ConfigOptions, config flagsets.Config) error {
+	if err := cpi.AddMediaFileSpecConfig(opts, config); err != nil {
+		return err
+	}
+	flagsets.AddFieldByOptionP(opts, options.SBOMFormatOption, config, "sbomFormat")
+	return nil
+}
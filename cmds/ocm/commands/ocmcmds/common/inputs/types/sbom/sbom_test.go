@@ -0,0 +1,74 @@
+package sbom_test
+
+import (
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"ocm.software/ocm/api/tech/sbom"
+	"ocm.software/ocm/api/utils/mime"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	sbominput "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/sbom"
+)
+
+var _ = Describe("sbom generation", func() {
+	var env *TestEnv
+	var ictx inputs.Context
+	var info inputs.InputResourceInfo
+
+	nv := common.NewNameVersion("test", "v1")
+
+	BeforeEach(func() {
+		info = inputs.InputResourceInfo{
+			ComponentVersion: nv,
+			ElementName:      "elemname",
+			InputFilePath:    "/testdata/dummy",
+		}
+		env = NewTestEnv(TestData())
+		ictx = inputs.NewContext(env.Context, common.NewPrinter(env.Context.StdOut()), nil)
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	packages := []sbom.Package{
+		{Name: "github.com/acme/logging", Version: "v1.14.1", PURL: "pkg:golang/github.com/acme/logging@v1.14.1"},
+		{Name: "golang.org/x/net", Version: "v0.23.0", PURL: "pkg:golang/golang.org/x/net@v0.23.0"},
+	}
+
+	DescribeTable("generates sbom", func(format, expformat, mediatype string) {
+		spec := sbominput.New("app.mod", format, "", false)
+		blob, s := Must2(spec.GetBlob(ictx, info))
+		defer blob.Close()
+		Expect(s).To(Equal(""))
+		Expect(blob.MimeType()).To(Equal(mediatype))
+
+		doc := Must(sbom.Parse(Must(blob.Get()), blob.MimeType()))
+		Expect(doc).To(Equal(&sbom.Document{
+			Format:   expformat,
+			Name:     "acme.org/app",
+			Packages: packages,
+		}))
+	},
+		Entry("default", "", sbom.FORMAT_CYCLONEDX, sbom.MIME_CYCLONEDX_JSON),
+		Entry("spdx", sbom.FORMAT_SPDX, sbom.FORMAT_SPDX, sbom.MIME_SPDX_JSON),
+		Entry("cyclonedx", sbom.FORMAT_CYCLONEDX, sbom.FORMAT_CYCLONEDX, sbom.MIME_CYCLONEDX_JSON),
+	)
+
+	It("compresses sbom", func() {
+		spec := sbominput.New("app.mod", sbom.FORMAT_SPDX, "", true)
+		blob, _ := Must2(spec.GetBlob(ictx, info))
+		defer blob.Close()
+		Expect(blob.MimeType()).To(Equal(mime.MIME_GZIP))
+	})
+
+	It("rejects invalid format", func() {
+		spec := sbominput.New("app.mod", "other", "", false)
+		Expect(spec.Validate(field.NewPath("input"), ictx, info.InputFilePath)).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,57 @@
+package sbom
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"ocm.software/ocm/api/tech/sbom"
+	"ocm.software/ocm/api/utils/blobaccess"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/cpi"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/file"
+)
+
+type Spec struct {
+	cpi.MediaFileSpec `json:",inline"`
+	// SBOMFormat is the format of the generated SBOM.
+	SBOMFormat string `json:"sbomFormat,omitempty"`
+}
+
+var _ inputs.InputSpec = (*Spec)(nil)
+
+func New(path, format, mediatype string, compress bool) *Spec {
+	return &Spec{
+		MediaFileSpec: cpi.NewMediaFileSpec(TYPE, path, mediatype, compress),
+		SBOMFormat:    format,
+	}
+}
+
+func (s *Spec) Validate(fldPath *field.Path, ctx inputs.Context, inputFilePath string) field.ErrorList {
+	allErrs := (&file.FileProcessSpec{s.MediaFileSpec, nil}).Validate(fldPath, ctx, inputFilePath)
+	if s.SBOMFormat != "" && sbom.MimeTypeForFormat(s.SBOMFormat) == "" {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("sbomFormat"), s.SBOMFormat, []string{sbom.FORMAT_SPDX, sbom.FORMAT_CYCLONEDX}))
+	}
+	return allErrs
+}
+
+func (s *Spec) GetBlob(ctx inputs.Context, info inputs.InputResourceInfo) (blobaccess.BlobAccess, string, error) {
+	spec := s.MediaFileSpec
+	if !spec.Compress() {
+		spec.SetMediaTypeIfNotDefined(sbom.MimeTypeForFormat(s.format()))
+	}
+	return (&file.FileProcessSpec{spec, s.process}).GetBlob(ctx, info)
+}
+
+func (s *Spec) format() string {
+	if s.SBOMFormat == "" {
+		return sbom.FORMAT_CYCLONEDX
+	}
+	return s.SBOMFormat
+}
+
+func (s *Spec) process(ctx inputs.Context, inputFilePath string, data []byte) ([]byte, error) {
+	doc, err := sbom.FromGoModule(data)
+	if err != nil {
+		return nil, err
+	}
+	return sbom.Generate(doc, s.format())
+}
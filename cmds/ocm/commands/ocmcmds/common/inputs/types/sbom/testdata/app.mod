@@ -0,0 +1,8 @@
+module acme.org/app
+
+go 1.23
+
+require (
+	github.com/acme/logging v1.14.1
+	golang.org/x/net v0.23.0
+)
@@ -0,0 +1,26 @@
+package sbom
+
+import (
+	"ocm.software/ocm/api/tech/sbom"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs/types/file"
+)
+
+const TYPE = "sbom"
+
+func init() {
+	inputs.DefaultInputTypeScheme.Register(inputs.NewInputType(TYPE, &Spec{}, usage(), ConfigHandler()))
+}
+
+func usage() string {
+	return file.Usage(`The path must denote a Go module file (<code>go.mod</code>) relative the
+resources file. A software bill of materials (SBOM) is generated for the
+required modules.`) + `
+- **<code>sbomFormat</code>** *string*
+
+  This OPTIONAL property describes the format of the generated SBOM.
+  Possible values are <code>` + sbom.FORMAT_SPDX + `</code> and <code>` + sbom.FORMAT_CYCLONEDX + `</code>
+  (default). If no media type is given, the media type of the format is used
+  (` + sbom.MIME_SPDX_JSON + ` or ` + sbom.MIME_CYCLONEDX_JSON + `).
+`
+}
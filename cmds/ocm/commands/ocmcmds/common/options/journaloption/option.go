@@ -0,0 +1,83 @@
+package journaloption
+
+import (
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/pflag"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/ocm/tools/transfer/journal"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
+	"ocm.software/ocm/cmds/ocm/common/options"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	standard.TransferOptionsCreator
+	JournalFile  string
+	ResumeFile   string
+	ForceJournal bool
+	Journal     *journal.Journal
+}
+
+var (
+	_ options.OptionWithCLIContextCompleter = (*Option)(nil)
+	_ transferhandler.TransferOption        = (*Option)(nil)
+)
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.JournalFile, "journal", "", "", "file used to record the transfer progress")
+	fs.StringVarP(&o.ResumeFile, "resume", "", "", "journal file of a previous transfer to resume")
+	fs.BoolVarP(&o.ForceJournal, "force-journal", "", false, "overwrite an existing journal file")
+}
+
+func (o *Option) Configure(ctx clictx.Context) error {
+	var err error
+
+	if o.JournalFile != "" && o.ResumeFile != "" {
+		return errors.Newf("only one of --journal or --resume may be set")
+	}
+	if o.ForceJournal && o.JournalFile == "" {
+		return errors.Newf("--force-journal requires --journal")
+	}
+	if o.ResumeFile != "" {
+		o.Journal, err = journal.Open(ctx.FileSystem(), o.ResumeFile)
+	}
+	if o.JournalFile != "" {
+		o.Journal, err = journal.Create(ctx.FileSystem(), o.JournalFile, o.ForceJournal)
+	}
+	return err
+}
+
+func (o *Option) Usage() string {
+	s := `
+With the option <code>--journal</code> the progress of the transfer is recorded
+in the given file. An existing journal file is only overwritten with the
+option <code>--force-journal</code>. Every completed component version and every resource blob
+with a known digest uploaded to an external location (for example by an uploader)
+is recorded. If a transfer is aborted, it can be
+continued by using the option <code>--resume</code> with the journal file of the
+aborted transfer. Completed component versions and already transferred resource
+blobs are then skipped, and the progress of the resumed transfer is recorded in
+the same journal. A journal can only be resumed for the same target repository.
+Because archive formats are only written when the transfer finishes, resuming
+is only useful for directory-based or remote target repositories.
+`
+	return s
+}
+
+func (o *Option) ApplyTransferOption(opts transferhandler.TransferOptions) error {
+	if o.Journal != nil {
+		return standard.Journal(o.Journal).ApplyTransferOption(opts)
+	}
+	return nil
+}
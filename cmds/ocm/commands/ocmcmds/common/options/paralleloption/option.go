@@ -0,0 +1,51 @@
+package paralleloption
+
+import (
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
+	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/standard"
+	"ocm.software/ocm/api/utils/cobrautils/flag"
+	"ocm.software/ocm/cmds/ocm/common/options"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+func New() *Option {
+	return &Option{}
+}
+
+type Option struct {
+	standard.TransferOptionsCreator
+	flag     *pflag.Flag
+	Parallel int
+}
+
+var _ transferhandler.TransferOption = (*Option)(nil)
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	o.flag = flag.IntVarPF(fs, &o.Parallel, "parallel", "", 0, "maximum number of concurrent transfer operations")
+}
+
+func (o *Option) Usage() string {
+	s := `
+The option <code>--parallel</code> can be used to transfer nested component
+versions and resource/source blobs concurrently. It specifies the maximum number
+of transfer operations executed at the same time. The printed transfer log
+and the resulting component descriptors are identical to a sequential transfer.
+This setting can also be configured with the <code>parallel</code> field
+of the <code>transport.ocm.config.ocm.software</code> config type.
+`
+	return s
+}
+
+func (o *Option) ApplyTransferOption(opts transferhandler.TransferOptions) error {
+	if (o.flag != nil && o.flag.Changed) || o.Parallel > 0 {
+		return standard.Parallel(o.Parallel).ApplyTransferOption(opts)
+	}
+	return nil
+}
@@ -8,15 +8,20 @@ import (
 	"github.com/spf13/pflag"
 
 	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/datacontext/attrs/vfsattr"
 	"ocm.software/ocm/api/ocm/compdesc"
 	"ocm.software/ocm/api/ocm/compdesc/normalizations/jsonv1"
 	"ocm.software/ocm/api/ocm/extensions/attrs/signingattr"
+	"ocm.software/ocm/api/ocm/extensions/attrs/verificationattr"
 	ocmsign "ocm.software/ocm/api/ocm/tools/signing"
+	"ocm.software/ocm/api/ocm/tools/signing/policy"
 	"ocm.software/ocm/api/tech/signing"
 	"ocm.software/ocm/api/tech/signing/handlers/rsa"
 	"ocm.software/ocm/api/tech/signing/hasher/sha256"
 	"ocm.software/ocm/api/tech/signing/signutils"
+	"ocm.software/ocm/api/utils"
 	"ocm.software/ocm/api/utils/listformat"
+	"ocm.software/ocm/api/utils/runtime"
 	"ocm.software/ocm/cmds/ocm/commands/common/options/keyoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/hashoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/storeoption"
@@ -61,6 +66,10 @@ type Option struct {
 	Keyless bool
 
 	Verified storeoption.Option
+
+	policyFile string
+	// Policy is the verification policy used to verify signatures.
+	Policy *policy.Policy
 }
 
 const DEFAULT_VERIFIED_FILE = "~/.ocm/verified"
@@ -78,6 +87,7 @@ func (o *Option) AddFlags(fs *pflag.FlagSet) {
 		fs.StringVarP(&o.TSAUrl, "tsa-url", "", "", "TSA server URL")
 	} else {
 		fs.BoolVarP(&o.local, "local", "L", false, "verification based on information found in component versions, only")
+		fs.StringVarP(&o.policyFile, "policy", "", "", "verification policy file")
 	}
 	fs.BoolVarP(&o.Verify, "verify", "V", o.SignMode, "verify existing digests")
 	fs.BoolVar(&o.Keyless, "keyless", false, "use keyless signing")
@@ -115,6 +125,10 @@ func (o *Option) Configure(ctx clictx.Context) error {
 		}
 	} else {
 		o.Recursively = !o.local
+		err := o.configurePolicy(ctx)
+		if err != nil {
+			return err
+		}
 	}
 
 	err := o.Option.Configure(ctx.OCMContext())
@@ -125,6 +139,33 @@ func (o *Option) Configure(ctx clictx.Context) error {
 	return o.Verified.Configure(ctx)
 }
 
+func (o *Option) configurePolicy(ctx clictx.Context) error {
+	if o.policyFile == "" {
+		if len(o.SignatureNames) == 0 {
+			o.Policy = verificationattr.Get(ctx)
+		}
+		return nil
+	}
+	data, err := utils.ReadFile(o.policyFile, vfsattr.Get(ctx))
+	if err != nil {
+		return errors.Wrapf(err, "cannot read verification policy %q", o.policyFile)
+	}
+	var cfg verificationattr.Config
+	err = runtime.DefaultYAMLEncoding.Unmarshal(data, &cfg)
+	if err != nil {
+		return errors.Wrapf(err, "invalid verification policy %q", o.policyFile)
+	}
+	if t := cfg.GetKind(); t != "" && t != verificationattr.ConfigType {
+		return errors.Newf("invalid verification policy %q: unexpected type %q", o.policyFile, t)
+	}
+	err = cfg.Policy.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "invalid verification policy %q", o.policyFile)
+	}
+	o.Policy = &cfg.Policy
+	return nil
+}
+
 func (o *Option) Usage() string {
 	s := `
 The <code>--public-key</code> and <code>--private-key</code> options can be
@@ -138,6 +179,16 @@ start with the prefix <code>!</code> or as direct string with the prefix
 <code>=</code>.
 ` + o.Verified.Usage()
 
+	if !o.SignMode {
+		s += `
+The option <code>--policy</code> can be used to verify the signatures of the
+component versions and the component versions reachable by following
+component references according to a verification policy. It is given by a file
+using the format of the config type <code>` + verificationattr.ConfigType + `</code>
+(see <CMD>ocm configfile</CMD>). If neither a policy file nor a signature name
+is given, a verification policy found in the CLI configuration is used.
+`
+	}
 	if o.SignMode {
 		s += `
 If in signing mode a public key is specified, existing signatures for the
@@ -146,7 +197,9 @@ given signature name will be verified, instead of recreated.
 		s += `
 
 The following signing types are supported with option <code>--algorithm</code>:
-` + listformat.FormatList(rsa.Algorithm, signing.DefaultRegistry().SignerNames()...)
+` + listformat.FormatList(rsa.Algorithm, signing.DefaultRegistry().SignerNames()...) + `
+Additional signing types may be provided by plugins (see <CMD>ocm describe plugins</CMD>).
+`
 
 		s += `
 
@@ -202,4 +255,7 @@ func (o *Option) ApplySigningOption(opts *ocmsign.Options) {
 	opts.Keyless = o.Keyless
 
 	opts.VerifiedStore = o.Verified.Store
+	if o.Policy != nil {
+		opts.Policy = o.Policy
+	}
 }
@@ -93,7 +93,7 @@ func (o *Command) Run() error {
 ////////////////////////////////////////////////////////////////////////////////
 
 var outputs = output.NewOutputs(OutputFactory(mapRegularOutput), output.Outputs{
-	"wide": OutputFactory(mapWideOutput, "MISSING", "NON-LOCAL"),
+	"wide": OutputFactory(mapWideOutput, "MISSING", "NON-LOCAL", "VIOLATIONS"),
 }).AddChainedManifestOutputs(NewAction)
 
 func OutputFactory(fmt processing.MappingFunction, wide ...string) output.OutputFactory {
@@ -162,7 +162,30 @@ func mapWideOutput(e interface{}) interface{} {
 		amsg += ")"
 	}
 
-	return append(line, mmsg, amsg)
+	vmsg := ""
+	if len(p.Results.Violations) > 0 {
+		sep := ""
+		keys := violationKeys(p.Results.Violations)
+		for _, nv := range utils2.StringMapKeys(keys) {
+			for _, v := range p.Results.Violations[keys[nv]] {
+				elem := ""
+				if v.Kind != "" {
+					elem = "/" + v.Identity.String()
+				}
+				vmsg = fmt.Sprintf("%s%s%s(%s%s)", vmsg, sep, v.Rule, nv, elem)
+				sep = ", "
+			}
+		}
+	}
+	return append(line, mmsg, amsg, vmsg)
+}
+
+func violationKeys(v check.Violations) map[string]common.NameVersion {
+	m := map[string]common.NameVersion{}
+	for k := range v {
+		m[k.String()] = k
+	}
+	return m
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -234,6 +257,10 @@ func (a *action) Map(in interface{}) interface{} {
 				a.erropt.AddError(fmt.Errorf("version %s with non-local sources", common.VersionedElementKey(i.ComponentVersion)))
 			}
 		}
+		if len(o.Results.Violations) > 0 {
+			status += ",Violations"
+			a.erropt.AddError(fmt.Errorf("version %s violates policy rules", common.VersionedElementKey(i.ComponentVersion)))
+		}
 	}
 	if status != "" {
 		o.Status = status[1:]
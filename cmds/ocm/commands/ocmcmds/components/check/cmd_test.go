@@ -6,13 +6,17 @@ import (
 	. "github.com/mandelsoft/goutils/testutils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/ocm/testhelper"
 	. "ocm.software/ocm/cmds/ocm/testhelper"
 
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
 	"ocm.software/ocm/api/ocm"
 	v1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/ociartifact"
 	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
 	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/mime"
 )
 
 const (
@@ -82,7 +86,7 @@ test.de/x v1      Incomplete
 			Expect(env.CatchOutput(buf).Execute("check", "components", ARCH+"//"+COMP, "-o", "wide")).To(Succeed())
 			Expect(buf.String()).To(StringEqualTrimmedWithContext(
 				`
-COMPONENT VERSION STATUS     ERROR MISSING                    NON-LOCAL
+COMPONENT VERSION STATUS     ERROR MISSING                    NON-LOCAL VIOLATIONS
 test.de/x v1      Incomplete       test.de/z:v1[test.de/x:v1]
 `))
 		})
@@ -251,8 +255,87 @@ test.de/x v1      Resources
 			Expect(env.CatchOutput(buf).Execute("check", "components", ARCH+"//"+COMP, "--local-resources", "-o=wide")).To(Succeed())
 			Expect(buf.String()).To(StringEqualTrimmedWithContext(
 				`
-COMPONENT VERSION STATUS    ERROR MISSING NON-LOCAL
+COMPONENT VERSION STATUS    ERROR MISSING NON-LOCAL          VIOLATIONS
 test.de/x v1      Resources               RSC("name"="rsc1")
+`))
+		})
+	})
+
+	Context("evaluates policy rules", func() {
+		BeforeEach(func() {
+			env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+				env.ComponentVersion(COMP, VERSION, func() {
+					env.Reference("ref", COMP2, VERSION)
+					env.Resource("image", VERSION, resourcetypes.OCI_IMAGE, v1.ExternalRelation, func() {
+						env.ModificationOptions(ocm.SkipDigest())
+						env.Access(ociartifact.New("ghcr.io/acme/nginx:1.0"))
+					})
+				})
+				env.ComponentVersion(COMP2, VERSION, func() {
+					env.Label("owner", "acme")
+					env.Resource("data", VERSION, resourcetypes.PLAIN_TEXT, v1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, "testdata")
+					})
+				})
+			})
+		})
+
+		It("outputs table", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("check", "components", ARCH+"//"+COMP, "--deny-registry", "ghcr.io", "--require-label", "owner")).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(
+				`
+COMPONENT VERSION STATUS     ERROR
+test.de/x v1      Violations
+`))
+		})
+
+		It("outputs wide table", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("check", "components", ARCH, "--deny-access-type", "ociArtifact", "--signed", "-o=wide")).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(
+				`
+COMPONENT VERSION STATUS     ERROR MISSING NON-LOCAL VIOLATIONS
+test.de/x v1      Violations                         forbidden-access-type(test.de/x:v1/"name"="image"), required-signature(test.de/x:v1), required-signature(test.de/y:v1)
+test.de/y v1      Violations                         required-signature(test.de/y:v1)
+`))
+		})
+
+		It("outputs json for denied digests", func() {
+			MustBeSuccessful(vfs.WriteFile(env.FileSystem(), "/tmp/deny", []byte("# denied\nsha256:"+D_TESTDATA+"\n"), 0o600))
+			buf := bytes.NewBuffer(nil)
+			ExpectError(env.CatchOutput(buf).Execute("check", "components", ARCH+"//"+COMP2, "--deny-digests", "/tmp/deny", "--max-resource-size", "4", "--fail-on-error", "-o", "json")).
+				To(MatchError("version test.de/y:v1 violates policy rules"))
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(
+				`
+{
+  "items": [
+    {
+      "componentVersion": "test.de/y:v1",
+      "status": "Violations",
+      "violations": {
+        "test.de/y:v1": [
+          {
+            "identity": {
+              "name": "data"
+            },
+            "kind": "component resource",
+            "message": "size 8 exceeds limit 4",
+            "rule": "max-resource-size"
+          },
+          {
+            "identity": {
+              "name": "data"
+            },
+            "kind": "component resource",
+            "message": "digest SHA-256:` + D_TESTDATA + ` is denied",
+            "rule": "denied-digest"
+          }
+        ]
+      }
+    }
+  ]
+}
 `))
 		})
 	})
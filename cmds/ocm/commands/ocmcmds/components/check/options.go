@@ -1,9 +1,12 @@
 package check
 
 import (
+	"github.com/mandelsoft/goutils/errors"
 	"github.com/mandelsoft/goutils/optionutils"
 	"github.com/spf13/pflag"
+	"k8s.io/apimachinery/pkg/api/resource"
 
+	clictx "ocm.software/ocm/api/cli"
 	"ocm.software/ocm/api/ocm/ocmutils/check"
 	"ocm.software/ocm/cmds/ocm/common/options"
 )
@@ -19,6 +22,17 @@ var _ options.Options = (*Option)(nil)
 type Option struct {
 	CheckLocalResources bool
 	CheckLocalSources   bool
+
+	DeniedAccessTypes      []string
+	DeniedRegistries       []string
+	RequiredLabels         []string
+	RequiredResourceLabels []string
+	RequiredSignatures     []string
+	Signed                 bool
+	MaxResourceSize        string
+	DeniedDigests          string
+
+	rules []check.Rule
 }
 
 func NewOption() *Option {
@@ -28,11 +42,54 @@ func NewOption() *Option {
 func (o *Option) ApplyTo(opts *check.Options) {
 	optionutils.ApplyOption(&o.CheckLocalSources, &opts.CheckLocalSources)
 	optionutils.ApplyOption(&o.CheckLocalResources, &opts.CheckLocalResources)
+	opts.Rules = append(opts.Rules, o.rules...)
 }
 
 func (o *Option) AddFlags(fs *pflag.FlagSet) {
 	fs.BoolVarP(&o.CheckLocalResources, "local-resources", "R", false, "check also for describing resources with local access method, only")
 	fs.BoolVarP(&o.CheckLocalSources, "local-sources", "S", false, "check also for describing sources with local access method, only")
+	fs.StringArrayVarP(&o.DeniedAccessTypes, "deny-access-type", "", nil, "access type not allowed for resources and sources")
+	fs.StringArrayVarP(&o.DeniedRegistries, "deny-registry", "", nil, "OCI registry (host pattern) not allowed for OCI artifact accesses")
+	fs.StringArrayVarP(&o.RequiredLabels, "require-label", "", nil, "label required for component versions")
+	fs.StringArrayVarP(&o.RequiredResourceLabels, "require-resource-label", "", nil, "label required for resources")
+	fs.StringArrayVarP(&o.RequiredSignatures, "require-signature", "", nil, "signature required for component versions")
+	fs.BoolVarP(&o.Signed, "signed", "", false, "require at least one signature for component versions")
+	fs.StringVarP(&o.MaxResourceSize, "max-resource-size", "", "", "maximum size of resource blobs (for example 100Mi)")
+	fs.StringVarP(&o.DeniedDigests, "deny-digests", "", "", "file with denied resource or reference digests")
+}
+
+func (o *Option) Configure(ctx clictx.Context) error {
+	o.rules = nil
+	if len(o.DeniedAccessTypes) > 0 {
+		o.rules = append(o.rules, check.ForbiddenAccessTypes(o.DeniedAccessTypes...))
+	}
+	if len(o.DeniedRegistries) > 0 {
+		o.rules = append(o.rules, check.DeniedRegistries(o.DeniedRegistries...))
+	}
+	if len(o.RequiredLabels) > 0 {
+		o.rules = append(o.rules, check.RequiredLabels(o.RequiredLabels...))
+	}
+	if len(o.RequiredResourceLabels) > 0 {
+		o.rules = append(o.rules, check.RequiredResourceLabels(o.RequiredResourceLabels...))
+	}
+	if len(o.RequiredSignatures) > 0 || o.Signed {
+		o.rules = append(o.rules, check.RequiredSignatures(o.RequiredSignatures...))
+	}
+	if o.MaxResourceSize != "" {
+		q, err := resource.ParseQuantity(o.MaxResourceSize)
+		if err != nil {
+			return errors.Wrapf(err, "invalid maximum resource size %q", o.MaxResourceSize)
+		}
+		o.rules = append(o.rules, check.MaxResourceSize(q.Value()))
+	}
+	if o.DeniedDigests != "" {
+		list, err := check.ReadDigestDenyList(o.DeniedDigests, ctx.FileSystem())
+		if err != nil {
+			return err
+		}
+		o.rules = append(o.rules, check.DeniedDigests(list))
+	}
+	return nil
 }
 
 func (o *Option) Usage() string {
@@ -40,6 +97,29 @@ func (o *Option) Usage() string {
 If the options <code>--local-resources</code> and/or <code>--local-sources</code> are given the 
 check additionally assures that all resources or sources are included into the component version.
 This means that they are using local access methods, only.
+
+Additionally, policy rules can be evaluated for all component versions
+of the reference graph:
+
+- <code>--deny-access-type</code>: resources and sources must not use the
+  given access types (with or without version).
+- <code>--deny-registry</code>: resources and sources with access type
+  <code>ociArtifact</code> must not refer to images located in the given
+  registries. The registries are given as host patterns, which may contain
+  shell wildcards (for example <code>*.example.com</code>).
+- <code>--require-label</code>: component versions must have the given labels.
+- <code>--require-resource-label</code>: all resources must have the given labels.
+- <code>--require-signature</code>: component versions must have signatures
+  with the given names. With <code>--signed</code> at least one signature
+  is required. The signatures are not verified.
+- <code>--max-resource-size</code>: the size of resource blobs must not exceed
+  the given limit (for example <code>100Mi</code> or <code>1G</code>).
+- <code>--deny-digests</code>: resources and component references must not have
+  a digest listed in the given file. Every line contains a digest value,
+  optionally prefixed by the hash algorithm (<code>sha256:&lt;value></code>).
+  Empty lines and lines starting with <code>#</code> are ignored.
+
+Rule violations are reported with status <code>Violations</code>.
 `
 	return s
 }
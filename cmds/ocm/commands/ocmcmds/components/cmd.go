@@ -6,6 +6,8 @@ import (
 	clictx "ocm.software/ocm/api/cli"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/add"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/check"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/convert"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/delete"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/download"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/get"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/hash"
@@ -38,4 +40,6 @@ func AddCommands(ctx clictx.Context, cmd *cobra.Command) {
 	cmd.AddCommand(verify.NewCommand(ctx, verify.Verb))
 	cmd.AddCommand(download.NewCommand(ctx, download.Verb))
 	cmd.AddCommand(check.NewCommand(ctx, check.Verb))
+	cmd.AddCommand(delete.NewCommand(ctx, delete.Verb))
+	cmd.AddCommand(convert.NewCommand(ctx, convert.Verb))
 }
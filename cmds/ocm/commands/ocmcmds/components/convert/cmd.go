@@ -0,0 +1,199 @@
+package convert
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	"ocm.software/ocm/api/ocm/tools/convert"
+	"ocm.software/ocm/api/utils/listformat"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/cmds/ocm/commands/common/options/closureoption"
+	ocmcommon "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/dryrunoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/schemaoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/versionconstraintsoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/names"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/options"
+	"ocm.software/ocm/cmds/ocm/common/output"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var (
+	Names = names.Components
+	Verb  = verbs.Convert
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Refs []string
+}
+
+// NewCommand creates a new convert command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(
+		&Command{BaseCommand: utils.NewBaseCommand(ctx,
+			versionconstraintsoption.New(),
+			repooption.New(),
+			output.OutputOptions(output.NewOutputs(NewAction),
+				closureoption.New("component reference"),
+				dryrunoption.New("only report the conversions", false),
+				&Option{},
+			),
+		)},
+		utils.Names(Names, names...)...,
+	)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] --scheme <version> {<component-reference>}",
+		Short: "convert component versions to another descriptor schema version",
+		Long: `
+Convert the component descriptors of all component versions specified into
+the schema version given by option <code>--scheme</code> and store them
+in their OCM repository. If only a component is specified, all versions of the
+component are converted. If no component is specified, but a repository is
+given with option <code>--repo</code>, all component versions of this
+repository are converted. This can be used to rewrite a complete CTF in place.
+
+All schema versions registered for component descriptors can be used as target,
+these are <code>v2</code>, <code>ocm.software/v3alpha1</code> and the stable
+schema version <code>ocm.software/v3</code>.
+
+The converted descriptor is validated against the JSON scheme of the target
+schema version. Elements of the original descriptor, which cannot be
+represented in the target schema version, are reported. This includes
+signatures and reference digests, which use the schema version specific
+normalization <code>` + compdesc.JsonNormalisationV1 + `</code>.
+Such lossy conversions are rejected unless the option <code>--lossy</code>
+is given.
+
+With the option <code>--recursive</code> all component versions referenced by
+the selected component versions and found in the same repository are converted,
+too. The option <code>--dry-run</code> just reports the conversions, which would
+be done.
+`,
+		Example: `
+$ ocm convert componentversion --scheme ocm.software/v3 ./ctf//ocm.software/ocmcli:0.17.0
+$ ocm convert componentversion --scheme v2 --dry-run --repo ./ctf
+`,
+		Annotations: map[string]string{"ExampleCodeStyle": "bash"},
+	}
+}
+
+func (o *Command) Complete(args []string) error {
+	o.Refs = args
+	if len(args) == 0 && repooption.From(o).Spec == "" {
+		return fmt.Errorf("a repository or at least one argument that defines the reference is needed")
+	}
+	return nil
+}
+
+func (o *Command) Run() (err error) {
+	session := ocm.NewSession(nil)
+	defer errors.PropagateError(&err, session.Close)
+
+	err = o.ProcessOnOptions(ocmcommon.CompleteOptionsWithSession(o, session))
+	if err != nil {
+		return err
+	}
+	handler := comphdlr.NewTypeHandler(o.Context.OCM(), session, repooption.From(o).Repository, comphdlr.OptionsFor(o))
+	return utils.HandleArgs(output.From(o).WithSession(session), handler, o.Refs...)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Option describes the conversion settings.
+type Option struct {
+	schemaoption.Option
+	Lossy bool
+}
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	o.Option.AddFlags(fs)
+	fs.BoolVarP(&o.Lossy, "lossy", "", false, "accept conversions losing information")
+}
+
+func (o *Option) Complete() error {
+	if o.Schema == "" {
+		return fmt.Errorf("target schema version required (option --scheme)")
+	}
+	return o.Option.Complete()
+}
+
+func (o *Option) Usage() string {
+	return `
+The following schema versions are supported as target of a conversion:
+` + listformat.FormatList("", compdesc.DefaultSchemes.Names()...)
+}
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Action collects the selected component versions (including the
+// reference closure, if requested) and converts them on output.
+type Action struct {
+	*output.ElementOutput
+	opts *output.Options
+}
+
+func NewAction(opts *output.Options) output.Output {
+	return &Action{
+		ElementOutput: output.NewElementOutput(opts, closureoption.Closure(opts, comphdlr.ClosureExplode, comphdlr.Sort)),
+		opts:          opts,
+	}
+}
+
+func (a *Action) Out() error {
+	if err := a.ElementOutput.Out(); err != nil {
+		return err
+	}
+
+	copts := From(a.opts)
+	printer := common.NewPrinter(a.opts.Context.StdOut())
+	opts := []convert.Option{
+		convert.DryRun(dryrunoption.From(a.opts).DryRun),
+		convert.AllowLoss(copts.Lossy),
+	}
+	list := errors.ErrListf("converting component versions")
+	done := map[common.NameVersion]bool{}
+
+	i := a.Elems.Iterator()
+	for i.HasNext() {
+		o := i.Next().(*comphdlr.Object)
+		if o.ComponentVersion == nil {
+			continue
+		}
+		key := common.VersionedElementKey(o.ComponentVersion)
+		if done[key] {
+			continue
+		}
+		done[key] = true
+		r, err := convert.ComponentVersion(o.ComponentVersion, copts.Schema, opts...)
+		if r == nil {
+			list.Add(errors.Wrapf(err, "%s", key))
+			printer.Printf("%s: conversion failed: %s\n", key, err)
+			continue
+		}
+		convert.Report(printer, r, err)
+		if err != nil {
+			list.Add(errors.Wrapf(err, "%s", key))
+		}
+	}
+	return list.Result()
+}
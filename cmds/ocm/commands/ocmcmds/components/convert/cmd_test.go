@@ -0,0 +1,184 @@
+package convert_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	compdescv3final "ocm.software/ocm/api/ocm/compdesc/versions/ocm.software/v3"
+	compdescv3 "ocm.software/ocm/api/ocm/compdesc/versions/ocm.software/v3alpha1"
+	compdescv2 "ocm.software/ocm/api/ocm/compdesc/versions/v2"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	ARCH     = "/tmp/ctf"
+	PROVIDER = "mandelsoft"
+	VERSION  = "v1"
+	VERSION2 = "v2"
+	COMP     = "test.de/x"
+	COMP2    = "test.de/y"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMP, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("ref", COMP2, VERSION)
+				env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, "testdata")
+				})
+			})
+			env.ComponentVersion(COMP, VERSION2, func() {
+				env.Provider(PROVIDER)
+			})
+			env.ComponentVersion(COMP2, VERSION, func() {
+				env.Provider(PROVIDER)
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	scheme := func(comp, vers string) string {
+		repo := Must(ctf.Open(env, accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo, "repo")
+		cv := Must(repo.LookupComponentVersion(comp, vers))
+		defer Close(cv, "component version")
+		return cv.GetDescriptor().SchemaVersion()
+	}
+
+	It("converts a component version", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("convert", "components", "--scheme", "v3alpha1", ARCH+"//"+COMP+":"+VERSION)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+test.de/x:v1: converted from v2 to ocm.software/v3alpha1
+`))
+		Expect(scheme(COMP, VERSION)).To(Equal(compdescv3.SchemaVersion))
+		Expect(scheme(COMP, VERSION2)).To(Equal(compdescv2.SchemaVersion))
+		Expect(scheme(COMP2, VERSION)).To(Equal(compdescv2.SchemaVersion))
+	})
+
+	It("converts a component version recursively", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("convert", "components", "--scheme", compdescv3.SchemaVersion, "-r", ARCH+"//"+COMP+":"+VERSION)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+test.de/x:v1: converted from v2 to ocm.software/v3alpha1
+test.de/y:v1: converted from v2 to ocm.software/v3alpha1
+`))
+		Expect(scheme(COMP, VERSION)).To(Equal(compdescv3.SchemaVersion))
+		Expect(scheme(COMP, VERSION2)).To(Equal(compdescv2.SchemaVersion))
+		Expect(scheme(COMP2, VERSION)).To(Equal(compdescv3.SchemaVersion))
+	})
+
+	It("converts a complete repository", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("convert", "components", "--scheme", "v3alpha1", "--repo", ARCH)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+test.de/x:v1: converted from v2 to ocm.software/v3alpha1
+test.de/x:v2: converted from v2 to ocm.software/v3alpha1
+test.de/y:v1: converted from v2 to ocm.software/v3alpha1
+`))
+		Expect(scheme(COMP, VERSION)).To(Equal(compdescv3.SchemaVersion))
+		Expect(scheme(COMP, VERSION2)).To(Equal(compdescv3.SchemaVersion))
+		Expect(scheme(COMP2, VERSION)).To(Equal(compdescv3.SchemaVersion))
+
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("convert", "components", "--scheme", "v2", "--repo", ARCH, COMP2)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+test.de/y:v1: converted from ocm.software/v3alpha1 to v2
+`))
+		Expect(scheme(COMP2, VERSION)).To(Equal(compdescv2.SchemaVersion))
+	})
+
+	It("converts a component version to the stable v3 scheme", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("convert", "components", "--scheme", "v3alpha1", ARCH+"//"+COMP+":"+VERSION)).To(Succeed())
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("convert", "components", "--scheme", "v3", ARCH+"//"+COMP+":"+VERSION)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+test.de/x:v1: converted from ocm.software/v3alpha1 to ocm.software/v3
+`))
+		Expect(scheme(COMP, VERSION)).To(Equal(compdescv3final.SchemaVersion))
+		Expect(scheme(COMP, VERSION2)).To(Equal(compdescv2.SchemaVersion))
+	})
+
+	It("handles a dry run", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("convert", "components", "--scheme", "v3alpha1", "--dry-run", ARCH+"//"+COMP)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+test.de/x:v1: would convert from v2 to ocm.software/v3alpha1
+test.de/x:v2: would convert from v2 to ocm.software/v3alpha1
+`))
+		Expect(scheme(COMP, VERSION)).To(Equal(compdescv2.SchemaVersion))
+	})
+
+	It("reports unchanged versions", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("convert", "components", "--scheme", "v2", ARCH+"//"+COMP+":"+VERSION)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+test.de/x:v1: already uses scheme v2
+`))
+	})
+
+	It("requires a scheme", func() {
+		Expect(env.Execute("convert", "components", ARCH+"//"+COMP)).To(MatchError("target schema version required (option --scheme)"))
+		Expect(env.Execute("convert", "components", "--scheme", "v0", ARCH+"//"+COMP)).To(MatchError(`schema version "v0" is unknown`))
+	})
+
+	Context("lossy", func() {
+		BeforeEach(func() {
+			repo := Must(ctf.Open(env, accessobj.ACC_WRITABLE, ARCH, 0, env))
+			defer Close(repo, "repo")
+			cv := Must(repo.LookupComponentVersion(COMP, VERSION))
+			defer Close(cv, "component version")
+			cv.GetDescriptor().Signatures = append(cv.GetDescriptor().Signatures, compdesc.Signature{
+				Name: "acme",
+				Digest: compdesc.DigestSpec{
+					HashAlgorithm:          "SHA-256",
+					NormalisationAlgorithm: compdesc.JsonNormalisationV1,
+					Value:                  "0123456789abcdef",
+				},
+				Signature: compdesc.SignatureSpec{
+					Algorithm: "RSASSA-PKCS1-V1_5",
+					Value:     "0123456789abcdef",
+					MediaType: "application/vnd.ocm.signature.rsa",
+				},
+			})
+		})
+
+		It("rejects lossy conversions", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("convert", "components", "--scheme", "v3alpha1", ARCH+"//"+COMP+":"+VERSION)).To(MatchError(ContainSubstring("lossy conversion")))
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+test.de/x:v1: conversion from v2 to ocm.software/v3alpha1 failed: lossy conversion: 1 element(s) cannot be kept
+  lost signature "acme": digest uses scheme specific normalization jsonNormalisation/v1
+`))
+			Expect(scheme(COMP, VERSION)).To(Equal(compdescv2.SchemaVersion))
+		})
+
+		It("accepts lossy conversions", func() {
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("convert", "components", "--scheme", "v3alpha1", "--lossy", ARCH+"//"+COMP+":"+VERSION)).To(Succeed())
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+test.de/x:v1: converted from v2 to ocm.software/v3alpha1
+  lost signature "acme": digest uses scheme specific normalization jsonNormalisation/v1
+`))
+			Expect(scheme(COMP, VERSION)).To(Equal(compdescv3.SchemaVersion))
+		})
+	})
+})
@@ -0,0 +1,314 @@
+package delete
+
+import (
+	"fmt"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/mandelsoft/goutils/maputils"
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/ocm"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/out"
+	"ocm.software/ocm/cmds/ocm/commands/common/options/closureoption"
+	ocmcommon "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/dryrunoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/versionconstraintsoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/names"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/output"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var (
+	Names = names.Components
+	Verb  = verbs.Delete
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Refs []string
+}
+
+// NewCommand creates a new delete command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(
+		&Command{BaseCommand: utils.NewBaseCommand(ctx,
+			versionconstraintsoption.New(),
+			repooption.New(),
+			output.OutputOptions(output.NewOutputs(NewAction),
+				closureoption.New("component reference"),
+				dryrunoption.New("only list the component versions to delete", false),
+			),
+		)},
+		utils.Names(Names, names...)...,
+	)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>] {<component-reference>}",
+		Short: "delete component versions from an OCM repository",
+		Long: `
+Delete all component versions specified from their OCM repository. If only a
+component is specified, all versions of the component are deleted.
+
+With the option <code>--recursive</code> all component versions referenced by
+the selected component versions and found in the same repository are deleted, too.
+Referenced component versions still used by other component versions
+kept in the repository are skipped.
+The option <code>--dry-run</code> just lists the component versions, which would
+be deleted.
+
+After the deletion, blobs of the repository which are not used anymore by
+any component version are removed, if supported by the repository type.
+`,
+		Example: `
+$ ocm delete componentversion ./ctf//ocm.software/ocmcli:0.17.0
+$ ocm delete componentversion --recursive --dry-run --repo ./ctf ocm.software/ocmcli:0.17.0
+`,
+		Annotations: map[string]string{"ExampleCodeStyle": "bash"},
+	}
+}
+
+func (o *Command) Complete(args []string) error {
+	o.Refs = args
+	if len(args) == 0 {
+		return fmt.Errorf("at least one argument that defines the reference is needed")
+	}
+	return nil
+}
+
+func (o *Command) Run() (err error) {
+	session := ocm.NewSession(nil)
+	defer errors.PropagateError(&err, session.Close)
+
+	err = o.ProcessOnOptions(ocmcommon.CompleteOptionsWithSession(o, session))
+	if err != nil {
+		return err
+	}
+	handler := comphdlr.NewTypeHandler(o.Context.OCM(), session, repooption.From(o).Repository, comphdlr.OptionsFor(o))
+	return utils.HandleArgs(output.From(o).WithSession(session), handler, o.Refs...)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Action collects the selected component versions (including the
+// reference closure, if requested) and deletes them on output.
+type Action struct {
+	*output.ElementOutput
+	opts *output.Options
+}
+
+func NewAction(opts *output.Options) output.Output {
+	return &Action{
+		ElementOutput: output.NewElementOutput(opts, closureoption.Closure(opts, comphdlr.ClosureExplode, comphdlr.Sort)),
+		opts:          opts,
+	}
+}
+
+// candidate is a component version selected for deletion.
+type candidate struct {
+	repo ocm.Repository
+	key  common.NameVersion
+	// explicit is set for versions selected by the command arguments,
+	// in contrast to versions selected by the reference closure.
+	explicit bool
+	// keep is the reason why a version selected by the
+	// reference closure is not deleted.
+	keep string
+}
+
+func (a *Action) Out() error {
+	if err := a.ElementOutput.Out(); err != nil {
+		return err
+	}
+
+	dryrun := dryrunoption.From(a.opts).DryRun
+	list := errors.ErrListf("deleting component versions")
+
+	var candidates []*candidate
+	var repos []ocm.Repository
+	found := map[common.NameVersion]*candidate{}
+	used := map[ocm.Repository]bool{}
+
+	i := a.Elems.Iterator()
+	for i.HasNext() {
+		o := i.Next().(*comphdlr.Object)
+		if o.ComponentVersion == nil {
+			continue
+		}
+		key := common.VersionedElementKey(o.ComponentVersion)
+		if c := found[key]; c != nil {
+			c.explicit = c.explicit || len(o.History) == 0
+			continue
+		}
+		c := &candidate{repo: o.Repository, key: key, explicit: len(o.History) == 0}
+		found[key] = c
+		candidates = append(candidates, c)
+		if !used[o.Repository] {
+			used[o.Repository] = true
+			repos = append(repos, o.Repository)
+		}
+	}
+
+	for _, r := range repos {
+		if err := retain(r, candidates); err != nil {
+			list.Add(errors.Wrapf(err, "repository %s", r.GetSpecification().GetKind()))
+		}
+	}
+
+	deleted := map[ocm.Repository]bool{}
+	for _, c := range candidates {
+		if c.keep != "" {
+			out.Outf(a.opts.Context, "skipping component version %s: %s\n", c.key, c.keep)
+			continue
+		}
+		if dryrun {
+			out.Outf(a.opts.Context, "would delete component version %s\n", c.key)
+			continue
+		}
+		err := deleteVersion(c.repo, c.key)
+		if err != nil {
+			list.Add(errors.Wrapf(err, "%s", c.key))
+			out.Outf(a.opts.Context, "deleting component version %s failed: %s\n", c.key, err)
+			continue
+		}
+		out.Outf(a.opts.Context, "deleted component version %s\n", c.key)
+		deleted[c.repo] = true
+	}
+
+	for _, r := range repos {
+		if !deleted[r] {
+			continue
+		}
+		blobs, err := ocm.CleanupBlobs(r, false)
+		if err != nil {
+			if !errors.IsErrNotSupported(err) {
+				list.Add(errors.Wrapf(err, "cleanup blobs"))
+			}
+			continue
+		}
+		if len(blobs) > 0 {
+			out.Outf(a.opts.Context, "removed %d orphaned blob(s)\n", len(blobs))
+		}
+	}
+	return list.Result()
+}
+
+// retain marks the candidates of a repository selected by the reference
+// closure, which are still referenced by component versions kept in
+// the repository. Explicitly selected versions are always deleted.
+// If the references cannot be determined, all candidates selected
+// by the closure are kept.
+func retain(repo ocm.Repository, candidates []*candidate) error {
+	selected := map[common.NameVersion]*candidate{}
+	for _, c := range candidates {
+		if c.repo == repo && !c.explicit {
+			selected[c.key] = c
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+
+	deleted := map[common.NameVersion]bool{}
+	for _, c := range candidates {
+		if c.repo == repo {
+			deleted[c.key] = true
+		}
+	}
+
+	refs, err := references(repo)
+	if err != nil {
+		for _, c := range selected {
+			c.keep = "cannot determine referencing component versions"
+		}
+		return errors.Wrapf(err, "cannot determine referencing component versions")
+	}
+
+	versions := maputils.Keys(refs, common.CompareNameVersion)
+	for changed := true; changed; {
+		changed = false
+		for _, v := range versions {
+			if deleted[v] {
+				continue
+			}
+			for _, r := range refs[v] {
+				if c := selected[r]; c != nil && deleted[r] {
+					c.keep = fmt.Sprintf("still referenced by %s", v)
+					deleted[r] = false
+					changed = true
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// references provides the references of all component versions
+// found in a repository.
+func references(repo ocm.Repository) (map[common.NameVersion][]common.NameVersion, error) {
+	lister := repo.ComponentLister()
+	if lister == nil {
+		return nil, errors.ErrNotSupported("component listing", repo.GetSpecification().GetKind())
+	}
+	names, err := lister.GetComponents("", true)
+	if err != nil {
+		return nil, err
+	}
+	refs := map[common.NameVersion][]common.NameVersion{}
+	for _, n := range names {
+		err := componentReferences(repo, n, refs)
+		if err != nil {
+			return nil, errors.Wrapf(err, "component %s", n)
+		}
+	}
+	return refs, nil
+}
+
+func componentReferences(repo ocm.Repository, name string, refs map[common.NameVersion][]common.NameVersion) (err error) {
+	comp, err := repo.LookupComponent(name)
+	if err != nil {
+		return err
+	}
+	defer errors.PropagateError(&err, comp.Close)
+
+	vers, err := comp.ListVersions()
+	if err != nil {
+		return err
+	}
+	for _, v := range vers {
+		cv, err := comp.LookupVersion(v)
+		if err != nil {
+			return err
+		}
+		key := common.VersionedElementKey(cv)
+		refs[key] = nil
+		for _, r := range cv.GetDescriptor().References {
+			refs[key] = append(refs[key], common.NewNameVersion(r.ComponentName, r.Version))
+		}
+		err = cv.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteVersion(repo ocm.Repository, key common.NameVersion) error {
+	comp, err := repo.LookupComponent(key.GetName())
+	if err != nil {
+		return err
+	}
+	defer comp.Close()
+
+	if !comp.IsVersionDeletionSupported() {
+		return errors.ErrNotSupported("component version deletion", repo.GetSpecification().GetKind())
+	}
+	return comp.DeleteVersion(key.GetVersion())
+}
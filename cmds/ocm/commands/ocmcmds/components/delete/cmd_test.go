@@ -0,0 +1,150 @@
+package delete_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	ARCH     = "/tmp/ctf"
+	ARCH2    = "/tmp/ctf2"
+	PROVIDER = "mandelsoft"
+	VERSION  = "v1"
+	VERSION2 = "v2"
+	COMP     = "test.de/x"
+	COMP2    = "test.de/y"
+	COMP3    = "test.de/z"
+)
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMP, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("ref", COMP2, VERSION)
+				env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_TEXT, "testdata")
+				})
+			})
+			env.ComponentVersion(COMP, VERSION2, func() {
+				env.Provider(PROVIDER)
+			})
+			env.ComponentVersion(COMP2, VERSION, func() {
+				env.Provider(PROVIDER)
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	versionsIn := func(path, comp string) []string {
+		repo := Must(ctf.Open(env, accessobj.ACC_READONLY, path, 0, env))
+		defer Close(repo, "repo")
+		c := Must(repo.LookupComponent(comp))
+		defer Close(c, "component")
+		return Must(c.ListVersions())
+	}
+
+	versions := func(comp string) []string {
+		return versionsIn(ARCH, comp)
+	}
+
+	It("deletes a component version", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("delete", "components", ARCH+"//"+COMP+":"+VERSION)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+deleted component version test.de/x:v1
+removed 4 orphaned blob(s)
+`))
+		Expect(versions(COMP)).To(ConsistOf(VERSION2))
+		Expect(versions(COMP2)).To(ConsistOf(VERSION))
+	})
+
+	It("deletes all versions of a component", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("delete", "components", ARCH+"//"+COMP)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+deleted component version test.de/x:v1
+deleted component version test.de/x:v2
+removed 7 orphaned blob(s)
+`))
+		Expect(versions(COMP)).To(BeEmpty())
+		Expect(versions(COMP2)).To(ConsistOf(VERSION))
+	})
+
+	It("deletes recursively", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("delete", "components", "-r", ARCH+"//"+COMP+":"+VERSION)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+deleted component version test.de/x:v1
+deleted component version test.de/y:v1
+removed 7 orphaned blob(s)
+`))
+		Expect(versions(COMP)).To(ConsistOf(VERSION2))
+		Expect(versions(COMP2)).To(BeEmpty())
+	})
+
+	It("lists versions in dry-run mode", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("delete", "components", "-r", "--dry-run", ARCH+"//"+COMP+":"+VERSION)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+would delete component version test.de/x:v1
+would delete component version test.de/y:v1
+`))
+		Expect(versions(COMP)).To(ConsistOf(VERSION, VERSION2))
+		Expect(versions(COMP2)).To(ConsistOf(VERSION))
+	})
+
+	It("keeps recursively selected versions still referenced by other versions", func() {
+		env.OCMCommonTransport(ARCH2, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMP, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("ref", COMP2, VERSION)
+			})
+			env.ComponentVersion(COMP, VERSION2, func() {
+				env.Provider(PROVIDER)
+				env.Reference("ref", COMP2, VERSION)
+			})
+			env.ComponentVersion(COMP2, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Reference("ref", COMP3, VERSION)
+			})
+			env.ComponentVersion(COMP3, VERSION, func() {
+				env.Provider(PROVIDER)
+			})
+		})
+
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("delete", "components", "-r", ARCH2+"//"+COMP+":"+VERSION)).To(Succeed())
+		Expect(buf.String()).To(ContainSubstring("deleted component version test.de/x:v1\n"))
+		Expect(buf.String()).To(ContainSubstring("skipping component version test.de/y:v1: still referenced by test.de/x:v2\n"))
+		Expect(buf.String()).To(ContainSubstring("skipping component version test.de/z:v1: still referenced by test.de/y:v1\n"))
+		Expect(versionsIn(ARCH2, COMP)).To(ConsistOf(VERSION2))
+		Expect(versionsIn(ARCH2, COMP2)).To(ConsistOf(VERSION))
+		Expect(versionsIn(ARCH2, COMP3)).To(ConsistOf(VERSION))
+	})
+
+	It("deletes explicitly selected versions even if still referenced", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("delete", "components", "-r", "--dry-run", ARCH+"//"+COMP+":"+VERSION2, ARCH+"//"+COMP2+":"+VERSION)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+would delete component version test.de/x:v2
+would delete component version test.de/y:v1
+`))
+	})
+})
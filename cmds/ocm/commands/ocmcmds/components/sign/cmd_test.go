@@ -2,7 +2,9 @@ package sign_test
 
 import (
 	"bytes"
+	"net"
 	"os"
+	"path/filepath"
 
 	. "github.com/mandelsoft/goutils/testutils"
 	. "github.com/onsi/ginkgo/v2"
@@ -21,6 +23,7 @@ import (
 	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
 	"ocm.software/ocm/api/ocm/tools/signing"
 	"ocm.software/ocm/api/tech/signing/handlers/rsa"
+	"ocm.software/ocm/api/tech/signing/handlers/signingagent"
 	"ocm.software/ocm/api/tech/signing/signutils"
 	"ocm.software/ocm/api/utils/accessio"
 	"ocm.software/ocm/api/utils/accessobj"
@@ -47,8 +50,9 @@ const (
 )
 
 const (
-	PUBKEY  = "/tmp/pub"
-	PRIVKEY = "/tmp/priv"
+	PUBKEY   = "/tmp/pub"
+	PRIVKEY  = "/tmp/priv"
+	AGENTKEY = "/tmp/agent"
 )
 
 const (
@@ -293,6 +297,58 @@ successfully signed github.com/mandelsoft/ref:v1 (digest SHA-256:3d1bf98adce0632
 		})
 	})
 
+	Context("signing agent", func() {
+		var listener net.Listener
+
+		BeforeEach(func() {
+			env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+				env.Component(COMPONENTA, func() {
+					env.Version(VERSION, func() {
+						env.Provider(PROVIDER)
+						env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+							env.BlobStringData(mime.MIME_TEXT, "testdata")
+						})
+					})
+				})
+			})
+
+			dir := Must(os.MkdirTemp("", "agent"))
+			DeferCleanup(os.RemoveAll, dir)
+			socket := filepath.Join(dir, "agent.sock")
+
+			server := signingagent.NewServer(nil, nil)
+			MustBeSuccessful(server.AddKey("test", priv, nil))
+			listener = Must(signingagent.Listen(socket))
+			go server.Serve(listener)
+
+			MustBeSuccessful(vfs.WriteFile(env.FileSystem(), AGENTKEY, []byte("socket: "+socket+"\nkey: test\n"), os.ModePerm))
+		})
+
+		AfterEach(func() {
+			listener.Close()
+		})
+
+		It("signs with signing agent", func() {
+			buf := bytes.NewBuffer(nil)
+			MustBeSuccessful(env.CatchOutput(buf).Execute("sign", "components", "-s", SIGNATURE, "-S", signingagent.Name, "-K", AGENTKEY, "--repo", ARCH, COMPONENTA+":"+VERSION))
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/test:v1]...
+  resource 0:  "name"="testdata": digest SHA-256:810ff2fb242a5dee4220f2cb0e6a519891fb67f2f828a6cab4ef8894633b1f50[genericBlobDigest/v1]
+successfully signed github.com/mandelsoft/test:v1 (digest SHA-256:5923de2b3b68e904eecb58eca91727926b36623623555025dc5a8700edfa9daa)
+`))
+
+			repo := Must(ctf.Open(env, accessobj.ACC_READONLY, ARCH, 0, env))
+			defer Close(repo, "repo")
+			cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+			defer Close(cv, "cv")
+			Expect(cv.GetDescriptor().Signatures[0].Signature.Algorithm).To(Equal(rsa.Algorithm))
+
+			buf.Reset()
+			MustBeSuccessful(env.CatchOutput(buf).Execute("verify", "components", "-s", SIGNATURE, "-k", PUBKEY, "--repo", ARCH, COMPONENTA+":"+VERSION))
+			Expect(buf.String()).To(ContainSubstring("successfully verified github.com/mandelsoft/test:v1"))
+		})
+	})
+
 	It("keyless verification", func() {
 		buf := bytes.NewBuffer(nil)
 
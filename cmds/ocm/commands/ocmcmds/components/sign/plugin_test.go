@@ -0,0 +1,76 @@
+//go:build unix
+
+package sign_test
+
+import (
+	"bytes"
+	"os"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/ocm/plugin/testutils"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	PLUGIN_ALGO = "test-kms"
+	KMSKEY      = "/tmp/kms"
+)
+
+var _ = Describe("plugin based signing", func() {
+	var env *TestEnv
+	var plugins TempPluginDir
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+		plugins = Must(ConfigureTestPlugins(env, "testdata/plugins"))
+		MustBeSuccessful(vfs.WriteFile(env.FileSystem(), KMSKEY, []byte("key"), os.ModePerm))
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMPONENTA, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					env.Resource("testdata", "", "PlainText", metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, "testdata")
+					})
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		plugins.Cleanup()
+		env.Cleanup()
+	})
+
+	It("signs with a plugin based algorithm", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("sign", "componentversions", "-s", SIGNATURE, "--algorithm", PLUGIN_ALGO, "-K", KMSKEY, "--repo", ARCH, COMPONENTA+":"+VERSION))
+		Expect(buf.String()).To(ContainSubstring("successfully signed github.com/mandelsoft/test:v1"))
+
+		repo := Must(ctf.Open(env, accessobj.ACC_READONLY, ARCH, 0, env))
+		defer Close(repo, "repo")
+		cv := Must(repo.LookupComponentVersion(COMPONENTA, VERSION))
+		defer Close(cv, "cv")
+		sig := cv.GetDescriptor().Signatures[0]
+		Expect(sig.Signature.Algorithm).To(Equal(PLUGIN_ALGO))
+		Expect(sig.Signature.Value).To(Equal("key:" + sig.Digest.Value))
+
+		buf.Reset()
+		MustBeSuccessful(env.CatchOutput(buf).Execute("verify", "componentversions", "-s", SIGNATURE, "-k", KMSKEY, "--repo", ARCH, COMPONENTA+":"+VERSION))
+		Expect(buf.String()).To(ContainSubstring("successfully verified github.com/mandelsoft/test:v1"))
+	})
+
+	It("rejects unknown algorithms", func() {
+		Expect(env.Execute("sign", "componentversions", "-s", SIGNATURE, "--algorithm", "other-kms", "-K", KMSKEY, "--repo", ARCH, COMPONENTA+":"+VERSION)).To(MatchError(ContainSubstring(`signing algorithm "other-kms" is unknown`)))
+	})
+})
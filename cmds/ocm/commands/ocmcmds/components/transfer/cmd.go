@@ -6,24 +6,32 @@ import (
 
 	"github.com/mandelsoft/goutils/errors"
 	"github.com/mandelsoft/goutils/maputils"
+	"github.com/mandelsoft/vfs/pkg/memoryfs"
 	"github.com/mandelsoft/vfs/pkg/vfs"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 
 	clictx "ocm.software/ocm/api/cli"
 	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/extensions/repositories/ctf"
 	"ocm.software/ocm/api/ocm/tools/transfer"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler"
 	"ocm.software/ocm/api/ocm/tools/transfer/transferhandler/spiff"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/accessobj"
 	common "ocm.software/ocm/api/utils/misc"
 	"ocm.software/ocm/api/utils/out"
+	"ocm.software/ocm/api/utils/runtime"
 	"ocm.software/ocm/cmds/ocm/commands/common/options/closureoption"
 	"ocm.software/ocm/cmds/ocm/commands/common/options/formatoption"
 	ocmcommon "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/handlers/comphdlr"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/dryrunoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/journaloption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/omitaccesstypeoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/overwriteoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/paralleloption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/rscbyvalueoption"
 	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/scriptoption"
@@ -51,6 +59,7 @@ type Command struct {
 	TargetName          string
 	BOMFile             string
 	DisableBlobHandlers bool
+	PlanFormat          string
 }
 
 // NewCommand creates a new ctf command.
@@ -67,6 +76,9 @@ func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
 		srcbyvalueoption.New(),
 		omitaccesstypeoption.New(),
 		stoponexistingoption.New(),
+		paralleloption.New(),
+		journaloption.New(),
+		dryrunoption.New("evaluate the transfer without modifying the target repository", false),
 		uploaderoption.New(ctx.OCMContext()),
 		scriptoption.New(),
 	)}, utils.Names(Names, names...)...)
@@ -81,11 +93,23 @@ func (o *Command) ForName(name string) *cobra.Command {
 Transfer all component versions specified to the given target repository.
 If only a component (instead of a component version) is specified all versions
 are transferred.
+
+With the option <code>--dry-run</code> the complete transfer is evaluated
+based on the actual state of the target repository, but the target
+repository is not modified. Together with the option <code>--output</code>
+a transfer plan is printed instead of the transfer log. It describes the
+planned action for every component version (<code>create</code>,
+<code>transport</code>, <code>update</code>, <code>overwrite</code>,
+<code>skip</code> or <code>reject</code>) and for its resources and sources
+(<code>copy</code>, <code>overwrite</code>, <code>keep</code> or
+<code>reference</code>) together with the blob sizes and access specifications,
+if known. The supported plan formats are <code>yaml</code> and <code>json</code>.
 `,
 		Example: `
 $ ocm transfer components -t tgz ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.17.0 ./ctf.tgz
 $ ocm transfer components --latest -t tgz --repo OCIRegistry::ghcr.io/open-component-model/ocm ocm.software/ocmcli ./ctf.tgz
 $ ocm transfer components --latest --copy-resources --type directory ghcr.io/open-component-model/ocm//ocm.software/ocmcli ./ctf
+$ ocm transfer components --dry-run -o yaml --copy-resources ghcr.io/open-component-model/ocm//ocm.software/ocmcli:0.17.0 ./ctf
 `,
 		Annotations: map[string]string{"ExampleCodeStyle": "bash"},
 	}
@@ -95,6 +119,7 @@ func (o *Command) AddFlags(fs *pflag.FlagSet) {
 	o.BaseCommand.AddFlags(fs)
 	fs.StringVarP(&o.BOMFile, "bom-file", "B", "", "file name to write the component version BOM")
 	fs.BoolVarP(&o.DisableBlobHandlers, "disable-uploads", "", false, "disable standard upload handlers for transport")
+	fs.StringVarP(&o.PlanFormat, "output", "o", "", "output format of the transfer plan for dry-run (yaml, json)")
 }
 
 func (o *Command) Complete(args []string) error {
@@ -103,6 +128,19 @@ func (o *Command) Complete(args []string) error {
 		return fmt.Errorf("a repository or at least one argument that defines the reference is required")
 	}
 	o.TargetName = args[len(args)-1]
+
+	switch o.PlanFormat {
+	case "", "yaml", "json":
+	default:
+		return errors.ErrInvalid("output format", o.PlanFormat)
+	}
+	if dryrunoption.From(o).DryRun {
+		if j := journaloption.From(o); j.JournalFile != "" || j.ResumeFile != "" {
+			return fmt.Errorf("a journal cannot be used for dry-run mode")
+		}
+	} else if o.PlanFormat != "" {
+		return fmt.Errorf("--output only usable for dry-run mode")
+	}
 	return nil
 }
 
@@ -125,7 +163,14 @@ func (o *Command) Run() error {
 		return err
 	}
 
-	target, err := ocm.AssureTargetRepository(session, o.Context.OCMContext(), o.TargetName, ocm.CommonTransportFormat, formatoption.From(o).ChangedFormat(), o.Context.FileSystem())
+	var target ocm.Repository
+	var plan *transfer.Plan
+	if dryrunoption.From(o).DryRun {
+		plan = transfer.NewPlan()
+		target, err = o.dryRunTarget(session)
+	} else {
+		target, err = ocm.AssureTargetRepository(session, o.Context.OCMContext(), o.TargetName, ocm.CommonTransportFormat, formatoption.From(o).ChangedFormat(), o.Context.FileSystem())
+	}
 	if err != nil {
 		return err
 	}
@@ -140,13 +185,18 @@ func (o *Command) Run() error {
 	if err != nil {
 		return err
 	}
+	printer := common.NewPrinter(o.Context.StdOut())
+	if o.PlanFormat != "" {
+		printer = common.NonePrinter
+	}
 	hdlr := comphdlr.NewTypeHandler(o.Context.OCM(), session, repooption.From(o).Repository, comphdlr.OptionsFor(o))
 	err = utils.HandleOutput(&action{
 		cmd:     o,
-		printer: common.NewPrinter(o.Context.StdOut()),
+		printer: printer,
 		target:  target,
 		handler: thdlr,
 		closure: transfer.TransportClosure{},
+		plan:    plan,
 		errors:  errors.ErrListf("transfer errors"),
 	}, hdlr, utils.StringElemSpecs(o.Refs...)...)
 	if err != nil {
@@ -155,6 +205,29 @@ func (o *Command) Run() error {
 	return session.Close()
 }
 
+// dryRunTarget provides the target repository for a dry-run without
+// creating it. A non-existing target archive is evaluated as empty
+// in-memory archive.
+func (o *Command) dryRunTarget(session ocm.Session) (ocm.Repository, error) {
+	ref, err := ocm.ParseRepo(o.TargetName)
+	if err != nil {
+		return nil, err
+	}
+	target, err := session.DetermineRepositoryBySpec(o.Context.OCMContext(), &ref)
+	if err == nil {
+		return target, nil
+	}
+	if !errors.IsErrUnknown(err) && !vfs.IsErrNotExist(err) {
+		return nil, err
+	}
+	target, err = ctf.Create(o.Context.OCMContext(), accessobj.ACC_CREATE, "target", 0o700, accessio.PathFileSystem(memoryfs.New()))
+	if err != nil {
+		return nil, err
+	}
+	session.Closer(target)
+	return target, nil
+}
+
 /////////////////////////////////////////////////////////////////////////////
 
 type action struct {
@@ -163,6 +236,7 @@ type action struct {
 	target  ocm.Repository
 	handler transferhandler.TransferHandler
 	closure transfer.TransportClosure
+	plan    *transfer.Plan
 	errors  *errors.ErrorList
 }
 
@@ -173,7 +247,12 @@ func (a *action) Add(e interface{}) error {
 	if !ok {
 		return fmt.Errorf("object of type %T is not a valid comphdlr.Object", e)
 	}
-	err := transfer.TransferVersion(a.printer, a.closure, o.ComponentVersion, a.target, a.handler)
+	var err error
+	if a.plan != nil {
+		err = transfer.PlanVersion(a.printer, a.closure, a.plan, o.ComponentVersion, a.target, a.handler)
+	} else {
+		err = transfer.TransferVersion(a.printer, a.closure, o.ComponentVersion, a.target, a.handler)
+	}
 	a.errors.Add(err)
 	if err != nil {
 		a.printer.Printf("Error: %s\n", err)
@@ -186,7 +265,13 @@ func (a *action) Close() error {
 }
 
 func (a *action) Out() error {
-	a.printer.Printf("%d versions transferred\n", len(a.closure))
+	if a.plan != nil {
+		if err := a.outPlan(); err != nil {
+			return err
+		}
+	} else {
+		a.printer.Printf("%d versions transferred\n", len(a.closure))
+	}
 	if a.errors.Result() != nil {
 		sum := "Error summary:"
 		for _, e := range a.errors.Entries() {
@@ -215,6 +300,27 @@ func (a *action) Out() error {
 	return nil
 }
 
+func (a *action) outPlan() error {
+	var data []byte
+	var err error
+
+	switch a.cmd.PlanFormat {
+	case "json":
+		data, err = json.MarshalIndent(a.plan, "", "  ")
+		data = append(data, '\n')
+	case "yaml":
+		data, err = runtime.DefaultYAMLEncoding.Marshal(a.plan)
+	default:
+		a.printer.Printf("%d versions evaluated (dry-run)\n", len(a.closure))
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(err, "cannot marshal transfer plan")
+	}
+	_, err = a.cmd.StdOut().Write(data)
+	return err
+}
+
 type BomEntry struct {
 	Component string `json:"component"`
 	Version   string `json:"version"`
@@ -0,0 +1,153 @@
+package transfer_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/oci/testhelper"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"ocm.software/ocm/api/oci"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/ociartifact"
+	resourcetypes "ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+var _ = Describe("Transfer plan", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+
+		FakeOCIRepo(env.Builder, OCIPATH, OCIHOST)
+
+		env.OCICommonTransport(OCIPATH, accessio.FormatDirectory, func() {
+			OCIManifest1(env.Builder)
+			OCIManifest2(env.Builder)
+		})
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.Component(COMPONENT, func() {
+				env.Version(VERSION, func() {
+					env.Provider(PROVIDER)
+					env.Resource("testdata", "", resourcetypes.PLAIN_TEXT, metav1.LocalRelation, func() {
+						env.BlobStringData(mime.MIME_TEXT, "testdata")
+					})
+					env.Resource("ref", VERSION, resourcetypes.OCI_IMAGE, metav1.ExternalRelation, func() {
+						env.Access(
+							ociartifact.New(oci.StandardOCIRef(OCIHOST+".alias", OCINAMESPACE2, OCIVERSION)),
+						)
+					})
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("renders plan for new target without creating it", func() {
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("transfer", "components", "--dry-run", "-o", "yaml", "--copy-local-resources", ARCH, ARCH, OUT))
+		Expect(buf.String()).To(YAMLEqual(`
+componentVersions:
+- action: create
+  component: github.com/mandelsoft/test
+  resources:
+  - access:
+      localReference: sha256:810ff2fb242a5dee4220f2cb0e6a519891fb67f2f828a6cab4ef8894633b1f50
+      mediaType: text/plain
+      type: localBlob
+    action: copy
+    digest:
+      hashAlgorithm: SHA-256
+      normalisationAlgorithm: genericBlobDigest/v1
+      value: 810ff2fb242a5dee4220f2cb0e6a519891fb67f2f828a6cab4ef8894633b1f50
+    index: 0
+    name: testdata
+    size: 8
+    type: plainText
+    version: v1
+  - access:
+      imageReference: alias.alias/ocm/ref:v2.0
+      type: ociArtifact
+    action: reference
+    digest:
+      hashAlgorithm: SHA-256
+      normalisationAlgorithm: ociArtifactDigest/v1
+      value: c2d2dca275c33c1270dea6168a002d67c0e98780d7a54960758139ae19984bd7
+    index: 1
+    name: ref
+    targetAccess:
+      imageReference: alias.alias/ocm/ref:v2.0
+      type: ociArtifact
+    type: ociImage
+    version: v1
+  version: v1
+`))
+		Expect(env.DirExists(OUT)).To(BeFalse())
+	})
+
+	It("renders plan for existing target", func() {
+		MustBeSuccessful(env.CatchOutput(bytes.NewBuffer(nil)).Execute("transfer", "components", "--copy-local-resources", ARCH, ARCH, OUT))
+
+		buf := bytes.NewBuffer(nil)
+		MustBeSuccessful(env.CatchOutput(buf).Execute("transfer", "components", "--dry-run", "-o", "json", "--copy-resources", ARCH, ARCH, OUT))
+		Expect(buf.String()).To(YAMLEqual(`
+componentVersions:
+- action: transport
+  component: github.com/mandelsoft/test
+  message: already present, but requires resource transport
+  resources:
+  - access:
+      localReference: sha256:810ff2fb242a5dee4220f2cb0e6a519891fb67f2f828a6cab4ef8894633b1f50
+      mediaType: text/plain
+      type: localBlob
+    action: keep
+    message: already present
+    digest:
+      hashAlgorithm: SHA-256
+      normalisationAlgorithm: genericBlobDigest/v1
+      value: 810ff2fb242a5dee4220f2cb0e6a519891fb67f2f828a6cab4ef8894633b1f50
+    index: 0
+    name: testdata
+    targetAccess:
+      localReference: sha256:810ff2fb242a5dee4220f2cb0e6a519891fb67f2f828a6cab4ef8894633b1f50
+      mediaType: text/plain
+      type: localBlob
+    type: plainText
+    version: v1
+  - access:
+      imageReference: alias.alias/ocm/ref:v2.0
+      type: ociArtifact
+    action: copy
+    digest:
+      hashAlgorithm: SHA-256
+      normalisationAlgorithm: ociArtifactDigest/v1
+      value: c2d2dca275c33c1270dea6168a002d67c0e98780d7a54960758139ae19984bd7
+    hint: ocm/ref:v2.0
+    index: 1
+    name: ref
+    type: ociImage
+    version: v1
+  version: v1
+`))
+
+		buf.Reset()
+		MustBeSuccessful(env.CatchOutput(buf).Execute("transfer", "components", "--dry-run", ARCH, ARCH, OUT))
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+transferring version "github.com/mandelsoft/test:v1"...
+  version "github.com/mandelsoft/test:v1" already present -> skip transport
+1 versions evaluated (dry-run)
+`))
+	})
+
+	It("rejects output format without dry-run", func() {
+		ExpectError(env.Execute("transfer", "components", "-o", "yaml", ARCH, ARCH, OUT)).To(MatchError("--output only usable for dry-run mode"))
+	})
+})
@@ -26,4 +26,8 @@ func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
 var desc = `
 If no signature name is given, only the digests are validated against the
 registered ones at the component version.
+
+With the option <code>--policy</code> the signatures are verified according
+to a verification policy describing the required signers for component
+versions.
 `
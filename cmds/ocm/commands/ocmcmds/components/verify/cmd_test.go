@@ -2,6 +2,7 @@ package verify_test
 
 import (
 	"bytes"
+	"encoding/base64"
 	"os"
 
 	. "github.com/mandelsoft/goutils/testutils"
@@ -188,6 +189,81 @@ successfully verified github.com/mandelsoft/ref:v1 (digest SHA-256:${ref})
 `, substitutions))
 	})
 
+	Context("verification policy", func() {
+		const POLICY = "/tmp/policy.yaml"
+
+		keydata := base64.StdEncoding.EncodeToString(Must(rsa.KeyData(pub)))
+
+		It("verifies with policy", func() {
+			Prepare()
+
+			MustBeSuccessful(vfs.WriteFile(env.FileSystem(), POLICY, []byte(`
+type: verification.config.ocm.software
+signers:
+  test:
+    publicKey:
+      data: `+keydata+`
+rules:
+- components:
+  - github.com/mandelsoft/ref
+  required:
+  - test
+`), os.ModePerm))
+
+			buf := bytes.NewBuffer(nil)
+			Expect(env.CatchOutput(buf).Execute("verify", "components", "--policy", POLICY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(Succeed())
+
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+applying to version "github.com/mandelsoft/ref:v1"[github.com/mandelsoft/ref:v1]...
+  no digest found for "github.com/mandelsoft/test:v1"
+  applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/ref:v1]...
+    resource 0:  "name"="testdata": digest SHA-256:${r0}[genericBlobDigest/v1]
+    resource 1:  "name"="value": digest SHA-256:${r1}[ociArtifactDigest/v1]
+    resource 2:  "name"="ref": digest SHA-256:${r2}[ociArtifactDigest/v1]
+  reference 0:  github.com/mandelsoft/test:v1: digest SHA-256:${test}[jsonNormalisation/v1]
+  resource 0:  "name"="otherdata": digest SHA-256:${rb0}[genericBlobDigest/v1]
+  signer "test": signature "test" verified
+successfully verified github.com/mandelsoft/ref:v1 (digest SHA-256:${ref})
+`, substitutions))
+		})
+
+		It("fails for unmatched policy", func() {
+			Prepare()
+
+			MustBeSuccessful(vfs.WriteFile(env.FileSystem(), POLICY, []byte(`
+type: verification.config.ocm.software
+signers:
+  test:
+    publicKey:
+      data: `+keydata+`
+  other:
+    publicKey:
+      data: `+keydata+`
+rules:
+- signers:
+  - test
+  - other
+  threshold: 2
+`), os.ModePerm))
+
+			buf := bytes.NewBuffer(nil)
+			ExpectError(env.CatchOutput(buf).Execute("verify", "components", "--policy", POLICY, "--repo", ARCH, COMPONENTB+":"+VERSION)).To(HaveOccurred())
+
+			Expect(buf.String()).To(StringEqualTrimmedWithContext(`
+applying to version "github.com/mandelsoft/ref:v1"[github.com/mandelsoft/ref:v1]...
+  no digest found for "github.com/mandelsoft/test:v1"
+  applying to version "github.com/mandelsoft/test:v1"[github.com/mandelsoft/ref:v1]...
+    resource 0:  "name"="testdata": digest SHA-256:${r0}[genericBlobDigest/v1]
+    resource 1:  "name"="value": digest SHA-256:${r1}[ociArtifactDigest/v1]
+    resource 2:  "name"="ref": digest SHA-256:${r2}[ociArtifactDigest/v1]
+    signer "test": signature "test" not found
+    signer "other": signature "other" not found
+failed verifying signature of github.com/mandelsoft/ref:v1: github.com/mandelsoft/ref:v1: failed applying to component reference ref[github.com/mandelsoft/test:v1]: github.com/mandelsoft/ref:v1->github.com/mandelsoft/test:v1: verification policy: 0 of 2 required signatures of [test, other] found
+finished with 1 error(s)
+`, substitutions))
+		})
+	})
+
 	Context("verified store", func() {
 		It("signs transport archive", func() {
 			Prepare()
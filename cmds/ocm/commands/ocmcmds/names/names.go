@@ -17,6 +17,8 @@ var (
 	RoutingSlips           = []string{"routingslips", "routingslip", "rs"}
 	PubSub                 = []string{"pubsub", "ps"}
 	Verified               = []string{"verified"}
+	Attestations           = []string{"attestations", "attestation", "att"}
+	SBOMs                  = []string{"sboms", "sbom"}
 )
 
 var Aliases = map[string][]string{}
@@ -39,6 +41,8 @@ func init() {
 		RoutingSlips,
 		PubSub,
 		Verified,
+		Attestations,
+		SBOMs,
 	)
 }
 
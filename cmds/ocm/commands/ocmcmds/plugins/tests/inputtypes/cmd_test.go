@@ -0,0 +1,108 @@
+package inputtypes_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/api/ocm/plugin/testutils"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	"github.com/mandelsoft/vfs/pkg/vfs"
+
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/accessmethods/localblob"
+	"ocm.software/ocm/api/ocm/extensions/repositories/comparch"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/inputs"
+)
+
+const (
+	CA      = "/tmp/ca"
+	VERSION = "v1"
+)
+
+var _ = Describe("Add with plugin input type", func() {
+	var env *TestEnv
+	var plugins TempPluginDir
+
+	BeforeEach(func() {
+		env = NewTestEnv(TestData())
+		plugins = Must(ConfigureTestPlugins(env, "testdata"))
+
+		Expect(env.Execute("create", "ca", "-ft", "directory", "test.de/x", VERSION, "--provider", "mandelsoft", "--file", CA)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		plugins.Cleanup()
+		env.Cleanup()
+	})
+
+	CheckResource := func(name, content, mediaType string) {
+		data := Must(env.ReadFile(env.Join(CA, comparch.ComponentDescriptorFileName)))
+		cd := Must(compdesc.Decode(data))
+		r := Must(cd.GetResourceByIdentity(metav1.NewIdentity(name)))
+		Expect(r.Relation).To(Equal(metav1.ResourceRelation("local")))
+
+		acc := Must(env.OCMContext().AccessSpecForSpec(r.Access))
+		Expect(acc.GetType()).To(Equal(localblob.Type))
+		Expect(acc.(*localblob.AccessSpec).MediaType).To(Equal(mediaType))
+		blob := Must(env.ReadFile(env.Join(CA, comparch.BlobsDirectoryName, acc.(*localblob.AccessSpec).LocalReference)))
+		Expect(string(blob)).To(Equal(content))
+	}
+
+	It("registers input type", func() {
+		Expect(env.Execute("add", "resources", "--help")).To(Succeed())
+		Expect(inputs.For(env).GetInputType("text-gen")).NotTo(BeNil())
+		Expect(inputs.DefaultInputTypeScheme.GetInputType("text-gen")).To(BeNil())
+	})
+
+	It("adds resource by options", func() {
+		Expect(env.Execute("add", "resources", CA,
+			"--type", "testContent",
+			"--name", "text",
+			"--version", "v0.1.0",
+			"--inputType", "text-gen",
+			"--textGenText", "generated content",
+			"--mediaType", "text/x-test")).To(Succeed())
+		CheckResource("text", "generated content", "text/x-test")
+	})
+
+	It("adds resource by input specification", func() {
+		Expect(env.Execute("add", "resources", CA,
+			"--type", "testContent",
+			"--name", "text",
+			"--version", "v0.1.0",
+			"--input", `{"type":"text-gen","text":"spec content"}`)).To(Succeed())
+		CheckResource("text", "spec content", "text/plain")
+	})
+
+	It("adds resource by resource file using relative path", func() {
+		dir := GinkgoT().TempDir()
+		Expect(os.WriteFile(filepath.Join(dir, "content"), []byte("file content"), 0o600)).To(Succeed())
+
+		res := filepath.Join(dir, "resources.yaml")
+		Expect(env.MkdirAll(dir, 0o755)).To(Succeed())
+		Expect(vfs.WriteFile(env, res, []byte(`
+name: text
+type: testContent
+version: v0.1.0
+input:
+  type: text-gen
+  path: content
+`), 0o600)).To(Succeed())
+
+		Expect(env.Execute("add", "resources", CA, res)).To(Succeed())
+		CheckResource("text", "file content", "text/plain")
+	})
+
+	It("fails for invalid specification", func() {
+		ExpectError(env.Execute("add", "resources", CA,
+			"--type", "testContent",
+			"--name", "text",
+			"--version", "v0.1.0",
+			"--input", `{"type":"text-gen"}`)).To(MatchError(ContainSubstring("text or path required")))
+	})
+})
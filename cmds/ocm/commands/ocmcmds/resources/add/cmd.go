@@ -83,7 +83,7 @@ The description file might contain:
 - a list of yaml documents with a single resource or resource list
 
 ` + o.Adder.Description() + (&template.Options{}).Usage() +
-		inputs.Usage(inputs.DefaultInputTypeScheme) +
+		inputs.Usage(inputs.For(o.Context)) +
 		ocm.AccessUsage(o.OCMContext().AccessMethods(), true) + `
 
 ` + (&addhdlrs.Options{}).Description()
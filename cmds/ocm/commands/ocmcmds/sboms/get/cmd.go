@@ -0,0 +1,220 @@
+package get
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	"ocm.software/ocm/api/ocm"
+	"ocm.software/ocm/api/ocm/compdesc"
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/tools/sbom"
+	common "ocm.software/ocm/api/utils/misc"
+	"ocm.software/ocm/api/utils/out"
+	"ocm.software/ocm/cmds/ocm/commands/common/options/closureoption"
+	ocmcommon "ocm.software/ocm/cmds/ocm/commands/ocmcmds/common"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/handlers/elemhdlr"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/lookupoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/repooption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/common/options/versionconstraintsoption"
+	"ocm.software/ocm/cmds/ocm/commands/ocmcmds/names"
+	rsccommon "ocm.software/ocm/cmds/ocm/commands/ocmcmds/resources/common"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/output"
+	"ocm.software/ocm/cmds/ocm/common/processing"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+var (
+	Names = names.SBOMs
+	Verb  = verbs.Get
+)
+
+type Command struct {
+	utils.BaseCommand
+
+	Comp string
+	Ids  []metav1.Identity
+}
+
+// NewCommand creates a new sbom command.
+func NewCommand(ctx clictx.Context, names ...string) *cobra.Command {
+	return utils.SetupCommand(&Command{BaseCommand: utils.NewBaseCommand(ctx, versionconstraintsoption.New(), repooption.New(), output.OutputOptions(outputs, &Option{}, closureoption.New("component reference"), lookupoption.New()))}, utils.Names(Names, names...)...)
+}
+
+func (o *Command) ForName(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "[<options>]  [<component> {<name> { <key>=<value> }}]",
+		Args:  cobra.ArbitraryArgs,
+		Short: "get packages listed in SBOMs of component versions",
+		Long: `
+Get the packages listed in the software bills of materials (SBOMs) provided
+by component versions. SBOMs are resources of type <code>sbom</code> (format
+derived from the media type or the content), <code>spdxSbom</code>
+(SPDX JSON) or <code>cyclonedxSbom</code> (CycloneDX JSON).
+
+If no resource identity is given, all SBOM resources of the selected
+component versions are evaluated. Otherwise, the SBOMs related to the
+selected resources are evaluated. An SBOM is related to a resource if
+
+- the resource refers to the SBOM with the label <code>ocm.software/sbom</code>
+  (the label value is the identity of the SBOM resource), or
+- the SBOM refers to the resource with the label <code>ocm.software/sbom/subject</code>
+  (the label value is the identity of the described resource), or
+- none of both labels is used and both resources share a source reference.
+
+If no component is given, all component versions found in the
+repository specified with option <code>--repo</code> are evaluated.
+`,
+		Example: `
+$ ocm get sbom --repo ctf --package 'log4j*' --package-version 2.14
+$ ocm get sbom ghcr.io/acme//acme.org/app:1.0.0 app
+`,
+	}
+}
+
+func (o *Command) Complete(args []string) error {
+	var err error
+	if len(args) == 0 {
+		if repooption.From(o).Spec == "" {
+			return fmt.Errorf("a repository or at least one argument that defines the component is needed")
+		}
+		return nil
+	}
+	o.Comp = args[0]
+	o.Ids, err = ocmcommon.MapArgsToIdentities(args[1:]...)
+	return err
+}
+
+func (o *Command) Run() (err error) {
+	session := ocm.NewSession(nil)
+	defer errors.PropagateError(&err, session.Close)
+
+	err = o.ProcessOnOptions(ocmcommon.CompleteOptionsWithSession(o, session))
+	if err != nil {
+		return err
+	}
+
+	var comps []string
+	if o.Comp != "" {
+		comps = []string{o.Comp}
+	}
+	hopts := []elemhdlr.Option{rsccommon.OptionsFor(o)}
+	if len(o.Ids) == 0 {
+		hopts = append(hopts, rsccommon.WithTypes(sbom.Types))
+	}
+
+	opts := output.From(o)
+	hdlr, err := rsccommon.NewTypeHandler(o.Context.OCM(), opts, repooption.From(o).Repository, session, comps, hopts...)
+	if err != nil {
+		return err
+	}
+	specs, err := utils.ElemSpecs(o.Ids)
+	if err != nil {
+		return err
+	}
+	return utils.HandleOutputs(opts, hdlr, specs...)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// Object describes a package found in an SBOM related to a resource.
+type Object struct {
+	*elemhdlr.Object
+	SBOM    *compdesc.Resource
+	Format  string
+	Package *sbom.Package
+}
+
+type Manifest struct {
+	History   common.History  `json:"context"`
+	Component string          `json:"component"`
+	Version   string          `json:"version"`
+	Resource  metav1.Identity `json:"resource"`
+	SBOM      metav1.Identity `json:"sbom"`
+	Format    string          `json:"format"`
+	Package   *sbom.Package   `json:"package"`
+}
+
+func (o *Object) AsManifest() interface{} {
+	cd := o.Version.GetDescriptor()
+	return &Manifest{
+		History:   o.History,
+		Component: o.Version.GetName(),
+		Version:   o.Version.GetVersion(),
+		Resource:  o.Id,
+		SBOM:      o.SBOM.GetIdentity(cd.Resources),
+		Format:    o.Format,
+		Package:   o.Package,
+	}
+}
+
+func explode(opts *output.Options) processing.ExplodeFunction {
+	filter := From(opts)
+	return func(e interface{}) []interface{} {
+		p := e.(*elemhdlr.Object)
+		cd := p.Version.GetDescriptor()
+		list, err := sbom.Related(cd, rsccommon.Elem(e))
+		if err != nil {
+			out.Errf(opts.Context, "Warning: %s: %s\n", p.History, err)
+			return nil
+		}
+		var result []interface{}
+		for _, s := range list {
+			doc, err := sbom.Get(p.Version, s)
+			if err != nil {
+				out.Errf(opts.Context, "Warning: %s: %s\n", p.History, err)
+				continue
+			}
+			pkgs := doc.Find(filter.Package, filter.PackageVersion)
+			for i := range pkgs {
+				result = append(result, &Object{
+					Object:  p,
+					SBOM:    s,
+					Format:  doc.Format,
+					Package: &pkgs[i],
+				})
+			}
+		}
+		return result
+	}
+}
+
+func chain(opts *output.Options) processing.ProcessChain {
+	// resources without matching packages are exploded to nil elements
+	return elemhdlr.Sort.Explode(explode(opts)).Filter(func(e interface{}) bool { return e != nil })
+}
+
+func TableOutput(opts *output.Options, mapping processing.MappingFunction, wide ...string) *output.TableOutput {
+	return &output.TableOutput{
+		Headers: output.Fields("COMPONENT", "VERSION", "RESOURCE", "SBOM", "PACKAGE", "PACKAGE VERSION", wide),
+		Options: opts,
+		Chain:   chain(opts),
+		Mapping: mapping,
+	}
+}
+
+var outputs = output.NewOutputs(getRegular, output.Outputs{
+	"wide": getWide,
+}).AddChainedManifestOutputs(chain)
+
+func getRegular(opts *output.Options) output.Output {
+	return closureoption.TableOutput(TableOutput(opts, mapGetRegularOutput)).New()
+}
+
+func getWide(opts *output.Options) output.Output {
+	return closureoption.TableOutput(TableOutput(opts, mapGetWideOutput, "FORMAT", "PURL", "LICENSES")).New()
+}
+
+func mapGetRegularOutput(e interface{}) interface{} {
+	p := e.(*Object)
+	return []string{p.Version.GetName(), p.Version.GetVersion(), p.Element.GetMeta().GetName(), p.SBOM.GetName(), p.Package.Name, p.Package.Version}
+}
+
+func mapGetWideOutput(e interface{}) interface{} {
+	p := e.(*Object)
+	return output.Fields(mapGetRegularOutput(e), p.Format, p.Package.PURL, strings.Join(p.Package.Licenses, ","))
+}
@@ -0,0 +1,135 @@
+package get_test
+
+import (
+	"bytes"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "ocm.software/ocm/cmds/ocm/testhelper"
+
+	metav1 "ocm.software/ocm/api/ocm/compdesc/meta/v1"
+	"ocm.software/ocm/api/ocm/extensions/artifacttypes"
+	"ocm.software/ocm/api/ocm/tools/sbom"
+	"ocm.software/ocm/api/utils/accessio"
+	"ocm.software/ocm/api/utils/mime"
+)
+
+const (
+	ARCH     = "/tmp/ctf"
+	VERSION  = "v1"
+	COMP     = "test.de/x"
+	COMP2    = "test.de/y"
+	PROVIDER = "mandelsoft"
+)
+
+const SPDX = `{
+  "spdxVersion": "SPDX-2.3",
+  "name": "app",
+  "packages": [
+    {
+      "name": "log4j-core",
+      "versionInfo": "2.14.1",
+      "licenseConcluded": "Apache-2.0",
+      "externalRefs": [
+        {
+          "referenceType": "purl",
+          "referenceLocator": "pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1"
+        }
+      ]
+    },
+    { "name": "commons-lang3", "versionInfo": "3.12.0" }
+  ]
+}`
+
+const CYCLONEDX = `{
+  "bomFormat": "CycloneDX",
+  "components": [
+    { "group": "org.apache.logging.log4j", "name": "log4j-core", "version": "2.17.1" },
+    { "name": "snakeyaml", "version": "1.33" }
+  ]
+}`
+
+var _ = Describe("Test Environment", func() {
+	var env *TestEnv
+
+	BeforeEach(func() {
+		env = NewTestEnv()
+
+		env.OCMCommonTransport(ARCH, accessio.FormatDirectory, func() {
+			env.ComponentVersion(COMP, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Resource("app", "", artifacttypes.EXECUTABLE, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_OCTET, "app")
+					env.Label(sbom.LABEL_SBOM, metav1.NewIdentity("app-sbom"))
+				})
+				env.Resource("app-sbom", "", artifacttypes.SPDX_SBOM, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_JSON, SPDX)
+				})
+				env.Resource("other", "", artifacttypes.EXECUTABLE, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_OCTET, "other")
+				})
+			})
+			env.ComponentVersion(COMP2, VERSION, func() {
+				env.Provider(PROVIDER)
+				env.Resource("lib", "", artifacttypes.BLOB, metav1.LocalRelation, func() {
+					env.BlobStringData(mime.MIME_OCTET, "lib")
+					env.SourceRef("name", "sources")
+				})
+				env.Resource("lib-sbom", "", artifacttypes.SBOM, metav1.LocalRelation, func() {
+					env.BlobStringData(sbom.MIME_CYCLONEDX_JSON, CYCLONEDX)
+					env.SourceRef("name", "sources")
+				})
+			})
+		})
+	})
+
+	AfterEach(func() {
+		env.Cleanup()
+	})
+
+	It("lists all SBOM packages of a component version", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("get", "sbom", ARCH+"//"+COMP)).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+COMPONENT VERSION RESOURCE SBOM     PACKAGE       PACKAGE VERSION
+test.de/x v1      app-sbom app-sbom log4j-core    2.14.1
+test.de/x v1      app-sbom app-sbom commons-lang3 3.12.0
+`))
+	})
+
+	It("lists SBOM packages related to a resource", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("get", "sbom", "-o", "wide", ARCH+"//"+COMP, "app", "other")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+COMPONENT VERSION RESOURCE SBOM     PACKAGE       PACKAGE VERSION FORMAT PURL                                                 LICENSES
+test.de/x v1      app      app-sbom log4j-core    2.14.1          spdx   pkg:maven/org.apache.logging.log4j/log4j-core@2.14.1 Apache-2.0
+test.de/x v1      app      app-sbom commons-lang3 3.12.0          spdx
+`))
+	})
+
+	It("searches packages in a repository", func() {
+		buf := bytes.NewBuffer(nil)
+		Expect(env.CatchOutput(buf).Execute("get", "sbom", "--repo", ARCH, "--package", "log4j*", "--package-version", "2.14")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+COMPONENT VERSION RESOURCE SBOM     PACKAGE    PACKAGE VERSION
+test.de/x v1      app-sbom app-sbom log4j-core 2.14.1
+`))
+
+		buf.Reset()
+		Expect(env.CatchOutput(buf).Execute("get", "sbom", "--repo", ARCH, "--package", "log4j-core")).To(Succeed())
+		Expect(buf.String()).To(StringEqualTrimmedWithContext(
+			`
+COMPONENT VERSION RESOURCE SBOM     PACKAGE                             PACKAGE VERSION
+test.de/x v1      app-sbom app-sbom log4j-core                          2.14.1
+test.de/y v1      lib-sbom lib-sbom org.apache.logging.log4j:log4j-core 2.17.1
+`))
+	})
+
+	It("requires a component or repository", func() {
+		ExpectError(env.Execute("get", "sbom")).To(MatchError("a repository or at least one argument that defines the component is needed"))
+	})
+})
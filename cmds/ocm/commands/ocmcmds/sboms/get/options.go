@@ -0,0 +1,40 @@
+package get
+
+import (
+	"github.com/spf13/pflag"
+
+	"ocm.software/ocm/cmds/ocm/common/options"
+)
+
+func From(o options.OptionSetProvider) *Option {
+	var opt *Option
+	o.AsOptionSet().Get(&opt)
+	return opt
+}
+
+var _ options.Options = (*Option)(nil)
+
+type Option struct {
+	Package        string
+	PackageVersion string
+}
+
+func (o *Option) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVarP(&o.Package, "package", "", "", "package name pattern to search for")
+	fs.StringVarP(&o.PackageVersion, "package-version", "", "", "package version (prefix) to search for")
+}
+
+func (o *Option) Usage() string {
+	s := `
+The option <code>--package</code> restricts the output to packages with a name
+matching the given pattern. The pattern may contain shell wildcards. It is
+matched against the package name (with and without group prefix) and the
+name part of the package URL.
+
+The option <code>--package-version</code> restricts the output to packages
+with the given version or a version starting with the given version
+followed by a dot (<code>2.14</code> matches <code>2.14</code> and
+<code>2.14.1</code>, but not <code>2.141</code>).
+`
+	return s
+}
@@ -65,7 +65,7 @@ The description file might contain:
 - a list of yaml documents with a single source or source list
 
 ` + o.Adder.Description() + (&template.Options{}).Usage() +
-		inputs.Usage(inputs.DefaultInputTypeScheme) +
+		inputs.Usage(inputs.For(o.Context)) +
 		ocm.AccessUsage(o.OCMContext().AccessMethods(), true) + `
 
 ` + (&addhdlrs.Options{}).Description()
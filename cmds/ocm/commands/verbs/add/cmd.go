@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	clictx "ocm.software/ocm/api/cli"
+	artifacts "ocm.software/ocm/cmds/ocm/commands/ocicmds/artifacts/add"
 	components "ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/add"
 	references "ocm.software/ocm/cmds/ocm/commands/ocmcmds/references/add"
 	resourceconfig "ocm.software/ocm/cmds/ocm/commands/ocmcmds/resourceconfig/add"
@@ -18,7 +19,7 @@ import (
 // NewCommand creates a new command.
 func NewCommand(ctx clictx.Context) *cobra.Command {
 	cmd := utils.MassageCommand(&cobra.Command{
-		Short: "Add elements to a component repository or component version",
+		Short: "Add elements to a component repository, component version or OCI repository",
 	}, verbs.Add)
 	cmd.AddCommand(resourceconfig.NewCommand(ctx))
 	cmd.AddCommand(sourceconfig.NewCommand(ctx))
@@ -28,5 +29,6 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 	cmd.AddCommand(references.NewCommand(ctx))
 	cmd.AddCommand(components.NewCommand(ctx))
 	cmd.AddCommand(routingslips.NewCommand(ctx))
+	cmd.AddCommand(artifacts.NewCommand(ctx))
 	return cmd
 }
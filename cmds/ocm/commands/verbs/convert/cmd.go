@@ -0,0 +1,19 @@
+package convert
+
+import (
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	components "ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/convert"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+// NewCommand creates a new convert command.
+func NewCommand(ctx clictx.Context) *cobra.Command {
+	cmd := utils.MassageCommand(&cobra.Command{
+		Short: "Convert elements",
+	}, verbs.Convert)
+	cmd.AddCommand(components.NewCommand(ctx))
+	return cmd
+}
@@ -6,6 +6,7 @@ import (
 	clictx "ocm.software/ocm/api/cli"
 	rsakeypair "ocm.software/ocm/cmds/ocm/commands/misccmds/rsakeypair"
 	ctf "ocm.software/ocm/cmds/ocm/commands/ocicmds/ctf/create"
+	attestations "ocm.software/ocm/cmds/ocm/commands/ocmcmds/attestations/create"
 	comparch "ocm.software/ocm/cmds/ocm/commands/ocmcmds/componentarchive/create"
 	"ocm.software/ocm/cmds/ocm/commands/verbs"
 	"ocm.software/ocm/cmds/ocm/common/utils"
@@ -19,5 +20,6 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 	cmd.AddCommand(comparch.NewCommand(ctx))
 	cmd.AddCommand(ctf.NewCommand(ctx))
 	cmd.AddCommand(rsakeypair.NewCommand(ctx))
+	cmd.AddCommand(attestations.NewCommand(ctx))
 	return cmd
 }
@@ -0,0 +1,23 @@
+package delete
+
+import (
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	credentials "ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/delete"
+	artifacts "ocm.software/ocm/cmds/ocm/commands/ocicmds/artifacts/delete"
+	components "ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/delete"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+// NewCommand creates a new delete command.
+func NewCommand(ctx clictx.Context) *cobra.Command {
+	cmd := utils.MassageCommand(&cobra.Command{
+		Short: "Delete elements",
+	}, verbs.Delete)
+	cmd.AddCommand(artifacts.NewCommand(ctx))
+	cmd.AddCommand(components.NewCommand(ctx))
+	cmd.AddCommand(credentials.NewCommand(ctx))
+	return cmd
+}
@@ -0,0 +1,19 @@
+package explain
+
+import (
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	credentials "ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/explain"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+// NewCommand creates a new explain command.
+func NewCommand(ctx clictx.Context) *cobra.Command {
+	cmd := utils.MassageCommand(&cobra.Command{
+		Short: "Explain decisions taken by OCM operations",
+	}, verbs.Explain)
+	cmd.AddCommand(credentials.NewCommand(ctx))
+	return cmd
+}
@@ -13,6 +13,7 @@ import (
 	references "ocm.software/ocm/cmds/ocm/commands/ocmcmds/references/get"
 	resources "ocm.software/ocm/cmds/ocm/commands/ocmcmds/resources/get"
 	routingslips "ocm.software/ocm/cmds/ocm/commands/ocmcmds/routingslips/get"
+	sboms "ocm.software/ocm/cmds/ocm/commands/ocmcmds/sboms/get"
 	sources "ocm.software/ocm/cmds/ocm/commands/ocmcmds/sources/get"
 	verified "ocm.software/ocm/cmds/ocm/commands/ocmcmds/verified/get"
 	"ocm.software/ocm/cmds/ocm/commands/verbs"
@@ -35,5 +36,6 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 	cmd.AddCommand(config.NewCommand(ctx))
 	cmd.AddCommand(pubsub.NewCommand(ctx))
 	cmd.AddCommand(verified.NewCommand(ctx))
+	cmd.AddCommand(sboms.NewCommand(ctx))
 	return cmd
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	clictx "ocm.software/ocm/api/cli"
+	credentials "ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/list"
 	components "ocm.software/ocm/cmds/ocm/commands/ocmcmds/components/list"
 	"ocm.software/ocm/cmds/ocm/commands/verbs"
 	"ocm.software/ocm/cmds/ocm/common/utils"
@@ -15,5 +16,6 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 		Short: "List information about components",
 	}, verbs.List)
 	cmd.AddCommand(components.NewCommand(ctx))
+	cmd.AddCommand(credentials.NewCommand(ctx))
 	return cmd
 }
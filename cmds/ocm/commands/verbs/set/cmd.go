@@ -4,6 +4,7 @@ import (
 	"github.com/spf13/cobra"
 
 	clictx "ocm.software/ocm/api/cli"
+	credentials "ocm.software/ocm/cmds/ocm/commands/misccmds/credentials/set"
 	pubsub "ocm.software/ocm/cmds/ocm/commands/ocmcmds/pubsub/set"
 	"ocm.software/ocm/cmds/ocm/commands/verbs"
 	"ocm.software/ocm/cmds/ocm/common/utils"
@@ -15,5 +16,6 @@ func NewCommand(ctx clictx.Context) *cobra.Command {
 		Short: "Set information about OCM repositories",
 	}, verbs.Set)
 	cmd.AddCommand(pubsub.NewCommand(ctx))
+	cmd.AddCommand(credentials.NewCommand(ctx))
 	return cmd
 }
@@ -0,0 +1,19 @@
+package tag
+
+import (
+	"github.com/spf13/cobra"
+
+	clictx "ocm.software/ocm/api/cli"
+	artifacts "ocm.software/ocm/cmds/ocm/commands/ocicmds/artifacts/tag"
+	"ocm.software/ocm/cmds/ocm/commands/verbs"
+	"ocm.software/ocm/cmds/ocm/common/utils"
+)
+
+// NewCommand creates a new command.
+func NewCommand(ctx clictx.Context) *cobra.Command {
+	cmd := utils.MassageCommand(&cobra.Command{
+		Short: "Tag elements",
+	}, verbs.Tag)
+	cmd.AddCommand(artifacts.NewCommand(ctx))
+	return cmd
+}
@@ -3,9 +3,11 @@ package verbs
 const (
 	Get       = "get"
 	Set       = "set"
+	Delete    = "delete"
 	List      = "list"
 	Check     = "check"
 	Describe  = "describe"
+	Explain   = "explain"
 	Hash      = "hash"
 	Add       = "add"
 	Create    = "create"
@@ -19,4 +21,6 @@ const (
 	Install   = "install"
 	Uninstall = "uninstall"
 	Execute   = "execute"
+	Tag       = "tag"
+	Convert   = "convert"
 )
@@ -16,6 +16,9 @@ func New(ctx clictx.Context) *cobra.Command {
 		Example: `
 +ctf+directory::./ocm/ctf//ocm.software/ocmcli/ocmcli-image:0.7.0@sha256:29c842be1ef1da67f6a1c07a3a3a8eb101bbcc4c80f174b87d147b341bca9625
 
+oci-layout::./layout//ocm.software/ocmcli/ocmcli-image:0.7.0
++oci-layout+tgz::./image.tgz//ocmcli-image:0.7.0
+
 oci::{"baseUrl": "ghcr.io"}//open-component-model/ocm/ocm.software/ocmcli/ocmcli-image:0.7.0@sha256:29c842be1ef1da67f6a1c07a3a3a8eb101bbcc4c80f174b87d147b341bca9625
 
 oci::https://ghcr.io/open-component-model/ocm/ocm.software/ocmcli/ocmcli-image:0.7.0@sha256:29c842be1ef1da67f6a1c07a3a3a8eb101bbcc4c80f174b87d147b341bca9625
@@ -130,6 +133,17 @@ or
 Notice that &lt;port> is optional in this notation since this cannot be
 an image reference and therefore cannot be ambiguous with the docker
 library notation.
+
+---
+
+Besides the Common Transport Format, OCI image layouts can be used as
+file based registries with the type <code>OCILayout</code> (short
+<code>oci-layout</code>). The tags
+are taken from the annotation <code>org.opencontainers.image.ref.name</code>
+of the index entries. A value of the form <code>&lt;repository>:&lt;tag></code>
+describes a tag of the given repository. Plain tags, which are typically
+used by other tools, are found for any repository name, if there is no
+dedicated tag for the repository.
 ` + FileBasedUsage(),
 	}
 }
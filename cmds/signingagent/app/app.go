@@ -0,0 +1,152 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/mandelsoft/goutils/errors"
+	"github.com/spf13/cobra"
+
+	"ocm.software/ocm/api/tech/signing/handlers/signingagent"
+	"ocm.software/ocm/api/tech/signing/signutils"
+	"ocm.software/ocm/api/version"
+)
+
+type Command struct {
+	Socket     string
+	Keys       []string
+	PublicKeys []string
+	RootCerts  string
+
+	server *signingagent.Server
+}
+
+// NewCliCommand creates the command for the reference signing agent.
+// If a context is given, the agent is stopped when the context is done.
+func NewCliCommand(ctx context.Context) *cobra.Command {
+	o := &Command{}
+	cmd := &cobra.Command{
+		Use:     "signingagent {--key <name>=<private key file>}",
+		Short:   "local signing agent for OCM signatures",
+		Version: version.Get().String(),
+		Long: `
+The signing agent provides signatures for the signing handler
+<code>` + signingagent.Name + `</code> on a Unix domain socket.
+The private keys are only held by the agent process, the signing
+process only gets access to the socket.
+
+The keys are given by the option <code>--key</code> with a name
+and a file containing the PEM encoded private key (RSA, ECDSA P-256/P-384
+or Ed25519). With <code>--public-key</code> a public key or
+certificate chain can be configured for a key name. If a certificate
+chain is given, the signatures contain the chain, which is validated
+against the root certificates given by <code>--root-certs</code>.
+`,
+		SilenceUsage: true,
+		Args:         cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if ctx == nil {
+				ctx = cmd.Context()
+			}
+			return o.Run(ctx)
+		},
+	}
+	cmd.Flags().StringVarP(&o.Socket, "socket", "s", os.Getenv(signingagent.ENV_SOCKET), "path of the Unix domain socket (default from environment variable "+signingagent.ENV_SOCKET+")")
+	cmd.Flags().StringArrayVarP(&o.Keys, "key", "k", nil, "private key (<name>=<file>)")
+	cmd.Flags().StringArrayVarP(&o.PublicKeys, "public-key", "p", nil, "public key or certificate chain for a key (<name>=<file>)")
+	cmd.Flags().StringVarP(&o.RootCerts, "root-certs", "", "", "root certificates used to validate certificate chains")
+	return cmd
+}
+
+func (o *Command) Complete() error {
+	if o.Socket == "" {
+		return errors.Newf("socket required")
+	}
+	if len(o.Keys) == 0 {
+		return errors.Newf("at least one key required")
+	}
+
+	var roots signutils.GenericCertificatePool
+	if o.RootCerts != "" {
+		pool, err := signutils.RootPoolFromFile(o.RootCerts, false)
+		if err != nil {
+			return errors.Wrapf(err, "root certificates")
+		}
+		roots = pool
+	}
+
+	pubs := map[string][]byte{}
+	for _, p := range o.PublicKeys {
+		name, data, err := readKeyFile(p)
+		if err != nil {
+			return errors.Wrapf(err, "public key")
+		}
+		pubs[name] = data
+	}
+
+	o.server = signingagent.NewServer(nil, roots)
+	for _, k := range o.Keys {
+		name, data, err := readKeyFile(k)
+		if err != nil {
+			return errors.Wrapf(err, "private key")
+		}
+		var pub signutils.GenericPublicKey
+		if p, ok := pubs[name]; ok {
+			pub = p
+			delete(pubs, name)
+		}
+		err = o.server.AddKey(name, data, pub)
+		if err != nil {
+			return err
+		}
+	}
+	for n := range pubs {
+		return errors.Newf("public key %q without private key", n)
+	}
+	return nil
+}
+
+func (o *Command) Run(ctx context.Context) error {
+	err := o.Complete()
+	if err != nil {
+		return err
+	}
+
+	l, err := signingagent.Listen(o.Socket)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(o.Socket)
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	go func() {
+		<-ctx.Done()
+		l.Close()
+	}()
+
+	for _, k := range o.server.Keys() {
+		fmt.Fprintf(os.Stderr, "providing key %s (%s)\n", k.Name, strings.Join(k.Algorithms, ", "))
+	}
+	fmt.Fprintf(os.Stderr, "signing agent listening on %s\n", o.Socket)
+	return o.server.Serve(l)
+}
+
+func readKeyFile(spec string) (string, []byte, error) {
+	name, path, ok := strings.Cut(spec, "=")
+	if !ok || name == "" || path == "" {
+		return "", nil, errors.Newf("invalid key specification %q (expected <name>=<file>)", spec)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "key %q", name)
+	}
+	return name, data, nil
+}
@@ -0,0 +1,60 @@
+package app_test
+
+import (
+	"context"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+
+	. "github.com/mandelsoft/goutils/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ocm.software/ocm/api/tech/signing/handlers/ed25519"
+	"ocm.software/ocm/api/tech/signing/handlers/signingagent"
+	"ocm.software/ocm/api/tech/signing/signutils"
+	"ocm.software/ocm/cmds/signingagent/app"
+)
+
+var _ = Describe("signing agent command", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = Must(os.MkdirTemp("", "agent"))
+		DeferCleanup(os.RemoveAll, dir)
+	})
+
+	It("serves keys until cancelled", func() {
+		priv, _ := Must2(ed25519.NewHandler().CreateKeyPair())
+		keyfile := filepath.Join(dir, "ed.priv")
+		MustBeSuccessful(os.WriteFile(keyfile, pemData(priv), 0o600))
+		socket := filepath.Join(dir, "agent.sock")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		cmd := app.NewCliCommand(ctx)
+		cmd.SetArgs([]string{"--socket", socket, "--key", "ed=" + keyfile})
+		done := make(chan error)
+		go func() { done <- cmd.Execute() }()
+
+		client := signingagent.NewClient(socket)
+		Eventually(func() error { _, err := client.Keys(); return err }).Should(Succeed())
+		Expect(client.Keys()).To(Equal([]signingagent.KeyInfo{{Name: "ed", Algorithms: []string{ed25519.Algorithm}}}))
+
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(socket).NotTo(BeAnExistingFile())
+	})
+
+	It("rejects missing keys", func() {
+		cmd := app.NewCliCommand(context.Background())
+		cmd.SetArgs([]string{"--socket", filepath.Join(dir, "agent.sock")})
+		cmd.SetErr(GinkgoWriter)
+		Expect(cmd.Execute()).To(MatchError("at least one key required"))
+	})
+})
+
+func pemData(key interface{}) []byte {
+	return pem.EncodeToMemory(signutils.PemBlockForPrivateKey(key))
+}
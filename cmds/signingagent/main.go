@@ -0,0 +1,14 @@
+package main
+
+import (
+	"os"
+
+	"ocm.software/ocm/cmds/signingagent/app"
+)
+
+func main() {
+	c := app.NewCliCommand(nil)
+	if err := c.Execute(); err != nil {
+		os.Exit(1)
+	}
+}